@@ -0,0 +1,128 @@
+// Package accounts validates the identifiers of bank accounts: IBANs, BBANs, UK sort codes and BICs.
+// Validators are registered by account number code and by bank ID code, so that new schemes can be plugged in.
+package accounts
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Account number codes
+const (
+	AccountNumberCodeIBAN = "IBAN"
+	AccountNumberCodeBBAN = "BBAN"
+)
+
+// Bank ID codes
+const (
+	BankIDCodeUKSortCode = "GBDSC"
+	BankIDCodeBIC        = "SWBIC"
+)
+
+// Identifier identifies the account of a party
+type Identifier struct {
+	AccountNumber     string
+	AccountNumberCode string
+	BankID            string
+	BankIDCode        string
+}
+
+// Fields of an Identifier reported by the validation errors
+const (
+	FieldAccountNumber = "account_number"
+	FieldBankID        = "bank_id"
+)
+
+// Error describes why a field of an identifier is not valid
+type Error struct {
+	Field   string
+	Message string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// Validator validates an account identifier, it returns an error per invalid field
+type Validator interface {
+	Validate(id Identifier) []Error
+}
+
+// ValidatorFunc adapts a function to the Validator interface
+type ValidatorFunc func(id Identifier) []Error
+
+// Validate calls f(id)
+func (f ValidatorFunc) Validate(id Identifier) []Error {
+	return f(id)
+}
+
+// Registry dispatches the identifiers to the validators registered for their account number code and bank ID code.
+// Identifiers with codes without validator are considered valid.
+type Registry struct {
+	mu            sync.RWMutex
+	accountNumber map[string]Validator
+	bankID        map[string]Validator
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		accountNumber: map[string]Validator{},
+		bankID:        map[string]Validator{},
+	}
+}
+
+// RegisterAccountNumberCode sets the validator of the identifiers with the given account number code
+func (r *Registry) RegisterAccountNumberCode(code string, v Validator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accountNumber[code] = v
+}
+
+// RegisterBankIDCode sets the validator of the identifiers with the given bank ID code
+func (r *Registry) RegisterBankIDCode(code string, v Validator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bankID[code] = v
+}
+
+// Validate runs the validators of the account number code and of the bank ID code of the identifier
+func (r *Registry) Validate(id Identifier) []Error {
+	r.mu.RLock()
+	accountNumber, bankID := r.accountNumber[id.AccountNumberCode], r.bankID[id.BankIDCode]
+	r.mu.RUnlock()
+
+	var errs []Error
+	if accountNumber != nil {
+		errs = append(errs, accountNumber.Validate(id)...)
+	}
+	if bankID != nil {
+		errs = append(errs, bankID.Validate(id)...)
+	}
+	return errs
+}
+
+// DefaultRegistry validates IBANs, BBANs, UK sort codes with the bundled modulus rules and BICs
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.RegisterAccountNumberCode(AccountNumberCodeIBAN, ValidatorFunc(ValidateIBAN))
+	DefaultRegistry.RegisterAccountNumberCode(AccountNumberCodeBBAN, ValidatorFunc(ValidateBBAN))
+	DefaultRegistry.RegisterBankIDCode(BankIDCodeUKSortCode, NewSortCodeValidator(bundledModulusRules))
+	DefaultRegistry.RegisterBankIDCode(BankIDCodeBIC, ValidatorFunc(ValidateBIC))
+}
+
+// RegisterAccountNumberCode sets the validator of an account number code in the default registry
+func RegisterAccountNumberCode(code string, v Validator) {
+	DefaultRegistry.RegisterAccountNumberCode(code, v)
+}
+
+// RegisterBankIDCode sets the validator of a bank ID code in the default registry
+func RegisterBankIDCode(code string, v Validator) {
+	DefaultRegistry.RegisterBankIDCode(code, v)
+}
+
+// Validate validates an identifier with the default registry
+func Validate(id Identifier) []Error {
+	return DefaultRegistry.Validate(id)
+}
@@ -0,0 +1,53 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Registry_Validate(t *testing.T) {
+	//Arrange
+	r := NewRegistry()
+	r.RegisterAccountNumberCode("IBAN", ValidatorFunc(ValidateIBAN))
+	r.RegisterBankIDCode("SWBIC", ValidatorFunc(ValidateBIC))
+
+	//Act
+	errs := r.Validate(Identifier{AccountNumberCode: "IBAN", AccountNumber: "GB00", BankIDCode: "SWBIC", BankID: "NWBK"})
+	unknown := r.Validate(Identifier{AccountNumberCode: "OTHER", AccountNumber: "GB00", BankIDCode: "OTHER", BankID: "NWBK"})
+
+	//Assert
+	assert.Len(t, errs, 2)
+	assert.Equal(t, FieldAccountNumber, errs[0].Field)
+	assert.Equal(t, FieldBankID, errs[1].Field)
+	assert.Empty(t, unknown)
+}
+
+func Test_DefaultRegistry(t *testing.T) {
+	// the accounts of form3-payload.json
+	assert.Empty(t, Validate(Identifier{AccountNumberCode: "IBAN", AccountNumber: "GB83XABC10161234567801", BankIDCode: "GBDSC", BankID: "203301"}))
+	assert.Empty(t, Validate(Identifier{AccountNumberCode: "BBAN", AccountNumber: "31926819", BankIDCode: "GBDSC", BankID: "403000"}))
+	assert.Empty(t, Validate(Identifier{AccountNumber: "56781234", BankIDCode: "GBDSC", BankID: "123123"}))
+
+	assert.NotEmpty(t, Validate(Identifier{AccountNumberCode: "IBAN", AccountNumber: "GB29XABC10161234567801"}))
+}
+
+func Test_ValidateBIC(t *testing.T) {
+	tests := []struct {
+		bic   string
+		valid bool
+	}{
+		{"NWBKGB2L", true},
+		{"NWBKGB2LXXX", true},
+		{"DEUTDEFF500", true},
+		{"NWBKGB2", false},
+		{"NWBKGB2LXX", false},
+		{"nwbkgb2l", false},
+		{"1WBKGB2L", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.bic, func(t *testing.T) {
+			assert.Equal(t, tt.valid, len(ValidateBIC(Identifier{BankID: tt.bic})) == 0)
+		})
+	}
+}
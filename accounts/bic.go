@@ -0,0 +1,14 @@
+package accounts
+
+import "regexp"
+
+// bicPattern is the ISO 9362 format: institution code, country code, location code and optional branch code
+var bicPattern = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// ValidateBIC checks the bank ID is a BIC
+func ValidateBIC(id Identifier) []Error {
+	if !bicPattern.MatchString(id.BankID) {
+		return []Error{{Field: FieldBankID, Message: "is not a BIC"}}
+	}
+	return nil
+}
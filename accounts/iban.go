@@ -0,0 +1,169 @@
+package accounts
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+// ibanFormats are the BBAN formats of the countries using IBANs, in the notation of the SWIFT IBAN registry:
+// n digits, a upper case letters, c upper case letters and digits, the length prefix being fixed ("!").
+var ibanFormats = map[string]string{
+	"AD": "4!n4!n12!c",
+	"AE": "3!n16!n",
+	"AL": "8!n16!c",
+	"AT": "5!n11!n",
+	"AZ": "4!a20!c",
+	"BA": "3!n3!n8!n2!n",
+	"BE": "3!n7!n2!n",
+	"BG": "4!a4!n2!n8!c",
+	"BH": "4!a14!c",
+	"BR": "8!n5!n10!n1!a1!c",
+	"CH": "5!n12!c",
+	"CR": "4!n14!n",
+	"CY": "3!n5!n16!c",
+	"CZ": "4!n6!n10!n",
+	"DE": "8!n10!n",
+	"DK": "4!n9!n1!n",
+	"DO": "4!c20!n",
+	"EE": "2!n2!n11!n1!n",
+	"ES": "4!n4!n1!n1!n10!n",
+	"FI": "3!n11!n",
+	"FO": "4!n9!n1!n",
+	"FR": "5!n5!n11!c2!n",
+	"GB": "4!a6!n8!n",
+	"GE": "2!a16!n",
+	"GI": "4!a15!c",
+	"GL": "4!n9!n1!n",
+	"GR": "3!n4!n16!c",
+	"GT": "4!c20!c",
+	"HR": "7!n10!n",
+	"HU": "3!n4!n1!n15!n1!n",
+	"IE": "4!a6!n8!n",
+	"IL": "3!n3!n13!n",
+	"IS": "4!n2!n6!n10!n",
+	"IT": "1!a5!n5!n12!c",
+	"JO": "4!a4!n18!c",
+	"KW": "4!a22!c",
+	"KZ": "3!n13!c",
+	"LB": "4!n20!c",
+	"LI": "5!n12!c",
+	"LT": "5!n11!n",
+	"LU": "3!n13!c",
+	"LV": "4!a13!c",
+	"MC": "5!n5!n11!c2!n",
+	"MD": "2!c18!c",
+	"ME": "3!n13!n2!n",
+	"MK": "3!n10!c2!n",
+	"MR": "5!n5!n11!n2!n",
+	"MT": "4!a5!n18!c",
+	"MU": "4!a2!n2!n12!n3!n3!a",
+	"NL": "4!a10!n",
+	"NO": "4!n6!n1!n",
+	"PK": "4!a16!c",
+	"PL": "8!n16!n",
+	"PS": "4!a21!c",
+	"PT": "4!n4!n11!n2!n",
+	"QA": "4!a21!c",
+	"RO": "4!a16!c",
+	"RS": "3!n13!n2!n",
+	"SA": "2!n18!c",
+	"SE": "3!n16!n1!n",
+	"SI": "5!n8!n2!n",
+	"SK": "4!n6!n10!n",
+	"SM": "1!a5!n5!n12!c",
+	"TN": "2!n3!n13!n2!n",
+	"TR": "5!n1!n16!c",
+	"UA": "6!n19!c",
+	"VG": "4!a16!n",
+	"XK": "4!n10!n2!n",
+}
+
+// ibanPatterns are the regular expressions of the IBANs of each country, compiled from ibanFormats
+var ibanPatterns = map[string]*regexp.Regexp{}
+
+var formatElement = regexp.MustCompile(`(\d+)!([nac])`)
+
+func init() {
+	for country, format := range ibanFormats {
+		ibanPatterns[country] = regexp.MustCompile("^" + country + `\d{2}` + formatPattern(format) + "$")
+	}
+}
+
+// formatPattern translates a registry format into a regular expression
+func formatPattern(format string) string {
+	classes := map[string]string{"n": `\d`, "a": "[A-Z]", "c": "[A-Z0-9]"}
+	pattern := ""
+	for _, m := range formatElement.FindAllStringSubmatch(format, -1) {
+		pattern += classes[m[2]] + "{" + m[1] + "}"
+	}
+	return pattern
+}
+
+// ibanLength returns the length of the IBANs of a country, 0 when the country does not use IBANs
+func ibanLength(country string) int {
+	format, ok := ibanFormats[country]
+	if !ok {
+		return 0
+	}
+	length := 4
+	for _, m := range formatElement.FindAllStringSubmatch(format, -1) {
+		n, _ := strconv.Atoi(m[1])
+		length += n
+	}
+	return length
+}
+
+// ValidateIBAN checks the account number is an IBAN in electronic format:
+// the country, the length, the format of the BBAN and the mod-97 check digits are validated.
+func ValidateIBAN(id Identifier) []Error {
+	iban := id.AccountNumber
+	if len(iban) < 4 {
+		return []Error{{Field: FieldAccountNumber, Message: "is not an IBAN"}}
+	}
+	country := iban[:2]
+	pattern, ok := ibanPatterns[country]
+	if !ok {
+		return []Error{{Field: FieldAccountNumber, Message: "has an unknown IBAN country code " + strconv.Quote(country)}}
+	}
+	if len(iban) != ibanLength(country) {
+		return []Error{{Field: FieldAccountNumber, Message: "should have " + strconv.Itoa(ibanLength(country)) + " characters for an IBAN of " + country}}
+	}
+	if !pattern.MatchString(iban) {
+		return []Error{{Field: FieldAccountNumber, Message: "does not match the IBAN format of " + country}}
+	}
+	if !ibanChecksumValid(iban) {
+		return []Error{{Field: FieldAccountNumber, Message: "has invalid IBAN check digits"}}
+	}
+	return nil
+}
+
+// ibanChecksumValid computes the ISO 7064 mod 97-10 checksum of the IBAN
+func ibanChecksumValid(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+	digits := make([]byte, 0, 2*len(rearranged))
+	for i := 0; i < len(rearranged); i++ {
+		c := rearranged[i]
+		if c >= 'A' && c <= 'Z' {
+			digits = strconv.AppendInt(digits, int64(c-'A'+10), 10)
+		} else {
+			digits = append(digits, c)
+		}
+	}
+	n, ok := new(big.Int).SetString(string(digits), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+var bbanPattern = regexp.MustCompile(`^[A-Z0-9]{1,30}$`)
+
+// ValidateBBAN checks the account number is a basic bank account number, i.e. up to 30 upper case letters and digits.
+// The country specific checks are done by the validators of the bank ID codes, e.g. the UK sort codes.
+func ValidateBBAN(id Identifier) []Error {
+	if !bbanPattern.MatchString(id.AccountNumber) {
+		return []Error{{Field: FieldAccountNumber, Message: "is not a BBAN"}}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateIBAN(t *testing.T) {
+	tests := []struct {
+		name  string
+		iban  string
+		valid bool
+	}{
+		{"GB", "GB29NWBK60161331926819", true},
+		{"DE", "DE89370400440532013000", true},
+		{"FR", "FR1420041010050500013M02606", true},
+		{"NL", "NL91ABNA0417164300", true},
+		{"check digits", "GB29XABC10161234567801", false},
+		{"unknown country", "US29NWBK60161331926819", false},
+		{"length", "GB29NWBK6016133192681", false},
+		{"format", "GB29NWB160161331926819", false},
+		{"lower case", "gb29nwbk60161331926819", false},
+		{"spaces", "GB29 NWBK 6016 1331 9268 19", false},
+		{"too short", "GB", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			errs := ValidateIBAN(Identifier{AccountNumber: tt.iban})
+			//Assert
+			if tt.valid {
+				assert.Empty(t, errs)
+			} else {
+				assert.Len(t, errs, 1)
+				assert.Equal(t, FieldAccountNumber, errs[0].Field)
+			}
+		})
+	}
+}
+
+func Test_ibanLength(t *testing.T) {
+	assert.Equal(t, 22, ibanLength("GB"))
+	assert.Equal(t, 27, ibanLength("FR"))
+	assert.Equal(t, 31, ibanLength("MT"))
+	assert.Equal(t, 0, ibanLength("US"))
+}
+
+func Test_ValidateBBAN(t *testing.T) {
+	assert.Empty(t, ValidateBBAN(Identifier{AccountNumber: "31926819"}))
+	assert.NotEmpty(t, ValidateBBAN(Identifier{AccountNumber: "3192-6819"}))
+	assert.NotEmpty(t, ValidateBBAN(Identifier{AccountNumber: ""}))
+}
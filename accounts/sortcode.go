@@ -0,0 +1,208 @@
+package accounts
+
+import (
+	"bufio"
+	_ "embed" // embeds the bundled modulus rules
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Modulus check methods of the VocaLink rules
+const (
+	methodMod10 = "MOD10"
+	methodMod11 = "MOD11"
+	methodDblAl = "DBLAL"
+)
+
+// ModulusRule is a row of the VocaLink modulus weight table (valacdos.txt)
+type ModulusRule struct {
+	// From and To are the inclusive range of sort codes the rule applies to
+	From string
+	To   string
+	// Method is MOD10, MOD11 or DBLAL
+	Method string
+	// Weights apply to the sort code and the account number digits, u v w x y z a b c d e f g h
+	Weights [14]int
+	// Exception is the number of the exception to the standard check, 0 if none
+	Exception int
+}
+
+// ModulusRules are the modulus rules of the sort codes
+type ModulusRules []ModulusRule
+
+// supportedExceptions are the exceptions implemented by the checks.
+// Sort codes with other exceptions are not checked, rather than rejecting valid accounts.
+var supportedExceptions = map[int]bool{0: true, 1: true, 4: true, 7: true}
+
+//go:embed data/valacdos.txt
+var bundledModulusRulesData string
+
+// bundledModulusRules are the rules shipped with the service, see data/valacdos.txt
+var bundledModulusRules = mustReadModulusRules(bundledModulusRulesData)
+
+func mustReadModulusRules(data string) ModulusRules {
+	rules, err := ReadModulusRules(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// LoadModulusRules reads the modulus rules from a VocaLink valacdos.txt file
+func LoadModulusRules(path string) (ModulusRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadModulusRules(f)
+}
+
+// ReadModulusRules reads modulus rules in the VocaLink valacdos.txt format:
+// sort code from, sort code to, method, 14 weights and an optional exception, separated by spaces.
+// Blank lines and lines starting with # are ignored.
+func ReadModulusRules(r io.Reader) (ModulusRules, error) {
+	var rules ModulusRules
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		rule, err := parseModulusRule(strings.Fields(text))
+		if err != nil {
+			return nil, fmt.Errorf("modulus rules line %d: %v", line, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+func parseModulusRule(fields []string) (ModulusRule, error) {
+	rule := ModulusRule{}
+	if len(fields) != 17 && len(fields) != 18 {
+		return rule, fmt.Errorf("expected 17 or 18 fields, got %d", len(fields))
+	}
+	rule.From, rule.To, rule.Method = fields[0], fields[1], fields[2]
+	if !sortCodePattern.MatchString(rule.From) || !sortCodePattern.MatchString(rule.To) {
+		return rule, fmt.Errorf("invalid sort code range %s-%s", rule.From, rule.To)
+	}
+	if rule.Method != methodMod10 && rule.Method != methodMod11 && rule.Method != methodDblAl {
+		return rule, fmt.Errorf("unknown method %s", rule.Method)
+	}
+	for i := range rule.Weights {
+		w, err := strconv.Atoi(fields[3+i])
+		if err != nil {
+			return rule, fmt.Errorf("invalid weight %s", fields[3+i])
+		}
+		rule.Weights[i] = w
+	}
+	if len(fields) == 18 {
+		exception, err := strconv.Atoi(fields[17])
+		if err != nil {
+			return rule, fmt.Errorf("invalid exception %s", fields[17])
+		}
+		rule.Exception = exception
+	}
+	return rule, nil
+}
+
+// rulesOf returns the rules of a sort code, in the order of the table
+func (rules ModulusRules) rulesOf(sortCode string) []ModulusRule {
+	var matching []ModulusRule
+	for _, r := range rules {
+		if sortCode >= r.From && sortCode <= r.To {
+			matching = append(matching, r)
+		}
+	}
+	return matching
+}
+
+// Check reports whether the account number passes the modulus checks of the sort code.
+// The account number must have 8 digits. Sort codes without rules cannot be checked and are valid.
+func (rules ModulusRules) Check(sortCode string, accountNumber string) bool {
+	matching := rules.rulesOf(sortCode)
+	for _, r := range matching {
+		if !supportedExceptions[r.Exception] {
+			return true
+		}
+	}
+	for _, r := range matching {
+		if !r.check(sortCode + accountNumber) {
+			return false
+		}
+	}
+	return true
+}
+
+// check runs the modulus check of the rule on the 14 digits of the sort code followed by the account number
+func (r ModulusRule) check(number string) bool {
+	var digits [14]int
+	for i := range digits {
+		digits[i] = int(number[i] - '0')
+	}
+	weights := r.Weights
+	// exception 7: when g is 9, the weights of the sort code and of a and b are zeroised
+	if r.Exception == 7 && digits[12] == 9 {
+		for i := 0; i < 8; i++ {
+			weights[i] = 0
+		}
+	}
+
+	total := 0
+	for i, d := range digits {
+		product := d * weights[i]
+		if r.Method == methodDblAl {
+			// the digits of the products are added up
+			total += product/10 + product%10
+		} else {
+			total += product
+		}
+	}
+
+	switch r.Method {
+	case methodMod11:
+		// exception 4: the remainder must be the check digits gh
+		if r.Exception == 4 {
+			return total%11 == digits[12]*10+digits[13]
+		}
+		return total%11 == 0
+	default:
+		// exception 1: 27 is added to the total
+		if r.Exception == 1 {
+			total += 27
+		}
+		return total%10 == 0
+	}
+}
+
+var (
+	sortCodePattern      = regexp.MustCompile(`^\d{6}$`)
+	ukAccountNumberRegex = regexp.MustCompile(`^\d{6,8}$`)
+)
+
+// NewSortCodeValidator returns a validator of UK sort codes. The BBAN account numbers of the sort code
+// are standardised to 8 digits and checked with the modulus rules.
+func NewSortCodeValidator(rules ModulusRules) Validator {
+	return ValidatorFunc(func(id Identifier) []Error {
+		if !sortCodePattern.MatchString(id.BankID) {
+			return []Error{{Field: FieldBankID, Message: "is not a sort code"}}
+		}
+		// IBANs are checked on their own, sponsor parties have no account number code
+		if id.AccountNumberCode != AccountNumberCodeBBAN && id.AccountNumberCode != "" {
+			return nil
+		}
+		if !ukAccountNumberRegex.MatchString(id.AccountNumber) {
+			return []Error{{Field: FieldAccountNumber, Message: "is not a UK account number"}}
+		}
+		accountNumber := strings.Repeat("0", 8-len(id.AccountNumber)) + id.AccountNumber
+		if !rules.Check(id.BankID, accountNumber) {
+			return []Error{{Field: FieldAccountNumber, Message: "does not pass the modulus check of sort code " + id.BankID}}
+		}
+		return nil
+	})
+}
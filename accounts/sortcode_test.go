@@ -0,0 +1,110 @@
+package accounts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadModulusRules(t *testing.T) {
+	//Arrange
+	data := "# comment\n\n010004 016715 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1\n070116 070116 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1 4\n"
+	//Act
+	rules, err := ReadModulusRules(strings.NewReader(data))
+	//Assert
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, ModulusRule{From: "010004", To: "016715", Method: "MOD11", Weights: [14]int{0, 0, 0, 0, 0, 0, 8, 7, 6, 5, 4, 3, 2, 1}}, rules[0])
+	assert.Equal(t, 4, rules[1].Exception)
+
+	for _, invalid := range []string{
+		"010004 016715 MOD11 0 0 0",
+		"010004 016715 MOD12 0 0 0 0 0 0 8 7 6 5 4 3 2 1",
+		"01000A 016715 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 1",
+		"010004 016715 MOD11 0 0 0 0 0 0 8 7 6 5 4 3 2 X",
+	} {
+		_, err := ReadModulusRules(strings.NewReader(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_bundledModulusRules(t *testing.T) {
+	assert.NotEmpty(t, bundledModulusRules)
+}
+
+// Test_ModulusRules_Check uses the test accounts of the VocaLink specification
+func Test_ModulusRules_Check(t *testing.T) {
+	tests := []struct {
+		name          string
+		sortCode      string
+		accountNumber string
+		valid         bool
+	}{
+		{"modulus 10", "089999", "66374958", true},
+		{"modulus 11", "107999", "88837491", true},
+		{"double alternate", "202959", "63748472", true},
+		{"modulus 10 failure", "089999", "66374959", false},
+		{"modulus 11 failure", "107999", "88837492", false},
+		{"double alternate failure", "202959", "63748473", false},
+		{"no rule", "123456", "12345678", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, bundledModulusRules.Check(tt.sortCode, tt.accountNumber))
+		})
+	}
+}
+
+func Test_ModulusRules_Exceptions(t *testing.T) {
+	weights := "0 0 0 0 0 0 8 7 6 5 4 3 2 1"
+	tests := []struct {
+		name          string
+		rule          string
+		accountNumber string
+		valid         bool
+	}{
+		// 1*2+9*1 = 11
+		{"standard", "MOD11 " + weights, "00000019", true},
+		{"standard failure", "MOD11 " + weights, "00000018", false},
+		// remainder 5 is the check digits gh
+		{"exception 4", "MOD11 " + weights + " 4", "00000005", true},
+		{"exception 4 failure", "MOD11 " + weights + " 4", "00100005", false},
+		// 3 + 27 = 30
+		{"exception 1", "DBLAL 0 0 0 0 0 0 2 1 2 1 2 1 2 1 1", "00000003", true},
+		{"exception 1 failure", "DBLAL 0 0 0 0 0 0 2 1 2 1 2 1 2 1 1", "00000000", false},
+		{"exception 7 zeroises u to b when g is 9", "MOD11 1 1 1 1 1 1 1 1 0 0 0 0 0 0 7", "99999990", true},
+		{"exception 7 failure", "MOD11 1 1 1 1 1 1 1 1 0 0 0 0 0 0 7", "99999900", false},
+		{"unsupported exception", "MOD11 " + weights + " 5", "12345678", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ReadModulusRules(strings.NewReader("100000 100000 " + tt.rule))
+			require.NoError(t, err)
+			assert.Equal(t, tt.valid, rules.Check("100000", tt.accountNumber))
+		})
+	}
+}
+
+func Test_SortCodeValidator(t *testing.T) {
+	v := NewSortCodeValidator(bundledModulusRules)
+	tests := []struct {
+		name     string
+		id       Identifier
+		expected []Error
+	}{
+		{"valid", Identifier{BankID: "089999", AccountNumberCode: "BBAN", AccountNumber: "66374958"}, nil},
+		{"sponsor party", Identifier{BankID: "089999", AccountNumber: "66374958"}, nil},
+		{"short account number", Identifier{BankID: "123456", AccountNumberCode: "BBAN", AccountNumber: "123456"}, nil},
+		{"IBAN", Identifier{BankID: "089999", AccountNumberCode: "IBAN", AccountNumber: "GB29NWBK60161331926819"}, nil},
+		{"sort code", Identifier{BankID: "08-99-99", AccountNumberCode: "BBAN", AccountNumber: "66374958"}, []Error{{Field: FieldBankID, Message: "is not a sort code"}}},
+		{"account number", Identifier{BankID: "089999", AccountNumberCode: "BBAN", AccountNumber: "6637495812"}, []Error{{Field: FieldAccountNumber, Message: "is not a UK account number"}}},
+		{"modulus", Identifier{BankID: "089999", AccountNumberCode: "BBAN", AccountNumber: "66374959"}, []Error{{Field: FieldAccountNumber, Message: "does not pass the modulus check of sort code 089999"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, v.Validate(tt.id))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Package archive writes documents to cold storage files: gzipped NDJSON, one JSON document per line, with their
+// SHA-256 checksum. The files are kept in a local directory or an object store served over HTTP.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumSuffix is appended to the name of a file to name the file holding its checksum, in the sha256sum format
+const ChecksumSuffix = ".sha256"
+
+// Write encodes documents as a gzipped NDJSON file and returns it with its hex encoded SHA-256
+func Write(docs ...interface{}) ([]byte, string, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), Checksum(buf.Bytes()), nil
+}
+
+// Checksum returns the hex encoded SHA-256 of a file
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Read checks a gzipped NDJSON file against its checksum and returns its documents
+func Read(data []byte, checksum string) ([]json.RawMessage, error) {
+	if got := Checksum(data); got != checksum {
+		return nil, fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, got)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var docs []json.RawMessage
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		docs = append(docs, json.RawMessage(append([]byte(nil), scanner.Bytes()...)))
+	}
+	return docs, scanner.Err()
+}
+
+// Store keeps the archive files
+type Store interface {
+	// Put writes a file, replacing the file of the same name
+	Put(ctx context.Context, name string, data []byte) error
+	// Get reads a file
+	Get(ctx context.Context, name string) ([]byte, error)
+}
+
+// NewStore returns the store of a target: an http or https URL for an object store, a directory otherwise
+func NewStore(target string) (Store, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		return NewHTTPStore(target, http.DefaultClient, nil)
+	}
+	return NewDirStore(target)
+}
+
+// DirStore keeps the files in a local directory
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a store of the files of a directory, created if missing
+func NewDirStore(dir string) (*DirStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+// Put writes a file to a temporary file renamed once complete, so a file is never read half written
+func (s *DirStore) Put(_ context.Context, name string, data []byte) error {
+	f, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.dir, filepath.Base(name)))
+}
+
+// Get reads a file
+func (s *DirStore) Get(_ context.Context, name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.dir, filepath.Base(name)))
+}
+
+// HTTPStore keeps the files in an object store accepting PUT and GET requests on the URL of an object, e.g. an
+// S3-compatible bucket through a signing proxy. The requests are not signed, the header is added to each.
+type HTTPStore struct {
+	base   *url.URL
+	client *http.Client
+	header http.Header
+}
+
+// NewHTTPStore returns a store of the objects under a base URL
+func NewHTTPStore(baseURL string, client *http.Client, header http.Header) (*HTTPStore, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPStore{base: u, client: client, header: header}, nil
+}
+
+// Put uploads a file
+func (s *HTTPStore) Put(ctx context.Context, name string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, name, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	return nil
+}
+
+// Get downloads a file
+func (s *HTTPStore) Get(ctx context.Context, name string) ([]byte, error) {
+	res, err := s.do(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return ioutil.ReadAll(res.Body)
+}
+
+// do sends a request on the URL of an object, the responses other than 2xx are errors
+func (s *HTTPStore) do(ctx context.Context, method string, name string, body io.Reader) (*http.Response, error) {
+	u := *s.base
+	u.Path = path.Join(u.Path, url.PathEscape(name))
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	res, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s", method, u.String(), res.Status)
+	}
+	return res, nil
+}
@@ -0,0 +1,96 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriteRead(t *testing.T) {
+	// Arrange
+	docs := []interface{}{map[string]string{"id": "1"}, map[string]string{"id": "2"}}
+
+	//Act
+	data, checksum, err := Write(docs...)
+	read, readErr := Read(data, checksum)
+	_, mismatchErr := Read(data, Checksum([]byte("other")))
+
+	//Assert
+	require.NoError(t, err)
+	require.NoError(t, readErr)
+	require.Len(t, read, 2)
+	var doc map[string]string
+	require.NoError(t, json.Unmarshal(read[1], &doc))
+	assert.Equal(t, "2", doc["id"])
+	assert.Error(t, mismatchErr)
+}
+
+func Test_DirStore(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	s, err := NewStore(dir)
+	require.NoError(t, err)
+
+	//Act
+	putErr := s.Put(context.Background(), "payments-1.ndjson.gz", []byte("data"))
+	data, getErr := s.Get(context.Background(), "payments-1.ndjson.gz")
+	_, missingErr := s.Get(context.Background(), "payments-2.ndjson.gz")
+
+	//Assert
+	require.NoError(t, putErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, "data", string(data))
+	assert.Error(t, missingErr)
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1, "no temporary file is left")
+}
+
+func Test_HTTPStore(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path], _ = ioutil.ReadAll(r.Body)
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		}
+	}))
+	defer server.Close()
+	s, err := NewHTTPStore(server.URL+"/bucket/", server.Client(), http.Header{"Authorization": {"Bearer token"}})
+	require.NoError(t, err)
+
+	//Act
+	putErr := s.Put(context.Background(), "payments-1.ndjson.gz", []byte("data"))
+	data, getErr := s.Get(context.Background(), "payments-1.ndjson.gz")
+	_, missingErr := s.Get(context.Background(), "payments-2.ndjson.gz")
+
+	//Assert
+	require.NoError(t, putErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, "data", string(data))
+	assert.Contains(t, objects, "/bucket/payments-1.ndjson.gz")
+	assert.Error(t, missingErr)
+}
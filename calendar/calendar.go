@@ -0,0 +1,269 @@
+// Package calendar tells the business days and the cut-off times of the payment schemes, e.g. the UK calendar of
+// FPS and Bacs or the TARGET2 calendar of SEPA. The holidays are declared in JSON, see data/uk.json.
+package calendar
+
+import (
+	"embed" // embeds the bundled calendars
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata" // the time zones of the calendars do not depend on the host
+)
+
+// DateFormat is the format of the dates of the calendars and of the payment processing dates
+const DateFormat = "2006-01-02"
+
+// cutOffFormat is the format of the cut-off times of the schemes
+const cutOffFormat = "15:04"
+
+// Holiday is a non business day of a calendar other than a weekend day
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// Calendar holds the non business days of a scheme: the weekends and the holidays
+type Calendar struct {
+	// Name of the calendar, e.g. UK or TARGET2
+	Name string
+	// Location is the time zone of the calendar, its days and cut-off times are local to it
+	Location *time.Location
+	holidays map[string]string
+}
+
+// New returns a calendar in UTC with the given holidays, names by date
+func New(name string, holidays map[string]string) *Calendar {
+	c := &Calendar{Name: name, Location: time.UTC, holidays: map[string]string{}}
+	for date, holiday := range holidays {
+		c.holidays[date] = holiday
+	}
+	return c
+}
+
+// calendarFile is the JSON declaration of a calendar
+type calendarFile struct {
+	Name     string    `json:"name"`
+	TimeZone string    `json:"time_zone"`
+	Holidays []Holiday `json:"holidays"`
+}
+
+// Read returns the calendar declared in JSON
+func Read(reader io.Reader) (*Calendar, error) {
+	var f calendarFile
+	if err := json.NewDecoder(reader).Decode(&f); err != nil {
+		return nil, err
+	}
+	if f.Name == "" {
+		return nil, fmt.Errorf("the calendar has no name")
+	}
+	c := New(f.Name, nil)
+	if f.TimeZone != "" {
+		loc, err := time.LoadLocation(f.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %v", f.Name, err)
+		}
+		c.Location = loc
+	}
+	for _, h := range f.Holidays {
+		if _, err := time.Parse(DateFormat, h.Date); err != nil {
+			return nil, fmt.Errorf("calendar %s: invalid holiday date %q", f.Name, h.Date)
+		}
+		c.holidays[h.Date] = h.Name
+	}
+	return c, nil
+}
+
+// IsBusinessDay reports whether the day of t is neither a weekend day nor a holiday
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	_, holiday := c.holidays[t.Format(DateFormat)]
+	return !holiday
+}
+
+// NextBusinessDay returns the first business day after the day of t, at the same time of the day
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	for t = t.AddDate(0, 0, 1); !c.IsBusinessDay(t); t = t.AddDate(0, 0, 1) {
+	}
+	return t
+}
+
+// PreviousBusinessDay returns the last business day before the day of t, at the same time of the day
+func (c *Calendar) PreviousBusinessDay(t time.Time) time.Time {
+	for t = t.AddDate(0, 0, -1); !c.IsBusinessDay(t); t = t.AddDate(0, 0, -1) {
+	}
+	return t
+}
+
+// Holidays returns the holidays of the calendar in the given year, or all of them when year is 0, by date
+func (c *Calendar) Holidays(year int) []Holiday {
+	prefix := ""
+	if year != 0 {
+		prefix = fmt.Sprintf("%04d-", year)
+	}
+	holidays := []Holiday{}
+	for date, name := range c.holidays {
+		if date[:len(prefix)] == prefix {
+			holidays = append(holidays, Holiday{Date: date, Name: name})
+		}
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date < holidays[j].Date })
+	return holidays
+}
+
+// Scheme is the calendar of a payment scheme with its cut-off time
+type Scheme struct {
+	// Name of the scheme, e.g. FPS
+	Name     string
+	Calendar *Calendar
+	// CutOff is the local time of the calendar, HH:MM, after which the payments are processed the next business day.
+	// A scheme without cut-off processes the payments until the end of the day.
+	CutOff string
+}
+
+// CutOffTime returns the cut-off time of the scheme on the day of the date, false when the scheme has no cut-off
+func (s *Scheme) CutOffTime(date time.Time) (time.Time, bool) {
+	if s.CutOff == "" {
+		return time.Time{}, false
+	}
+	cutOff, _ := time.Parse(cutOffFormat, s.CutOff)
+	return time.Date(date.Year(), date.Month(), date.Day(), cutOff.Hour(), cutOff.Minute(), 0, 0, s.Calendar.Location), true
+}
+
+// ProcessingDate returns the date, at midnight UTC, on which a payment instructed at t is processed by the scheme:
+// the day of t in the calendar when it is a business day before the cut-off, the next business day otherwise
+func (s *Scheme) ProcessingDate(t time.Time) time.Time {
+	local := t.In(s.Calendar.Location)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	if !s.Calendar.IsBusinessDay(date) {
+		return s.Calendar.NextBusinessDay(date)
+	}
+	if cutOff, ok := s.CutOffTime(date); ok && !local.Before(cutOff) {
+		return s.Calendar.NextBusinessDay(date)
+	}
+	return date
+}
+
+// schemeFile is the JSON declaration of the calendar of a scheme
+type schemeFile struct {
+	Calendar string `json:"calendar"`
+	CutOff   string `json:"cut_off"`
+}
+
+// Registry holds the calendars and the calendars of the payment schemes
+type Registry struct {
+	mu        sync.RWMutex
+	calendars map[string]*Calendar
+	schemes   map[string]schemeFile
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{calendars: map[string]*Calendar{}, schemes: map[string]schemeFile{}}
+}
+
+// Register sets a calendar, replacing the calendar of the same name
+func (r *Registry) Register(c *Calendar) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calendars[c.Name] = c
+}
+
+// RegisterScheme sets the calendar of a scheme and its cut-off time, HH:MM or empty
+func (r *Registry) RegisterScheme(scheme string, calendar string, cutOff string) error {
+	if cutOff != "" {
+		if _, err := time.Parse(cutOffFormat, cutOff); err != nil {
+			return fmt.Errorf("scheme %s: invalid cut-off %q, expected HH:MM", scheme, cutOff)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemes[scheme] = schemeFile{Calendar: calendar, CutOff: cutOff}
+	return nil
+}
+
+// Load registers the calendar declared in JSON
+func (r *Registry) Load(reader io.Reader) error {
+	c, err := Read(reader)
+	if err != nil {
+		return err
+	}
+	r.Register(c)
+	return nil
+}
+
+// LoadFile registers the calendar declared in a JSON file
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.Load(f)
+}
+
+// LoadSchemes registers the calendars of the schemes declared in JSON, by scheme name
+func (r *Registry) LoadSchemes(reader io.Reader) error {
+	declared := map[string]schemeFile{}
+	if err := json.NewDecoder(reader).Decode(&declared); err != nil {
+		return err
+	}
+	for scheme, s := range declared {
+		if err := r.RegisterScheme(scheme, s.Calendar, s.CutOff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForScheme returns the calendar of a scheme, false when the scheme or its calendar is unknown
+func (r *Registry) ForScheme(scheme string) (*Scheme, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schemes[scheme]
+	if !ok {
+		return nil, false
+	}
+	c, ok := r.calendars[s.Calendar]
+	if !ok {
+		return nil, false
+	}
+	return &Scheme{Name: scheme, Calendar: c, CutOff: s.CutOff}, true
+}
+
+//go:embed data/*.json
+var bundled embed.FS
+
+// DefaultRegistry holds the bundled UK and TARGET2 calendars, of the FPS, Bacs and SEPA schemes
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	for _, name := range []string{"data/uk.json", "data/target2.json"} {
+		f, err := bundled.Open(name)
+		if err != nil {
+			panic(err)
+		}
+		if err := DefaultRegistry.Load(f); err != nil {
+			panic(err)
+		}
+		f.Close()
+	}
+	f, err := bundled.Open("data/schemes.json")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	if err := DefaultRegistry.LoadSchemes(f); err != nil {
+		panic(err)
+	}
+}
+
+// ForScheme returns the calendar of a scheme from the default registry
+func ForScheme(scheme string) (*Scheme, bool) {
+	return DefaultRegistry.ForScheme(scheme)
+}
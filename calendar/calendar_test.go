@@ -0,0 +1,143 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse(DateFormat, s)
+	return t
+}
+
+func Test_Calendar_IsBusinessDay(t *testing.T) {
+	c := New("UK", map[string]string{"2019-12-25": "Christmas Day"})
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2019-01-18", true},
+		{"2019-01-19", false},
+		{"2019-01-20", false},
+		{"2019-12-25", false},
+		{"2019-12-24", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.IsBusinessDay(date(tt.date)))
+		})
+	}
+}
+
+func Test_Calendar_NextBusinessDay(t *testing.T) {
+	c := New("UK", map[string]string{"2019-12-25": "Christmas Day", "2019-12-26": "Boxing Day"})
+
+	assert.Equal(t, date("2019-01-21"), c.NextBusinessDay(date("2019-01-18")))
+	assert.Equal(t, date("2019-01-21"), c.NextBusinessDay(date("2019-01-19")))
+	assert.Equal(t, date("2019-12-27"), c.NextBusinessDay(date("2019-12-24")))
+}
+
+func Test_Calendar_PreviousBusinessDay(t *testing.T) {
+	c := New("UK", map[string]string{"2019-12-25": "Christmas Day", "2019-12-26": "Boxing Day"})
+
+	assert.Equal(t, date("2019-01-18"), c.PreviousBusinessDay(date("2019-01-21")))
+	assert.Equal(t, date("2019-01-18"), c.PreviousBusinessDay(date("2019-01-20")))
+	assert.Equal(t, date("2019-12-24"), c.PreviousBusinessDay(date("2019-12-27")))
+}
+
+func Test_Calendar_Holidays(t *testing.T) {
+	c := New("UK", map[string]string{"2020-01-01": "New Year's Day", "2019-12-26": "Boxing Day", "2019-12-25": "Christmas Day"})
+
+	assert.Equal(t, []Holiday{{"2019-12-25", "Christmas Day"}, {"2019-12-26", "Boxing Day"}}, c.Holidays(2019))
+	assert.Len(t, c.Holidays(0), 3)
+	assert.Empty(t, c.Holidays(2018))
+}
+
+func Test_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{name: "valid", json: `{"name": "UK", "time_zone": "Europe/London", "holidays": [{"date": "2019-12-25", "name": "Christmas Day"}]}`},
+		{name: "no name", json: `{"holidays": []}`, wantErr: true},
+		{name: "unknown time zone", json: `{"name": "UK", "time_zone": "Europe/Nowhere"}`, wantErr: true},
+		{name: "invalid date", json: `{"name": "UK", "holidays": [{"date": "25/12/2019"}]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Read(strings.NewReader(tt.json))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Europe/London", c.Location.String())
+			assert.False(t, c.IsBusinessDay(date("2019-12-25")))
+		})
+	}
+}
+
+func Test_Scheme_ProcessingDate(t *testing.T) {
+	bacs, _ := ForScheme("Bacs")
+	fps, _ := ForScheme("FPS")
+	tests := []struct {
+		name   string
+		scheme *Scheme
+		at     string
+		want   string
+	}{
+		{name: "before the cut-off", scheme: bacs, at: "2019-07-18T21:00:00Z", want: "2019-07-18"},
+		// 22:30 in London is 21:30 UTC in the summer
+		{name: "after the cut-off", scheme: bacs, at: "2019-07-18T21:30:00Z", want: "2019-07-19"},
+		{name: "after the cut-off on a friday", scheme: bacs, at: "2019-07-19T22:00:00Z", want: "2019-07-22"},
+		{name: "without cut-off", scheme: fps, at: "2019-07-18T22:59:00Z", want: "2019-07-18"},
+		{name: "next local day", scheme: fps, at: "2019-07-18T23:30:00Z", want: "2019-07-19"},
+		{name: "on a bank holiday", scheme: fps, at: "2019-08-26T10:00:00Z", want: "2019-08-27"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tt.at)
+			assert.Equal(t, date(tt.want), tt.scheme.ProcessingDate(at))
+		})
+	}
+}
+
+func Test_Scheme_CutOffTime(t *testing.T) {
+	sepa, _ := ForScheme("SEPA")
+	fps, _ := ForScheme("FPS")
+
+	cutOff, ok := sepa.CutOffTime(date("2019-01-18"))
+	assert.True(t, ok)
+	assert.Equal(t, "2019-01-18T15:00:00Z", cutOff.UTC().Format(time.RFC3339))
+	_, ok = fps.CutOffTime(date("2019-01-18"))
+	assert.False(t, ok)
+}
+
+func Test_Registry_RegisterScheme(t *testing.T) {
+	r := NewRegistry()
+	r.Register(New("UK", nil))
+
+	assert.Error(t, r.RegisterScheme("Bacs", "UK", "25:00"))
+	assert.NoError(t, r.RegisterScheme("Bacs", "UK", "22:30"))
+	assert.NoError(t, r.RegisterScheme("SEPA", "TARGET2", ""))
+	_, ok := r.ForScheme("Bacs")
+	assert.True(t, ok)
+	_, ok = r.ForScheme("SEPA")
+	assert.False(t, ok, "the TARGET2 calendar is not registered")
+}
+
+func Test_ForScheme(t *testing.T) {
+	fps, ok := ForScheme("FPS")
+	assert.True(t, ok)
+	assert.Equal(t, "UK", fps.Calendar.Name)
+	assert.False(t, fps.Calendar.IsBusinessDay(date("2019-12-25")))
+	sepa, _ := ForScheme("SEPA")
+	assert.Equal(t, "TARGET2", sepa.Calendar.Name)
+	assert.False(t, sepa.Calendar.IsBusinessDay(date("2019-05-01")))
+	_, ok = ForScheme("Swift")
+	assert.False(t, ok)
+}
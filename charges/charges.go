@@ -0,0 +1,253 @@
+// Package charges computes the charges of the payments: the totals of the sender charges, the amounts debited and
+// credited depending on who bears the charges, and the fees of the payment schemes applied when the charges are
+// omitted. The fee schedules are declared in JSON, see data/fees.json.
+package charges
+
+import (
+	_ "embed" // embeds the bundled fee schedules
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/elkousy/payments-api/currency"
+)
+
+// Bearer tells who bears the charges of a payment
+type Bearer string
+
+const (
+	// BearerShared payments have the sender charges borne by the debtor and the receiver charges by the beneficiary
+	BearerShared Bearer = "SHAR"
+	// BearerOurs payments have all the charges borne by the debtor
+	BearerOurs Bearer = "OUR"
+	// BearerBeneficiary payments have all the charges borne by the beneficiary, deducted from the amount credited
+	BearerBeneficiary Bearer = "BEN"
+)
+
+// ValidBearer reports whether a bearer code is SHAR, OUR or BEN
+func ValidBearer(code string) bool {
+	switch Bearer(code) {
+	case BearerShared, BearerOurs, BearerBeneficiary:
+		return true
+	}
+	return false
+}
+
+// Amount is an amount in a currency
+type Amount struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Totals returns the total of the charges in each currency, sorted by currency. The amounts must be decimal numbers.
+func Totals(charges []Amount) []Amount {
+	sums := map[string]*big.Rat{}
+	for _, c := range charges {
+		amount, ok := new(big.Rat).SetString(c.Amount)
+		if !ok {
+			continue
+		}
+		if sums[c.Currency] == nil {
+			sums[c.Currency] = new(big.Rat)
+		}
+		sums[c.Currency].Add(sums[c.Currency], amount)
+	}
+	totals := make([]Amount, 0, len(sums))
+	for code, sum := range sums {
+		totals = append(totals, Amount{Amount: format(sum, code), Currency: code})
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Currency < totals[j].Currency })
+	return totals
+}
+
+// Instruction holds the amounts of a payment and its charges
+type Instruction struct {
+	Bearer          Bearer
+	Amount          string
+	Currency        string
+	SenderCharges   []Amount
+	ReceiverCharges Amount
+}
+
+// Settlement is what the debtor is debited and what the beneficiary is credited, in the currency of the payment
+type Settlement struct {
+	TotalDebitAmount string
+	NetCreditAmount  string
+}
+
+// Settle returns the settlement of a payment: the debtor is debited the amount and the charges it bears, the
+// beneficiary is credited the amount less the charges it bears. Only the charges in the currency of the payment are
+// counted, the others are billed separately.
+func Settle(in Instruction) (Settlement, error) {
+	amount, ok := new(big.Rat).SetString(in.Amount)
+	if !ok {
+		return Settlement{}, fmt.Errorf("invalid amount %s", in.Amount)
+	}
+	var sender, receiver = new(big.Rat), new(big.Rat)
+	for _, total := range Totals(in.SenderCharges) {
+		if total.Currency == in.Currency {
+			sender.SetString(total.Amount)
+		}
+	}
+	if in.ReceiverCharges.Currency == in.Currency {
+		if r, ok := new(big.Rat).SetString(in.ReceiverCharges.Amount); ok {
+			receiver = r
+		}
+	}
+
+	debit, credit := new(big.Rat).Set(amount), new(big.Rat).Set(amount)
+	switch in.Bearer {
+	case BearerOurs:
+		debit.Add(debit, sender).Add(debit, receiver)
+	case BearerShared:
+		debit.Add(debit, sender)
+		credit.Sub(credit, receiver)
+	case BearerBeneficiary:
+		credit.Sub(credit, sender).Sub(credit, receiver)
+	default:
+		return Settlement{}, fmt.Errorf("unknown bearer code %s", in.Bearer)
+	}
+	return Settlement{TotalDebitAmount: format(debit, in.Currency), NetCreditAmount: format(credit, in.Currency)}, nil
+}
+
+// format formats an amount with the minor units of its currency, 2 for the unknown currencies
+func format(amount *big.Rat, code string) string {
+	minorUnits := 2
+	if c, ok := currency.Lookup(code); ok {
+		minorUnits = c.MinorUnits
+	}
+	return amount.FloatString(minorUnits)
+}
+
+// Schedule is the fee of a payment scheme: a fixed fee plus a rate of the amount, within a minimum and a maximum
+type Schedule struct {
+	// Currency of the fee
+	Currency string `json:"currency"`
+	Fixed    string `json:"fixed"`
+	// Rate is the part of the amount charged, e.g. 0.001, it only applies to the payments in the currency of the fee
+	Rate string `json:"rate"`
+	// Min and Max are the inclusive limits of the fee, no limit when empty
+	Min string `json:"min"`
+	Max string `json:"max"`
+
+	fixed, rate, min, max *big.Rat
+}
+
+// compile parses the amounts of the schedule
+func (s *Schedule) compile() error {
+	if _, ok := currency.Lookup(s.Currency); !ok {
+		return fmt.Errorf("invalid currency %s", s.Currency)
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+		rat   **big.Rat
+	}{{"fixed", s.Fixed, &s.fixed}, {"rate", s.Rate, &s.rate}, {"min", s.Min, &s.min}, {"max", s.Max, &s.max}} {
+		if v.value == "" {
+			continue
+		}
+		r, ok := new(big.Rat).SetString(v.value)
+		if !ok || r.Sign() < 0 {
+			return fmt.Errorf("invalid %s %s", v.name, v.value)
+		}
+		*v.rat = r
+	}
+	return nil
+}
+
+// Fee returns the fee of a payment of the amount in the currency
+func (s *Schedule) Fee(amount string, code string) Amount {
+	fee := new(big.Rat)
+	if s.fixed != nil {
+		fee.Add(fee, s.fixed)
+	}
+	if a, ok := new(big.Rat).SetString(amount); ok && s.rate != nil && code == s.Currency {
+		fee.Add(fee, new(big.Rat).Mul(a, s.rate))
+	}
+	if s.min != nil && fee.Cmp(s.min) < 0 {
+		fee.Set(s.min)
+	}
+	if s.max != nil && fee.Cmp(s.max) > 0 {
+		fee.Set(s.max)
+	}
+	return Amount{Amount: format(fee, s.Currency), Currency: s.Currency}
+}
+
+// Registry holds the fee schedules of the payment schemes
+type Registry struct {
+	mu        sync.RWMutex
+	schedules map[string]*Schedule
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{schedules: map[string]*Schedule{}}
+}
+
+// Register sets the fee schedule of a scheme
+func (r *Registry) Register(scheme string, s Schedule) error {
+	if err := s.compile(); err != nil {
+		return fmt.Errorf("scheme %s: %v", scheme, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedules[scheme] = &s
+	return nil
+}
+
+// Load registers the fee schedules of the schemes declared in JSON, by scheme name
+func (r *Registry) Load(reader io.Reader) error {
+	declared := map[string]Schedule{}
+	if err := json.NewDecoder(reader).Decode(&declared); err != nil {
+		return err
+	}
+	for scheme, s := range declared {
+		if err := r.Register(scheme, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile registers the fee schedules of the schemes declared in a JSON file
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.Load(f)
+}
+
+// Fee returns the fee of a payment of the scheme, false when the scheme has no fee schedule
+func (r *Registry) Fee(scheme string, amount string, code string) (Amount, bool) {
+	r.mu.RLock()
+	s, ok := r.schedules[scheme]
+	r.mu.RUnlock()
+	if !ok {
+		return Amount{}, false
+	}
+	return s.Fee(amount, code), true
+}
+
+//go:embed data/fees.json
+var bundledFees string
+
+// DefaultRegistry holds the bundled fee schedules of the FPS, Bacs and SEPA schemes
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	if err := DefaultRegistry.Load(strings.NewReader(bundledFees)); err != nil {
+		panic(err)
+	}
+}
+
+// Fee returns the fee of a payment of the scheme from the default registry
+func Fee(scheme string, amount string, code string) (Amount, bool) {
+	return DefaultRegistry.Fee(scheme, amount, code)
+}
@@ -0,0 +1,118 @@
+package charges
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidBearer(t *testing.T) {
+	for _, code := range []string{"SHAR", "OUR", "BEN"} {
+		assert.True(t, ValidBearer(code), code)
+	}
+	for _, code := range []string{"", "shar", "SHA", "CRED"} {
+		assert.False(t, ValidBearer(code), code)
+	}
+}
+
+func Test_Totals(t *testing.T) {
+	// Arrange
+	charges := []Amount{
+		{Amount: "5.00", Currency: "GBP"},
+		{Amount: "10", Currency: "USD"},
+		{Amount: "0.5", Currency: "GBP"},
+		{Amount: "100", Currency: "JPY"},
+	}
+
+	//Act
+	totals := Totals(charges)
+
+	//Assert
+	assert.Equal(t, []Amount{
+		{Amount: "5.50", Currency: "GBP"},
+		{Amount: "100", Currency: "JPY"},
+		{Amount: "10.00", Currency: "USD"},
+	}, totals)
+}
+
+func Test_Settle(t *testing.T) {
+	in := Instruction{
+		Amount:          "100.00",
+		Currency:        "GBP",
+		SenderCharges:   []Amount{{Amount: "5.00", Currency: "GBP"}, {Amount: "10.00", Currency: "USD"}, {Amount: "1.00", Currency: "GBP"}},
+		ReceiverCharges: Amount{Amount: "1.00", Currency: "GBP"},
+	}
+	tests := []struct {
+		bearer Bearer
+		want   Settlement
+	}{
+		{BearerShared, Settlement{TotalDebitAmount: "106.00", NetCreditAmount: "99.00"}},
+		{BearerOurs, Settlement{TotalDebitAmount: "107.00", NetCreditAmount: "100.00"}},
+		{BearerBeneficiary, Settlement{TotalDebitAmount: "100.00", NetCreditAmount: "93.00"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.bearer), func(t *testing.T) {
+			// Arrange
+			in.Bearer = tt.bearer
+
+			//Act
+			got, err := Settle(in)
+
+			//Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	in.Bearer = "CRED"
+	_, err := Settle(in)
+	assert.Error(t, err)
+}
+
+func Test_Registry_Fee(t *testing.T) {
+	// Arrange
+	r := NewRegistry()
+	err := r.Load(strings.NewReader(`{"SEPA": {"currency": "EUR", "fixed": "0.20", "rate": "0.001", "min": "0.50", "max": "25"}}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     string
+	}{
+		{"minimum", "100.00", "EUR", "0.50"},
+		{"rate", "1000.00", "EUR", "1.20"},
+		{"rounded to the minor units", "1234.56", "EUR", "1.43"},
+		{"maximum", "1000000.00", "EUR", "25.00"},
+		{"rate only in the currency of the fee", "1000.00", "GBP", "0.50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			fee, ok := r.Fee("SEPA", tt.amount, tt.currency)
+
+			//Assert
+			assert.True(t, ok)
+			assert.Equal(t, Amount{Amount: tt.want, Currency: "EUR"}, fee)
+		})
+	}
+
+	_, ok := r.Fee("SWIFT", "100.00", "EUR")
+	assert.False(t, ok)
+}
+
+func Test_Registry_Register_Invalid(t *testing.T) {
+	r := NewRegistry()
+	assert.Error(t, r.Register("FPS", Schedule{Currency: "XXX1", Fixed: "0.35"}))
+	assert.Error(t, r.Register("FPS", Schedule{Currency: "GBP", Fixed: "-1"}))
+	assert.Error(t, r.Register("FPS", Schedule{Currency: "GBP", Rate: "one"}))
+}
+
+func Test_DefaultRegistry(t *testing.T) {
+	fee, ok := Fee("FPS", "100.00", "GBP")
+	assert.True(t, ok)
+	assert.Equal(t, Amount{Amount: "0.35", Currency: "GBP"}, fee)
+}
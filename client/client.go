@@ -0,0 +1,897 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/payments"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// userIDHeader identifies the user creating or approving a payment
+const userIDHeader = "X-User-ID"
+
+// requestIDHeader identifies a request in the audit log of the payments it changes
+const requestIDHeader = "X-Request-ID"
+
+// Client is a Go client of the payments API.
+// It implements payments.Service so it can stand in for a local service.
+type Client struct {
+	endpoints payments.Endpoints
+	timeout   time.Duration
+}
+
+type options struct {
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+	timeout    time.Duration
+}
+
+// Option configures the client
+type Option func(*options)
+
+// WithHTTPClient sets the http client used to call the API
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// WithRetries sets the number of retries of a failed call and the initial backoff, doubled after each attempt.
+// Only transport errors and server side errors are retried.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// WithTimeout sets the timeout of a call, including its retries
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// New returns a client of the payments API served at the given base URL, e.g. https://api.example.com
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	o := options{
+		httpClient: http.DefaultClient,
+		retries:    3,
+		backoff:    100 * time.Millisecond,
+		timeout:    10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	clientOptions := []kithttp.ClientOption{
+		kithttp.SetClient(o.httpClient),
+	}
+	retry := retryMiddleware(o.retries, o.backoff)
+
+	return &Client{
+		endpoints: payments.Endpoints{
+			GetPayment:        retry(kithttp.NewClient(http.MethodGet, u, encodeGetPaymentRequest, decodeGetPaymentResponse, clientOptions...).Endpoint()),
+			GetListOfPayments: retry(kithttp.NewClient(http.MethodGet, u, encodeGetListOfPaymentsRequest, decodeGetListOfPaymentsResponse, clientOptions...).Endpoint()),
+			PostPayment:       retry(kithttp.NewClient(http.MethodPost, u, encodePostPaymentRequest, decodePostPaymentResponse, clientOptions...).Endpoint()),
+			UpdatePayment:     retry(kithttp.NewClient(http.MethodPut, u, encodeUpdatePaymentRequest, decodeUpdatePaymentResponse, clientOptions...).Endpoint()),
+			DeletePayment:     retry(kithttp.NewClient(http.MethodDelete, u, encodeDeletePaymentRequest, decodeDeletePaymentResponse, clientOptions...).Endpoint()),
+			ReviewPayment:     retry(kithttp.NewClient(http.MethodPost, u, encodeReviewPaymentRequest, decodeReviewPaymentResponse, clientOptions...).Endpoint()),
+			// a retried restoration restored by the first attempt would fail as not deleted
+			RestorePayment: kithttp.NewClient(http.MethodPost, u, encodeRestorePaymentRequest, decodeRestorePaymentResponse, clientOptions...).Endpoint(),
+
+			ApprovePayment:      retry(kithttp.NewClient(http.MethodPost, u, encodeApprovePaymentRequest, decodeApprovePaymentResponse, clientOptions...).Endpoint()),
+			GetPaymentApprovals: retry(kithttp.NewClient(http.MethodGet, u, encodeGetPaymentApprovalsRequest, decodeGetPaymentApprovalsResponse, clientOptions...).Endpoint()),
+			GetPaymentAudit:     retry(kithttp.NewClient(http.MethodGet, u, encodeGetPaymentAuditRequest, decodeGetPaymentAuditResponse, clientOptions...).Endpoint()),
+
+			// a return has no idempotency key, retrying its creation could return the payment twice
+			CreateReturn:       kithttp.NewClient(http.MethodPost, u, encodeCreateReturnRequest, decodeCreateReturnResponse, clientOptions...).Endpoint(),
+			GetReturn:          retry(kithttp.NewClient(http.MethodGet, u, encodeGetReturnRequest, decodeGetReturnResponse, clientOptions...).Endpoint()),
+			GetPaymentReturns:  retry(kithttp.NewClient(http.MethodGet, u, encodeGetPaymentReturnsRequest, decodeGetPaymentReturnsResponse, clientOptions...).Endpoint()),
+			UpdateReturnStatus: retry(kithttp.NewClient(http.MethodPost, u, encodeUpdateReturnStatusRequest, decodeUpdateReturnStatusResponse, clientOptions...).Endpoint()),
+
+			// a retried recall created by the first attempt would fail as already requested
+			CreateRecall:      kithttp.NewClient(http.MethodPost, u, encodeCreateRecallRequest, decodeCreateRecallResponse, clientOptions...).Endpoint(),
+			GetRecall:         retry(kithttp.NewClient(http.MethodGet, u, encodeGetRecallRequest, decodeGetRecallResponse, clientOptions...).Endpoint()),
+			GetPaymentRecalls: retry(kithttp.NewClient(http.MethodGet, u, encodeGetPaymentRecallsRequest, decodeGetPaymentRecallsResponse, clientOptions...).Endpoint()),
+			DecideRecall:      retry(kithttp.NewClient(http.MethodPost, u, encodeDecideRecallRequest, decodeDecideRecallResponse, clientOptions...).Endpoint()),
+
+			GetAccountBalance: retry(kithttp.NewClient(http.MethodGet, u, encodeGetAccountBalanceRequest, decodeGetAccountBalanceResponse, clientOptions...).Endpoint()),
+			GetAccountEntries: retry(kithttp.NewClient(http.MethodGet, u, encodeGetAccountEntriesRequest, decodeGetAccountEntriesResponse, clientOptions...).Endpoint()),
+			CheckLedger:       retry(kithttp.NewClient(http.MethodGet, u, encodeCheckLedgerRequest, decodeCheckLedgerResponse, clientOptions...).Endpoint()),
+
+			GetOrganisationLimits:    retry(kithttp.NewClient(http.MethodGet, u, encodeGetOrganisationLimitsRequest, decodeGetOrganisationLimitsResponse, clientOptions...).Endpoint()),
+			UpdateOrganisationLimits: retry(kithttp.NewClient(http.MethodPut, u, encodeUpdateOrganisationLimitsRequest, decodeUpdateOrganisationLimitsResponse, clientOptions...).Endpoint()),
+			DeleteOrganisationLimits: retry(kithttp.NewClient(http.MethodDelete, u, encodeDeleteOrganisationLimitsRequest, decodeDeleteOrganisationLimitsResponse, clientOptions...).Endpoint()),
+		},
+		timeout: o.timeout,
+	}, nil
+}
+
+// GetPayment retrieves a specific payment by ID
+func (c *Client) GetPayment(req payments.GetPaymentRequest) (*payments.GetPaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetPaymentResponse), nil
+}
+
+// GetListOfPayments returns a page of payments
+func (c *Client) GetListOfPayments(req payments.GetListOfPaymentsRequest) (*payments.GetListOfPaymentsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetListOfPayments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetListOfPaymentsResponse), nil
+}
+
+// PostPayment creates a new payment. An idempotency key is generated when the request has none,
+// so retried attempts return the payment created by the first one.
+func (c *Client) PostPayment(req payments.CreatePaymentRequest) (*payments.CreatePaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = uuid.NewV4().String()
+	}
+	res, err := c.endpoints.PostPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.CreatePaymentResponse), nil
+}
+
+// UpdatePayment updates a payment ressource
+func (c *Client) UpdatePayment(req payments.UpdatePaymentRequest) (*payments.UpdatePaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if _, err := c.endpoints.UpdatePayment(ctx, req); err != nil {
+		return nil, err
+	}
+	return &payments.UpdatePaymentResponse{PaymentID: req.PaymentID}, nil
+}
+
+// DeletePayment deletes a given payment by ID
+func (c *Client) DeletePayment(req payments.DeletePaymentRequest) (*payments.DeletePaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if _, err := c.endpoints.DeletePayment(ctx, req); err != nil {
+		return nil, err
+	}
+	return &payments.DeletePaymentResponse{PaymentID: req.PaymentID}, nil
+}
+
+// RestorePayment restores a deleted payment
+func (c *Client) RestorePayment(req payments.RestorePaymentRequest) (*payments.RestorePaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.RestorePayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.RestorePaymentResponse), nil
+}
+
+// ReviewPayment releases or rejects a payment held for review
+func (c *Client) ReviewPayment(req payments.ReviewPaymentRequest) (*payments.ReviewPaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.ReviewPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.ReviewPaymentResponse), nil
+}
+
+// ApprovePayment approves or rejects a payment pending approval, on behalf of the approver
+func (c *Client) ApprovePayment(req payments.ApprovePaymentRequest) (*payments.ApprovePaymentResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.ApprovePayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.ApprovePaymentResponse), nil
+}
+
+// GetPaymentApprovals returns the approvals of a payment, oldest first
+func (c *Client) GetPaymentApprovals(req payments.GetPaymentApprovalsRequest) (*payments.GetPaymentApprovalsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetPaymentApprovals(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetPaymentApprovalsResponse), nil
+}
+
+// GetPaymentAudit returns the audit log of a payment, oldest first
+func (c *Client) GetPaymentAudit(req payments.GetPaymentAuditRequest) (*payments.GetPaymentAuditResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetPaymentAudit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetPaymentAuditResponse), nil
+}
+
+// CreateReturn returns or reverses all or part of a payment, on behalf of the user
+func (c *Client) CreateReturn(req payments.CreateReturnRequest) (*payments.CreateReturnResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.CreateReturn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.CreateReturnResponse), nil
+}
+
+// GetReturn retrieves a return or a reversal of a payment
+func (c *Client) GetReturn(req payments.GetReturnRequest) (*payments.GetReturnResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetReturn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetReturnResponse), nil
+}
+
+// GetPaymentReturns returns the returns or the reversals of a payment, oldest first
+func (c *Client) GetPaymentReturns(req payments.GetPaymentReturnsRequest) (*payments.GetPaymentReturnsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetPaymentReturns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetPaymentReturnsResponse), nil
+}
+
+// UpdateReturnStatus completes or fails a pending return or reversal
+func (c *Client) UpdateReturnStatus(req payments.UpdateReturnStatusRequest) (*payments.UpdateReturnStatusResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.UpdateReturnStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.UpdateReturnStatusResponse), nil
+}
+
+// CreateRecall requests the recall of a payment, on behalf of the user
+func (c *Client) CreateRecall(req payments.CreateRecallRequest) (*payments.CreateRecallResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.CreateRecall(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.CreateRecallResponse), nil
+}
+
+// GetRecall retrieves a recall of a payment
+func (c *Client) GetRecall(req payments.GetRecallRequest) (*payments.GetRecallResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetRecall(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetRecallResponse), nil
+}
+
+// GetPaymentRecalls returns the recalls of a payment, oldest first
+func (c *Client) GetPaymentRecalls(req payments.GetPaymentRecallsRequest) (*payments.GetPaymentRecallsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetPaymentRecalls(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetPaymentRecallsResponse), nil
+}
+
+// DecideRecall accepts or rejects a requested recall
+func (c *Client) DecideRecall(req payments.DecideRecallRequest) (*payments.DecideRecallResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.DecideRecall(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.DecideRecallResponse), nil
+}
+
+// GetAccountBalance returns the balance of a ledger account in each currency
+func (c *Client) GetAccountBalance(req payments.GetAccountBalanceRequest) (*payments.GetAccountBalanceResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetAccountBalance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetAccountBalanceResponse), nil
+}
+
+// GetAccountEntries returns a page of the entries of a ledger account, oldest first
+func (c *Client) GetAccountEntries(req payments.GetAccountEntriesRequest) (*payments.GetAccountEntriesResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetAccountEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetAccountEntriesResponse), nil
+}
+
+// CheckLedger checks every ledger transaction sums to zero
+func (c *Client) CheckLedger(req payments.CheckLedgerRequest) (*payments.CheckLedgerResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.CheckLedger(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.CheckLedgerResponse), nil
+}
+
+// GetOrganisationLimits returns the payment limits of an organisation
+func (c *Client) GetOrganisationLimits(req payments.GetOrganisationLimitsRequest) (*payments.GetOrganisationLimitsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.GetOrganisationLimits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.GetOrganisationLimitsResponse), nil
+}
+
+// UpdateOrganisationLimits replaces the payment limits of an organisation
+func (c *Client) UpdateOrganisationLimits(req payments.UpdateOrganisationLimitsRequest) (*payments.UpdateOrganisationLimitsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	res, err := c.endpoints.UpdateOrganisationLimits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*payments.UpdateOrganisationLimitsResponse), nil
+}
+
+// DeleteOrganisationLimits removes the payment limits of an organisation
+func (c *Client) DeleteOrganisationLimits(req payments.DeleteOrganisationLimitsRequest) (*payments.DeleteOrganisationLimitsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if _, err := c.endpoints.DeleteOrganisationLimits(ctx, req); err != nil {
+		return nil, err
+	}
+	return &payments.DeleteOrganisationLimitsResponse{OrganisationID: req.OrganisationID}, nil
+}
+
+// retryMiddleware retries the calls failing with a transport or a server side error, with an exponential backoff
+func retryMiddleware(retries int, backoff time.Duration) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			for attempt := 0; ; attempt++ {
+				response, err = next(ctx, request)
+				if err == nil || attempt >= retries || !retryable(err) {
+					return response, err
+				}
+				select {
+				case <-ctx.Done():
+					return nil, err
+				case <-time.After(backoff << uint(attempt)):
+				}
+			}
+		}
+	}
+}
+
+// retryable reports whether a failed call may succeed when retried
+func retryable(err error) bool {
+	if apiErr, ok := err.(apierrors.APIError); ok {
+		return apiErr.ResponseCode >= http.StatusInternalServerError || apiErr.ResponseCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// paymentsPath returns the path of the payments collection, or of a payment when ids are given,
+// relative to the path of the base URL
+func paymentsPath(r *http.Request, id ...string) string {
+	return path.Join(append([]string{r.URL.Path, "/v1/payments"}, id...)...) + "/"
+}
+
+func encodeGetPaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetPaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID))
+	query := r.URL.Query()
+	if req.Version != nil {
+		query.Set("version", strconv.FormatUint(uint64(*req.Version), 10))
+	}
+	if req.AsOf != nil {
+		query.Set("as_of", req.AsOf.Format(time.RFC3339Nano))
+	}
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
+
+func encodeGetListOfPaymentsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetListOfPaymentsRequest)
+	r.URL.Path = paymentsPath(r)
+	query := r.URL.Query()
+	if req.Page != 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize != 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	if req.IncludeDeleted {
+		query.Set("include", "deleted")
+	}
+	if req.AccountNumber != "" {
+		query.Set("account_number", req.AccountNumber)
+	}
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
+
+func encodePostPaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.CreatePaymentRequest)
+	r.URL.Path = paymentsPath(r)
+	if req.IdempotencyKey != "" {
+		r.Header.Set(idempotencyKeyHeader, req.IdempotencyKey)
+	}
+	setActorHeaders(r, req.Actor)
+	return encodeJSONBody(r, req.Payment)
+}
+
+func encodeUpdatePaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.UpdatePaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID))
+	setActorHeaders(r, req.Actor)
+	return encodeJSONBody(r, req.Payment)
+}
+
+func encodeDeletePaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.DeletePaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID))
+	setActorHeaders(r, req.Actor)
+	return nil
+}
+
+func encodeRestorePaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.RestorePaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID), "restore")
+	setActorHeaders(r, req.Actor)
+	return nil
+}
+
+func encodeReviewPaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.ReviewPaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID), url.PathEscape(string(req.Decision)))
+	setActorHeaders(r, req.Actor)
+	return encodeJSONBody(r, req)
+}
+
+func encodeApprovePaymentRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.ApprovePaymentRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID), "approvals")
+	setActorHeaders(r, req.Actor)
+	r.Header.Set(userIDHeader, req.Approver)
+	return encodeJSONBody(r, req)
+}
+
+func encodeGetPaymentApprovalsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetPaymentApprovalsRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID), "approvals")
+	return nil
+}
+
+func encodeGetPaymentAuditRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetPaymentAuditRequest)
+	r.URL.Path = paymentsPath(r, url.PathEscape(req.PaymentID), "audit")
+	return nil
+}
+
+// setActorHeaders sets the user and the request ID headers recorded by the audit log, when given
+func setActorHeaders(r *http.Request, actor payments.Actor) {
+	if actor.UserID != "" {
+		r.Header.Set(userIDHeader, actor.UserID)
+	}
+	if actor.RequestID != "" {
+		r.Header.Set(requestIDHeader, actor.RequestID)
+	}
+}
+
+// returnsPath returns the path of the returns or of the reversals of a payment, or of one of them when ids are given
+func returnsPath(r *http.Request, paymentID string, t payments.ReturnType, id ...string) string {
+	return paymentsPath(r, append([]string{url.PathEscape(paymentID), string(t) + "s"}, id...)...)
+}
+
+func encodeCreateReturnRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.CreateReturnRequest)
+	r.URL.Path = returnsPath(r, req.PaymentID, req.Type)
+	if req.UserID != "" {
+		r.Header.Set(userIDHeader, req.UserID)
+	}
+	return encodeJSONBody(r, req)
+}
+
+func encodeGetReturnRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetReturnRequest)
+	r.URL.Path = returnsPath(r, req.PaymentID, req.Type, url.PathEscape(req.ReturnID))
+	return nil
+}
+
+func encodeGetPaymentReturnsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetPaymentReturnsRequest)
+	r.URL.Path = returnsPath(r, req.PaymentID, req.Type)
+	return nil
+}
+
+// encodeUpdateReturnStatusRequest encodes the status as the action of the request, complete or fail
+func encodeUpdateReturnStatusRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.UpdateReturnStatusRequest)
+	action := "complete"
+	if req.Status == payments.ReturnStatusFailed {
+		action = "fail"
+	}
+	r.URL.Path = returnsPath(r, req.PaymentID, req.Type, url.PathEscape(req.ReturnID), action)
+	setActorHeaders(r, req.Actor)
+	return encodeJSONBody(r, req)
+}
+
+// recallsPath returns the path of the recalls of a payment, or of one of them when ids are given
+func recallsPath(r *http.Request, paymentID string, id ...string) string {
+	return paymentsPath(r, append([]string{url.PathEscape(paymentID), "recalls"}, id...)...)
+}
+
+func encodeCreateRecallRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.CreateRecallRequest)
+	r.URL.Path = recallsPath(r, req.PaymentID)
+	if req.UserID != "" {
+		r.Header.Set(userIDHeader, req.UserID)
+	}
+	return encodeJSONBody(r, req)
+}
+
+func encodeGetRecallRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetRecallRequest)
+	r.URL.Path = recallsPath(r, req.PaymentID, url.PathEscape(req.RecallID))
+	return nil
+}
+
+func encodeGetPaymentRecallsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetPaymentRecallsRequest)
+	r.URL.Path = recallsPath(r, req.PaymentID)
+	return nil
+}
+
+// encodeDecideRecallRequest encodes the decision as the action of the request, accept or reject
+func encodeDecideRecallRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.DecideRecallRequest)
+	r.URL.Path = recallsPath(r, req.PaymentID, url.PathEscape(req.RecallID), string(req.Decision))
+	setActorHeaders(r, req.Actor)
+	return encodeJSONBody(r, req)
+}
+
+// ledgerPath returns the path of a resource of the ledger, relative to the path of the base URL
+func ledgerPath(r *http.Request, elem ...string) string {
+	return path.Join(append([]string{r.URL.Path, "/v1/ledger"}, elem...)...) + "/"
+}
+
+func encodeGetAccountBalanceRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetAccountBalanceRequest)
+	r.URL.Path = ledgerPath(r, "accounts", url.PathEscape(req.Account), "balance")
+	return nil
+}
+
+func encodeGetAccountEntriesRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetAccountEntriesRequest)
+	r.URL.Path = ledgerPath(r, "accounts", url.PathEscape(req.Account), "entries")
+	query := r.URL.Query()
+	if req.Page != 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize != 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
+
+func encodeCheckLedgerRequest(_ context.Context, r *http.Request, request interface{}) error {
+	r.URL.Path = ledgerPath(r, "check")
+	return nil
+}
+
+// limitsPath returns the path of the limits of an organisation, relative to the path of the base URL
+func limitsPath(r *http.Request, organisationID string) string {
+	return path.Join(r.URL.Path, "/v1/admin/organisations", url.PathEscape(organisationID), "limits") + "/"
+}
+
+func encodeGetOrganisationLimitsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.GetOrganisationLimitsRequest)
+	r.URL.Path = limitsPath(r, req.OrganisationID)
+	return nil
+}
+
+func encodeUpdateOrganisationLimitsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.UpdateOrganisationLimitsRequest)
+	r.URL.Path = limitsPath(r, req.OrganisationID)
+	return encodeJSONBody(r, req.Limits)
+}
+
+func encodeDeleteOrganisationLimitsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req := request.(payments.DeleteOrganisationLimitsRequest)
+	r.URL.Path = limitsPath(r, req.OrganisationID)
+	return nil
+}
+
+func encodeJSONBody(r *http.Request, body interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.ContentLength = int64(buf.Len())
+	r.Body = ioutil.NopCloser(&buf)
+	return nil
+}
+
+func decodeGetPaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetPaymentResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetListOfPaymentsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetListOfPaymentsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodePostPaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.CreatePaymentResponse
+	if err := decodeJSONResponse(r, http.StatusCreated, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeUpdatePaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusAccepted {
+		return nil, decodeError(r)
+	}
+	return &payments.UpdatePaymentResponse{}, nil
+}
+
+func decodeDeletePaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusAccepted {
+		return nil, decodeError(r)
+	}
+	return &payments.DeletePaymentResponse{}, nil
+}
+
+func decodeRestorePaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.RestorePaymentResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeReviewPaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.ReviewPaymentResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeApprovePaymentResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.ApprovePaymentResponse
+	if err := decodeJSONResponse(r, http.StatusCreated, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetPaymentApprovalsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetPaymentApprovalsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetPaymentAuditResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetPaymentAuditResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeCreateReturnResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.CreateReturnResponse
+	if err := decodeJSONResponse(r, http.StatusCreated, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetReturnResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetReturnResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetPaymentReturnsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetPaymentReturnsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeUpdateReturnStatusResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.UpdateReturnStatusResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeCreateRecallResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.CreateRecallResponse
+	if err := decodeJSONResponse(r, http.StatusCreated, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetRecallResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetRecallResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetPaymentRecallsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetPaymentRecallsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeDecideRecallResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.DecideRecallResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetAccountBalanceResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetAccountBalanceResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetAccountEntriesResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetAccountEntriesResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeCheckLedgerResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.CheckLedgerResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeGetOrganisationLimitsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.GetOrganisationLimitsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeUpdateOrganisationLimitsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	var res payments.UpdateOrganisationLimitsResponse
+	if err := decodeJSONResponse(r, http.StatusOK, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func decodeDeleteOrganisationLimitsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusAccepted {
+		return nil, decodeError(r)
+	}
+	return &payments.DeleteOrganisationLimitsResponse{}, nil
+}
+
+func decodeJSONResponse(r *http.Response, expectedStatus int, res interface{}) error {
+	if r.StatusCode != expectedStatus {
+		return decodeError(r)
+	}
+	return json.NewDecoder(r.Body).Decode(res)
+}
+
+// decodeError decodes an error response back into an APIError.
+// Errors which are not encoded as an APIError, e.g. from a proxy, keep the body as message.
+func decodeError(r *http.Response) error {
+	body, _ := ioutil.ReadAll(r.Body)
+	apiErr := apierrors.APIError{ResponseCode: r.StatusCode}
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
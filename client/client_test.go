@@ -0,0 +1,474 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/ledger"
+	"github.com/elkousy/payments-api/payments"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+const paymentID = "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+
+func newTestClient(t *testing.T, svc payments.Service, opts ...Option) (*Client, *httptest.Server) {
+	// the test server stands for the gateway too, every caller is an administrator
+	admin := payments.WithAdminCheck(func(context.Context) bool { return true })
+	server := httptest.NewServer(payments.MakeHTTPHandler(payments.MakeEndpoints(svc), payments.NewEventBroker(10, 10), mux.NewRouter(), admin))
+	c, err := New(server.URL, append([]Option{WithRetries(2, time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+	return c, server
+}
+
+func Test_Client_ImplementsService(t *testing.T) {
+	var _ payments.Service = &Client{}
+}
+
+func Test_Client_GetPayment(t *testing.T) {
+	//Arrange
+	id, _ := uuid.FromString(paymentID)
+	svc := &payments.MockService{}
+	svc.On("GetPayment", payments.GetPaymentRequest{PaymentID: paymentID}).
+		Return(&payments.GetPaymentResponse{Payment: payments.Payment{ID: id, Type: "Payment"}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.GetPayment(payments.GetPaymentRequest{PaymentID: paymentID})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, id, res.ID)
+	assert.Equal(t, server.URL+"/v1/payments/"+paymentID+"/", res.HateoasLink.Self)
+}
+
+func Test_Client_GetPayment_Settlement(t *testing.T) {
+	//Arrange
+	svc := &payments.MockService{}
+	svc.On("GetPayment", payments.GetPaymentRequest{PaymentID: paymentID}).
+		Return(&payments.GetPaymentResponse{
+			SenderChargesTotals: []charges.Amount{{Amount: "7.00", Currency: "GBP"}},
+			TotalDebitAmount:    "100.21",
+			NetCreditAmount:     "95.21",
+		}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.GetPayment(payments.GetPaymentRequest{PaymentID: paymentID})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, []charges.Amount{{Amount: "7.00", Currency: "GBP"}}, res.SenderChargesTotals)
+	assert.Equal(t, "100.21", res.TotalDebitAmount)
+	assert.Equal(t, "95.21", res.NetCreditAmount)
+}
+
+func Test_Client_GetPayment_Version(t *testing.T) {
+	//Arrange
+	version, at := uint(2), time.Date(2019, 3, 1, 12, 30, 0, 0, time.UTC)
+	svc := &payments.MockService{}
+	svc.On("GetPayment", payments.GetPaymentRequest{PaymentID: paymentID, Version: &version}).
+		Return(&payments.GetPaymentResponse{Payment: payments.Payment{Version: 2}}, nil)
+	svc.On("GetPayment", payments.GetPaymentRequest{PaymentID: paymentID, AsOf: &at}).
+		Return(&payments.GetPaymentResponse{Payment: payments.Payment{Version: 1}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	byVersion, versionErr := c.GetPayment(payments.GetPaymentRequest{PaymentID: paymentID, Version: &version})
+	byTime, timeErr := c.GetPayment(payments.GetPaymentRequest{PaymentID: paymentID, AsOf: &at})
+
+	//Assert
+	require.NoError(t, versionErr)
+	require.NoError(t, timeErr)
+	assert.Equal(t, uint(2), byVersion.Version)
+	assert.Equal(t, uint(1), byTime.Version)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_GetListOfPayments(t *testing.T) {
+	//Arrange
+	req := payments.GetListOfPaymentsRequest{Page: 2, PageSize: 10}
+	svc := &payments.MockService{}
+	svc.On("GetListOfPayments", req).
+		Return(&payments.GetListOfPaymentsResponse{Data: []payments.Payment{}, Meta: payments.PageMeta{Page: 2, PageSize: 10}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.GetListOfPayments(req)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, payments.PageMeta{Page: 2, PageSize: 10}, res.Meta)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_PostPayment_IdempotencyKey(t *testing.T) {
+	//Arrange
+	svc := &payments.MockService{}
+	svc.On("PostPayment", mock.MatchedBy(func(req payments.CreatePaymentRequest) bool {
+		return req.IdempotencyKey != ""
+	})).Return(&payments.CreatePaymentResponse{PaymentID: paymentID}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.PostPayment(payments.CreatePaymentRequest{Payment: payments.Payment{Type: "Payment"}})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, paymentID, res.PaymentID)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_UpdateAndDeletePayment(t *testing.T) {
+	//Arrange
+	svc := &payments.MockService{}
+	svc.On("UpdatePayment", mock.Anything).Return(&payments.UpdatePaymentResponse{PaymentID: paymentID}, nil)
+	actor := payments.Actor{UserID: "alice", RequestID: "req-1"}
+	svc.On("DeletePayment", payments.DeletePaymentRequest{PaymentID: paymentID, Actor: payments.Actor{UserID: "alice", RequestID: "req-1", SourceIP: "127.0.0.1"}}).Return(&payments.DeletePaymentResponse{PaymentID: paymentID}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	updated, updateErr := c.UpdatePayment(payments.UpdatePaymentRequest{PaymentID: paymentID})
+	deleted, deleteErr := c.DeletePayment(payments.DeletePaymentRequest{PaymentID: paymentID, Actor: actor})
+
+	//Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, deleteErr)
+	assert.Equal(t, paymentID, updated.PaymentID)
+	assert.Equal(t, paymentID, deleted.PaymentID)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_GetPaymentAudit(t *testing.T) {
+	//Arrange
+	entry := payments.AuditEntry{
+		PaymentID: uuid.FromStringOrNil(paymentID),
+		Operation: payments.AuditUpdate,
+		Actor:     "alice",
+		Changes:   payments.AuditChanges{{Field: "attributes.amount", Before: "100.21", After: "200.00"}},
+	}
+	svc := &payments.MockService{}
+	svc.On("GetPaymentAudit", payments.GetPaymentAuditRequest{PaymentID: paymentID}).
+		Return(&payments.GetPaymentAuditResponse{Data: []payments.AuditEntry{entry}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.GetPaymentAudit(payments.GetPaymentAuditRequest{PaymentID: paymentID})
+
+	//Assert
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, entry.Changes, res.Data[0].Changes)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_RestorePayment(t *testing.T) {
+	//Arrange
+	req := payments.RestorePaymentRequest{PaymentID: paymentID, Actor: payments.Actor{UserID: "alice"}}
+	svc := &payments.MockService{}
+	received := req
+	received.Actor.SourceIP = "127.0.0.1"
+	svc.On("RestorePayment", received).Return(&payments.RestorePaymentResponse{PaymentID: paymentID}, nil)
+	svc.On("GetListOfPayments", payments.GetListOfPaymentsRequest{IncludeDeleted: true}).
+		Return(&payments.GetListOfPaymentsResponse{Data: []payments.Payment{{Deleted: true}}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.RestorePayment(req)
+	list, listErr := c.GetListOfPayments(payments.GetListOfPaymentsRequest{IncludeDeleted: true})
+
+	//Assert
+	require.NoError(t, err)
+	require.NoError(t, listErr)
+	assert.Equal(t, paymentID, res.PaymentID)
+	assert.True(t, list.Data[0].Deleted)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_GetListOfPayments_AccountNumber(t *testing.T) {
+	//Arrange
+	req := payments.GetListOfPaymentsRequest{AccountNumber: "GB29XABC10161234567801"}
+	svc := &payments.MockService{}
+	svc.On("GetListOfPayments", req).Return(&payments.GetListOfPaymentsResponse{Data: []payments.Payment{}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	_, err := c.GetListOfPayments(req)
+
+	//Assert
+	require.NoError(t, err)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_ReviewPayment(t *testing.T) {
+	//Arrange
+	req := payments.ReviewPaymentRequest{PaymentID: paymentID, Decision: payments.ReviewReject, Reason: "confirmed match"}
+	svc := &payments.MockService{}
+	received := req
+	received.Actor.SourceIP = "127.0.0.1"
+	svc.On("ReviewPayment", received).Return(&payments.ReviewPaymentResponse{PaymentID: paymentID, Status: payments.StatusRejected}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.ReviewPayment(req)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, payments.StatusRejected, res.Status)
+	assert.Equal(t, server.URL+"/v1/payments/"+paymentID+"/", res.HateoasLink.Self)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_ApprovePayment(t *testing.T) {
+	//Arrange
+	req := payments.ApprovePaymentRequest{PaymentID: paymentID, Approver: "bob", Decision: payments.ApprovalApprove, Comment: "checked"}
+	approval := payments.Approval{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(paymentID), Approver: "bob", Decision: payments.ApprovalApprove}
+	svc := &payments.MockService{}
+	received := req
+	received.Actor = payments.Actor{UserID: "bob", SourceIP: "127.0.0.1"}
+	svc.On("ApprovePayment", received).Return(&payments.ApprovePaymentResponse{Approval: approval, Status: payments.StatusSubmitted}, nil)
+	svc.On("GetPaymentApprovals", payments.GetPaymentApprovalsRequest{PaymentID: paymentID}).
+		Return(&payments.GetPaymentApprovalsResponse{Data: []payments.Approval{approval}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	res, err := c.ApprovePayment(req)
+	list, listErr := c.GetPaymentApprovals(payments.GetPaymentApprovalsRequest{PaymentID: paymentID})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, payments.StatusSubmitted, res.Status)
+	assert.Equal(t, approval.ID, res.ID)
+	require.NoError(t, listErr)
+	assert.Equal(t, []payments.Approval{approval}, list.Data)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_Returns(t *testing.T) {
+	//Arrange
+	req := payments.CreateReturnRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal, UserID: "alice", Amount: "10.00", ReasonCode: "AM05"}
+	reversal := payments.Return{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(paymentID), Type: payments.ReturnTypeReversal, Amount: "10.00", Status: payments.ReturnStatusPending}
+	failed := reversal
+	failed.Status = payments.ReturnStatusFailed
+	update := payments.UpdateReturnStatusRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal, ReturnID: reversal.ID.String(), Status: payments.ReturnStatusFailed, Reason: "rejected by the scheme",
+		Actor: payments.Actor{UserID: "bob", RequestID: "req-1"}}
+	received := update
+	received.Actor.SourceIP = "127.0.0.1"
+	svc := &payments.MockService{}
+	svc.On("CreateReturn", req).Return(&payments.CreateReturnResponse{Return: reversal}, nil)
+	svc.On("GetReturn", payments.GetReturnRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal, ReturnID: reversal.ID.String()}).
+		Return(&payments.GetReturnResponse{Return: reversal}, nil)
+	svc.On("GetPaymentReturns", payments.GetPaymentReturnsRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal}).
+		Return(&payments.GetPaymentReturnsResponse{Data: []payments.Return{reversal}}, nil)
+	svc.On("UpdateReturnStatus", received).Return(&payments.UpdateReturnStatusResponse{Return: failed}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	created, err := c.CreateReturn(req)
+	got, getErr := c.GetReturn(payments.GetReturnRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal, ReturnID: reversal.ID.String()})
+	list, listErr := c.GetPaymentReturns(payments.GetPaymentReturnsRequest{PaymentID: paymentID, Type: payments.ReturnTypeReversal})
+	updated, updateErr := c.UpdateReturnStatus(update)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, reversal.ID, created.ID)
+	assert.Equal(t, server.URL+"/v1/payments/"+paymentID+"/reversals/"+reversal.ID.String()+"/complete/", created.Complete)
+	require.NoError(t, getErr)
+	assert.Equal(t, "10.00", got.Amount)
+	require.NoError(t, listErr)
+	assert.Len(t, list.Data, 1)
+	require.NoError(t, updateErr)
+	assert.Equal(t, payments.ReturnStatusFailed, updated.Status)
+	assert.Empty(t, updated.Fail)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_Recalls(t *testing.T) {
+	//Arrange
+	req := payments.CreateRecallRequest{PaymentID: paymentID, UserID: "alice", ReasonCode: "DUPL"}
+	recall := payments.Recall{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(paymentID), ReasonCode: "DUPL", Status: payments.RecallStatusRequested}
+	rejected := recall
+	rejected.Status = payments.RecallStatusRejected
+	decide := payments.DecideRecallRequest{PaymentID: paymentID, RecallID: recall.ID.String(), Decision: payments.RecallReject, Reason: "funds already withdrawn",
+		Actor: payments.Actor{UserID: "bob"}}
+	decided := decide
+	decided.Actor.SourceIP = "127.0.0.1"
+	svc := &payments.MockService{}
+	svc.On("CreateRecall", req).Return(&payments.CreateRecallResponse{Recall: recall}, nil)
+	svc.On("GetRecall", payments.GetRecallRequest{PaymentID: paymentID, RecallID: recall.ID.String()}).Return(&payments.GetRecallResponse{Recall: recall}, nil)
+	svc.On("GetPaymentRecalls", payments.GetPaymentRecallsRequest{PaymentID: paymentID}).Return(&payments.GetPaymentRecallsResponse{Data: []payments.Recall{recall}}, nil)
+	svc.On("DecideRecall", decided).Return(&payments.DecideRecallResponse{Recall: rejected}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	created, err := c.CreateRecall(req)
+	got, getErr := c.GetRecall(payments.GetRecallRequest{PaymentID: paymentID, RecallID: recall.ID.String()})
+	list, listErr := c.GetPaymentRecalls(payments.GetPaymentRecallsRequest{PaymentID: paymentID})
+	res, decideErr := c.DecideRecall(decide)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/v1/payments/"+paymentID+"/recalls/"+recall.ID.String()+"/accept/", created.Accept)
+	require.NoError(t, getErr)
+	assert.Equal(t, "DUPL", got.ReasonCode)
+	require.NoError(t, listErr)
+	assert.Len(t, list.Data, 1)
+	require.NoError(t, decideErr)
+	assert.Equal(t, payments.RecallStatusRejected, res.Status)
+	assert.Empty(t, res.Reject)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_Ledger(t *testing.T) {
+	//Arrange
+	account := "party:134667:GB29NWBK60161331926819"
+	balance := &payments.GetAccountBalanceResponse{Account: account, Balances: []ledger.Balance{{Currency: "GBP", Debits: "100.21", Credits: "0.00", Balance: "-100.21"}}}
+	entries := &payments.GetAccountEntriesResponse{
+		Data: []ledger.Entry{{Reference: paymentID, Account: account, Direction: ledger.Debit, Amount: "100.21", Currency: "GBP"}},
+		Meta: payments.PageMeta{Page: 2, PageSize: 1},
+	}
+	svc := &payments.MockService{}
+	svc.On("GetAccountBalance", payments.GetAccountBalanceRequest{Account: account}).Return(balance, nil)
+	svc.On("GetAccountEntries", payments.GetAccountEntriesRequest{Account: account, Page: 2, PageSize: 1}).Return(entries, nil)
+	svc.On("CheckLedger", payments.CheckLedgerRequest{}).Return(&payments.CheckLedgerResponse{Balanced: true, Imbalances: []ledger.Imbalance{}}, nil)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	gotBalance, balanceErr := c.GetAccountBalance(payments.GetAccountBalanceRequest{Account: account})
+	gotEntries, entriesErr := c.GetAccountEntries(payments.GetAccountEntriesRequest{Account: account, Page: 2, PageSize: 1})
+	check, checkErr := c.CheckLedger(payments.CheckLedgerRequest{})
+
+	//Assert
+	require.NoError(t, balanceErr)
+	assert.Equal(t, balance, gotBalance)
+	require.NoError(t, entriesErr)
+	assert.Equal(t, entries.Meta, gotEntries.Meta)
+	assert.Equal(t, paymentID, gotEntries.Data[0].Reference)
+	require.NoError(t, checkErr)
+	assert.True(t, check.Balanced)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_OrganisationLimits(t *testing.T) {
+	//Arrange
+	organisationID := uuid.NewV4().String()
+	limits := payments.OrganisationLimits{AllowedSchemes: []string{"FPS"}, Currencies: []payments.CurrencyLimit{{Currency: "GBP", MaxAmount: "100.00"}}}
+	svc := &payments.MockService{}
+	svc.On("UpdateOrganisationLimits", payments.UpdateOrganisationLimitsRequest{OrganisationID: organisationID, Limits: limits}).
+		Return(&payments.UpdateOrganisationLimitsResponse{OrganisationLimits: limits}, nil)
+	svc.On("GetOrganisationLimits", payments.GetOrganisationLimitsRequest{OrganisationID: organisationID}).
+		Return(&payments.GetOrganisationLimitsResponse{OrganisationLimits: limits}, nil)
+	svc.On("DeleteOrganisationLimits", payments.DeleteOrganisationLimitsRequest{OrganisationID: organisationID}).
+		Return(nil, payments.ErrLimitsNotFound)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	updated, updateErr := c.UpdateOrganisationLimits(payments.UpdateOrganisationLimitsRequest{OrganisationID: organisationID, Limits: limits})
+	got, getErr := c.GetOrganisationLimits(payments.GetOrganisationLimitsRequest{OrganisationID: organisationID})
+	_, deleteErr := c.DeleteOrganisationLimits(payments.DeleteOrganisationLimitsRequest{OrganisationID: organisationID})
+
+	//Assert
+	require.NoError(t, updateErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, limits.Currencies, updated.Currencies)
+	assert.Equal(t, limits.AllowedSchemes, got.AllowedSchemes)
+	assert.Equal(t, payments.ErrLimitsNotFound.Message, deleteErr.(apierrors.APIError).Message)
+	svc.AssertExpectations(t)
+}
+
+func Test_Client_Errors(t *testing.T) {
+	//Arrange
+	svc := &payments.MockService{}
+	svc.On("GetPayment", mock.Anything).Return(nil, payments.ErrNotFound)
+	c, server := newTestClient(t, svc)
+	defer server.Close()
+
+	//Act
+	_, err := c.GetPayment(payments.GetPaymentRequest{PaymentID: paymentID})
+
+	//Assert
+	assert.Equal(t, payments.ErrNotFound, err)
+	// client errors are not retried
+	svc.AssertNumberOfCalls(t, "GetPayment", 1)
+}
+
+func Test_Client_Retries(t *testing.T) {
+	//Arrange
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if len(keys) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"` + paymentID + `"}`))
+	}))
+	defer server.Close()
+	c, err := New(server.URL, WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+
+	//Act
+	res, err := c.PostPayment(payments.CreatePaymentRequest{})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, paymentID, res.PaymentID)
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1], "retries reuse the idempotency key")
+}
+
+func Test_decodeError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected apierrors.APIError
+	}{
+		{"json", http.StatusBadRequest, `{"message":"invalid body"}`, apierrors.APIError{ResponseCode: http.StatusBadRequest, Message: "invalid body"}},
+		{"plain text", http.StatusBadGateway, "bad gateway\n", apierrors.APIError{ResponseCode: http.StatusBadGateway, Message: "bad gateway"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(tt.status)
+			rec.WriteString(tt.body)
+			//Act
+			err := decodeError(rec.Result())
+			//Assert
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package currency provides the ISO 4217 currencies and validates the amounts against their minor units.
+package currency
+
+import (
+	_ "embed" // embeds the ISO 4217 table
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code       string `json:"code"`
+	Numeric    string `json:"numeric"`
+	MinorUnits int    `json:"minor_units"`
+	Name       string `json:"name"`
+}
+
+//go:embed data/iso4217.csv
+var iso4217 string
+
+// currencies are indexed by code
+var currencies = mustReadCurrencies(iso4217)
+
+func mustReadCurrencies(data string) map[string]Currency {
+	all, err := readCurrencies(strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	byCode := make(map[string]Currency, len(all))
+	for _, c := range all {
+		byCode[c.Code] = c
+	}
+	return byCode
+}
+
+// readCurrencies reads the code, numeric code, minor units and name of the currencies, lines starting with # are ignored
+func readCurrencies(r io.Reader) ([]Currency, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Currency, 0, len(records))
+	for _, record := range records {
+		minorUnits, err := strconv.Atoi(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("currency %s: invalid minor units %s", record[0], record[2])
+		}
+		all = append(all, Currency{Code: record[0], Numeric: record[1], MinorUnits: minorUnits, Name: record[3]})
+	}
+	return all, nil
+}
+
+// Lookup returns the currency of an ISO 4217 alphabetic code
+func Lookup(code string) (Currency, bool) {
+	c, ok := currencies[code]
+	return c, ok
+}
+
+// All returns the currencies sorted by code
+func All() []Currency {
+	all := make([]Currency, 0, len(currencies))
+	for _, c := range currencies {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Code < all[j].Code })
+	return all
+}
+
+// ErrInvalidAmount is returned for amounts which are not positive decimal numbers, e.g. 100.21
+var ErrInvalidAmount = errors.New("is not a decimal amount")
+
+// AmountPattern is the regular expression of the decimal amounts
+const AmountPattern = `^\d+(\.\d+)?$`
+
+var amountPattern = regexp.MustCompile(AmountPattern)
+
+// Scale returns the number of decimals of an amount
+func Scale(amount string) (int, error) {
+	if !amountPattern.MatchString(amount) {
+		return 0, ErrInvalidAmount
+	}
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		return len(amount) - i - 1, nil
+	}
+	return 0, nil
+}
+
+// ValidateAmount checks the amount does not have more decimals than the minor units of the currency
+func (c Currency) ValidateAmount(amount string) error {
+	scale, err := Scale(amount)
+	if err != nil {
+		return err
+	}
+	if scale > c.MinorUnits {
+		return fmt.Errorf("has more than %d decimals for %s", c.MinorUnits, c.Code)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package currency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Lookup(t *testing.T) {
+	gbp, ok := Lookup("GBP")
+	require.True(t, ok)
+	assert.Equal(t, Currency{Code: "GBP", Numeric: "826", MinorUnits: 2, Name: "Pound Sterling"}, gbp)
+
+	jpy, ok := Lookup("JPY")
+	require.True(t, ok)
+	assert.Equal(t, 0, jpy.MinorUnits)
+
+	_, ok = Lookup("XXX")
+	assert.False(t, ok)
+	_, ok = Lookup("gbp")
+	assert.False(t, ok)
+}
+
+func Test_All(t *testing.T) {
+	all := All()
+	require.NotEmpty(t, all)
+	assert.Len(t, all, len(currencies))
+	for i := 1; i < len(all); i++ {
+		assert.True(t, all[i-1].Code < all[i].Code, "currencies should be sorted by code")
+	}
+}
+
+func Test_readCurrencies_Invalid(t *testing.T) {
+	_, err := readCurrencies(strings.NewReader("GBP,826,two,Pound Sterling\n"))
+	assert.Error(t, err)
+	_, err = readCurrencies(strings.NewReader("GBP,826,2\n"))
+	assert.Error(t, err)
+}
+
+func Test_Currency_ValidateAmount(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		valid    bool
+	}{
+		{"GBP", "100.21", true},
+		{"GBP", "100.2", true},
+		{"GBP", "100", true},
+		{"GBP", "100.211", false},
+		{"JPY", "100", true},
+		{"JPY", "100.0", false},
+		{"KWD", "1.125", true},
+		{"GBP", "-1.00", false},
+		{"GBP", "1,00", false},
+		{"GBP", ".5", false},
+		{"GBP", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency+" "+tt.amount, func(t *testing.T) {
+			c, _ := Lookup(tt.currency)
+			assert.Equal(t, tt.valid, c.ValidateAmount(tt.amount) == nil)
+		})
+	}
+}
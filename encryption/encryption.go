@@ -0,0 +1,208 @@
+// Package encryption encrypts values at rest with envelope encryption: the values of a record are encrypted by a data
+// key of its own, stored with the record once encrypted by a key encryption key (KEK) of a KeyProvider. Blind indexes,
+// keyed hashes of values, let the encrypted values be looked up by equality.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// keySize is the size of the data keys and of the key encryption keys, AES-256
+const keySize = 32
+
+// KeyProvider holds the key encryption keys. A KEK is named by an id stored with the data keys it encrypts, so the data
+// keys encrypted by a former KEK can still be decrypted once the current one is rotated.
+type KeyProvider interface {
+	// CurrentKeyID returns the id of the KEK encrypting the new data keys
+	CurrentKeyID() string
+	// WrapKey encrypts a data key with a KEK
+	WrapKey(keyID string, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key encrypted by a KEK
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+	// IndexKey returns the key of the blind indexes. It is not rotated, the indexes would have to be computed again.
+	IndexKey() []byte
+}
+
+// DataKey encrypts the values of a record
+type DataKey struct {
+	// KeyID is the id of the KEK encrypting the data key
+	KeyID string
+	// Wrapped is the data key encrypted by the KEK
+	Wrapped []byte
+	aead    cipher.AEAD
+}
+
+// NewDataKey returns a random data key encrypted by the current KEK
+func NewDataKey(keys KeyProvider) (DataKey, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return DataKey{}, err
+	}
+	keyID := keys.CurrentKeyID()
+	wrapped, err := keys.WrapKey(keyID, key)
+	if err != nil {
+		return DataKey{}, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return DataKey{}, err
+	}
+	return DataKey{KeyID: keyID, Wrapped: wrapped, aead: aead}, nil
+}
+
+// OpenDataKey decrypts a data key encrypted by a KEK
+func OpenDataKey(keys KeyProvider, keyID string, wrapped []byte) (DataKey, error) {
+	key, err := keys.UnwrapKey(keyID, wrapped)
+	if err != nil {
+		return DataKey{}, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return DataKey{}, err
+	}
+	return DataKey{KeyID: keyID, Wrapped: wrapped, aead: aead}, nil
+}
+
+// Encrypt returns the base64 encoded nonce and ciphertext of a value, an empty value is kept empty
+func (k DataKey) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	sealed, err := seal(k.aead, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the value of a ciphertext returned by Encrypt
+func (k DataKey) Decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(k.aead, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns the hex encoded HMAC-SHA256 of a value, equal values have equal indexes
+func BlindIndex(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// FileKeyProvider holds the KEKs in a local JSON file, for development and tests:
+//
+//	{"current": "2021-01", "keys": {"2020-06": "<base64>", "2021-01": "<base64>"}, "index_key": "<base64>"}
+//
+// The keys are 32 random bytes. A KEK is rotated by adding a key and making it current, the former keys are kept
+// until no data key is encrypted by them anymore.
+type FileKeyProvider struct {
+	current string
+	keys    map[string]cipher.AEAD
+	index   []byte
+}
+
+// NewFileKeyProvider reads the keys of a file
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Current  string            `json:"current"`
+		Keys     map[string]string `json:"keys"`
+		IndexKey string            `json:"index_key"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	p := &FileKeyProvider{current: file.Current, keys: map[string]cipher.AEAD{}}
+	for id, encoded := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("%s: key %s is not %d base64 encoded bytes", path, id, keySize)
+		}
+		if p.keys[id], err = newAEAD(key); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := p.keys[p.current]; !ok {
+		return nil, fmt.Errorf("%s: the current key %q is missing", path, p.current)
+	}
+	if p.index, err = base64.StdEncoding.DecodeString(file.IndexKey); err != nil || len(p.index) < keySize {
+		return nil, fmt.Errorf("%s: the index key is not at least %d base64 encoded bytes", path, keySize)
+	}
+	return p, nil
+}
+
+// CurrentKeyID returns the id of the current key
+func (p *FileKeyProvider) CurrentKeyID() string {
+	return p.current
+}
+
+// WrapKey encrypts a data key with a key of the file
+func (p *FileKeyProvider) WrapKey(keyID string, dataKey []byte) ([]byte, error) {
+	kek, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+	return seal(kek, dataKey, []byte(keyID))
+}
+
+// UnwrapKey decrypts a data key encrypted by a key of the file
+func (p *FileKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+	return open(kek, wrapped, []byte(keyID))
+}
+
+// IndexKey returns the index key of the file
+func (p *FileKeyProvider) IndexKey() []byte {
+	return p.index
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts with a random nonce prepended to the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts a ciphertext returned by seal
+func open(aead cipher.AEAD, sealed []byte, data []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], data)
+}
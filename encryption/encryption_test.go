@@ -0,0 +1,115 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeys writes a key file of the given keys, named by their ids and made of the same repeated byte
+func writeKeys(t *testing.T, dir string, current string, ids ...string) string {
+	keys := []string{}
+	for _, id := range ids {
+		keys = append(keys, `"`+id+`": "`+base64.StdEncoding.EncodeToString([]byte(strings.Repeat(id[:1], keySize)))+`"`)
+	}
+	path := filepath.Join(dir, "keys.json")
+	data := `{"current": "` + current + `", "keys": {` + strings.Join(keys, ", ") + `}, "index_key": "` +
+		base64.StdEncoding.EncodeToString([]byte(strings.Repeat("i", keySize))) + `"}`
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+func Test_DataKey(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keys, err := NewFileKeyProvider(writeKeys(t, dir, "a", "a"))
+	require.NoError(t, err)
+	key, err := NewDataKey(keys)
+	require.NoError(t, err)
+
+	//Act
+	ciphertext, err := key.Encrypt("GB29XABC10161234567801")
+	opened, openErr := OpenDataKey(keys, key.KeyID, key.Wrapped)
+	plaintext, decryptErr := opened.Decrypt(ciphertext)
+	_, tamperedErr := opened.Decrypt(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 40))))
+	empty, _ := key.Encrypt("")
+
+	//Assert
+	require.NoError(t, err)
+	require.NoError(t, openErr)
+	require.NoError(t, decryptErr)
+	assert.NotContains(t, ciphertext, "GB29XABC10161234567801")
+	assert.Equal(t, "GB29XABC10161234567801", plaintext)
+	assert.Equal(t, "a", key.KeyID)
+	assert.Error(t, tamperedErr)
+	assert.Empty(t, empty)
+}
+
+func Test_FileKeyProvider_Rotation(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	before, err := NewFileKeyProvider(writeKeys(t, dir, "a", "a"))
+	require.NoError(t, err)
+	key, err := NewDataKey(before)
+	require.NoError(t, err)
+	ciphertext, err := key.Encrypt("Wilfred Jeremiah Owens")
+	require.NoError(t, err)
+
+	//Act
+	after, err := NewFileKeyProvider(writeKeys(t, dir, "b", "a", "b"))
+	require.NoError(t, err)
+	opened, openErr := OpenDataKey(after, key.KeyID, key.Wrapped)
+	plaintext, _ := opened.Decrypt(ciphertext)
+	rotated, _ := NewDataKey(after)
+	_, wrongKeyErr := OpenDataKey(after, "b", key.Wrapped)
+
+	//Assert
+	require.NoError(t, openErr)
+	assert.Equal(t, "Wilfred Jeremiah Owens", plaintext)
+	assert.Equal(t, "b", rotated.KeyID)
+	assert.Error(t, wrongKeyErr)
+}
+
+func Test_NewFileKeyProvider_Invalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "Should reject a file which is not JSON", data: "keys"},
+		{name: "Should reject a missing current key", data: `{"current": "b", "keys": {"a": "` + base64.StdEncoding.EncodeToString(make([]byte, keySize)) + `"}}`},
+		{name: "Should reject a short key", data: `{"current": "a", "keys": {"a": "YWJj"}}`},
+		{name: "Should reject a missing index key", data: `{"current": "a", "keys": {"a": "` + base64.StdEncoding.EncodeToString(make([]byte, keySize)) + `"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(dir, "keys.json")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.data), 0600))
+
+			//Act
+			_, err := NewFileKeyProvider(path)
+
+			//Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_BlindIndex(t *testing.T) {
+	assert.Equal(t, BlindIndex([]byte("key"), "12345678"), BlindIndex([]byte("key"), "12345678"))
+	assert.NotEqual(t, BlindIndex([]byte("key"), "12345678"), BlindIndex([]byte("key"), "87654321"))
+	assert.NotEqual(t, BlindIndex([]byte("key"), "12345678"), BlindIndex([]byte("other"), "12345678"))
+}
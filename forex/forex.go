@@ -0,0 +1,103 @@
+// Package forex checks the foreign exchange details of the payments are consistent with their amount.
+package forex
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// RateDirection tells how the exchange rate of a payment is quoted
+type RateDirection string
+
+const (
+	// RateOriginalToAmount rates are units of the payment currency per unit of the original currency:
+	// amount = original amount × exchange rate
+	RateOriginalToAmount RateDirection = "original_to_amount"
+	// RateAmountToOriginal rates are units of the original currency per unit of the payment currency:
+	// amount = original amount ÷ exchange rate
+	RateAmountToOriginal RateDirection = "amount_to_original"
+)
+
+// DefaultTolerance is the relative difference accepted between the amount and the converted original amount, 1 basis point
+const DefaultTolerance = "0.0001"
+
+// ErrInvalidRate is returned for exchange rates which are not positive decimal numbers, e.g. 0.50000
+var ErrInvalidRate = errors.New("is not a positive decimal rate")
+
+var decimalPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// Checker checks the amounts of the payments match their original amounts converted at their exchange rate
+type Checker struct {
+	direction RateDirection
+	tolerance *big.Rat
+}
+
+// NewChecker returns a checker of the rates quoted in the direction, accepting a relative difference up to the tolerance, e.g. 0.0001
+func NewChecker(direction RateDirection, tolerance string) (Checker, error) {
+	if direction != RateOriginalToAmount && direction != RateAmountToOriginal {
+		return Checker{}, fmt.Errorf("unknown rate direction %s", direction)
+	}
+	if !decimalPattern.MatchString(tolerance) {
+		return Checker{}, fmt.Errorf("invalid tolerance %s", tolerance)
+	}
+	t, _ := new(big.Rat).SetString(tolerance)
+	return Checker{direction: direction, tolerance: t}, nil
+}
+
+// Conversion is the conversion of an original amount into the amount of a payment
+type Conversion struct {
+	Amount         string
+	MinorUnits     int
+	OriginalAmount string
+	ExchangeRate   string
+}
+
+// Check returns the expected amount of the conversion, rounded to the minor units, and whether the amount matches it.
+// The amount matches when it is the rounded expected amount or within the tolerance of the exact converted amount.
+// The amounts must be decimal numbers.
+func (c Checker) Check(conv Conversion) (expected string, ok bool, err error) {
+	exact, err := c.convert(conv.OriginalAmount, conv.ExchangeRate)
+	if err != nil {
+		return "", false, err
+	}
+	expected = exact.FloatString(conv.MinorUnits)
+	if conv.Amount == expected {
+		return expected, true, nil
+	}
+	amount, valid := new(big.Rat).SetString(conv.Amount)
+	if !valid {
+		return expected, false, fmt.Errorf("invalid amount %s", conv.Amount)
+	}
+	difference := new(big.Rat).Sub(amount, exact)
+	allowed := new(big.Rat).Mul(exact, c.tolerance)
+	return expected, difference.Abs(difference).Cmp(allowed) <= 0, nil
+}
+
+// convert returns the exact original amount converted at the exchange rate
+func (c Checker) convert(originalAmount string, rate string) (*big.Rat, error) {
+	if !decimalPattern.MatchString(rate) {
+		return nil, ErrInvalidRate
+	}
+	r, _ := new(big.Rat).SetString(rate)
+	if r.Sign() == 0 {
+		return nil, ErrInvalidRate
+	}
+	original, ok := new(big.Rat).SetString(originalAmount)
+	if !ok {
+		return nil, fmt.Errorf("invalid original amount %s", originalAmount)
+	}
+	if c.direction == RateAmountToOriginal {
+		return original.Quo(original, r), nil
+	}
+	return original.Mul(original, r), nil
+}
+
+// DefaultChecker checks the rates quoted from the original currency to the payment currency, with the default tolerance
+var DefaultChecker, _ = NewChecker(RateOriginalToAmount, DefaultTolerance)
+
+// Check checks the conversion with the default checker
+func Check(conv Conversion) (expected string, ok bool, err error) {
+	return DefaultChecker.Check(conv)
+}
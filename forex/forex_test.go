@@ -0,0 +1,60 @@
+package forex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewChecker_Invalid(t *testing.T) {
+	_, err := NewChecker("sideways", DefaultTolerance)
+	assert.Error(t, err)
+	_, err = NewChecker(RateOriginalToAmount, "1%")
+	assert.Error(t, err)
+	_, err = NewChecker(RateOriginalToAmount, "-0.1")
+	assert.Error(t, err)
+}
+
+func Test_Checker_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction RateDirection
+		tolerance string
+		conv      Conversion
+		expected  string
+		ok        bool
+	}{
+		{"original to amount", RateOriginalToAmount, "0", Conversion{"100.21", 2, "200.42", "0.50000"}, "100.21", true},
+		{"inverted rate", RateOriginalToAmount, "0", Conversion{"100.21", 2, "200.42", "2.00000"}, "400.84", false},
+		{"amount to original", RateAmountToOriginal, "0", Conversion{"100.21", 2, "200.42", "2.00000"}, "100.21", true},
+		{"rounded half up", RateOriginalToAmount, "0", Conversion{"1.01", 2, "1.00", "1.005"}, "1.01", true},
+		{"rounded to the minor units", RateOriginalToAmount, "0", Conversion{"123", 0, "1.00", "123.456"}, "123", true},
+		{"within the tolerance", RateOriginalToAmount, "0.0001", Conversion{"1000.10", 2, "2000.00", "0.50000"}, "1000.00", true},
+		{"outside the tolerance", RateOriginalToAmount, "0.0001", Conversion{"1000.11", 2, "2000.00", "0.50000"}, "1000.00", false},
+		{"rate quoted with few decimals", RateAmountToOriginal, "0.0001", Conversion{"81.23", 2, "100.00", "1.2311"}, "81.23", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			c, err := NewChecker(tt.direction, tt.tolerance)
+			require.NoError(t, err)
+
+			//Act
+			expected, ok, err := c.Check(tt.conv)
+
+			//Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expected)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func Test_Checker_Check_InvalidRate(t *testing.T) {
+	for _, rate := range []string{"0", "0.00000", "-0.5", "", "1,5"} {
+		_, ok, err := Check(Conversion{"100.21", 2, "200.42", rate})
+		assert.Equal(t, ErrInvalidRate, err, rate)
+		assert.False(t, ok)
+	}
+}
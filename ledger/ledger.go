@@ -0,0 +1,196 @@
+// Package ledger keeps the double-entry accounts of the money moved by the payments. Every transaction debits and
+// credits its accounts for the same total in each currency, a change is undone by a reversing transaction.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Direction tells whether an entry debits or credits its account
+type Direction string
+
+const (
+	// Debit entries take money out of their account
+	Debit Direction = "debit"
+	// Credit entries put money into their account
+	Credit Direction = "credit"
+)
+
+// ErrUnbalanced is returned for the transactions whose debits and credits differ in a currency
+var ErrUnbalanced = errors.New("the transaction does not balance")
+
+// maxDecimals is the number of decimals of the amounts reported by the invariant check
+const maxDecimals = 8
+
+var amountPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// accountPattern matches the party accounts, party:<bank_id>:<account_number>, and the charges accounts, charges:<bank_id>
+var accountPattern = regexp.MustCompile(`^(party:[^:/\s]+:[^:/\s]+|charges:[^:/\s]+)$`)
+
+// PartyAccount returns the internal account of the account of a payment party at its bank
+func PartyAccount(bankID string, accountNumber string) string {
+	return "party:" + bankID + ":" + accountNumber
+}
+
+// ChargesAccount returns the internal account collecting the charges of a bank
+func ChargesAccount(bankID string) string {
+	return "charges:" + bankID
+}
+
+// ValidAccount reports whether an account is a party account or a charges account
+func ValidAccount(account string) bool {
+	return accountPattern.MatchString(account)
+}
+
+// Entry debits or credits an account with a positive amount
+type Entry struct {
+	ID            uint      `json:"-" gorm:"primary_key"`
+	TransactionID uuid.UUID `json:"transaction_id" gorm:"type:uuid" sql:"index"`
+	// Reference is the reference of the transaction, copied so the entries of an account tell where they come from
+	Reference string    `json:"reference"`
+	Account   string    `json:"account" sql:"index"`
+	Direction Direction `json:"direction"`
+	Amount    string    `json:"amount" gorm:"type:numeric"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName stores the entries in the ledger_entries table
+func (Entry) TableName() string {
+	return "ledger_entries"
+}
+
+// Transaction is a set of entries posted together, which sums to zero in each currency
+type Transaction struct {
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	// Reference is the resource whose change posted the transaction, e.g. a payment ID
+	Reference   string `json:"reference" sql:"index"`
+	Description string `json:"description"`
+	// Reverses is the transaction undone by this one
+	Reverses  *uuid.UUID `json:"reverses,omitempty" gorm:"type:uuid"`
+	Entries   []Entry    `json:"entries" gorm:"foreignkey:TransactionID"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName stores the transactions in the ledger_transactions table
+func (Transaction) TableName() string {
+	return "ledger_transactions"
+}
+
+// NewTransaction returns a transaction without entries
+func NewTransaction(reference string, description string) *Transaction {
+	return &Transaction{ID: uuid.NewV4(), Reference: reference, Description: description}
+}
+
+// Transfer adds the entries moving an amount from an account to another, nothing when the amount is zero
+func (t *Transaction) Transfer(from string, to string, amount string, currency string) {
+	if r, ok := new(big.Rat).SetString(amount); ok && r.Sign() == 0 {
+		return
+	}
+	t.Entries = append(t.Entries,
+		Entry{TransactionID: t.ID, Reference: t.Reference, Account: from, Direction: Debit, Amount: amount, Currency: currency},
+		Entry{TransactionID: t.ID, Reference: t.Reference, Account: to, Direction: Credit, Amount: amount, Currency: currency},
+	)
+}
+
+// Reversal returns the transaction undoing t: the same entries in the other direction
+func (t *Transaction) Reversal(description string) *Transaction {
+	reversal := NewTransaction(t.Reference, description)
+	reversal.Reverses = &t.ID
+	for _, e := range t.Entries {
+		e.ID, e.TransactionID, e.CreatedAt = 0, reversal.ID, time.Time{}
+		e.Direction = opposite(e.Direction)
+		reversal.Entries = append(reversal.Entries, e)
+	}
+	return reversal
+}
+
+func opposite(d Direction) Direction {
+	if d == Debit {
+		return Credit
+	}
+	return Debit
+}
+
+// Check returns an error when an entry is invalid or when the debits and the credits differ in a currency
+func (t *Transaction) Check() error {
+	sums := map[string]*big.Rat{}
+	for _, e := range t.Entries {
+		if !amountPattern.MatchString(e.Amount) {
+			return fmt.Errorf("transaction %s: invalid amount %q of account %s", t.ID, e.Amount, e.Account)
+		}
+		if e.Direction != Debit && e.Direction != Credit {
+			return fmt.Errorf("transaction %s: invalid direction %q of account %s", t.ID, e.Direction, e.Account)
+		}
+		if sums[e.Currency] == nil {
+			sums[e.Currency] = new(big.Rat)
+		}
+		amount, _ := new(big.Rat).SetString(e.Amount)
+		if e.Direction == Debit {
+			sums[e.Currency].Add(sums[e.Currency], amount)
+		} else {
+			sums[e.Currency].Sub(sums[e.Currency], amount)
+		}
+	}
+	var unbalanced []string
+	for currency, sum := range sums {
+		if sum.Sign() != 0 {
+			unbalanced = append(unbalanced, fmt.Sprintf("%s %s", decimal(sum), currency))
+		}
+	}
+	if len(unbalanced) > 0 {
+		sort.Strings(unbalanced)
+		return fmt.Errorf("%w, %s: the debits exceed the credits by %s", ErrUnbalanced, t.ID, strings.Join(unbalanced, ", "))
+	}
+	return nil
+}
+
+// decimal formats an exact decimal amount without trailing zeros
+func decimal(r *big.Rat) string {
+	s := strings.TrimRight(r.FloatString(maxDecimals), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Balance is the position of an account in a currency
+type Balance struct {
+	Currency string `json:"currency"`
+	Debits   string `json:"debits"`
+	Credits  string `json:"credits"`
+	// Balance is the credits less the debits
+	Balance string `json:"balance"`
+}
+
+// NewBalance returns the balance of the total debits and credits of an account, decimal amounts formatted with the
+// given minor units
+func NewBalance(currency string, debits string, credits string, minorUnits int) Balance {
+	d, _ := new(big.Rat).SetString(debits)
+	c, _ := new(big.Rat).SetString(credits)
+	if d == nil {
+		d = new(big.Rat)
+	}
+	if c == nil {
+		c = new(big.Rat)
+	}
+	return Balance{
+		Currency: currency,
+		Debits:   d.FloatString(minorUnits),
+		Credits:  c.FloatString(minorUnits),
+		Balance:  new(big.Rat).Sub(c, d).FloatString(minorUnits),
+	}
+}
+
+// Imbalance is a transaction whose debits and credits differ in a currency, found by the invariant check of the ledger
+type Imbalance struct {
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Currency      string    `json:"currency"`
+	// Difference is the debits less the credits
+	Difference string `json:"difference"`
+}
@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Transaction_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []Entry
+		wantErr string
+	}{
+		{
+			name: "balanced in each currency",
+			entries: []Entry{
+				{Account: "party:1:a", Direction: Debit, Amount: "100.21", Currency: "GBP"},
+				{Account: "party:2:b", Direction: Credit, Amount: "100.21", Currency: "GBP"},
+				{Account: "party:1:a", Direction: Debit, Amount: "10", Currency: "USD"},
+				{Account: "charges:1", Direction: Credit, Amount: "10.00", Currency: "USD"},
+			},
+		},
+		{
+			name: "unbalanced in a currency",
+			entries: []Entry{
+				{Account: "party:1:a", Direction: Debit, Amount: "100.21", Currency: "GBP"},
+				{Account: "party:2:b", Direction: Credit, Amount: "100.20", Currency: "GBP"},
+				{Account: "party:1:a", Direction: Debit, Amount: "10.00", Currency: "USD"},
+				{Account: "charges:1", Direction: Credit, Amount: "10.00", Currency: "USD"},
+			},
+			wantErr: "the debits exceed the credits by 0.01 GBP",
+		},
+		{
+			name:    "negative amount",
+			entries: []Entry{{Account: "party:1:a", Direction: Debit, Amount: "-1.00", Currency: "GBP"}},
+			wantErr: `invalid amount "-1.00" of account party:1:a`,
+		},
+		{
+			name:    "unknown direction",
+			entries: []Entry{{Account: "party:1:a", Direction: "sideways", Amount: "1.00", Currency: "GBP"}},
+			wantErr: `invalid direction "sideways" of account party:1:a`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			tx := NewTransaction("payment", "payment created")
+			tx.Entries = tt.entries
+
+			//Act
+			err := tx.Check()
+
+			//Assert
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func Test_Transaction_Transfer_Reversal(t *testing.T) {
+	//Arrange
+	tx := NewTransaction("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", "payment created")
+
+	//Act
+	tx.Transfer("party:1:a", "party:2:b", "100.21", "GBP")
+	tx.Transfer("party:1:a", "charges:1", "0.00", "GBP")
+	reversal := tx.Reversal("payment deleted")
+
+	//Assert
+	require.Len(t, tx.Entries, 2, "a zero amount posts nothing")
+	assert.Equal(t, Entry{TransactionID: tx.ID, Reference: tx.Reference, Account: "party:1:a", Direction: Debit, Amount: "100.21", Currency: "GBP"}, tx.Entries[0])
+	assert.NoError(t, tx.Check())
+	assert.Equal(t, &tx.ID, reversal.Reverses)
+	assert.Equal(t, tx.Reference, reversal.Reference)
+	require.Len(t, reversal.Entries, 2)
+	assert.Equal(t, Entry{TransactionID: reversal.ID, Reference: tx.Reference, Account: "party:1:a", Direction: Credit, Amount: "100.21", Currency: "GBP"}, reversal.Entries[0])
+	assert.Equal(t, Debit, reversal.Entries[1].Direction)
+	assert.NoError(t, reversal.Check())
+}
+
+func Test_ValidAccount(t *testing.T) {
+	assert.True(t, ValidAccount(PartyAccount("134667", "GB29NWBK60161331926819")))
+	assert.True(t, ValidAccount(ChargesAccount("134667")))
+	assert.False(t, ValidAccount("party:134667"))
+	assert.False(t, ValidAccount("charges:"))
+	assert.False(t, ValidAccount("savings:134667:1"))
+}
+
+func Test_NewBalance(t *testing.T) {
+	assert.Equal(t, Balance{Currency: "GBP", Debits: "105.21", Credits: "40.00", Balance: "-65.21"}, NewBalance("GBP", "105.21", "40", 2))
+	assert.Equal(t, Balance{Currency: "JPY", Debits: "0", Credits: "1500", Balance: "1500"}, NewBalance("JPY", "", "1500", 0))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,7 +13,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/elkousy/payments-api/accounts"
+	"github.com/elkousy/payments-api/archive"
+	"github.com/elkousy/payments-api/calendar"
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/forex"
 	"github.com/elkousy/payments-api/payments"
+	"github.com/elkousy/payments-api/schemes"
+	"github.com/elkousy/payments-api/screening"
 	"github.com/elkousy/payments-api/utility/config"
 	"github.com/elkousy/payments-api/utility/logger"
 	"github.com/gorilla/mux"
@@ -31,6 +40,9 @@ func main() {
 	//errc <- fmt.Errorf("%s", <-c)
 	//}()
 
+	// mask the sensitive values in the logs
+	logger.Redact(config.LogRedactFields...)
+
 	// init database
 	db, err := payments.DbConnect()
 	if err != nil {
@@ -38,19 +50,161 @@ func main() {
 		os.Exit(0)
 	}
 
-	// init repository
-	repository := payments.NewPaymentRepository(db)
+	// init repository, the parties are encrypted at rest when a key file is set
+	var repositoryOpts []payments.RepositoryOption
+	var keys encryption.KeyProvider
+	if config.EncryptionKeysFile != "" {
+		keys, err = encryption.NewFileKeyProvider(config.EncryptionKeysFile)
+		if err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when reading the encryption keys"))
+			os.Exit(0)
+		}
+		repositoryOpts = append(repositoryOpts, payments.WithEncryption(keys))
+	}
+	repository := payments.NewPaymentRepository(db, repositoryOpts...)
 	defer payments.DbClose(db)
 	payments.DbMigrate(db)
 
+	// the payments are looked up in the archive once moved out of the database
+	var archiveStore archive.Store
+	if config.ArchiveTarget != "" {
+		if archiveStore, err = archive.NewStore(config.ArchiveTarget); err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when opening the archive"))
+			os.Exit(0)
+		}
+	}
+
+	// `app archive` moves the payments older than ARCHIVE_AFTER to the archive and exits
+	if len(os.Args) > 1 && os.Args[1] == "archive" {
+		code := runArchiver(repository, archiveStore, keys)
+		payments.DbClose(db)
+		os.Exit(code)
+	}
+
+	// load the modulus rules checking the UK account numbers
+	if config.ModulusRulesFile != "" {
+		rules, err := accounts.LoadModulusRules(config.ModulusRulesFile)
+		if err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when loading the modulus rules"))
+			os.Exit(0)
+		}
+		accounts.RegisterBankIDCode(accounts.BankIDCodeUKSortCode, accounts.NewSortCodeValidator(rules))
+	}
+
+	// load the payment scheme rules
+	if config.SchemeRulesFile != "" {
+		if err := schemes.DefaultRegistry.LoadFile(config.SchemeRulesFile); err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when loading the payment scheme rules"))
+			os.Exit(0)
+		}
+	}
+
+	// load the fee schedules of the payment schemes
+	if config.ChargesFile != "" {
+		if err := charges.DefaultRegistry.LoadFile(config.ChargesFile); err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when loading the fee schedules"))
+			os.Exit(0)
+		}
+	}
+
+	// load the business-day calendars of the payment schemes
+	for _, path := range config.CalendarFiles {
+		if err := calendar.DefaultRegistry.LoadFile(path); err != nil {
+			logger.LogStdErr.Error(errors.Wrapf(err, "error when loading the calendar %s", path))
+			os.Exit(0)
+		}
+	}
+
+	// check the fx of the payments with the configured rate direction
+	fx, err := forex.NewChecker(forex.RateDirection(config.FXRateDirection), config.FXTolerance)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when configuring the fx checks"))
+		os.Exit(0)
+	}
+	forex.DefaultChecker = fx
+
+	// init the broker of the payment events, the replicas share them through the payment_events table
+	eventLog, err := payments.NewPostgresEventLog(db)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when listening to the payment events"))
+		os.Exit(0)
+	}
+	events := payments.NewEventBroker(config.EventsRetention, config.EventsBufferSize, payments.WithEventLog(eventLog))
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	go events.Run(eventsCtx, config.EventsPollInterval)
+
+	// flag or reject the payments looking like a recent payment of their organisation
+	duplicatePolicy, err := payments.ParseDuplicatePolicy(config.DuplicatePolicy)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when configuring the duplicate check"))
+		os.Exit(0)
+	}
+	organisationPolicies, err := payments.ParseDuplicatePolicies(config.DuplicatePolicyOrganisations)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when configuring the duplicate check"))
+		os.Exit(0)
+	}
+	duplicates := payments.DuplicateCheck{Window: config.DuplicateWindow, Policy: duplicatePolicy, Organisations: organisationPolicies}
+
+	// hold for review the payments whose parties match the sanctions lists
+	var screener *screening.Screener
+	if config.ScreeningSDNFile != "" {
+		entries, err := screening.LoadSDN(config.ScreeningSDNFile, config.ScreeningAltFile, config.ScreeningAddFile)
+		if err != nil {
+			logger.LogStdErr.Error(errors.Wrap(err, "error when loading the sanctions lists"))
+			os.Exit(0)
+		}
+		screener = screening.NewScreener(entries, config.ScreeningThreshold)
+	}
+
 	// init service
-	svc, err := payments.NewPaymentService(repository)
+	opts := []payments.ServiceOption{payments.WithDuplicateCheck(duplicates), payments.WithScreening(screener), payments.WithRecallWindow(config.RecallWindow)}
+	if keys != nil {
+		opts = append(opts, payments.WithFingerprintKey(keys.IndexKey()))
+	}
+	if archiveStore != nil {
+		opts = append(opts, payments.WithArchive(archiveStore, keys))
+	}
+	svc, err := payments.NewPaymentService(repository, events, opts...)
 	if err != nil {
 		errc <- err
 	}
 
-	// build api endpoints
+	// mark the scheduled payments due on their processing date, on the replica holding the scheduler lock only
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if config.SchedulerInterval > 0 {
+		handler := payments.DuePaymentHandlerFunc(func(_ context.Context, p payments.Payment) error {
+			logger.LogStdOut.Infow("payment due", "payment_id", p.ID.String(), "processing_date", p.Attributes.ProcessingDate)
+			return nil
+		})
+		scheduler := payments.NewScheduler(repository, events, handler, payments.NewAdvisoryLockLeader(db.DB(), payments.SchedulerLockKey))
+		go scheduler.Run(schedulerCtx, config.SchedulerInterval)
+	}
+	// purge the payments deleted longer ago than the retention period, on the replica holding the purger lock only
+	if config.PaymentRetention > 0 && config.PurgeInterval > 0 {
+		purger := payments.NewPurger(repository, payments.NewAdvisoryLockLeader(db.DB(), payments.PurgerLockKey), config.PaymentRetention)
+		go purger.Run(schedulerCtx, config.PurgeInterval)
+	}
+	// re-encrypt the parties encrypted with a former key, on the replica holding the rotator lock only
+	if config.EncryptionKeysFile != "" && config.KeyRotationInterval > 0 {
+		rotator := payments.NewKeyRotator(repository, payments.NewAdvisoryLockLeader(db.DB(), payments.KeyRotatorLockKey))
+		go rotator.Run(schedulerCtx, config.KeyRotationInterval)
+	}
+
+	// build api endpoints, the account numbers are shown in full to the users having the privileged role only
 	endpoints := payments.MakeEndpoints(svc)
+	proxies, err := payments.ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when reading the trusted proxies"))
+		os.Exit(0)
+	}
+	transportOpts := []payments.TransportOption{
+		payments.WithPrivilegeCheck(payments.RolePrivilegeCheck(config.PrivilegedRole)),
+		payments.WithAdminCheck(payments.RolePrivilegeCheck(config.AdminRole)),
+		payments.WithTrustedProxies(proxies),
+	}
 
 	// Instances a new HTTP server
 
@@ -70,7 +224,7 @@ func main() {
 		})
 
 		// init and register to the router the various endpoints
-		payments.MakeHTTPHandler(endpoints, mux)
+		payments.MakeHTTPHandler(endpoints, events, mux, transportOpts...)
 
 		logger.LogStdOut.Info(fmt.Sprintf("The %s has started on port %s", config.AppName, httpAddr))
 
@@ -78,6 +232,19 @@ func main() {
 		errc <- s.ListenAndServe()
 	}()
 
+	// launch the gRPC server exposing the same endpoints
+	grpcServer := payments.MakeGRPCServer(endpoints, transportOpts...)
+	go func() {
+		grpcAddr := ":" + strconv.Itoa(config.GRPCPort)
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			errc <- err
+			return
+		}
+		logger.LogStdOut.Info(fmt.Sprintf("The gRPC server has started on port %s", grpcAddr))
+		errc <- grpcServer.Serve(lis)
+	}()
+
 	// launch a dedicated webserver for observability , i.e. health check and metrics
 	go func() {
 		opsHTTPAddr := ":" + strconv.Itoa(config.OpsPort)
@@ -109,6 +276,9 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
+		stopScheduler()
+		grpcServer.GracefulStop()
+
 		if err := s.Shutdown(ctx); err != nil {
 			logger.LogStdErr.Fatalf("Could not stop the http server gracefully: %v", err)
 			if err := s.Close(); err != nil {
@@ -125,3 +295,19 @@ func getStackTraceHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(stack)
 	pprof.Lookup("goroutine").WriteTo(w, 2)
 }
+
+// runArchiver moves the payments older than ARCHIVE_AFTER to the archive, their parties encrypted with the keys when
+// set, it returns the exit code of the command
+func runArchiver(repository payments.Repository, store archive.Store, keys encryption.KeyProvider) int {
+	if store == nil || config.ArchiveAfter <= 0 {
+		logger.LogStdErr.Error("ARCHIVE_TARGET and ARCHIVE_AFTER are required to archive the payments")
+		return 1
+	}
+	archived, err := payments.NewArchiver(repository, store, keys, config.ArchiveAfter, config.ArchiveBatchSize).Run(context.Background())
+	logger.LogStdOut.Infow("payments archived", "count", archived)
+	if err != nil {
+		logger.LogStdErr.Error(errors.Wrap(err, "error when archiving the payments"))
+		return 1
+	}
+	return 0
+}
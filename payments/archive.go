@@ -0,0 +1,219 @@
+package payments
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/archive"
+	"github.com/elkousy/payments-api/encryption"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+// defaultArchiveBatchSize is the number of payments of an archive file
+const defaultArchiveBatchSize = 500
+
+// archiverActor marks the payments moved to the archive
+var archiverActor = Actor{UserID: "archiver"}
+
+// ArchiveFile is an entry of the manifest of the archive: a file of payments moved out of the database
+type ArchiveFile struct {
+	ID   uint   `gorm:"primary_key"`
+	Name string `gorm:"unique_index"`
+	// Checksum is the hex encoded SHA-256 of the file
+	Checksum  string
+	Payments  int
+	CreatedAt time.Time
+}
+
+// ArchivedPayment tells in which archive file a payment is
+type ArchivedPayment struct {
+	PaymentID     uuid.UUID `gorm:"type:uuid;primary_key"`
+	ArchiveFileID uint      `sql:"index"`
+	ArchivedAt    time.Time
+}
+
+// archiveDocument is a payment of an archive file. When the archive is encrypted, the names, addresses and account
+// numbers of its parties are encrypted by a data key of its own, stored with the document once encrypted by a key of
+// the provider.
+type archiveDocument struct {
+	Payment
+	KeyID   string `json:"key_id,omitempty"`
+	DataKey string `json:"data_key,omitempty"`
+}
+
+// Archiver moves the payments created longer ago than the retention period out of the database, to gzipped NDJSON
+// files of their full documents in an archive store
+type Archiver struct {
+	repository Repository
+	store      archive.Store
+	keys       encryption.KeyProvider
+	after      time.Duration
+	batchSize  int
+	now        func() time.Time
+}
+
+// NewArchiver returns an archiver of the payments of the repository created longer ago than after, the parties of the
+// payments are encrypted with the keys, nil to archive them in clear
+func NewArchiver(repository Repository, store archive.Store, keys encryption.KeyProvider, after time.Duration, batchSize int) *Archiver {
+	if batchSize <= 0 {
+		batchSize = defaultArchiveBatchSize
+	}
+	return &Archiver{repository: repository, store: store, keys: keys, after: after, batchSize: batchSize, now: time.Now}
+}
+
+// Run archives the payments created longer ago than the retention period, a file per batch. A file is written to the
+// store before its payments are deleted from the database, so a payment is never lost: when the deletion fails the
+// file is left out of the manifest and its payments are archived again by the next run. It returns the number of
+// payments archived.
+func (a *Archiver) Run(ctx context.Context) (int, error) {
+	archived := 0
+	for {
+		payments, err := a.repository.GetPaymentsToArchive(a.now().Add(-a.after), a.batchSize)
+		if err != nil || len(payments) == 0 {
+			return archived, err
+		}
+		docs := make([]interface{}, 0, len(payments))
+		ids := make([]string, 0, len(payments))
+		for _, p := range payments {
+			doc, err := sealArchiveDocument(a.keys, p)
+			if err != nil {
+				return archived, err
+			}
+			docs = append(docs, doc)
+			ids = append(ids, p.ID.String())
+		}
+		data, checksum, err := archive.Write(docs...)
+		if err != nil {
+			return archived, err
+		}
+		file := ArchiveFile{
+			Name:     fmt.Sprintf("payments-%s-%s.ndjson.gz", a.now().UTC().Format("20060102T150405Z"), uuid.NewV4()),
+			Checksum: checksum,
+			Payments: len(payments),
+		}
+		if err := a.store.Put(ctx, file.Name, data); err != nil {
+			return archived, err
+		}
+		if err := a.store.Put(ctx, file.Name+archive.ChecksumSuffix, []byte(checksum+"  "+file.Name+"\n")); err != nil {
+			return archived, err
+		}
+		if err := a.repository.ArchivePayments(file, ids, archiverActor); err != nil {
+			return archived, err
+		}
+		archived += len(payments)
+		if len(payments) < a.batchSize {
+			return archived, nil
+		}
+	}
+}
+
+// WithArchive finds the payments moved out of the database in the archive store, the keys decrypt the parties of an
+// encrypted archive
+func WithArchive(store archive.Store, keys encryption.KeyProvider) ServiceOption {
+	return func(s *service) {
+		s.archive, s.archiveKeys = store, keys
+	}
+}
+
+// getArchivedPayment returns a payment from its archive file, ErrNotFound when it is not archived
+func (s service) getArchivedPayment(id string) (Payment, error) {
+	file, err := s.repository.GetArchiveFile(id)
+	if err != nil {
+		return Payment{}, err
+	}
+	if file == nil {
+		return Payment{}, ErrNotFound
+	}
+	data, err := s.archive.Get(context.Background(), file.Name)
+	if err != nil {
+		return Payment{}, err
+	}
+	docs, err := archive.Read(data, file.Checksum)
+	if err != nil {
+		return Payment{}, fmt.Errorf("archive file %s: %v", file.Name, err)
+	}
+	for _, data := range docs {
+		doc := archiveDocument{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return Payment{}, fmt.Errorf("archive file %s: %v", file.Name, err)
+		}
+		if doc.ID.String() == id {
+			p, err := openArchiveDocument(s.archiveKeys, doc)
+			if err != nil {
+				return Payment{}, fmt.Errorf("archive file %s: payment %s: %v", file.Name, id, err)
+			}
+			p.Archived = true
+			return p, nil
+		}
+	}
+	return Payment{}, fmt.Errorf("archive file %s: payment %s is missing", file.Name, id)
+}
+
+// sealArchiveDocument returns the archive document of a payment, its parties encrypted with a new data key unless the
+// keys are nil
+func sealArchiveDocument(keys encryption.KeyProvider, p Payment) (archiveDocument, error) {
+	doc := archiveDocument{Payment: p}
+	if keys == nil {
+		return doc, nil
+	}
+	key, err := encryption.NewDataKey(keys)
+	if err != nil {
+		return doc, err
+	}
+	for _, field := range partyFieldsOf(&doc.Payment) {
+		if *field, err = key.Encrypt(*field); err != nil {
+			return doc, err
+		}
+	}
+	doc.KeyID, doc.DataKey = key.KeyID, base64.StdEncoding.EncodeToString(key.Wrapped)
+	return doc, nil
+}
+
+// openArchiveDocument returns the payment of an archive document, its parties decrypted when they are encrypted
+func openArchiveDocument(keys encryption.KeyProvider, doc archiveDocument) (Payment, error) {
+	if doc.KeyID == "" {
+		return doc.Payment, nil
+	}
+	if keys == nil {
+		return Payment{}, fmt.Errorf("the parties are encrypted with the key %s, no keys are set", doc.KeyID)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(doc.DataKey)
+	if err != nil {
+		return Payment{}, err
+	}
+	key, err := encryption.OpenDataKey(keys, doc.KeyID, wrapped)
+	if err != nil {
+		return Payment{}, err
+	}
+	p := doc.Payment
+	for _, field := range partyFieldsOf(&p) {
+		if *field, err = key.Decrypt(*field); err != nil {
+			return Payment{}, err
+		}
+	}
+	return p, nil
+}
+
+// partyFieldsOf returns the names, addresses and account numbers of the parties of a payment
+func partyFieldsOf(p *Payment) []*string {
+	var all []*string
+	a := &p.Attributes
+	for _, party := range []interface{}{&a.DebtorParty, &a.BeneficiaryParty, &a.SponsorParty} {
+		forEachParty(party, func(_ *SponsorParty, fields []*string) error {
+			all = append(all, fields...)
+			return nil
+		})
+	}
+	return all
+}
+
+// isNotFound reports whether an error is ErrNotFound
+func isNotFound(err error) bool {
+	e, ok := err.(apierrors.APIError)
+	return ok && e.Message == ErrNotFound.Message
+}
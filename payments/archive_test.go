@@ -0,0 +1,189 @@
+package payments
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/archive"
+)
+
+// memoryStore is an archive store keeping the files in memory
+type memoryStore struct {
+	mu     sync.Mutex
+	files  map[string][]byte
+	putErr error
+}
+
+func (s *memoryStore) Put(_ context.Context, name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.putErr != nil {
+		return s.putErr
+	}
+	if s.files == nil {
+		s.files = map[string][]byte{}
+	}
+	s.files[name] = data
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func Test_Archiver_Run(t *testing.T) {
+	// Arrange
+	now := time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC)
+	p1, p2 := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"), mockNewPayment("6ef6057f-0ed4-48c9-a128-f85b8f024519")
+	store := &memoryStore{}
+	var file ArchiveFile
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPaymentsToArchive", now.Add(-time.Hour), 2).Return([]Payment{p1, p2}, nil).Once()
+	repositoryMock.On("GetPaymentsToArchive", now.Add(-time.Hour), 2).Return([]Payment{}, nil).Once()
+	repositoryMock.On("ArchivePayments", mock.Anything, []string{p1.ID.String(), p2.ID.String()}, archiverActor).
+		Run(func(args mock.Arguments) { file = args.Get(0).(ArchiveFile) }).Return(nil)
+	archiver := NewArchiver(repositoryMock, store, nil, time.Hour, 2)
+	archiver.now = func() time.Time { return now }
+
+	//Act
+	archived, err := archiver.Run(context.Background())
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, archived)
+	assert.Equal(t, 2, file.Payments)
+	require.Contains(t, store.files, file.Name)
+	assert.Equal(t, file.Checksum+"  "+file.Name+"\n", string(store.files[file.Name+archive.ChecksumSuffix]))
+	docs, err := archive.Read(store.files[file.Name], file.Checksum)
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Archiver_Run_StoreFails(t *testing.T) {
+	// Arrange
+	store := &memoryStore{putErr: errors.New("disk full")}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPaymentsToArchive", mock.Anything, defaultArchiveBatchSize).Return([]Payment{mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")}, nil)
+	archiver := NewArchiver(repositoryMock, store, nil, time.Hour, 0)
+
+	//Act
+	archived, err := archiver.Run(context.Background())
+
+	//Assert
+	assert.Error(t, err)
+	assert.Zero(t, archived)
+	repositoryMock.AssertNotCalled(t, "ArchivePayments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Service_GetPayment_Archive(t *testing.T) {
+	// Arrange
+	id, otherID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", "6ef6057f-0ed4-48c9-a128-f85b8f024519"
+	data, checksum, err := archive.Write(mockNewPayment(otherID), mockNewPayment(id))
+	require.NoError(t, err)
+	store := &memoryStore{files: map[string][]byte{"payments-1.ndjson.gz": data}}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPayment", mock.Anything).Return(Payment{}, ErrNotFound.FromError(errors.New("record not found")))
+	repositoryMock.On("GetArchiveFile", id).Return(&ArchiveFile{Name: "payments-1.ndjson.gz", Checksum: checksum}, nil)
+	repositoryMock.On("GetArchiveFile", "c1ea6f47-0d43-4b1e-a2b6-7a9d8a0e1c3f").Return(nil, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithArchive(store, nil))
+
+	//Act
+	res, err := service.GetPayment(GetPaymentRequest{PaymentID: id})
+	_, notFoundErr := service.GetPayment(GetPaymentRequest{PaymentID: "c1ea6f47-0d43-4b1e-a2b6-7a9d8a0e1c3f"})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, id, res.ID.String())
+	assert.True(t, res.Archived)
+	assert.Equal(t, "100.21", res.Attributes.Amount)
+	assert.Equal(t, ErrNotFound, notFoundErr)
+}
+
+func Test_Archiver_Run_Encrypted(t *testing.T) {
+	// Arrange
+	keys := newTestKeys(t)
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	store := &memoryStore{}
+	var file ArchiveFile
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPaymentsToArchive", mock.Anything, 2).Return([]Payment{p}, nil).Once()
+	repositoryMock.On("GetPaymentsToArchive", mock.Anything, 2).Return([]Payment{}, nil).Once()
+	repositoryMock.On("ArchivePayments", mock.Anything, []string{p.ID.String()}, archiverActor).
+		Run(func(args mock.Arguments) { file = args.Get(0).(ArchiveFile) }).Return(nil)
+	repositoryMock.On("GetPayment", mock.Anything).Return(Payment{}, ErrNotFound.FromError(errors.New("record not found")))
+	repositoryMock.On("GetArchiveFile", p.ID.String()).Return(&file, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithArchive(store, keys))
+	noKeys, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithArchive(store, nil))
+
+	//Act
+	_, err := NewArchiver(repositoryMock, store, keys, time.Hour, 2).Run(context.Background())
+	require.NoError(t, err)
+	res, err := service.GetPayment(GetPaymentRequest{PaymentID: p.ID.String()})
+	_, noKeysErr := noKeys.GetPayment(GetPaymentRequest{PaymentID: p.ID.String()})
+
+	//Assert
+	require.NoError(t, err)
+	docs, readErr := archive.Read(store.files[file.Name], file.Checksum)
+	require.NoError(t, readErr)
+	require.Len(t, docs, 1)
+	assert.NotContains(t, string(docs[0]), "ING Dfh")
+	assert.NotContains(t, string(docs[0]), "GB29NWBK60161331926819")
+	assert.Contains(t, string(docs[0]), `"key_id":"`)
+	assert.True(t, res.Archived)
+	assert.Equal(t, "ING Dfh", res.Attributes.BeneficiaryParty.Name)
+	assert.Equal(t, "GB29NWBK60161331926819", res.Attributes.DebtorParty.AccountNumber)
+	assert.Error(t, noKeysErr)
+}
+
+func Test_ArchivePayments(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	var archived, audited []driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "attributes_id": 7}},
+		},
+		{
+			Pattern:  "INSERT INTO \"archived_payments\"",
+			Callback: func(_ string, args []driver.NamedValue) { archived = args },
+		},
+		{
+			Pattern:  "INSERT INTO \"audit_entries\"",
+			Callback: func(_ string, args []driver.NamedValue) { audited = args },
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	err := r.ArchivePayments(ArchiveFile{Name: "payments-1.ndjson.gz", Checksum: "abc", Payments: 1}, []string{id}, archiverActor)
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, archived)
+	assert.Equal(t, id, archived[0].Value)
+	var values []interface{}
+	for _, arg := range audited {
+		values = append(values, arg.Value)
+	}
+	assert.Contains(t, values, string(AuditArchive))
+}
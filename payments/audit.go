@@ -0,0 +1,242 @@
+package payments
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditOperation is the change of a payment recorded by an audit entry
+type AuditOperation string
+
+const (
+	// AuditCreate records the creation of a payment
+	AuditCreate AuditOperation = "create"
+	// AuditUpdate records an update of a payment
+	AuditUpdate AuditOperation = "update"
+	// AuditDelete records the deletion of a payment
+	AuditDelete AuditOperation = "delete"
+	// AuditRestore records the restoration of a deleted payment
+	AuditRestore AuditOperation = "restore"
+	// AuditPurge records the removal of a payment deleted longer ago than the retention period, it has no changes
+	AuditPurge AuditOperation = "purge"
+	// AuditArchive records the move of a payment to the archive, it has no changes
+	AuditArchive AuditOperation = "archive"
+	// AuditStatusChange records a review or a scheduling moving a payment to another status
+	AuditStatusChange AuditOperation = "status_change"
+	// AuditApproval records the decision of an approver moving a payment to another status
+	AuditApproval AuditOperation = "approval"
+	// AuditReturnStatusChange records a return or a reversal of a payment completed or failed
+	AuditReturnStatusChange AuditOperation = "return_status_change"
+	// AuditRecallDecision records the decision of the beneficiary bank on a recall of a payment
+	AuditRecallDecision AuditOperation = "recall_decision"
+)
+
+// Actor identifies who changed a payment and from where
+type Actor struct {
+	UserID string
+	// RequestID is the X-Request-ID header of the request, the x-request-id metadata over gRPC
+	RequestID string
+	SourceIP  string
+}
+
+// schedulerActor marks the payments due on their processing date
+var schedulerActor = Actor{UserID: "scheduler"}
+
+// AuditChange is a field of the JSON document of a payment changed by an operation, Before is null for a created
+// field and After is null for a removed field. Nested fields are separated by dots and array items are indexed,
+// e.g. attributes.charges_information.sender_charges[0].amount. The fields of the returns, reversals and recalls of
+// the payment are under their collection and ID, e.g. returns.<id>.status.
+type AuditChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditChanges are stored as a JSON document
+type AuditChanges []AuditChange
+
+// Value implements driver.Valuer
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		c = AuditChanges{}
+	}
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner
+func (c *AuditChanges) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case nil:
+		*c = nil
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into the audit changes", src)
+}
+
+// AuditEntry records who changed a payment, when and how. The audit log is append-only: the entries are written in
+// the database transaction changing the payment and are never updated nor deleted, but for the re-encryption of their
+// values with the current key.
+type AuditEntry struct {
+	ID        uint           `json:"-" gorm:"primary_key"`
+	PaymentID uuid.UUID      `json:"payment_id" gorm:"type:uuid" sql:"index"`
+	Operation AuditOperation `json:"operation"`
+	Actor     string         `json:"actor,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	SourceIP  string         `json:"source_ip,omitempty"`
+	Changes   AuditChanges   `json:"changes" gorm:"type:jsonb"`
+	CreatedAt time.Time      `json:"created_at"`
+	// KeyID is the id of the key encrypting the data key of an encrypted entry, empty when the entry is not encrypted
+	KeyID string `json:"-"`
+	// DataKey is the base64 encoded data key encrypting the values of the party fields changed
+	DataKey string `json:"-"`
+}
+
+// partyFields are the fields of the parties encrypted at rest, by their name in the JSON document of a party
+var partyFields = map[string]bool{"account_number": true, "account_name": true, "address": true, "name": true}
+
+// partyValues returns the values, before and after, of the names, addresses and account numbers of the parties changed
+// by the entry, the empty values left out
+func (e *AuditEntry) partyValues() []*interface{} {
+	var values []*interface{}
+	for i := range e.Changes {
+		c := &e.Changes[i]
+		party := strings.LastIndex(c.Field, "_party.")
+		if !strings.HasPrefix(c.Field, "attributes.") || party < 0 || !partyFields[c.Field[party+len("_party."):]] {
+			continue
+		}
+		for _, v := range []*interface{}{&c.Before, &c.After} {
+			if s, ok := (*v).(string); ok && s != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// recordAudit inserts the audit entry of a change of a payment in the database transaction changing it, before is nil
+// for a created payment and after is nil for a deleted payment
+func recordAudit(tx *gorm.DB, op AuditOperation, paymentID uuid.UUID, actor Actor, before *Payment, after *Payment) error {
+	changes, err := diffPayments(before, after)
+	if err != nil {
+		return err
+	}
+	return insertAudit(tx, op, paymentID, actor, changes)
+}
+
+// recordTransitionAudit inserts the audit entry of a return, a reversal or a recall of a payment moving to another
+// status, in the database transaction changing it. Its changed fields are under the given path.
+func recordTransitionAudit(tx *gorm.DB, op AuditOperation, paymentID uuid.UUID, actor Actor, path string, before interface{}, after interface{}) error {
+	beforeFields, err := documentFields(path, before)
+	if err != nil {
+		return err
+	}
+	afterFields, err := documentFields(path, after)
+	if err != nil {
+		return err
+	}
+	return insertAudit(tx, op, paymentID, actor, diffFields(beforeFields, afterFields))
+}
+
+func insertAudit(tx *gorm.DB, op AuditOperation, paymentID uuid.UUID, actor Actor, changes AuditChanges) error {
+	return tx.Create(&AuditEntry{
+		PaymentID: paymentID,
+		Operation: op,
+		Actor:     actor.UserID,
+		RequestID: actor.RequestID,
+		SourceIP:  actor.SourceIP,
+		Changes:   changes,
+	}).Error
+}
+
+// diffPayments returns the fields of the JSON documents of two payments which differ, sorted by field
+func diffPayments(before *Payment, after *Payment) (AuditChanges, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+	return diffFields(beforeFields, afterFields), nil
+}
+
+// diffFields returns the fields which differ between two sets of leaf fields, sorted by field
+func diffFields(beforeFields map[string]interface{}, afterFields map[string]interface{}) AuditChanges {
+	changes := AuditChanges{}
+	for field, b := range beforeFields {
+		if a, ok := afterFields[field]; !ok || !reflect.DeepEqual(a, b) {
+			changes = append(changes, AuditChange{Field: field, Before: b, After: a})
+		}
+	}
+	for field, a := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes = append(changes, AuditChange{Field: field, After: a})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// jsonFields returns the leaf fields of the JSON document of a payment by path, none for a nil payment
+func jsonFields(p *Payment) (map[string]interface{}, error) {
+	if p == nil {
+		return map[string]interface{}{}, nil
+	}
+	return documentFields("", p)
+}
+
+// documentFields returns the leaf fields of the JSON document of a value by path, under the given path
+func documentFields(path string, v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	flatten(path, doc, fields)
+	return fields, nil
+}
+
+// flatten adds the leaf values of a JSON value to the fields, the empty objects and arrays are leaves
+func flatten(path string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			break
+		}
+		for key, child := range v {
+			if path == "" {
+				flatten(key, child, fields)
+			} else {
+				flatten(path+"."+key, child, fields)
+			}
+		}
+		return
+	case []interface{}:
+		if len(v) == 0 {
+			break
+		}
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+		return
+	}
+	fields[path] = value
+}
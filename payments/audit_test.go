@@ -0,0 +1,197 @@
+package payments
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diffPayments(t *testing.T) {
+	//Arrange
+	before := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	after := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	after.OrganisationID = before.OrganisationID
+	after.Attributes.Amount = "200.00"
+	after.Attributes.ChargesInformation.SenderCharges = after.Attributes.ChargesInformation.SenderCharges[:1]
+	after.Status = StatusHeldForReview
+
+	//Act
+	changes, err := diffPayments(&before, &after)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, AuditChanges{
+		{Field: "attributes.amount", Before: "100.21", After: "200.00"},
+		{Field: "attributes.charges_information.sender_charges[1].amount", Before: "10.00"},
+		{Field: "attributes.charges_information.sender_charges[1].currency", Before: "USD"},
+		{Field: "status", Before: string(before.Status), After: string(StatusHeldForReview)},
+	}, changes)
+}
+
+func Test_diffPayments_CreatedAndDeleted(t *testing.T) {
+	//Arrange
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+
+	//Act
+	created, createErr := diffPayments(nil, &p)
+	deleted, deleteErr := diffPayments(&p, nil)
+
+	//Assert
+	require.NoError(t, createErr)
+	require.NoError(t, deleteErr)
+	require.NotEmpty(t, created)
+	assert.Len(t, deleted, len(created))
+	for i := range created {
+		assert.Nil(t, created[i].Before, created[i].Field)
+		assert.Equal(t, created[i].After, deleted[i].Before, created[i].Field)
+		assert.Nil(t, deleted[i].After, deleted[i].Field)
+	}
+	assert.Contains(t, created, AuditChange{Field: "attributes.debtor_party.bank_id", After: "134667"})
+}
+
+func Test_AuditChanges_ValueScan(t *testing.T) {
+	//Arrange
+	changes := AuditChanges{{Field: "attributes.amount", Before: "100.21", After: "200.00"}}
+
+	//Act
+	value, err := changes.Value()
+	var scanned AuditChanges
+	scanErr := scanned.Scan([]byte(value.(string)))
+
+	//Assert
+	require.NoError(t, err)
+	require.NoError(t, scanErr)
+	assert.Equal(t, changes, scanned)
+}
+
+func Test_UpdatePayment_RecordsAudit(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	var audit []driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "status": StatusSubmitted, "created_by": "alice"}},
+		},
+		{
+			Pattern:  "INSERT INTO \"audit_entries\"",
+			Callback: func(_ string, args []driver.NamedValue) { audit = args },
+		},
+	})
+	r := NewPaymentRepository(db)
+	p := mockNewPayment(id)
+	p.Status = StatusRejected
+
+	//Act
+	err := r.UpdatePayment(id, p, Actor{UserID: "bob", RequestID: "req-1", SourceIP: "203.0.113.7"})
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, audit)
+	var values []interface{}
+	var changes AuditChanges
+	for _, arg := range audit {
+		values = append(values, arg.Value)
+		if s, ok := arg.Value.(string); ok && json.Valid([]byte(s)) {
+			require.NoError(t, json.Unmarshal([]byte(s), &changes))
+		}
+	}
+	assert.Contains(t, values, string(AuditUpdate))
+	assert.Contains(t, values, "bob")
+	assert.Contains(t, values, "req-1")
+	assert.Contains(t, values, "203.0.113.7")
+	assert.Contains(t, changes, AuditChange{Field: "attributes.amount", Before: "", After: "100.21"})
+	assert.NotContains(t, changes, AuditChange{Field: "status", Before: string(StatusSubmitted), After: string(StatusRejected)},
+		"the status does not change through an update")
+}
+
+func Test_ReturnsAndRecalls_RecordAudit(t *testing.T) {
+	paymentID, id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", "31f135b4-4c8b-45eb-a6d9-60c453ec303d"
+	decidedAt := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	actor := Actor{UserID: "bob", RequestID: "req-1", SourceIP: "203.0.113.7"}
+	tests := []struct {
+		name      string
+		table     string
+		row       map[string]interface{}
+		transit   func(r Repository) (bool, error)
+		operation AuditOperation
+		changes   []AuditChange
+	}{
+		{
+			name:  "Should record a reversal failed",
+			table: "returns",
+			row:   map[string]interface{}{"id": id, "payment_id": paymentID, "type": ReturnTypeReversal, "status": ReturnStatusPending},
+			transit: func(r Repository) (bool, error) {
+				return r.TransitionReturnStatus(id, ReturnStatusPending, ReturnStatusFailed, "rejected", actor)
+			},
+			operation: AuditReturnStatusChange,
+			changes: []AuditChange{
+				{Field: "reversals." + id + ".status", Before: string(ReturnStatusPending), After: string(ReturnStatusFailed)},
+				{Field: "reversals." + id + ".status_reason", After: "rejected"},
+			},
+		},
+		{
+			name:  "Should record a recall accepted",
+			table: "recalls",
+			row:   map[string]interface{}{"id": id, "payment_id": paymentID, "status": RecallStatusRequested},
+			transit: func(r Repository) (bool, error) {
+				return r.DecideRecall(id, RecallStatusAccepted, "returned", decidedAt, actor)
+			},
+			operation: AuditRecallDecision,
+			changes: []AuditChange{
+				{Field: "recalls." + id + ".decided_at", After: "2019-01-18T12:00:00Z"},
+				{Field: "recalls." + id + ".decision_reason", After: "returned"},
+				{Field: "recalls." + id + ".status", Before: string(RecallStatusRequested), After: string(RecallStatusAccepted)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			db := SetupDBTests()
+			defer db.Close()
+			var audit []driver.NamedValue
+			mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+				{
+					Pattern:  "SELECT * FROM \"" + tt.table + "\"",
+					Response: []map[string]interface{}{tt.row},
+				},
+				{
+					Pattern:  "INSERT INTO \"audit_entries\"",
+					Callback: func(_ string, args []driver.NamedValue) { audit = args },
+				},
+			})
+			r := NewPaymentRepository(db)
+
+			//Act
+			done, err := tt.transit(r)
+
+			//Assert
+			require.NoError(t, err)
+			assert.True(t, done)
+			require.NotEmpty(t, audit)
+			var values []interface{}
+			var changes AuditChanges
+			for _, arg := range audit {
+				values = append(values, arg.Value)
+				if s, ok := arg.Value.(string); ok && strings.HasPrefix(s, "[") {
+					require.NoError(t, json.Unmarshal([]byte(s), &changes))
+				}
+			}
+			assert.Contains(t, values, string(tt.operation))
+			assert.Contains(t, values, paymentID)
+			assert.Contains(t, values, "bob")
+			assert.Contains(t, values, "req-1")
+			assert.Contains(t, values, "203.0.113.7")
+			assert.Equal(t, AuditChanges(tt.changes), changes)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package payments
+
+import (
+	"github.com/elkousy/payments-api/charges"
+)
+
+// senderCharges returns the sender charges of a payment as amounts
+func senderCharges(p Payment) []charges.Amount {
+	amounts := make([]charges.Amount, 0, len(p.Attributes.ChargesInformation.SenderCharges))
+	for _, c := range p.Attributes.ChargesInformation.SenderCharges {
+		amounts = append(amounts, charges.Amount{Amount: c.Amount, Currency: c.Currency})
+	}
+	return amounts
+}
+
+// applySchemeFee sets the fee of the payment scheme as the sender charges of a payment which omits them, it leaves
+// the payment unchanged when its scheme has no fee schedule
+func applySchemeFee(p *Payment) {
+	a := &p.Attributes
+	if len(a.ChargesInformation.SenderCharges) > 0 {
+		return
+	}
+	if fee, ok := charges.Fee(a.PaymentScheme, a.Amount, a.Currency); ok {
+		a.ChargesInformation.SenderCharges = []Charge{{Amount: fee.Amount, Currency: fee.Currency}}
+	}
+}
+
+// withCharges returns the response of a payment with its derived charges: the total sender charges per currency and,
+// depending on the bearer code, the amount debited from the debtor and the amount credited to the beneficiary. The
+// amounts are left empty when the payment cannot be settled, e.g. it predates the validation of the bearer codes.
+func withCharges(p Payment) *GetPaymentResponse {
+	a := p.Attributes
+	response := &GetPaymentResponse{Payment: p, SenderChargesTotals: charges.Totals(senderCharges(p))}
+	settlement, err := charges.Settle(charges.Instruction{
+		Bearer:        charges.Bearer(a.ChargesInformation.BearerCode),
+		Amount:        a.Amount,
+		Currency:      a.Currency,
+		SenderCharges: senderCharges(p),
+		ReceiverCharges: charges.Amount{
+			Amount:   a.ChargesInformation.ReceiverChargesAmount,
+			Currency: a.ChargesInformation.ReceiverChargesCurrency,
+		},
+	})
+	if err == nil {
+		response.TotalDebitAmount, response.NetCreditAmount = settlement.TotalDebitAmount, settlement.NetCreditAmount
+	}
+	return response
+}
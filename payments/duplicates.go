@@ -0,0 +1,151 @@
+package payments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/currency"
+	"github.com/elkousy/payments-api/encryption"
+)
+
+// DuplicatePolicy tells what happens to a payment looking like a payment recently created by its organisation
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyOff does not look for duplicates
+	DuplicatePolicyOff DuplicatePolicy = "off"
+	// DuplicatePolicyWarn creates the payment with a possible_duplicate warning
+	DuplicatePolicyWarn DuplicatePolicy = "warn"
+	// DuplicatePolicyReject rejects the payment with a 409
+	DuplicatePolicyReject DuplicatePolicy = "reject"
+)
+
+// WarningPossibleDuplicate is the code of the warning returned when a created payment looks like a recent payment
+const WarningPossibleDuplicate = "possible_duplicate"
+
+// Warning is returned along with a payment accepted despite looking suspicious
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// PaymentID is the payment the warning refers to, e.g. the payment possibly duplicated
+	PaymentID string `json:"payment_id,omitempty"`
+}
+
+// DuplicateCheck configures the detection of the business duplicates: payments of an organisation with the same
+// debtor account, beneficiary account, amount, currency and end to end reference, created within the window
+type DuplicateCheck struct {
+	Window time.Duration
+	// Policy applies to the organisations without a policy of their own
+	Policy        DuplicatePolicy
+	Organisations map[uuid.UUID]DuplicatePolicy
+}
+
+// policyOf returns the duplicate policy of an organisation
+func (c DuplicateCheck) policyOf(organisationID uuid.UUID) DuplicatePolicy {
+	if c.Window <= 0 {
+		return DuplicatePolicyOff
+	}
+	if policy, ok := c.Organisations[organisationID]; ok {
+		return policy
+	}
+	if c.Policy == "" {
+		return DuplicatePolicyOff
+	}
+	return c.Policy
+}
+
+// ParseDuplicatePolicy parses off, warn or reject
+func ParseDuplicatePolicy(s string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(s); policy {
+	case DuplicatePolicyOff, DuplicatePolicyWarn, DuplicatePolicyReject:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown duplicate policy %s", s)
+}
+
+// ParseDuplicatePolicies parses the policies of the organisations, e.g.
+// 743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb=reject,2e1a4b0c-3f0b-4a8f-9d7e-5c3b2a1f0e9d=off
+func ParseDuplicatePolicies(s string) (map[uuid.UUID]DuplicatePolicy, error) {
+	policies := map[uuid.UUID]DuplicatePolicy{}
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid duplicate policy %s, expected organisation_id=policy", entry)
+		}
+		organisationID, err := uuid.FromString(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid organisation %s: %v", parts[0], err)
+		}
+		policy, err := ParseDuplicatePolicy(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		policies[organisationID] = policy
+	}
+	return policies, nil
+}
+
+// fingerprint identifies the payments considered duplicates of each other.
+// The account numbers and the amounts are normalised, so 100.2 and 100.20 GBP are the same amount. With a key, the
+// fingerprint is a blind index, so it cannot be matched against guessed account numbers when the parties are encrypted.
+func fingerprint(p Payment, key []byte) string {
+	a := p.Attributes
+	parts := []string{
+		p.OrganisationID.String(),
+		normaliseAccount(a.DebtorParty.BankID), normaliseAccount(a.DebtorParty.AccountNumber),
+		normaliseAccount(a.BeneficiaryParty.BankID), normaliseAccount(a.BeneficiaryParty.AccountNumber),
+		normaliseAmount(a.Amount, a.Currency), a.Currency,
+		a.EndToEndReference,
+	}
+	if key != nil {
+		return encryption.BlindIndex(key, strings.Join(parts, "\x1f"))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprintPayments keys with the index key up to limit payments, deleted or not, fingerprinted before the
+// encryption was enabled. It returns the number of payments fingerprinted again.
+func (r *paymentRepository) fingerprintPayments(limit int) (int, error) {
+	var payments []Payment
+	err := r.db.Unscoped().Select("id").Where("fingerprint_keyed IS NOT TRUE").Order("created_at, id").Limit(limit).
+		Find(&payments).Error
+	if err != nil {
+		return 0, err
+	}
+	for i := range payments {
+		p, err := findPayment(r.db.Unscoped(), payments[i].ID.String())
+		if err != nil {
+			return i, err
+		}
+		// a payment updated meanwhile was fingerprinted with the key already
+		err = r.db.Model(&Payment{}).Unscoped().Where("id = ? AND fingerprint_keyed IS NOT TRUE", p.ID).
+			UpdateColumns(map[string]interface{}{"fingerprint": fingerprint(p, r.keys.IndexKey()), "fingerprint_keyed": true}).Error
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(payments), nil
+}
+
+func normaliseAccount(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+func normaliseAmount(amount string, code string) string {
+	c, ok := currency.Lookup(code)
+	r, valid := new(big.Rat).SetString(amount)
+	if !ok || !valid {
+		return amount
+	}
+	return r.FloatString(c.MinorUnits)
+}
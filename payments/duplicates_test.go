@@ -0,0 +1,127 @@
+package payments
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fingerprint(t *testing.T) {
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	tests := []struct {
+		name   string
+		update func(p *Payment)
+		same   bool
+	}{
+		{"same amount with another precision", func(p *Payment) { p.Attributes.Amount = "100.210" }, true},
+		{"spaces in the account number", func(p *Payment) { p.Attributes.DebtorParty.AccountNumber = "GB29 NWBK 6016 1331 9268 19" }, true},
+		{"other fields", func(p *Payment) { p.Attributes.Reference, p.Attributes.NumericReference = "Other", "1" }, true},
+		{"amount", func(p *Payment) { p.Attributes.Amount = "100.22" }, false},
+		{"currency", func(p *Payment) { p.Attributes.Currency = "EUR" }, false},
+		{"end to end reference", func(p *Payment) { p.Attributes.EndToEndReference = "Other" }, false},
+		{"beneficiary account", func(p *Payment) { p.Attributes.BeneficiaryParty.AccountNumber = "31926819" }, false},
+		{"organisation", func(p *Payment) { p.OrganisationID = uuid.NewV4() }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := mockNewPayment("0d5f5f3a-64e8-4c2e-8f4a-1b2b3c4d5e6f")
+			other.OrganisationID = p.OrganisationID
+			tt.update(&other)
+			assert.Equal(t, tt.same, fingerprint(p, nil) == fingerprint(other, nil))
+		})
+	}
+}
+
+func Test_fingerprint_Keyed(t *testing.T) {
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	key, other := []byte(strings.Repeat("k", 32)), []byte(strings.Repeat("o", 32))
+
+	assert.Equal(t, fingerprint(p, key), fingerprint(p, key))
+	assert.NotEqual(t, fingerprint(p, nil), fingerprint(p, key))
+	assert.NotEqual(t, fingerprint(p, other), fingerprint(p, key))
+}
+
+func Test_fingerprintPayments(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	keys := newTestKeys(t)
+	id, org := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	var updated []driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT id FROM \"payments\"  WHERE (fingerprint_keyed IS NOT TRUE)",
+			Response: []map[string]interface{}{{"id": id}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "organisation_id": org, "attributes_id": 7}},
+		},
+		{
+			Pattern: "SELECT * FROM \"attributes\"",
+			Response: []map[string]interface{}{{"id": 7, "amount": "100.2", "currency": "GBP", "end_to_end_reference": "Wil piano Jan",
+				"debtor_party_id": 3, "beneficiary_party_id": 4}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"debtor_parties\"",
+			Response: []map[string]interface{}{{"id": 3, "account_number": "GB29XABC10161234567801", "bank_id": "203301"}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"beneficiary_parties\"",
+			Response: []map[string]interface{}{{"id": 4, "account_number": "31926819", "bank_id": "403000"}},
+		},
+		{
+			Pattern:  "UPDATE \"payments\" SET",
+			Callback: func(_ string, args []driver.NamedValue) { updated = args },
+		},
+	})
+	r := NewPaymentRepository(db, WithEncryption(keys)).(*paymentRepository)
+	p := Payment{OrganisationID: uuid.FromStringOrNil(org), Attributes: Attributes{
+		Amount: "100.20", Currency: "GBP", EndToEndReference: "Wil piano Jan",
+		DebtorParty:      DebtorParty{SponsorParty: SponsorParty{AccountNumber: "GB29 XABC 1016 1234 5678 01", BankID: "203301"}},
+		BeneficiaryParty: BeneficiaryParty{DebtorParty: DebtorParty{SponsorParty: SponsorParty{AccountNumber: "31926819", BankID: "403000"}}},
+	}}
+
+	//Act
+	n, err := r.fingerprintPayments(10)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.NotEmpty(t, updated)
+	var values []interface{}
+	for _, arg := range updated {
+		values = append(values, arg.Value)
+	}
+	assert.Contains(t, values, fingerprint(p, keys.IndexKey()))
+	assert.Contains(t, values, true)
+	assert.Contains(t, values, id)
+}
+
+func Test_ParseDuplicatePolicies(t *testing.T) {
+	policies, err := ParseDuplicatePolicies("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb=reject, 2e1a4b0c-3f0b-4a8f-9d7e-5c3b2a1f0e9d = off,")
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]DuplicatePolicy{
+		uuid.FromStringOrNil("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"): DuplicatePolicyReject,
+		uuid.FromStringOrNil("2e1a4b0c-3f0b-4a8f-9d7e-5c3b2a1f0e9d"): DuplicatePolicyOff,
+	}, policies)
+
+	for _, invalid := range []string{"743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", "org=reject", "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb=ignore"} {
+		_, err := ParseDuplicatePolicies(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_DuplicateCheck_policyOf(t *testing.T) {
+	org := uuid.NewV4()
+	assert.Equal(t, DuplicatePolicyOff, DuplicateCheck{}.policyOf(org))
+	assert.Equal(t, DuplicatePolicyOff, DuplicateCheck{Policy: DuplicatePolicyReject}.policyOf(org), "no window")
+	check := DuplicateCheck{Window: 1, Policy: DuplicatePolicyWarn, Organisations: map[uuid.UUID]DuplicatePolicy{org: DuplicatePolicyReject}}
+	assert.Equal(t, DuplicatePolicyReject, check.policyOf(org))
+	assert.Equal(t, DuplicatePolicyWarn, check.policyOf(uuid.NewV4()))
+}
@@ -0,0 +1,293 @@
+package payments
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/utility/logger"
+)
+
+// KeyRotatorLockKey is the key of the Postgres advisory lock electing the replica re-encrypting the parties
+const KeyRotatorLockKey int64 = 0x726f74617465
+
+// defaultReencryptBatchSize is the number of parties of each table re-encrypted by a transaction
+const defaultReencryptBatchSize = 500
+
+// RepositoryOption configures the payment repository
+type RepositoryOption func(*paymentRepository)
+
+// WithEncryption encrypts the names, addresses and account numbers of the parties at rest, with a data key per party
+// encrypted by a key of the provider, and their values changed in the audit log, with a data key per entry. The account
+// numbers are looked up through their blind index.
+func WithEncryption(keys encryption.KeyProvider) RepositoryOption {
+	return func(r *paymentRepository) {
+		r.keys = keys
+		e := partyEncryption{keys: keys}
+		r.db.Callback().Create().Before("gorm:create").Register("payments:encrypt_parties", e.encrypt)
+		r.db.Callback().Create().After("gorm:create").Register("payments:decrypt_parties", e.decrypt)
+		r.db.Callback().Update().Before("gorm:update").Register("payments:encrypt_parties", e.encrypt)
+		r.db.Callback().Update().After("gorm:update").Register("payments:decrypt_parties", e.decrypt)
+		r.db.Callback().Query().After("gorm:after_query").Register("payments:decrypt_parties", e.decrypt)
+	}
+}
+
+// partyEncryption encrypts the parties and the audit entries saved to the database, and decrypts them back once saved
+// or loaded
+type partyEncryption struct {
+	keys encryption.KeyProvider
+}
+
+// encrypt encrypts the parties and the audit entries about to be saved with a new data key each
+func (e partyEncryption) encrypt(scope *gorm.Scope) {
+	if scope.HasError() || updatesColumns(scope) {
+		return
+	}
+	if err := forEachAuditEntry(scope.Value, e.encryptChanges); err != nil {
+		scope.Err(err)
+		return
+	}
+	scope.Err(forEachParty(scope.Value, func(party *SponsorParty, fields []*string) error {
+		key, err := encryption.NewDataKey(e.keys)
+		if err != nil {
+			return err
+		}
+		index := encryption.BlindIndex(e.keys.IndexKey(), normaliseAccount(party.AccountNumber))
+		for _, field := range fields {
+			if *field, err = key.Encrypt(*field); err != nil {
+				return err
+			}
+		}
+		party.KeyID, party.DataKey, party.AccountNumberIndex = key.KeyID, base64.StdEncoding.EncodeToString(key.Wrapped), index
+		return nil
+	}))
+}
+
+// decrypt decrypts the parties and the audit entries saved or loaded, those saved before the encryption was enabled
+// are left as is
+func (e partyEncryption) decrypt(scope *gorm.Scope) {
+	if updatesColumns(scope) {
+		return
+	}
+	if err := forEachAuditEntry(scope.Value, e.decryptChanges); err != nil {
+		scope.Err(fmt.Errorf("error when decrypting the audit log: %v", err))
+		return
+	}
+	err := forEachParty(scope.Value, func(party *SponsorParty, fields []*string) error {
+		if party.KeyID == "" {
+			return nil
+		}
+		wrapped, err := base64.StdEncoding.DecodeString(party.DataKey)
+		if err != nil {
+			return err
+		}
+		key, err := encryption.OpenDataKey(e.keys, party.KeyID, wrapped)
+		if err != nil {
+			return err
+		}
+		for _, field := range fields {
+			if *field, err = key.Decrypt(*field); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		scope.Err(fmt.Errorf("error when decrypting the parties: %v", err))
+	}
+}
+
+// encryptChanges encrypts with a new data key the values of the party fields changed by an audit entry
+func (e partyEncryption) encryptChanges(entry *AuditEntry) error {
+	key, err := encryption.NewDataKey(e.keys)
+	if err != nil {
+		return err
+	}
+	for _, value := range entry.partyValues() {
+		if *value, err = key.Encrypt((*value).(string)); err != nil {
+			return err
+		}
+	}
+	entry.KeyID, entry.DataKey = key.KeyID, base64.StdEncoding.EncodeToString(key.Wrapped)
+	return nil
+}
+
+// decryptChanges decrypts the values of the party fields changed by an encrypted audit entry
+func (e partyEncryption) decryptChanges(entry *AuditEntry) error {
+	if entry.KeyID == "" {
+		return nil
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(entry.DataKey)
+	if err != nil {
+		return err
+	}
+	key, err := encryption.OpenDataKey(e.keys, entry.KeyID, wrapped)
+	if err != nil {
+		return err
+	}
+	for _, value := range entry.partyValues() {
+		if *value, err = key.Decrypt((*value).(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatesColumns reports whether a scope updates columns from a map rather than saving a struct
+func updatesColumns(scope *gorm.Scope) bool {
+	_, ok := scope.InstanceGet("gorm:update_interface")
+	return ok
+}
+
+// forEachParty calls f with the encrypted fields of each party of a value, a party or a slice of parties
+func forEachParty(value interface{}, f func(party *SponsorParty, fields []*string) error) error {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Slice {
+		return eachParty(v, f)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := eachParty(reflect.Indirect(v.Index(i)), f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eachParty(v reflect.Value, f func(party *SponsorParty, fields []*string) error) error {
+	if !v.CanAddr() {
+		return nil
+	}
+	switch p := v.Addr().Interface().(type) {
+	case *SponsorParty:
+		return f(p, []*string{&p.AccountNumber})
+	case *DebtorParty:
+		return f(&p.SponsorParty, []*string{&p.AccountNumber, &p.AccountName, &p.Address, &p.Name})
+	case *BeneficiaryParty:
+		return f(&p.SponsorParty, []*string{&p.AccountNumber, &p.AccountName, &p.Address, &p.Name})
+	}
+	return nil
+}
+
+// forEachAuditEntry calls f with each audit entry of a value, an audit entry or a slice of audit entries
+func forEachAuditEntry(value interface{}, f func(entry *AuditEntry) error) error {
+	switch v := value.(type) {
+	case *AuditEntry:
+		return f(v)
+	case *[]AuditEntry:
+		for i := range *v {
+			if err := f(&(*v)[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// accountNumberCondition returns the column and the value matching the parties of an account number: its blind index
+// when the parties are encrypted
+func (r *paymentRepository) accountNumberCondition(accountNumber string) (string, string) {
+	if r.keys == nil {
+		return "account_number", accountNumber
+	}
+	return "account_number_index", encryption.BlindIndex(r.keys.IndexKey(), normaliseAccount(accountNumber))
+}
+
+// ReencryptParties encrypts with the current key up to limit parties of each table, deleted or not, and audit entries
+// which are encrypted with a former key or not encrypted yet, then keys by their blind index up to limit party accounts
+// of the ledger and up to limit payment fingerprints. It returns the number of parties, entries, accounts and
+// fingerprints keyed.
+func (r *paymentRepository) ReencryptParties(limit int) (int, error) {
+	if r.keys == nil {
+		return 0, nil
+	}
+	current := r.keys.CurrentKeyID()
+	count := 0
+	for _, parties := range []interface{}{&[]SponsorParty{}, &[]DebtorParty{}, &[]BeneficiaryParty{}, &[]AuditEntry{}} {
+		n, err := reencryptParties(r.db, parties, current, limit)
+		count += n
+		if err != nil {
+			return count, err
+		}
+	}
+	n, err := r.indexLedgerAccounts(limit)
+	count += n
+	if err != nil {
+		return count, err
+	}
+	n, err = r.fingerprintPayments(limit)
+	return count + n, err
+}
+
+// reencryptParties saves again, so with the current key, the parties of a table encrypted with another key
+func reencryptParties(db *gorm.DB, parties interface{}, current string, limit int) (int, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	defer tx.Rollback()
+
+	err := tx.Unscoped().Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").Where("key_id IS NULL OR key_id <> ?", current).
+		Order("id").Limit(limit).Find(parties).Error
+	if err != nil {
+		return 0, err
+	}
+	v := reflect.ValueOf(parties).Elem()
+	for i := 0; i < v.Len(); i++ {
+		if err := tx.Unscoped().Save(v.Index(i).Addr().Interface()).Error; err != nil {
+			return 0, err
+		}
+	}
+	return v.Len(), tx.Commit().Error
+}
+
+// KeyRotator re-encrypts in the background the parties encrypted with a former key, once the current key is rotated
+type KeyRotator struct {
+	repository Repository
+	leader     Leader
+	batchSize  int
+}
+
+// NewKeyRotator returns a rotator of the keys of the parties of the repository, the elected replica only re-encrypts
+func NewKeyRotator(repository Repository, leader Leader) *KeyRotator {
+	return &KeyRotator{repository: repository, leader: leader, batchSize: defaultReencryptBatchSize}
+}
+
+// Run fires the rotator at every interval until the context is done, the leadership is then given up
+func (k *KeyRotator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer k.leader.Resign(context.Background())
+	for {
+		if _, err := k.RunOnce(ctx); err != nil {
+			logger.LogStdErr.Errorw("error when re-encrypting the parties", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce re-encrypts batches of parties until none is left encrypted with a former key, when this replica is the
+// leader. It returns the number of parties re-encrypted.
+func (k *KeyRotator) RunOnce(ctx context.Context) (int, error) {
+	lead, err := k.leader.Lead(ctx)
+	if err != nil || !lead {
+		return 0, err
+	}
+	count := 0
+	for ctx.Err() == nil {
+		n, err := k.repository.ReencryptParties(k.batchSize)
+		count += n
+		if err != nil || n == 0 {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,217 @@
+package payments
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/encryption"
+)
+
+// newTestKeys returns the keys of a key file whose current key is a
+func newTestKeys(t *testing.T) encryption.KeyProvider {
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keys.json")
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	data := fmt.Sprintf(`{"current": "a", "keys": {"a": %q}, "index_key": %q}`, key, key)
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	keys, err := encryption.NewFileKeyProvider(path)
+	require.NoError(t, err)
+	return keys
+}
+
+func Test_WithEncryption_SaveParty(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	keys := newTestKeys(t)
+	var inserted []interface{}
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern: "INSERT INTO \"debtor_parties\"",
+			Callback: func(_ string, args []driver.NamedValue) {
+				for _, arg := range args {
+					inserted = append(inserted, arg.Value)
+				}
+			},
+		},
+	})
+	NewPaymentRepository(db, WithEncryption(keys))
+	party := DebtorParty{
+		SponsorParty: SponsorParty{AccountNumber: "GB29 XABC 1016 1234 5678 01", BankID: "203301", BankIDCode: "GBDSC"},
+		AccountName:  "W Owens",
+		Address:      "1 The Beneficiary Localtown SE2",
+		Name:         "Wilfred Jeremiah Owens",
+	}
+
+	//Act
+	err := db.Save(&party).Error
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, inserted)
+	for _, plaintext := range []string{party.AccountNumber, party.AccountName, party.Address, party.Name} {
+		assert.NotContains(t, inserted, plaintext)
+	}
+	assert.Contains(t, inserted, "203301", "the bank id is not encrypted")
+	assert.Contains(t, inserted, "a")
+	assert.Contains(t, inserted, encryption.BlindIndex(keys.IndexKey(), "GB29XABC10161234567801"))
+	assert.Equal(t, "Wilfred Jeremiah Owens", party.Name, "the saved party is decrypted back")
+	assert.Equal(t, "a", party.KeyID)
+}
+
+func Test_WithEncryption_FindParty(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	keys := newTestKeys(t)
+	key, err := encryption.NewDataKey(keys)
+	require.NoError(t, err)
+	name, _ := key.Encrypt("Wilfred Jeremiah Owens")
+	accountNumber, _ := key.Encrypt("GB29XABC10161234567801")
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern: "SELECT * FROM \"debtor_parties\"",
+			Response: []map[string]interface{}{
+				{"id": 1, "name": name, "account_number": accountNumber, "bank_id": "203301", "key_id": key.KeyID, "data_key": base64.StdEncoding.EncodeToString(key.Wrapped)},
+				{"id": 2, "name": "Emelia Jane Brown", "account_number": "GB29XABC10161234567802", "bank_id": "203301"},
+			},
+		},
+	})
+	NewPaymentRepository(db, WithEncryption(keys))
+	var parties []DebtorParty
+
+	//Act
+	err = db.Find(&parties).Error
+
+	//Assert
+	require.NoError(t, err)
+	require.Len(t, parties, 2)
+	assert.Equal(t, "Wilfred Jeremiah Owens", parties[0].Name)
+	assert.Equal(t, "GB29XABC10161234567801", parties[0].AccountNumber)
+	assert.Equal(t, "Emelia Jane Brown", parties[1].Name, "the parties saved before the encryption are read as is")
+}
+
+func Test_WithEncryption_SaveAuditEntry(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	keys := newTestKeys(t)
+	var changes string
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern: "INSERT INTO \"audit_entries\"",
+			Callback: func(_ string, args []driver.NamedValue) {
+				for _, arg := range args {
+					if s, ok := arg.Value.(string); ok && strings.HasPrefix(s, "[") {
+						changes = s
+					}
+				}
+			},
+		},
+	})
+	NewPaymentRepository(db, WithEncryption(keys))
+	entry := AuditEntry{Operation: AuditUpdate, Changes: AuditChanges{
+		{Field: "attributes.amount", Before: "10.00", After: "20.00"},
+		{Field: "attributes.debtor_party.account_number", Before: "GB29XABC10161234567801", After: "GB29XABC10161234567802"},
+		{Field: "attributes.beneficiary_party.name", Before: nil, After: "Emelia Jane Brown"},
+	}}
+
+	//Act
+	err := db.Create(&entry).Error
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, changes)
+	for _, plaintext := range []string{"GB29XABC10161234567801", "GB29XABC10161234567802", "Emelia Jane Brown"} {
+		assert.NotContains(t, changes, plaintext)
+	}
+	assert.Contains(t, changes, `"after":"20.00"`, "the other fields are not encrypted")
+	assert.Contains(t, changes, `"before":null`)
+	assert.Equal(t, "a", entry.KeyID)
+	assert.Equal(t, "Emelia Jane Brown", entry.Changes[2].After, "the saved entry is decrypted back")
+	assert.Equal(t, "GB29XABC10161234567801", entry.Changes[1].Before)
+}
+
+func Test_GetListOfPayments_AccountNumber(t *testing.T) {
+	keys := newTestKeys(t)
+	tests := []struct {
+		name  string
+		opts  []RepositoryOption
+		value string
+	}{
+		{name: "Should match the account number", value: "GB29 XABC 1016 1234 5678 01"},
+		{name: "Should match the blind index of the account number", opts: []RepositoryOption{WithEncryption(keys)}, value: encryption.BlindIndex(keys.IndexKey(), "GB29XABC10161234567801")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			db := SetupDBTests()
+			defer db.Close()
+			var query string
+			var args []driver.NamedValue
+			mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+				{
+					Pattern:  "SELECT * FROM \"payments\"  WHERE \"payments\".\"deleted_at\" IS NULL AND ((attributes_id IN",
+					Callback: func(q string, a []driver.NamedValue) { query, args = q, a },
+				},
+			})
+			r := NewPaymentRepository(db, tt.opts...)
+
+			//Act
+			_, err := r.GetListOfPayments(ListQuery{Limit: 10, AccountNumber: "GB29 XABC 1016 1234 5678 01"})
+
+			//Assert
+			require.NoError(t, err)
+			require.Len(t, args, 2)
+			assert.Equal(t, tt.value, args[0].Value)
+			assert.Equal(t, tt.value, args[1].Value)
+			if tt.opts != nil {
+				assert.Contains(t, query, "debtor_parties.account_number_index = ?")
+			}
+		})
+	}
+}
+
+func Test_KeyRotator_RunOnce(t *testing.T) {
+	// Arrange
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("ReencryptParties", defaultReencryptBatchSize).Return(500, nil).Once()
+	repositoryMock.On("ReencryptParties", defaultReencryptBatchSize).Return(12, nil).Once()
+	repositoryMock.On("ReencryptParties", defaultReencryptBatchSize).Return(0, nil).Once()
+	rotator := NewKeyRotator(repositoryMock, &mockLeader{lead: true})
+
+	//Act
+	count, err := rotator.RunOnce(context.Background())
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, 512, count)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_KeyRotator_RunOnce_Follower(t *testing.T) {
+	// Arrange
+	repositoryMock := &MockRepository{}
+	rotator := NewKeyRotator(repositoryMock, &mockLeader{})
+
+	//Act
+	count, err := rotator.RunOnce(context.Background())
+
+	//Assert
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	repositoryMock.AssertNotCalled(t, "ReencryptParties")
+}
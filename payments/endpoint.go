@@ -14,6 +14,30 @@ type Endpoints struct {
 	PostPayment       endpoint.Endpoint
 	UpdatePayment     endpoint.Endpoint
 	DeletePayment     endpoint.Endpoint
+	RestorePayment    endpoint.Endpoint
+	ReviewPayment     endpoint.Endpoint
+
+	ApprovePayment      endpoint.Endpoint
+	GetPaymentApprovals endpoint.Endpoint
+	GetPaymentAudit     endpoint.Endpoint
+
+	CreateReturn       endpoint.Endpoint
+	GetReturn          endpoint.Endpoint
+	GetPaymentReturns  endpoint.Endpoint
+	UpdateReturnStatus endpoint.Endpoint
+
+	CreateRecall      endpoint.Endpoint
+	GetRecall         endpoint.Endpoint
+	GetPaymentRecalls endpoint.Endpoint
+	DecideRecall      endpoint.Endpoint
+
+	GetAccountBalance endpoint.Endpoint
+	GetAccountEntries endpoint.Endpoint
+	CheckLedger       endpoint.Endpoint
+
+	GetOrganisationLimits    endpoint.Endpoint
+	UpdateOrganisationLimits endpoint.Endpoint
+	DeleteOrganisationLimits endpoint.Endpoint
 }
 
 //MakeEndpoints ...
@@ -24,6 +48,30 @@ func MakeEndpoints(svc Service) Endpoints {
 		PostPayment:       makePostPaymentEndpoint(svc),
 		UpdatePayment:     makeUpdatePaymentEndpoint(svc),
 		DeletePayment:     makeDeletePaymentEndpoint(svc),
+		RestorePayment:    makeRestorePaymentEndpoint(svc),
+		ReviewPayment:     makeReviewPaymentEndpoint(svc),
+
+		ApprovePayment:      makeApprovePaymentEndpoint(svc),
+		GetPaymentApprovals: makeGetPaymentApprovalsEndpoint(svc),
+		GetPaymentAudit:     makeGetPaymentAuditEndpoint(svc),
+
+		CreateReturn:       makeCreateReturnEndpoint(svc),
+		GetReturn:          makeGetReturnEndpoint(svc),
+		GetPaymentReturns:  makeGetPaymentReturnsEndpoint(svc),
+		UpdateReturnStatus: makeUpdateReturnStatusEndpoint(svc),
+
+		CreateRecall:      makeCreateRecallEndpoint(svc),
+		GetRecall:         makeGetRecallEndpoint(svc),
+		GetPaymentRecalls: makeGetPaymentRecallsEndpoint(svc),
+		DecideRecall:      makeDecideRecallEndpoint(svc),
+
+		GetAccountBalance: makeGetAccountBalanceEndpoint(svc),
+		GetAccountEntries: makeGetAccountEntriesEndpoint(svc),
+		CheckLedger:       makeCheckLedgerEndpoint(svc),
+
+		GetOrganisationLimits:    makeGetOrganisationLimitsEndpoint(svc),
+		UpdateOrganisationLimits: makeUpdateOrganisationLimitsEndpoint(svc),
+		DeleteOrganisationLimits: makeDeleteOrganisationLimitsEndpoint(svc),
 	}
 }
 
@@ -44,7 +92,12 @@ func makeGetPaymentEndpoint(svc Service) endpoint.Endpoint {
 // makeGetListOfPaymentsEndpoint creates a go-kit like endpoint used to get list of payments
 func makeGetListOfPaymentsEndpoint(svc Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		r := GetListOfPaymentsRequest{}
+		var r GetListOfPaymentsRequest
+		var ok bool
+
+		if r, ok = request.(GetListOfPaymentsRequest); !ok {
+			return nil, errors.New("failed to cast GetListOfPaymentsRequest")
+		}
 		return svc.GetListOfPayments(r)
 	}
 }
@@ -90,3 +143,255 @@ func makeDeletePaymentEndpoint(svc Service) endpoint.Endpoint {
 		return svc.DeletePayment(r)
 	}
 }
+
+// makeRestorePaymentEndpoint creates a go-kit like endpoint used to restore a deleted payment by ID
+func makeRestorePaymentEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r RestorePaymentRequest
+		var ok bool
+
+		if r, ok = request.(RestorePaymentRequest); !ok {
+			return nil, errors.New("failed to cast RestorePaymentRequest")
+		}
+
+		return svc.RestorePayment(r)
+	}
+}
+
+// makeReviewPaymentEndpoint creates a go-kit like endpoint used to release or reject a payment held for review
+func makeReviewPaymentEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r ReviewPaymentRequest
+		var ok bool
+
+		if r, ok = request.(ReviewPaymentRequest); !ok {
+			return nil, errors.New("failed to cast ReviewPaymentRequest")
+		}
+
+		return svc.ReviewPayment(r)
+	}
+}
+
+// makeApprovePaymentEndpoint creates a go-kit like endpoint used to approve or reject a payment pending approval
+func makeApprovePaymentEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r ApprovePaymentRequest
+		var ok bool
+
+		if r, ok = request.(ApprovePaymentRequest); !ok {
+			return nil, errors.New("failed to cast ApprovePaymentRequest")
+		}
+		return svc.ApprovePayment(r)
+	}
+}
+
+// makeGetPaymentApprovalsEndpoint creates a go-kit like endpoint used to list the approvals of a payment
+func makeGetPaymentApprovalsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetPaymentApprovalsRequest
+		var ok bool
+
+		if r, ok = request.(GetPaymentApprovalsRequest); !ok {
+			return nil, errors.New("failed to cast GetPaymentApprovalsRequest")
+		}
+		return svc.GetPaymentApprovals(r)
+	}
+}
+
+// makeGetPaymentAuditEndpoint creates a go-kit like endpoint used to list the audit log of a payment
+func makeGetPaymentAuditEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetPaymentAuditRequest
+		var ok bool
+
+		if r, ok = request.(GetPaymentAuditRequest); !ok {
+			return nil, errors.New("failed to cast GetPaymentAuditRequest")
+		}
+		return svc.GetPaymentAudit(r)
+	}
+}
+
+// makeCreateReturnEndpoint creates a go-kit like endpoint used to return or reverse a payment
+func makeCreateReturnEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r CreateReturnRequest
+		var ok bool
+
+		if r, ok = request.(CreateReturnRequest); !ok {
+			return nil, errors.New("failed to cast CreateReturnRequest")
+		}
+		return svc.CreateReturn(r)
+	}
+}
+
+// makeGetReturnEndpoint creates a go-kit like endpoint used to retrieve a return or a reversal of a payment
+func makeGetReturnEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetReturnRequest
+		var ok bool
+
+		if r, ok = request.(GetReturnRequest); !ok {
+			return nil, errors.New("failed to cast GetReturnRequest")
+		}
+		return svc.GetReturn(r)
+	}
+}
+
+// makeGetPaymentReturnsEndpoint creates a go-kit like endpoint used to list the returns or the reversals of a payment
+func makeGetPaymentReturnsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetPaymentReturnsRequest
+		var ok bool
+
+		if r, ok = request.(GetPaymentReturnsRequest); !ok {
+			return nil, errors.New("failed to cast GetPaymentReturnsRequest")
+		}
+		return svc.GetPaymentReturns(r)
+	}
+}
+
+// makeUpdateReturnStatusEndpoint creates a go-kit like endpoint used to complete or fail a return or a reversal
+func makeUpdateReturnStatusEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r UpdateReturnStatusRequest
+		var ok bool
+
+		if r, ok = request.(UpdateReturnStatusRequest); !ok {
+			return nil, errors.New("failed to cast UpdateReturnStatusRequest")
+		}
+		return svc.UpdateReturnStatus(r)
+	}
+}
+
+// makeCreateRecallEndpoint creates a go-kit like endpoint used to request the recall of a payment
+func makeCreateRecallEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r CreateRecallRequest
+		var ok bool
+
+		if r, ok = request.(CreateRecallRequest); !ok {
+			return nil, errors.New("failed to cast CreateRecallRequest")
+		}
+		return svc.CreateRecall(r)
+	}
+}
+
+// makeGetRecallEndpoint creates a go-kit like endpoint used to retrieve a recall of a payment
+func makeGetRecallEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetRecallRequest
+		var ok bool
+
+		if r, ok = request.(GetRecallRequest); !ok {
+			return nil, errors.New("failed to cast GetRecallRequest")
+		}
+		return svc.GetRecall(r)
+	}
+}
+
+// makeGetPaymentRecallsEndpoint creates a go-kit like endpoint used to list the recalls of a payment
+func makeGetPaymentRecallsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetPaymentRecallsRequest
+		var ok bool
+
+		if r, ok = request.(GetPaymentRecallsRequest); !ok {
+			return nil, errors.New("failed to cast GetPaymentRecallsRequest")
+		}
+		return svc.GetPaymentRecalls(r)
+	}
+}
+
+// makeDecideRecallEndpoint creates a go-kit like endpoint used to accept or reject a recall
+func makeDecideRecallEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r DecideRecallRequest
+		var ok bool
+
+		if r, ok = request.(DecideRecallRequest); !ok {
+			return nil, errors.New("failed to cast DecideRecallRequest")
+		}
+		return svc.DecideRecall(r)
+	}
+}
+
+// makeGetAccountBalanceEndpoint creates a go-kit like endpoint used to retrieve the balance of a ledger account
+func makeGetAccountBalanceEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetAccountBalanceRequest
+		var ok bool
+
+		if r, ok = request.(GetAccountBalanceRequest); !ok {
+			return nil, errors.New("failed to cast GetAccountBalanceRequest")
+		}
+		return svc.GetAccountBalance(r)
+	}
+}
+
+// makeGetAccountEntriesEndpoint creates a go-kit like endpoint used to list the entries of a ledger account
+func makeGetAccountEntriesEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetAccountEntriesRequest
+		var ok bool
+
+		if r, ok = request.(GetAccountEntriesRequest); !ok {
+			return nil, errors.New("failed to cast GetAccountEntriesRequest")
+		}
+		return svc.GetAccountEntries(r)
+	}
+}
+
+// makeCheckLedgerEndpoint creates a go-kit like endpoint used to check every ledger transaction sums to zero
+func makeCheckLedgerEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r CheckLedgerRequest
+		var ok bool
+
+		if r, ok = request.(CheckLedgerRequest); !ok {
+			return nil, errors.New("failed to cast CheckLedgerRequest")
+		}
+		return svc.CheckLedger(r)
+	}
+}
+
+// makeGetOrganisationLimitsEndpoint creates a go-kit like endpoint used to retrieve the limits of an organisation
+func makeGetOrganisationLimitsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r GetOrganisationLimitsRequest
+		var ok bool
+
+		if r, ok = request.(GetOrganisationLimitsRequest); !ok {
+			return nil, errors.New("failed to cast GetOrganisationLimitsRequest")
+		}
+
+		return svc.GetOrganisationLimits(r)
+	}
+}
+
+// makeUpdateOrganisationLimitsEndpoint creates a go-kit like endpoint used to replace the limits of an organisation
+func makeUpdateOrganisationLimitsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r UpdateOrganisationLimitsRequest
+		var ok bool
+
+		if r, ok = request.(UpdateOrganisationLimitsRequest); !ok {
+			return nil, errors.New("failed to cast UpdateOrganisationLimitsRequest")
+		}
+
+		return svc.UpdateOrganisationLimits(r)
+	}
+}
+
+// makeDeleteOrganisationLimitsEndpoint creates a go-kit like endpoint used to remove the limits of an organisation
+func makeDeleteOrganisationLimitsEndpoint(svc Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		var r DeleteOrganisationLimitsRequest
+		var ok bool
+
+		if r, ok = request.(DeleteOrganisationLimitsRequest); !ok {
+			return nil, errors.New("failed to cast DeleteOrganisationLimitsRequest")
+		}
+
+		return svc.DeleteOrganisationLimits(r)
+	}
+}
@@ -36,4 +36,244 @@ var (
 		ResponseCode: http.StatusBadRequest,
 		Message:      "invalid body",
 	}
+
+	// ErrInvalidIdempotencyKey is thrown when the Idempotency-Key header is too long
+	ErrInvalidIdempotencyKey = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid Idempotency-Key header",
+	}
+
+	// ErrInvalidPagination is thrown when the requested page or page size is not valid
+	ErrInvalidPagination = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid page or page_size",
+	}
+
+	// ErrInvalidEventFilter is thrown when the organisation or the event types of the events stream are not valid
+	ErrInvalidEventFilter = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid organisation_id or type",
+	}
+
+	// ErrEventsForbidden is thrown when the caller streams the events of another organisation than its own
+	ErrEventsForbidden = apierrors.APIError{
+		ResponseCode: http.StatusForbidden,
+		Message:      "the caller is not allowed to stream the events of this organisation",
+	}
+
+	// ErrInvalidLastEventID is thrown when the Last-Event-ID header is not an event ID
+	ErrInvalidLastEventID = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid Last-Event-ID header",
+	}
+
+	// ErrSchemeRulesViolation is thrown when the payment does not comply with the rules of its payment scheme
+	ErrSchemeRulesViolation = apierrors.APIError{
+		ResponseCode: http.StatusUnprocessableEntity,
+		Message:      "the payment does not comply with the payment scheme rules",
+	}
+
+	// ErrDuplicatePayment is thrown when the payment looks like a payment recently created by the organisation
+	ErrDuplicatePayment = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "a payment with the same accounts, amount, currency and end to end reference was recently created",
+	}
+
+	// ErrInvalidReviewDecision is thrown when the decision of a review is neither release nor reject
+	ErrInvalidReviewDecision = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid review decision, expected release or reject",
+	}
+
+	// ErrMissingUserID is thrown when the user of a request which requires one is not identified
+	ErrMissingUserID = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "missing X-User-ID header",
+	}
+
+	// ErrInvalidApprovalDecision is thrown when the decision of an approval is neither approve nor reject
+	ErrInvalidApprovalDecision = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid approval decision, expected approve or reject",
+	}
+
+	// ErrPaymentNotPendingApproval is thrown when a payment approved is not pending approval
+	ErrPaymentNotPendingApproval = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the payment is not pending approval",
+	}
+
+	// ErrPaymentNotUpdatable is thrown when a payment updated is already due or rejected
+	ErrPaymentNotUpdatable = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the payment is due or rejected, it can no longer be updated",
+	}
+
+	// ErrSelfApproval is thrown when the creator of a payment tries to approve it
+	ErrSelfApproval = apierrors.APIError{
+		ResponseCode: http.StatusForbidden,
+		Message:      "the creator of a payment cannot approve it",
+	}
+
+	// ErrInvalidProcessingDate is thrown when the processing date of a payment is in the past or is not a business day of its scheme
+	ErrInvalidProcessingDate = apierrors.APIError{
+		ResponseCode: http.StatusUnprocessableEntity,
+		Message:      "the processing date is not a business day of the payment scheme from today",
+	}
+
+	// ErrLimitExceeded is thrown when the payment breaks the limits of its organisation, the errors state the remaining allowance
+	ErrLimitExceeded = apierrors.APIError{
+		ResponseCode: http.StatusUnprocessableEntity,
+		Message:      "the payment exceeds the limits of the organisation",
+	}
+
+	// ErrInvalidOrganisationID is thrown when organisation ID is not valid
+	ErrInvalidOrganisationID = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid organisation ID",
+	}
+
+	// ErrInvalidLimits is thrown when the limits of an organisation are not valid
+	ErrInvalidLimits = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid organisation limits",
+	}
+
+	// ErrLimitsForbidden is thrown when the caller changing the limits of an organisation is not an administrator
+	ErrLimitsForbidden = apierrors.APIError{
+		ResponseCode: http.StatusForbidden,
+		Message:      "the caller is not allowed to change the limits of the organisations",
+	}
+
+	// ErrLimitsNotFound is thrown when the organisation has no limits
+	ErrLimitsNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "organisation limits not found",
+	}
+
+	// ErrPaymentNotHeldForReview is thrown when a payment reviewed is not held for review
+	ErrPaymentNotHeldForReview = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the payment is not held for review",
+	}
+
+	// ErrCalendarNotFound is thrown when the calendar of an unknown payment scheme is requested
+	ErrCalendarNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "no calendar for this payment scheme",
+	}
+
+	// ErrInvalidCalendarYear is thrown when the year of the holidays of a calendar is not a year
+	ErrInvalidCalendarYear = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid year",
+	}
+
+	// ErrInvalidReturnID is thrown when the ID of a return or a reversal is not valid
+	ErrInvalidReturnID = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid return ID",
+	}
+
+	// ErrInvalidReturn is thrown when the amount or the reason code of a return or a reversal is not valid
+	ErrInvalidReturn = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid return",
+	}
+
+	// ErrReturnNotFound is thrown when the return or the reversal of a payment was not found
+	ErrReturnNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "return not found",
+	}
+
+	// ErrPaymentNotReturnable is thrown when a payment which has not gone out is returned or reversed
+	ErrPaymentNotReturnable = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the payment has not gone out",
+	}
+
+	// ErrReturnAmountExceeded is thrown when a return or a reversal exceeds the amount of the payment not yet returned
+	ErrReturnAmountExceeded = apierrors.APIError{
+		ResponseCode: http.StatusUnprocessableEntity,
+		Message:      "the amount exceeds the amount of the payment not yet returned",
+	}
+
+	// ErrReturnNotPending is thrown when a return or a reversal already completed or failed is completed or failed
+	ErrReturnNotPending = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the return is not pending",
+	}
+
+	// ErrInvalidRecallID is thrown when the ID of a recall is not valid
+	ErrInvalidRecallID = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid recall ID",
+	}
+
+	// ErrInvalidRecall is thrown when the reason code of a recall or the decision on a recall is not valid
+	ErrInvalidRecall = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid recall",
+	}
+
+	// ErrRecallNotFound is thrown when the recall of a payment was not found
+	ErrRecallNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "recall not found",
+	}
+
+	// ErrRecallNotEligible is thrown when a payment cannot be recalled, e.g. after the recall window
+	ErrRecallNotEligible = apierrors.APIError{
+		ResponseCode: http.StatusUnprocessableEntity,
+		Message:      "the payment cannot be recalled",
+	}
+
+	// ErrRecallAlreadyRequested is thrown when a recall is requested on a payment whose recall is waiting for a decision
+	ErrRecallAlreadyRequested = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "a recall of the payment is already requested",
+	}
+
+	// ErrRecallNotRequested is thrown when a decision is made on a recall already accepted or rejected
+	ErrRecallNotRequested = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the recall is not requested",
+	}
+
+	// ErrInvalidPaymentVersion is thrown when the version or the time of a payment requested is not valid, or both are given
+	ErrInvalidPaymentVersion = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid version or as_of, expected a version number or an RFC 3339 time",
+	}
+
+	// ErrVersionNotFound is thrown when a payment has no such version, or did not exist yet at the requested time
+	ErrVersionNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "payment version not found",
+	}
+
+	// ErrInvalidInclude is thrown when the payments list is asked to include anything but the deleted payments
+	ErrInvalidInclude = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid include, expected deleted",
+	}
+
+	// ErrPaymentNotDeleted is thrown when a payment which is not deleted is restored
+	ErrPaymentNotDeleted = apierrors.APIError{
+		ResponseCode: http.StatusConflict,
+		Message:      "the payment is not deleted",
+	}
+
+	// ErrInvalidAccount is thrown when a ledger account is neither a party account nor a charges account
+	ErrInvalidAccount = apierrors.APIError{
+		ResponseCode: http.StatusBadRequest,
+		Message:      "invalid ledger account",
+	}
+
+	// ErrAccountNotFound is thrown when a ledger account has no entries
+	ErrAccountNotFound = apierrors.APIError{
+		ResponseCode: http.StatusNotFound,
+		Message:      "ledger account not found",
+	}
 )
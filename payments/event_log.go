@@ -0,0 +1,115 @@
+package payments
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+
+	"github.com/elkousy/payments-api/utility/config"
+	"github.com/elkousy/payments-api/utility/logger"
+)
+
+// EventsLockKey is the key of the Postgres advisory lock serialising the appends to the event log, so that the events
+// are committed in the order of their IDs
+const EventsLockKey int64 = 0x6576656e7473
+
+// eventsChannel is the Postgres notification channel of the events appended to the log
+const eventsChannel = "payment_events"
+
+// EventLog stores the payment events published by all the replicas, so that they share the event IDs and the
+// subscribers of a replica receive the events published by the others
+type EventLog interface {
+	// Append stores an event with the next ID and removes the events older than the last retention ones
+	Append(e Event, retention int) (Event, error)
+	// Since returns up to limit events with an ID greater than id, oldest first
+	Since(id uint64, limit int) ([]Event, error)
+	// Bounds returns the IDs of the oldest and of the last events stored, 0 when there is none
+	Bounds() (oldest uint64, last uint64, err error)
+	// Appended receives a value when an event was appended by any replica, or may have been
+	Appended() <-chan struct{}
+}
+
+// TableName stores the events in the payment_events table
+func (Event) TableName() string {
+	return "payment_events"
+}
+
+type postgresEventLog struct {
+	db       *gorm.DB
+	appended chan struct{}
+}
+
+// NewPostgresEventLog returns the event log of the payment_events table. The replicas are notified of the events
+// appended through LISTEN/NOTIFY, on a connection of their own.
+func NewPostgresEventLog(db *gorm.DB) (EventLog, error) {
+	cnx := newConnection(config.DBHost, config.DBPort, config.DBName, config.DBUser, config.DBPassword, config.DBTimeout, config.AppName)
+	listener := pq.NewListener(cnx, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.LogStdErr.Errorw("error when listening to the payment events", "err", err)
+		}
+	})
+	if err := listener.Listen(eventsChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	l := &postgresEventLog{db: db, appended: make(chan struct{}, 1)}
+	go func() {
+		// a nil notification is received on reconnection, the events appended meanwhile are read too
+		for range listener.Notify {
+			select {
+			case l.appended <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return l, nil
+}
+
+func (l *postgresEventLog) Append(e Event, retention int) (Event, error) {
+	tx := l.db.Begin()
+	if tx.Error != nil {
+		return e, tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", EventsLockKey).Error; err != nil {
+		return e, err
+	}
+	e.ID = 0
+	if err := tx.Create(&e).Error; err != nil {
+		return e, err
+	}
+	if e.ID > uint64(retention) {
+		if err := tx.Where("id <= ?", e.ID-uint64(retention)).Delete(&Event{}).Error; err != nil {
+			return e, err
+		}
+	}
+	// the notification is sent on commit
+	if err := tx.Exec("SELECT pg_notify(?, '')", eventsChannel).Error; err != nil {
+		return e, err
+	}
+	return e, tx.Commit().Error
+}
+
+func (l *postgresEventLog) Since(id uint64, limit int) ([]Event, error) {
+	events := []Event{}
+	if err := l.db.Where("id > ?", id).Order("id").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (l *postgresEventLog) Bounds() (uint64, uint64, error) {
+	var bounds struct {
+		Oldest uint64
+		Last   uint64
+	}
+	err := l.db.Model(&Event{}).Select("COALESCE(MIN(id), 0) AS oldest, COALESCE(MAX(id), 0) AS last").Scan(&bounds).Error
+	return bounds.Oldest, bounds.Last, err
+}
+
+func (l *postgresEventLog) Appended() <-chan struct{} {
+	return l.appended
+}
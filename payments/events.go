@@ -0,0 +1,282 @@
+package payments
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/utility/instrumenting"
+	"github.com/elkousy/payments-api/utility/logger"
+)
+
+// eventsReadBatch is the number of events read at once from the event log
+const eventsReadBatch = 500
+
+// EventType is the type of a payment change
+type EventType string
+
+const (
+	// EventPaymentCreated is published when a payment is created
+	EventPaymentCreated EventType = "payment.created"
+	// EventPaymentUpdated is published when a payment is updated
+	EventPaymentUpdated EventType = "payment.updated"
+	// EventPaymentDeleted is published when a payment is deleted
+	EventPaymentDeleted EventType = "payment.deleted"
+	// EventPaymentRestored is published when a deleted payment is restored
+	EventPaymentRestored EventType = "payment.restored"
+	// EventPaymentStateChanged is published when a payment moves to another state of its lifecycle
+	EventPaymentStateChanged EventType = "payment.state_changed"
+)
+
+var eventTypes = map[EventType]bool{
+	EventPaymentCreated:      true,
+	EventPaymentUpdated:      true,
+	EventPaymentDeleted:      true,
+	EventPaymentRestored:     true,
+	EventPaymentStateChanged: true,
+}
+
+// Event is a change of a payment, streamed to the events subscribers
+type Event struct {
+	ID             uint64    `json:"-" gorm:"primary_key"`
+	Type           EventType `json:"type"`
+	PaymentID      string    `json:"payment_id"`
+	OrganisationID uuid.UUID `json:"organisation_id" gorm:"type:uuid"`
+	// Status is the new status of the payment of a payment.state_changed event
+	Status    PaymentStatus `json:"status,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// EventFilter selects the events of a subscription, empty fields match all events
+type EventFilter struct {
+	OrganisationID uuid.UUID
+	Types          map[EventType]bool
+}
+
+func (f EventFilter) match(e Event) bool {
+	if f.OrganisationID != uuid.Nil && f.OrganisationID != e.OrganisationID {
+		return false
+	}
+	return len(f.Types) == 0 || f.Types[e.Type]
+}
+
+// subscription receives the events matching its filter published after the event it resumes from.
+// The channel is closed when the subscriber cannot keep up with the published events.
+type subscription struct {
+	filter EventFilter
+	after  uint64
+	events chan Event
+}
+
+// EventBroker fans out the payment events to the subscribers and retains the last ones,
+// so that subscribers can resume a stream from the last event they received
+type EventBroker struct {
+	mu          sync.Mutex
+	retained    []Event
+	retention   int
+	bufferSize  int
+	lastID      uint64
+	subscribers map[*subscription]bool
+	// log retains the events of all the replicas instead, nil when the events are retained in memory
+	log EventLog
+	// following is set once lastID is the ID of an event of the log
+	following bool
+}
+
+// EventBrokerOption configures the optional features of the event broker
+type EventBrokerOption func(*EventBroker)
+
+// WithEventLog shares the events between the replicas through a log, Run sends them to the subscribers
+func WithEventLog(log EventLog) EventBrokerOption {
+	return func(b *EventBroker) {
+		b.log = log
+	}
+}
+
+// NewEventBroker returns a broker retaining the given number of events.
+// A subscriber lagging behind by more than bufferSize events is disconnected.
+func NewEventBroker(retention int, bufferSize int, opts ...EventBrokerOption) *EventBroker {
+	b := &EventBroker{
+		retention:   retention,
+		bufferSize:  bufferSize,
+		subscribers: map[*subscription]bool{},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Publish assigns the next ID to the event, retains it and sends it to the matching subscribers. With an event log,
+// the event is appended to the log and sent once read back from it, as on the other replicas.
+func (b *EventBroker) Publish(e Event) {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+	if b.log != nil {
+		if _, err := b.log.Append(e, b.retention); err != nil {
+			logger.LogStdErr.Errorw("error when appending a payment event", "type", e.Type, "payment_id", e.PaymentID, "err", err)
+		}
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+
+	b.retained = append(b.retained, e)
+	if len(b.retained) > b.retention {
+		b.retained = append([]Event(nil), b.retained[len(b.retained)-b.retention:]...)
+	}
+	b.send(e)
+}
+
+// Run sends to the subscribers the events appended to the log by any replica until the context is done, the log is
+// read at every poll interval as well in case a notification is missed
+func (b *EventBroker) Run(ctx context.Context, poll time.Duration) {
+	if b.log == nil {
+		return
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		if err := b.readLog(); err != nil {
+			logger.LogStdErr.Errorw("error when reading the payment events", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.log.Appended():
+		case <-ticker.C:
+		}
+	}
+}
+
+// readLog sends to the subscribers the events of the log appended since the last one sent
+func (b *EventBroker) readLog() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.follow(); err != nil {
+		return err
+	}
+	for {
+		events, err := b.log.Since(b.lastID, eventsReadBatch)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			b.lastID = e.ID
+			b.send(e)
+		}
+		if len(events) < eventsReadBatch {
+			return nil
+		}
+	}
+}
+
+// follow starts following the log from its last event, the older ones are replayed to the subscribers resuming only
+func (b *EventBroker) follow() error {
+	if b.following {
+		return nil
+	}
+	_, last, err := b.log.Bounds()
+	if err != nil {
+		return err
+	}
+	b.lastID, b.following = last, true
+	return nil
+}
+
+// send sends an event to the matching subscribers, it is called with the lock held
+func (b *EventBroker) send(e Event) {
+	for s := range b.subscribers {
+		if e.ID <= s.after || !s.filter.match(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// never block the publishers on a slow subscriber, it resumes with its last event ID
+			b.remove(s)
+			instrumenting.StreamEvictionsCounter.With("component", componentName, "stream", eventsStreamName).Add(1)
+		}
+	}
+}
+
+// subscribe registers a subscription and returns the retained events published after lastEventID,
+// when lastEventID is not 0. gap is true when some of these events are not retained anymore.
+func (b *EventBroker) subscribe(filter EventFilter, lastEventID uint64) (s *subscription, replay []Event, gap bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.log != nil {
+		if replay, gap, err = b.replayLog(filter, lastEventID); err != nil {
+			return nil, nil, false, err
+		}
+	} else if lastEventID != 0 {
+		oldestID := b.lastID - uint64(len(b.retained)) + 1
+		// an ID greater than the last one was issued before a restart
+		gap = lastEventID > b.lastID || lastEventID+1 < oldestID
+		for _, e := range b.retained {
+			if e.ID > lastEventID && filter.match(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	s = &subscription{filter: filter, events: make(chan Event, b.bufferSize)}
+	if !gap {
+		s.after = lastEventID
+	}
+	b.subscribers[s] = true
+	return s, replay, gap, nil
+}
+
+// replayLog returns the events of the log published after lastEventID and already sent by this replica, the next
+// ones are sent by readLog. It is called with the lock held.
+func (b *EventBroker) replayLog(filter EventFilter, lastEventID uint64) ([]Event, bool, error) {
+	if err := b.follow(); err != nil || lastEventID == 0 {
+		return nil, false, err
+	}
+	oldestID, last, err := b.log.Bounds()
+	if err != nil {
+		return nil, false, err
+	}
+	// an ID greater than the last one was not issued by the log
+	gap := lastEventID > last || lastEventID+1 < oldestID
+	var replay []Event
+	for id := lastEventID; !gap && id < b.lastID; {
+		events, err := b.log.Since(id, eventsReadBatch)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, e := range events {
+			if e.ID <= b.lastID && filter.match(e) {
+				replay = append(replay, e)
+			}
+			id = e.ID
+		}
+	}
+	return replay, gap, nil
+}
+
+// unsubscribe removes a subscription, if it has not been removed already
+func (b *EventBroker) unsubscribe(s *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(s)
+}
+
+func (b *EventBroker) remove(s *subscription) {
+	if b.subscribers[s] {
+		delete(b.subscribers, s)
+		close(s.events)
+	}
+}
@@ -0,0 +1,174 @@
+package payments
+
+import (
+	"sync"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EventFilter_match(t *testing.T) {
+	org1 := uuid.NewV4()
+	org2 := uuid.NewV4()
+	e := Event{Type: EventPaymentCreated, OrganisationID: org1}
+
+	tests := []struct {
+		name     string
+		filter   EventFilter
+		expected bool
+	}{
+		{"all", EventFilter{}, true},
+		{"organisation", EventFilter{OrganisationID: org1}, true},
+		{"other organisation", EventFilter{OrganisationID: org2}, false},
+		{"type", EventFilter{Types: map[EventType]bool{EventPaymentCreated: true}}, true},
+		{"other type", EventFilter{Types: map[EventType]bool{EventPaymentDeleted: true}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.filter.match(e))
+		})
+	}
+}
+
+func Test_EventBroker_Publish(t *testing.T) {
+	// Arrange
+	org := uuid.NewV4()
+	b := NewEventBroker(10, 10)
+	sub, _, _, _ := b.subscribe(EventFilter{OrganisationID: org}, 0)
+
+	// Act
+	b.Publish(Event{Type: EventPaymentCreated, OrganisationID: uuid.NewV4()})
+	b.Publish(Event{Type: EventPaymentCreated, OrganisationID: org})
+
+	// Assert
+	e := <-sub.events
+	assert.Equal(t, uint64(2), e.ID)
+	assert.Equal(t, org, e.OrganisationID)
+	assert.False(t, e.CreatedAt.IsZero())
+	assert.Len(t, sub.events, 0)
+}
+
+func Test_EventBroker_Resume(t *testing.T) {
+	// Arrange
+	b := NewEventBroker(3, 10)
+	for i := 0; i < 5; i++ {
+		b.Publish(Event{Type: EventPaymentUpdated})
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		replayed    []uint64
+		gap         bool
+	}{
+		{"no resume", 0, nil, false},
+		{"retained", 2, []uint64{3, 4, 5}, false},
+		{"up to date", 5, nil, false},
+		{"not retained anymore", 1, []uint64{3, 4, 5}, true},
+		{"issued before a restart", 42, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sub, replay, gap, _ := b.subscribe(EventFilter{}, tt.lastEventID)
+			defer b.unsubscribe(sub)
+
+			// Assert
+			var ids []uint64
+			for _, e := range replay {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.replayed, ids)
+			assert.Equal(t, tt.gap, gap)
+		})
+	}
+}
+
+func Test_EventBroker_EvictsSlowSubscribers(t *testing.T) {
+	// Arrange
+	b := NewEventBroker(10, 1)
+	sub, _, _, _ := b.subscribe(EventFilter{}, 0)
+
+	// Act
+	b.Publish(Event{Type: EventPaymentCreated})
+	b.Publish(Event{Type: EventPaymentCreated})
+
+	// Assert
+	e, ok := <-sub.events
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), e.ID)
+	_, ok = <-sub.events
+	assert.False(t, ok, "the subscription should be closed")
+	assert.Empty(t, b.subscribers)
+
+	// unsubscribing an evicted subscriber is a no-op
+	b.unsubscribe(sub)
+}
+
+// memoryEventLog is an event log shared by the brokers of a test, as the Postgres log by the replicas
+type memoryEventLog struct {
+	mu       sync.Mutex
+	events   []Event
+	appended chan struct{}
+}
+
+func (l *memoryEventLog) Append(e Event, retention int) (Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e.ID = uint64(len(l.events)) + 1
+	l.events = append(l.events, e)
+	return e, nil
+}
+
+func (l *memoryEventLog) Since(id uint64, limit int) ([]Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var events []Event
+	for _, e := range l.events {
+		if e.ID > id && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (l *memoryEventLog) Bounds() (uint64, uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) == 0 {
+		return 0, 0, nil
+	}
+	return l.events[0].ID, l.events[len(l.events)-1].ID, nil
+}
+
+func (l *memoryEventLog) Appended() <-chan struct{} {
+	return l.appended
+}
+
+func Test_EventBroker_EventLog(t *testing.T) {
+	// Arrange
+	log := &memoryEventLog{}
+	publisher, other := NewEventBroker(10, 10, WithEventLog(log)), NewEventBroker(10, 10, WithEventLog(log))
+	publisher.Publish(Event{Type: EventPaymentCreated, PaymentID: "1"})
+	require.NoError(t, other.readLog())
+	live, _, _, err := other.subscribe(EventFilter{}, 0)
+	require.NoError(t, err)
+
+	// Act
+	publisher.Publish(Event{Type: EventPaymentUpdated, PaymentID: "1"})
+	publisher.Publish(Event{Type: EventPaymentDeleted, PaymentID: "1"})
+	require.NoError(t, other.readLog())
+	resumed, replay, gap, err := other.subscribe(EventFilter{}, 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), (<-live.events).ID, "the events published by another replica are sent")
+	assert.Equal(t, uint64(3), (<-live.events).ID)
+	assert.False(t, gap)
+	if assert.Len(t, replay, 1, "the event IDs are shared by the replicas") {
+		assert.Equal(t, EventPaymentDeleted, replay[0].Type)
+	}
+	assert.Len(t, resumed.events, 0)
+}
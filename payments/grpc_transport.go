@@ -0,0 +1,1169 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/elkousy/payments-api/payments/pb"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+	"github.com/elkousy/payments-api/utility/instrumenting"
+)
+
+type grpcServer struct {
+	pb.UnimplementedPaymentsServer
+	getPayment        kitgrpc.Handler
+	getListOfPayments kitgrpc.Handler
+	postPayment       kitgrpc.Handler
+	updatePayment     kitgrpc.Handler
+	deletePayment     kitgrpc.Handler
+	restorePayment    kitgrpc.Handler
+	reviewPayment     kitgrpc.Handler
+	approvePayment    kitgrpc.Handler
+	listApprovals     kitgrpc.Handler
+	getLimits         kitgrpc.Handler
+	updateLimits      kitgrpc.Handler
+	deleteLimits      kitgrpc.Handler
+	createReturn      kitgrpc.Handler
+	getReturn         kitgrpc.Handler
+	listReturns       kitgrpc.Handler
+	updateReturn      kitgrpc.Handler
+	createRecall      kitgrpc.Handler
+	getRecall         kitgrpc.Handler
+	listRecalls       kitgrpc.Handler
+	decideRecall      kitgrpc.Handler
+	getBalance        kitgrpc.Handler
+	listEntries       kitgrpc.Handler
+	checkLedger       kitgrpc.Handler
+	listAuditEntries  kitgrpc.Handler
+}
+
+// userIDMetadata is the gRPC metadata identifying the user, like the X-User-ID header of the http transport
+const userIDMetadata = "x-user-id"
+
+// requestIDMetadata is the gRPC metadata identifying the request, like the X-Request-ID header of the http transport
+const requestIDMetadata = "x-request-id"
+
+// MakeGRPCServer returns a gRPC server exposing the payments endpoints,
+// sharing the instrumenting, the error mapping and the masking of the account numbers of the http transport
+func MakeGRPCServer(endpoints Endpoints, opts ...TransportOption) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		instrumenting.UnaryServerInterceptor(componentName),
+		apierrors.LoggingServerInterceptor,
+	))
+	pb.RegisterPaymentsServer(s, newGRPCServer(endpoints, newTransportOptions(opts)))
+	return s
+}
+
+func newGRPCServer(endpoints Endpoints, o transportOptions) pb.PaymentsServer {
+	privilege := kitgrpc.ServerBefore(populateGRPCPrivilege(o.privileged))
+	requireAdmin := requirePrivilege(o.admin, ErrLimitsForbidden)
+	return &grpcServer{
+		getPayment: kitgrpc.NewServer(
+			endpoints.GetPayment,
+			decodeGRPCGetPaymentRequest,
+			encodeGRPCGetPaymentResponse,
+			privilege,
+		),
+		getListOfPayments: kitgrpc.NewServer(
+			endpoints.GetListOfPayments,
+			decodeGRPCListPaymentsRequest,
+			encodeGRPCListPaymentsResponse,
+			privilege,
+		),
+		postPayment: kitgrpc.NewServer(
+			endpoints.PostPayment,
+			decodeGRPCCreatePaymentRequest,
+			encodeGRPCCreatePaymentResponse,
+		),
+		updatePayment: kitgrpc.NewServer(
+			endpoints.UpdatePayment,
+			decodeGRPCUpdatePaymentRequest,
+			encodeGRPCUpdatePaymentResponse,
+		),
+		deletePayment: kitgrpc.NewServer(
+			endpoints.DeletePayment,
+			decodeGRPCDeletePaymentRequest,
+			encodeGRPCDeletePaymentResponse,
+		),
+		restorePayment: kitgrpc.NewServer(
+			endpoints.RestorePayment,
+			decodeGRPCRestorePaymentRequest,
+			encodeGRPCRestorePaymentResponse,
+		),
+		reviewPayment: kitgrpc.NewServer(
+			endpoints.ReviewPayment,
+			decodeGRPCReviewPaymentRequest,
+			encodeGRPCReviewPaymentResponse,
+		),
+		approvePayment: kitgrpc.NewServer(
+			endpoints.ApprovePayment,
+			decodeGRPCApprovePaymentRequest,
+			encodeGRPCApprovePaymentResponse,
+		),
+		listApprovals: kitgrpc.NewServer(
+			endpoints.GetPaymentApprovals,
+			decodeGRPCListApprovalsRequest,
+			encodeGRPCListApprovalsResponse,
+		),
+		getLimits: kitgrpc.NewServer(
+			endpoints.GetOrganisationLimits,
+			decodeGRPCGetOrganisationLimitsRequest,
+			encodeGRPCGetOrganisationLimitsResponse,
+		),
+		updateLimits: kitgrpc.NewServer(
+			requireAdmin(endpoints.UpdateOrganisationLimits),
+			decodeGRPCUpdateOrganisationLimitsRequest,
+			encodeGRPCUpdateOrganisationLimitsResponse,
+		),
+		deleteLimits: kitgrpc.NewServer(
+			requireAdmin(endpoints.DeleteOrganisationLimits),
+			decodeGRPCDeleteOrganisationLimitsRequest,
+			encodeGRPCDeleteOrganisationLimitsResponse,
+		),
+		createReturn: kitgrpc.NewServer(
+			endpoints.CreateReturn,
+			decodeGRPCCreateReturnRequest,
+			encodeGRPCCreateReturnResponse,
+		),
+		getReturn: kitgrpc.NewServer(
+			endpoints.GetReturn,
+			decodeGRPCGetReturnRequest,
+			encodeGRPCGetReturnResponse,
+		),
+		listReturns: kitgrpc.NewServer(
+			endpoints.GetPaymentReturns,
+			decodeGRPCListReturnsRequest,
+			encodeGRPCListReturnsResponse,
+		),
+		updateReturn: kitgrpc.NewServer(
+			endpoints.UpdateReturnStatus,
+			decodeGRPCUpdateReturnStatusRequest,
+			encodeGRPCUpdateReturnStatusResponse,
+		),
+		createRecall: kitgrpc.NewServer(
+			endpoints.CreateRecall,
+			decodeGRPCCreateRecallRequest,
+			encodeGRPCCreateRecallResponse,
+		),
+		getRecall: kitgrpc.NewServer(
+			endpoints.GetRecall,
+			decodeGRPCGetRecallRequest,
+			encodeGRPCGetRecallResponse,
+		),
+		listRecalls: kitgrpc.NewServer(
+			endpoints.GetPaymentRecalls,
+			decodeGRPCListRecallsRequest,
+			encodeGRPCListRecallsResponse,
+		),
+		decideRecall: kitgrpc.NewServer(
+			endpoints.DecideRecall,
+			decodeGRPCDecideRecallRequest,
+			encodeGRPCDecideRecallResponse,
+		),
+		getBalance: kitgrpc.NewServer(
+			endpoints.GetAccountBalance,
+			decodeGRPCGetAccountBalanceRequest,
+			encodeGRPCGetAccountBalanceResponse,
+			privilege,
+		),
+		listEntries: kitgrpc.NewServer(
+			endpoints.GetAccountEntries,
+			decodeGRPCListAccountEntriesRequest,
+			encodeGRPCListAccountEntriesResponse,
+			privilege,
+		),
+		checkLedger: kitgrpc.NewServer(
+			endpoints.CheckLedger,
+			decodeGRPCCheckLedgerRequest,
+			encodeGRPCCheckLedgerResponse,
+		),
+		listAuditEntries: kitgrpc.NewServer(
+			endpoints.GetPaymentAudit,
+			decodeGRPCListAuditEntriesRequest,
+			encodeGRPCListAuditEntriesResponse,
+			privilege,
+		),
+	}
+}
+
+func (s *grpcServer) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	_, resp, err := s.getPayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetPaymentResponse), nil
+}
+
+func (s *grpcServer) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	_, resp, err := s.getListOfPayments.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListPaymentsResponse), nil
+}
+
+func (s *grpcServer) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.CreatePaymentResponse, error) {
+	_, resp, err := s.postPayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CreatePaymentResponse), nil
+}
+
+func (s *grpcServer) UpdatePayment(ctx context.Context, req *pb.UpdatePaymentRequest) (*pb.UpdatePaymentResponse, error) {
+	_, resp, err := s.updatePayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.UpdatePaymentResponse), nil
+}
+
+func (s *grpcServer) DeletePayment(ctx context.Context, req *pb.DeletePaymentRequest) (*pb.DeletePaymentResponse, error) {
+	_, resp, err := s.deletePayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeletePaymentResponse), nil
+}
+
+func (s *grpcServer) RestorePayment(ctx context.Context, req *pb.RestorePaymentRequest) (*pb.RestorePaymentResponse, error) {
+	_, resp, err := s.restorePayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.RestorePaymentResponse), nil
+}
+
+func (s *grpcServer) ReviewPayment(ctx context.Context, req *pb.ReviewPaymentRequest) (*pb.ReviewPaymentResponse, error) {
+	_, resp, err := s.reviewPayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ReviewPaymentResponse), nil
+}
+
+func (s *grpcServer) ApprovePayment(ctx context.Context, req *pb.ApprovePaymentRequest) (*pb.ApprovePaymentResponse, error) {
+	_, resp, err := s.approvePayment.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ApprovePaymentResponse), nil
+}
+
+func (s *grpcServer) ListApprovals(ctx context.Context, req *pb.ListApprovalsRequest) (*pb.ListApprovalsResponse, error) {
+	_, resp, err := s.listApprovals.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListApprovalsResponse), nil
+}
+
+func (s *grpcServer) GetOrganisationLimits(ctx context.Context, req *pb.GetOrganisationLimitsRequest) (*pb.GetOrganisationLimitsResponse, error) {
+	_, resp, err := s.getLimits.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetOrganisationLimitsResponse), nil
+}
+
+func (s *grpcServer) UpdateOrganisationLimits(ctx context.Context, req *pb.UpdateOrganisationLimitsRequest) (*pb.UpdateOrganisationLimitsResponse, error) {
+	_, resp, err := s.updateLimits.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.UpdateOrganisationLimitsResponse), nil
+}
+
+func (s *grpcServer) DeleteOrganisationLimits(ctx context.Context, req *pb.DeleteOrganisationLimitsRequest) (*pb.DeleteOrganisationLimitsResponse, error) {
+	_, resp, err := s.deleteLimits.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DeleteOrganisationLimitsResponse), nil
+}
+
+func (s *grpcServer) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error) {
+	_, resp, err := s.createReturn.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CreateReturnResponse), nil
+}
+
+func (s *grpcServer) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.GetReturnResponse, error) {
+	_, resp, err := s.getReturn.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetReturnResponse), nil
+}
+
+func (s *grpcServer) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	_, resp, err := s.listReturns.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListReturnsResponse), nil
+}
+
+func (s *grpcServer) UpdateReturnStatus(ctx context.Context, req *pb.UpdateReturnStatusRequest) (*pb.UpdateReturnStatusResponse, error) {
+	_, resp, err := s.updateReturn.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.UpdateReturnStatusResponse), nil
+}
+
+func (s *grpcServer) CreateRecall(ctx context.Context, req *pb.CreateRecallRequest) (*pb.CreateRecallResponse, error) {
+	_, resp, err := s.createRecall.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CreateRecallResponse), nil
+}
+
+func (s *grpcServer) GetRecall(ctx context.Context, req *pb.GetRecallRequest) (*pb.GetRecallResponse, error) {
+	_, resp, err := s.getRecall.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetRecallResponse), nil
+}
+
+func (s *grpcServer) ListRecalls(ctx context.Context, req *pb.ListRecallsRequest) (*pb.ListRecallsResponse, error) {
+	_, resp, err := s.listRecalls.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListRecallsResponse), nil
+}
+
+func (s *grpcServer) DecideRecall(ctx context.Context, req *pb.DecideRecallRequest) (*pb.DecideRecallResponse, error) {
+	_, resp, err := s.decideRecall.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.DecideRecallResponse), nil
+}
+
+func (s *grpcServer) GetAccountBalance(ctx context.Context, req *pb.GetAccountBalanceRequest) (*pb.GetAccountBalanceResponse, error) {
+	_, resp, err := s.getBalance.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.GetAccountBalanceResponse), nil
+}
+
+func (s *grpcServer) ListAccountEntries(ctx context.Context, req *pb.ListAccountEntriesRequest) (*pb.ListAccountEntriesResponse, error) {
+	_, resp, err := s.listEntries.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListAccountEntriesResponse), nil
+}
+
+func (s *grpcServer) CheckLedger(ctx context.Context, req *pb.CheckLedgerRequest) (*pb.CheckLedgerResponse, error) {
+	_, resp, err := s.checkLedger.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CheckLedgerResponse), nil
+}
+
+func (s *grpcServer) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.ListAuditEntriesResponse, error) {
+	_, resp, err := s.listAuditEntries.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.ListAuditEntriesResponse), nil
+}
+
+// actorFromContext returns the user and the request of the incoming metadata, empty when missing, and the address of the peer
+func actorFromContext(ctx context.Context) Actor {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	actor := Actor{UserID: first(userIDMetadata), RequestID: first(requestIDMetadata)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		actor.SourceIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(actor.SourceIP); err == nil {
+			actor.SourceIP = host
+		}
+	}
+	return actor
+}
+
+func decodeGRPCGetPaymentRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetPaymentRequest)
+	res := GetPaymentRequest{PaymentID: req.Id}
+	if req.Version != nil {
+		v := uint(*req.Version)
+		res.Version = &v
+	}
+	if req.AsOf != "" {
+		t, err := time.Parse(time.RFC3339, req.AsOf)
+		if err != nil {
+			return nil, ErrInvalidPaymentVersion
+		}
+		res.AsOf = &t
+	}
+	return res, nil
+}
+
+func decodeGRPCListPaymentsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListPaymentsRequest)
+	return GetListOfPaymentsRequest{
+		Page:           int(req.Page),
+		PageSize:       int(req.PageSize),
+		IncludeDeleted: req.IncludeDeleted,
+		AccountNumber:  req.AccountNumber,
+	}, nil
+}
+
+func decodeGRPCCreatePaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreatePaymentRequest)
+	p, err := paymentFromPB(req.Payment)
+	if err != nil {
+		return nil, err
+	}
+	return CreatePaymentRequest{Payment: p, Actor: actorFromContext(ctx)}, nil
+}
+
+func decodeGRPCUpdatePaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdatePaymentRequest)
+	p, err := paymentFromPB(req.Payment)
+	if err != nil {
+		return nil, err
+	}
+	return UpdatePaymentRequest{PaymentID: req.Id, Payment: p, Actor: actorFromContext(ctx)}, nil
+}
+
+func decodeGRPCDeletePaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeletePaymentRequest)
+	return DeletePaymentRequest{PaymentID: req.Id, Actor: actorFromContext(ctx)}, nil
+}
+
+func decodeGRPCRestorePaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.RestorePaymentRequest)
+	return RestorePaymentRequest{PaymentID: req.Id, Actor: actorFromContext(ctx)}, nil
+}
+
+func decodeGRPCReviewPaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ReviewPaymentRequest)
+	return ReviewPaymentRequest{PaymentID: req.Id, Decision: ReviewDecision(req.Decision), Reason: req.Reason, Actor: actorFromContext(ctx)}, nil
+}
+
+func decodeGRPCApprovePaymentRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ApprovePaymentRequest)
+	actor := actorFromContext(ctx)
+	return ApprovePaymentRequest{
+		PaymentID: req.Id,
+		Approver:  actor.UserID,
+		Decision:  ApprovalDecision(req.Decision),
+		Comment:   req.Comment,
+		Actor:     actor,
+	}, nil
+}
+
+func decodeGRPCListApprovalsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListApprovalsRequest)
+	return GetPaymentApprovalsRequest{PaymentID: req.Id}, nil
+}
+
+func decodeGRPCGetOrganisationLimitsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetOrganisationLimitsRequest)
+	return GetOrganisationLimitsRequest{OrganisationID: req.OrganisationId}, nil
+}
+
+func decodeGRPCUpdateOrganisationLimitsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateOrganisationLimitsRequest)
+	if req.Limits == nil {
+		return nil, ErrInvalidBody
+	}
+	return UpdateOrganisationLimitsRequest{OrganisationID: req.OrganisationId, Limits: limitsFromPB(req.Limits)}, nil
+}
+
+func decodeGRPCDeleteOrganisationLimitsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeleteOrganisationLimitsRequest)
+	return DeleteOrganisationLimitsRequest{OrganisationID: req.OrganisationId}, nil
+}
+
+// returnTypeFromPB returns the type of the returns of a request, the http transport tells it by the path
+func returnTypeFromPB(t string) (ReturnType, error) {
+	if _, ok := returnsSegments[ReturnType(t)]; !ok {
+		return "", ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "type", Message: "is not return or reversal"})
+	}
+	return ReturnType(t), nil
+}
+
+func decodeGRPCCreateReturnRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateReturnRequest)
+	t, err := returnTypeFromPB(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return CreateReturnRequest{
+		PaymentID:  req.PaymentId,
+		Type:       t,
+		UserID:     actorFromContext(ctx).UserID,
+		Amount:     req.Amount,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+	}, nil
+}
+
+func decodeGRPCGetReturnRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetReturnRequest)
+	t, err := returnTypeFromPB(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return GetReturnRequest{PaymentID: req.PaymentId, Type: t, ReturnID: req.ReturnId}, nil
+}
+
+func decodeGRPCListReturnsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListReturnsRequest)
+	t, err := returnTypeFromPB(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return GetPaymentReturnsRequest{PaymentID: req.PaymentId, Type: t}, nil
+}
+
+func decodeGRPCUpdateReturnStatusRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateReturnStatusRequest)
+	t, err := returnTypeFromPB(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return UpdateReturnStatusRequest{
+		PaymentID: req.PaymentId,
+		Type:      t,
+		ReturnID:  req.ReturnId,
+		Status:    ReturnStatus(req.Status),
+		Reason:    req.Reason,
+		Actor:     actorFromContext(ctx),
+	}, nil
+}
+
+func decodeGRPCCreateRecallRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateRecallRequest)
+	return CreateRecallRequest{PaymentID: req.PaymentId, UserID: actorFromContext(ctx).UserID, ReasonCode: req.ReasonCode, Reason: req.Reason}, nil
+}
+
+func decodeGRPCGetRecallRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetRecallRequest)
+	return GetRecallRequest{PaymentID: req.PaymentId, RecallID: req.RecallId}, nil
+}
+
+func decodeGRPCListRecallsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListRecallsRequest)
+	return GetPaymentRecallsRequest{PaymentID: req.PaymentId}, nil
+}
+
+func decodeGRPCDecideRecallRequest(ctx context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DecideRecallRequest)
+	return DecideRecallRequest{
+		PaymentID: req.PaymentId,
+		RecallID:  req.RecallId,
+		Decision:  RecallDecision(req.Decision),
+		Reason:    req.Reason,
+		Actor:     actorFromContext(ctx),
+	}, nil
+}
+
+func decodeGRPCGetAccountBalanceRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GetAccountBalanceRequest)
+	return GetAccountBalanceRequest{Account: req.Account}, nil
+}
+
+func decodeGRPCListAccountEntriesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListAccountEntriesRequest)
+	return GetAccountEntriesRequest{Account: req.Account, Page: int(req.Page), PageSize: int(req.PageSize)}, nil
+}
+
+func decodeGRPCCheckLedgerRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return CheckLedgerRequest{}, nil
+}
+
+func decodeGRPCListAuditEntriesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListAuditEntriesRequest)
+	return GetPaymentAuditRequest{PaymentID: req.PaymentId}, nil
+}
+
+func encodeGRPCGetPaymentResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetPaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetPaymentResponse")
+	}
+	maskResponse(ctx, res)
+	totals := make([]*pb.Charge, 0, len(res.SenderChargesTotals))
+	for _, t := range res.SenderChargesTotals {
+		totals = append(totals, &pb.Charge{Amount: t.Amount, Currency: t.Currency})
+	}
+	return &pb.GetPaymentResponse{
+		Payment:             paymentToPB(res.Payment),
+		SenderChargesTotals: totals,
+		TotalDebitAmount:    res.TotalDebitAmount,
+		NetCreditAmount:     res.NetCreditAmount,
+	}, nil
+}
+
+func encodeGRPCListPaymentsResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetListOfPaymentsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetListOfPaymentsResponse")
+	}
+	maskResponse(ctx, res)
+	payments := make([]*pb.Payment, 0, len(res.Data))
+	for _, p := range res.Data {
+		payments = append(payments, paymentToPB(p))
+	}
+	return &pb.ListPaymentsResponse{Payments: payments, Page: int32(res.Meta.Page), PageSize: int32(res.Meta.PageSize)}, nil
+}
+
+func encodeGRPCCreatePaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*CreatePaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast CreatePaymentResponse")
+	}
+	warnings := make([]*pb.Warning, 0, len(res.Warnings))
+	for _, w := range res.Warnings {
+		warnings = append(warnings, &pb.Warning{Code: w.Code, Message: w.Message, PaymentId: w.PaymentID})
+	}
+	return &pb.CreatePaymentResponse{Id: res.PaymentID, Status: string(res.Status), Warnings: warnings}, nil
+}
+
+func encodeGRPCUpdatePaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*UpdatePaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast UpdatePaymentResponse")
+	}
+	return &pb.UpdatePaymentResponse{Id: res.PaymentID}, nil
+}
+
+func encodeGRPCDeletePaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*DeletePaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast DeletePaymentResponse")
+	}
+	return &pb.DeletePaymentResponse{Id: res.PaymentID}, nil
+}
+
+func encodeGRPCRestorePaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*RestorePaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast RestorePaymentResponse")
+	}
+	return &pb.RestorePaymentResponse{Id: res.PaymentID}, nil
+}
+
+func encodeGRPCReviewPaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*ReviewPaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast ReviewPaymentResponse")
+	}
+	return &pb.ReviewPaymentResponse{Id: res.PaymentID, Status: string(res.Status)}, nil
+}
+
+func encodeGRPCApprovePaymentResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*ApprovePaymentResponse)
+	if !ok {
+		return nil, errors.New("failed to cast ApprovePaymentResponse")
+	}
+	return &pb.ApprovePaymentResponse{Approval: approvalToPB(res.Approval), Status: string(res.Status)}, nil
+}
+
+func encodeGRPCListApprovalsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetPaymentApprovalsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetPaymentApprovalsResponse")
+	}
+	approvals := make([]*pb.Approval, 0, len(res.Data))
+	for _, a := range res.Data {
+		approvals = append(approvals, approvalToPB(a))
+	}
+	return &pb.ListApprovalsResponse{Approvals: approvals}, nil
+}
+
+func encodeGRPCGetOrganisationLimitsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetOrganisationLimitsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetOrganisationLimitsResponse")
+	}
+	return &pb.GetOrganisationLimitsResponse{Limits: limitsToPB(res.OrganisationLimits)}, nil
+}
+
+func encodeGRPCUpdateOrganisationLimitsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*UpdateOrganisationLimitsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast UpdateOrganisationLimitsResponse")
+	}
+	return &pb.UpdateOrganisationLimitsResponse{Limits: limitsToPB(res.OrganisationLimits)}, nil
+}
+
+func encodeGRPCDeleteOrganisationLimitsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*DeleteOrganisationLimitsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast DeleteOrganisationLimitsResponse")
+	}
+	return &pb.DeleteOrganisationLimitsResponse{OrganisationId: res.OrganisationID}, nil
+}
+
+func encodeGRPCCreateReturnResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*CreateReturnResponse)
+	if !ok {
+		return nil, errors.New("failed to cast CreateReturnResponse")
+	}
+	return &pb.CreateReturnResponse{Return: returnToPB(res.Return)}, nil
+}
+
+func encodeGRPCGetReturnResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetReturnResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetReturnResponse")
+	}
+	return &pb.GetReturnResponse{Return: returnToPB(res.Return)}, nil
+}
+
+func encodeGRPCListReturnsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetPaymentReturnsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetPaymentReturnsResponse")
+	}
+	returns := make([]*pb.Return, 0, len(res.Data))
+	for _, r := range res.Data {
+		returns = append(returns, returnToPB(r))
+	}
+	return &pb.ListReturnsResponse{Returns: returns}, nil
+}
+
+func encodeGRPCUpdateReturnStatusResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*UpdateReturnStatusResponse)
+	if !ok {
+		return nil, errors.New("failed to cast UpdateReturnStatusResponse")
+	}
+	return &pb.UpdateReturnStatusResponse{Return: returnToPB(res.Return)}, nil
+}
+
+// returnToPB converts a return or a reversal into its protobuf representation
+func returnToPB(r Return) *pb.Return {
+	return &pb.Return{
+		Id:           r.ID.String(),
+		PaymentId:    r.PaymentID.String(),
+		Type:         string(r.Type),
+		ReasonCode:   r.ReasonCode,
+		Reason:       r.Reason,
+		Amount:       r.Amount,
+		Currency:     r.Currency,
+		Status:       string(r.Status),
+		StatusReason: r.StatusReason,
+		CreatedBy:    r.CreatedBy,
+		CreatedAt:    r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func encodeGRPCCreateRecallResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*CreateRecallResponse)
+	if !ok {
+		return nil, errors.New("failed to cast CreateRecallResponse")
+	}
+	return &pb.CreateRecallResponse{Recall: recallToPB(res.Recall)}, nil
+}
+
+func encodeGRPCGetRecallResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetRecallResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetRecallResponse")
+	}
+	return &pb.GetRecallResponse{Recall: recallToPB(res.Recall)}, nil
+}
+
+func encodeGRPCListRecallsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetPaymentRecallsResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetPaymentRecallsResponse")
+	}
+	recalls := make([]*pb.Recall, 0, len(res.Data))
+	for _, r := range res.Data {
+		recalls = append(recalls, recallToPB(r))
+	}
+	return &pb.ListRecallsResponse{Recalls: recalls}, nil
+}
+
+func encodeGRPCDecideRecallResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*DecideRecallResponse)
+	if !ok {
+		return nil, errors.New("failed to cast DecideRecallResponse")
+	}
+	return &pb.DecideRecallResponse{Recall: recallToPB(res.Recall)}, nil
+}
+
+// recallToPB converts a recall into its protobuf representation
+func recallToPB(r Recall) *pb.Recall {
+	recall := &pb.Recall{
+		Id:             r.ID.String(),
+		PaymentId:      r.PaymentID.String(),
+		ReasonCode:     r.ReasonCode,
+		Reason:         r.Reason,
+		Status:         string(r.Status),
+		RequestedBy:    r.RequestedBy,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		DecisionReason: r.DecisionReason,
+	}
+	if r.DecidedAt != nil {
+		recall.DecidedAt = r.DecidedAt.Format(time.RFC3339)
+	}
+	return recall
+}
+
+func encodeGRPCGetAccountBalanceResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetAccountBalanceResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetAccountBalanceResponse")
+	}
+	maskResponse(ctx, res)
+	balances := make([]*pb.Balance, 0, len(res.Balances))
+	for _, b := range res.Balances {
+		balances = append(balances, &pb.Balance{Currency: b.Currency, Debits: b.Debits, Credits: b.Credits, Balance: b.Balance})
+	}
+	return &pb.GetAccountBalanceResponse{Account: res.Account, Balances: balances}, nil
+}
+
+func encodeGRPCListAccountEntriesResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetAccountEntriesResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetAccountEntriesResponse")
+	}
+	maskResponse(ctx, res)
+	entries := make([]*pb.Entry, 0, len(res.Data))
+	for _, e := range res.Data {
+		entries = append(entries, &pb.Entry{
+			TransactionId: e.TransactionID.String(),
+			Reference:     e.Reference,
+			Account:       e.Account,
+			Direction:     string(e.Direction),
+			Amount:        e.Amount,
+			Currency:      e.Currency,
+			CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return &pb.ListAccountEntriesResponse{Entries: entries, Page: int32(res.Meta.Page), PageSize: int32(res.Meta.PageSize)}, nil
+}
+
+func encodeGRPCCheckLedgerResponse(_ context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*CheckLedgerResponse)
+	if !ok {
+		return nil, errors.New("failed to cast CheckLedgerResponse")
+	}
+	imbalances := make([]*pb.Imbalance, 0, len(res.Imbalances))
+	for _, i := range res.Imbalances {
+		imbalances = append(imbalances, &pb.Imbalance{TransactionId: i.TransactionID.String(), Currency: i.Currency, Difference: i.Difference})
+	}
+	return &pb.CheckLedgerResponse{Balanced: res.Balanced, Imbalances: imbalances}, nil
+}
+
+func encodeGRPCListAuditEntriesResponse(ctx context.Context, response interface{}) (interface{}, error) {
+	res, ok := response.(*GetPaymentAuditResponse)
+	if !ok {
+		return nil, errors.New("failed to cast GetPaymentAuditResponse")
+	}
+	maskResponse(ctx, res)
+	entries := make([]*pb.AuditEntry, 0, len(res.Data))
+	for _, e := range res.Data {
+		entry, err := auditEntryToPB(e)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return &pb.ListAuditEntriesResponse{Entries: entries}, nil
+}
+
+// auditEntryToPB converts an audit entry into its protobuf representation, the values changed are JSON encoded
+func auditEntryToPB(e AuditEntry) (*pb.AuditEntry, error) {
+	changes := make([]*pb.AuditChange, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		before, err := json.Marshal(c.Before)
+		if err != nil {
+			return nil, err
+		}
+		after, err := json.Marshal(c.After)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &pb.AuditChange{Field: c.Field, Before: string(before), After: string(after)})
+	}
+	return &pb.AuditEntry{
+		PaymentId: e.PaymentID.String(),
+		Operation: string(e.Operation),
+		Actor:     e.Actor,
+		RequestId: e.RequestID,
+		SourceIp:  e.SourceIP,
+		Changes:   changes,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// limitsFromPB converts protobuf limits into the limits model, the organisation is the one of the request
+func limitsFromPB(l *pb.OrganisationLimits) OrganisationLimits {
+	limits := OrganisationLimits{AllowedSchemes: l.AllowedSchemes}
+	for _, c := range l.Currencies {
+		limits.Currencies = append(limits.Currencies, CurrencyLimit{
+			Currency:          c.Currency,
+			MaxAmount:         c.MaxAmount,
+			DailyTotal:        c.DailyTotal,
+			MonthlyTotal:      c.MonthlyTotal,
+			ApprovalThreshold: c.ApprovalThreshold,
+		})
+	}
+	return limits
+}
+
+// limitsToPB converts limits into their protobuf representation
+func limitsToPB(l OrganisationLimits) *pb.OrganisationLimits {
+	currencies := make([]*pb.CurrencyLimit, 0, len(l.Currencies))
+	for _, c := range l.Currencies {
+		currencies = append(currencies, &pb.CurrencyLimit{
+			Currency:          c.Currency,
+			MaxAmount:         c.MaxAmount,
+			DailyTotal:        c.DailyTotal,
+			MonthlyTotal:      c.MonthlyTotal,
+			ApprovalThreshold: c.ApprovalThreshold,
+		})
+	}
+	return &pb.OrganisationLimits{
+		OrganisationId: l.OrganisationID.String(),
+		AllowedSchemes: l.AllowedSchemes,
+		Currencies:     currencies,
+		UpdatedAt:      l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// approvalToPB converts an approval into its protobuf representation
+func approvalToPB(a Approval) *pb.Approval {
+	return &pb.Approval{
+		Id:        a.ID.String(),
+		PaymentId: a.PaymentID.String(),
+		Approver:  a.Approver,
+		Decision:  string(a.Decision),
+		Comment:   a.Comment,
+		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// paymentFromPB converts a protobuf payment into the payment model.
+// Like the json decoding, malformed uuids are reported as an invalid body.
+func paymentFromPB(p *pb.Payment) (Payment, error) {
+	if p == nil {
+		return Payment{}, ErrInvalidBody
+	}
+
+	var id, organisationID uuid.UUID
+	var err error
+	if p.Id != "" {
+		if id, err = uuid.FromString(p.Id); err != nil {
+			return Payment{}, ErrInvalidBody
+		}
+	}
+	if p.OrganisationId != "" {
+		if organisationID, err = uuid.FromString(p.OrganisationId); err != nil {
+			return Payment{}, ErrInvalidBody
+		}
+	}
+
+	a := p.Attributes
+	if a == nil {
+		a = &pb.Attributes{}
+	}
+	return Payment{
+		ID:             id,
+		Type:           p.Type,
+		Version:        uint(p.Version),
+		OrganisationID: organisationID,
+		Attributes: Attributes{
+			Amount:               a.Amount,
+			BeneficiaryParty:     beneficiaryPartyFromPB(a.BeneficiaryParty),
+			ChargesInformation:   chargesInformationFromPB(a.ChargesInformation),
+			Currency:             a.Currency,
+			DebtorParty:          debtorPartyFromPB(a.DebtorParty),
+			EndToEndReference:    a.EndToEndReference,
+			Forex:                forexFromPB(a.Fx),
+			NumericReference:     a.NumericReference,
+			PayID:                a.PaymentId,
+			PaymentPurpose:       a.PaymentPurpose,
+			PaymentScheme:        a.PaymentScheme,
+			PaymentType:          a.PaymentType,
+			ProcessingDate:       a.ProcessingDate,
+			Reference:            a.Reference,
+			SchemePaymentSubType: a.SchemePaymentSubType,
+			SchemePaymentType:    a.SchemePaymentType,
+			SponsorParty:         sponsorPartyFromPB(a.SponsorParty),
+		},
+	}, nil
+}
+
+func beneficiaryPartyFromPB(p *pb.BeneficiaryParty) BeneficiaryParty {
+	if p == nil {
+		return BeneficiaryParty{}
+	}
+	return BeneficiaryParty{
+		DebtorParty: DebtorParty{
+			SponsorParty: SponsorParty{
+				AccountNumber: p.AccountNumber,
+				BankID:        p.BankId,
+				BankIDCode:    p.BankIdCode,
+			},
+			AccountName:       p.AccountName,
+			AccountNumberCode: p.AccountNumberCode,
+			Address:           p.Address,
+			Name:              p.Name,
+		},
+		AccountType: int(p.AccountType),
+	}
+}
+
+func debtorPartyFromPB(p *pb.DebtorParty) DebtorParty {
+	if p == nil {
+		return DebtorParty{}
+	}
+	return DebtorParty{
+		SponsorParty: SponsorParty{
+			AccountNumber: p.AccountNumber,
+			BankID:        p.BankId,
+			BankIDCode:    p.BankIdCode,
+		},
+		AccountName:       p.AccountName,
+		AccountNumberCode: p.AccountNumberCode,
+		Address:           p.Address,
+		Name:              p.Name,
+	}
+}
+
+func sponsorPartyFromPB(p *pb.SponsorParty) SponsorParty {
+	if p == nil {
+		return SponsorParty{}
+	}
+	return SponsorParty{
+		AccountNumber: p.AccountNumber,
+		BankID:        p.BankId,
+		BankIDCode:    p.BankIdCode,
+	}
+}
+
+func chargesInformationFromPB(c *pb.ChargesInformation) ChargesInformation {
+	if c == nil {
+		return ChargesInformation{}
+	}
+	var charges []Charge
+	for _, sc := range c.SenderCharges {
+		charges = append(charges, Charge{Amount: sc.Amount, Currency: sc.Currency})
+	}
+	return ChargesInformation{
+		BearerCode:              c.BearerCode,
+		SenderCharges:           charges,
+		ReceiverChargesAmount:   c.ReceiverChargesAmount,
+		ReceiverChargesCurrency: c.ReceiverChargesCurrency,
+	}
+}
+
+func forexFromPB(f *pb.Forex) Forex {
+	if f == nil {
+		return Forex{}
+	}
+	return Forex{
+		ContractReference: f.ContractReference,
+		ExchangeRate:      f.ExchangeRate,
+		OriginalAmount:    f.OriginalAmount,
+		OriginalCurrency:  f.OriginalCurrency,
+	}
+}
+
+// paymentToPB converts a payment into its protobuf representation
+func paymentToPB(p Payment) *pb.Payment {
+	a := p.Attributes
+	charges := make([]*pb.Charge, 0, len(a.ChargesInformation.SenderCharges))
+	for _, c := range a.ChargesInformation.SenderCharges {
+		charges = append(charges, &pb.Charge{Amount: c.Amount, Currency: c.Currency})
+	}
+	hits := make([]*pb.ScreeningHit, 0, len(p.ScreeningHits))
+	for _, h := range p.ScreeningHits {
+		hits = append(hits, &pb.ScreeningHit{Party: h.Party, Field: h.Field, EntryUid: h.EntryUID, EntryName: h.EntryName, Matched: h.Matched, Score: h.Score})
+	}
+	return &pb.Payment{
+		Id:               p.ID.String(),
+		Type:             p.Type,
+		Version:          uint32(p.Version),
+		OrganisationId:   p.OrganisationID.String(),
+		Status:           string(p.Status),
+		StatusReason:     p.StatusReason,
+		ScreeningHits:    hits,
+		CreatedBy:        p.CreatedBy,
+		ApprovalRequired: p.ApprovalRequired,
+		Deleted:          p.Deleted,
+		Attributes: &pb.Attributes{
+			Amount: a.Amount,
+			BeneficiaryParty: &pb.BeneficiaryParty{
+				AccountName:       a.BeneficiaryParty.AccountName,
+				AccountNumber:     a.BeneficiaryParty.AccountNumber,
+				AccountNumberCode: a.BeneficiaryParty.AccountNumberCode,
+				AccountType:       int32(a.BeneficiaryParty.AccountType),
+				Address:           a.BeneficiaryParty.Address,
+				BankId:            a.BeneficiaryParty.BankID,
+				BankIdCode:        a.BeneficiaryParty.BankIDCode,
+				Name:              a.BeneficiaryParty.Name,
+			},
+			ChargesInformation: &pb.ChargesInformation{
+				BearerCode:              a.ChargesInformation.BearerCode,
+				SenderCharges:           charges,
+				ReceiverChargesAmount:   a.ChargesInformation.ReceiverChargesAmount,
+				ReceiverChargesCurrency: a.ChargesInformation.ReceiverChargesCurrency,
+			},
+			Currency: a.Currency,
+			DebtorParty: &pb.DebtorParty{
+				AccountName:       a.DebtorParty.AccountName,
+				AccountNumber:     a.DebtorParty.AccountNumber,
+				AccountNumberCode: a.DebtorParty.AccountNumberCode,
+				Address:           a.DebtorParty.Address,
+				BankId:            a.DebtorParty.BankID,
+				BankIdCode:        a.DebtorParty.BankIDCode,
+				Name:              a.DebtorParty.Name,
+			},
+			EndToEndReference: a.EndToEndReference,
+			Fx: &pb.Forex{
+				ContractReference: a.Forex.ContractReference,
+				ExchangeRate:      a.Forex.ExchangeRate,
+				OriginalAmount:    a.Forex.OriginalAmount,
+				OriginalCurrency:  a.Forex.OriginalCurrency,
+			},
+			NumericReference:     a.NumericReference,
+			PaymentId:            a.PayID,
+			PaymentPurpose:       a.PaymentPurpose,
+			PaymentScheme:        a.PaymentScheme,
+			PaymentType:          a.PaymentType,
+			ProcessingDate:       a.ProcessingDate,
+			Reference:            a.Reference,
+			SchemePaymentSubType: a.SchemePaymentSubType,
+			SchemePaymentType:    a.SchemePaymentType,
+			SponsorParty: &pb.SponsorParty{
+				AccountNumber: a.SponsorParty.AccountNumber,
+				BankId:        a.SponsorParty.BankID,
+				BankIdCode:    a.SponsorParty.BankIDCode,
+			},
+		},
+	}
+}
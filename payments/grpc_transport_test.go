@@ -0,0 +1,514 @@
+package payments
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/ledger"
+	"github.com/elkousy/payments-api/payments/pb"
+	"github.com/elkousy/payments-api/utility/redact"
+)
+
+// newGRPCTestClient serves the given service over an in-memory gRPC connection
+func newGRPCTestClient(t *testing.T, svc Service, opts ...TransportOption) pb.PaymentsClient {
+	lis := bufconn.Listen(1024 * 1024)
+	s := MakeGRPCServer(MakeEndpoints(svc), opts...)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewPaymentsClient(conn)
+}
+
+// Test_MakeGRPCServer_Routes fails when a route registered in MakeHTTPHandler has no gRPC method in MakeGRPCServer
+func Test_MakeGRPCServer_Routes(t *testing.T) {
+	// Arrange
+	// the documentation and the reference data are served over HTTP only, and the events stream is a server-sent
+	// events stream which has no unary method to map to
+	httpOnly := map[string]bool{
+		"GET " + openAPIPath: true, "GET " + docsPath: true, "GET " + docsAssetsPath: true,
+		"GET " + currenciesPath: true, "GET " + calendarsPath: true, "GET /v1/payments/events/": true,
+	}
+	methods := map[string]string{
+		"GET /v1/payments/{id}/":                                   "GetPayment",
+		"GET /v1/payments/":                                        "ListPayments",
+		"POST /v1/payments/":                                       "CreatePayment",
+		"PUT /v1/payments/{id}/":                                   "UpdatePayment",
+		"DELETE /v1/payments/{id}/":                                "DeletePayment",
+		"POST /v1/payments/{id}/restore/":                          "RestorePayment",
+		"POST /v1/payments/{id}/release/":                          "ReviewPayment",
+		"POST /v1/payments/{id}/reject/":                           "ReviewPayment",
+		"POST /v1/payments/{id}/approvals/":                        "ApprovePayment",
+		"GET /v1/payments/{id}/approvals/":                         "ListApprovals",
+		"GET /v1/payments/{id}/audit/":                             "ListAuditEntries",
+		"GET /v1/admin/organisations/{organisation_id}/limits/":    "GetOrganisationLimits",
+		"PUT /v1/admin/organisations/{organisation_id}/limits/":    "UpdateOrganisationLimits",
+		"DELETE /v1/admin/organisations/{organisation_id}/limits/": "DeleteOrganisationLimits",
+		"GET /v1/ledger/accounts/{account}/balance/":               "GetAccountBalance",
+		"GET /v1/ledger/accounts/{account}/entries/":               "ListAccountEntries",
+		"GET /v1/ledger/check/":                                    "CheckLedger",
+		"POST /v1/payments/{id}/recalls/":                          "CreateRecall",
+		"GET /v1/payments/{id}/recalls/":                           "ListRecalls",
+		"GET /v1/payments/{id}/recalls/{recall_id}/":               "GetRecall",
+		"POST /v1/payments/{id}/recalls/{recall_id}/accept/":       "DecideRecall",
+		"POST /v1/payments/{id}/recalls/{recall_id}/reject/":       "DecideRecall",
+	}
+	for _, segment := range returnsSegments {
+		collection := "/v1/payments/{id}/" + segment + "/"
+		methods["POST "+collection] = "CreateReturn"
+		methods["GET "+collection] = "ListReturns"
+		methods["GET "+collection+"{return_id}/"] = "GetReturn"
+		methods["POST "+collection+"{return_id}/complete/"] = "UpdateReturnStatus"
+		methods["POST "+collection+"{return_id}/fail/"] = "UpdateReturnStatus"
+	}
+	router := mux.NewRouter()
+	MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+	served := map[string]bool{}
+	for _, m := range MakeGRPCServer(Endpoints{}).GetServiceInfo()["payments.v1.Payments"].Methods {
+		served[m.Name] = true
+	}
+
+	// Act
+	var routes []string
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		routeMethods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, m := range routeMethods {
+			routes = append(routes, m+" "+path)
+		}
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, routes)
+	for _, route := range routes {
+		if httpOnly[route] {
+			continue
+		}
+		method, ok := methods[route]
+		if assert.True(t, ok, "%s is not mapped to a gRPC method", route) {
+			assert.True(t, served[method], "%s is mapped to %s, which MakeGRPCServer does not serve", route, method)
+		}
+	}
+}
+
+func Test_GRPC_GetPayment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	mockService := &MockService{}
+	mockService.On("GetPayment", GetPaymentRequest{PaymentID: id}).Return(&GetPaymentResponse{Payment: p}, nil)
+	client := newGRPCTestClient(t, mockService, WithPrivilegeCheck(RolePrivilegeCheck("payments:pii")))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), rolesMetadata, "payments:read,payments:pii")
+
+	// Act
+	res, err := client.GetPayment(ctx, &pb.GetPaymentRequest{Id: id})
+
+	// Assert
+	require.NoError(t, err)
+	got, err := paymentFromPB(res.Payment)
+	require.NoError(t, err)
+	assert.Equal(t, p, got)
+}
+
+func Test_GRPC_GetPayment_Settlement(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	mockService := &MockService{}
+	mockService.On("GetPayment", GetPaymentRequest{PaymentID: id}).Return(&GetPaymentResponse{
+		Payment:             mockNewPayment(id),
+		SenderChargesTotals: []charges.Amount{{Amount: "7.00", Currency: "GBP"}, {Amount: "10.00", Currency: "USD"}},
+		TotalDebitAmount:    "100.21",
+		NetCreditAmount:     "95.21",
+	}, nil)
+	client := newGRPCTestClient(t, mockService)
+
+	// Act
+	res, err := client.GetPayment(context.Background(), &pb.GetPaymentRequest{Id: id})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, res.SenderChargesTotals, 2)
+	assert.Equal(t, "7.00", res.SenderChargesTotals[0].Amount)
+	assert.Equal(t, "GBP", res.SenderChargesTotals[0].Currency)
+	assert.Equal(t, "10.00", res.SenderChargesTotals[1].Amount)
+	assert.Equal(t, "USD", res.SenderChargesTotals[1].Currency)
+	assert.Equal(t, "100.21", res.TotalDebitAmount)
+	assert.Equal(t, "95.21", res.NetCreditAmount)
+}
+
+func Test_GRPC_GetPayment_MasksAccountNumbers(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	mockService := &MockService{}
+	mockService.On("GetPayment", GetPaymentRequest{PaymentID: id}).Return(&GetPaymentResponse{Payment: p}, nil)
+	client := newGRPCTestClient(t, mockService, WithPrivilegeCheck(RolePrivilegeCheck("payments:pii")))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), rolesMetadata, "payments:read")
+
+	// Act
+	res, err := client.GetPayment(ctx, &pb.GetPaymentRequest{Id: id})
+
+	// Assert
+	require.NoError(t, err)
+	a := res.Payment.Attributes
+	assert.Equal(t, redact.Mask(p.Attributes.DebtorParty.AccountNumber), a.DebtorParty.AccountNumber)
+	assert.Equal(t, redact.Mask(p.Attributes.BeneficiaryParty.AccountNumber), a.BeneficiaryParty.AccountNumber)
+	assert.Equal(t, redact.Mask(p.Attributes.SponsorParty.AccountNumber), a.SponsorParty.AccountNumber)
+	assert.Equal(t, p.Attributes.DebtorParty.Name, a.DebtorParty.Name)
+}
+
+func Test_decodeGRPCGetPaymentRequest(t *testing.T) {
+	version := uint32(0)
+	asOf := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		req     *pb.GetPaymentRequest
+		want    GetPaymentRequest
+		wantErr error
+	}{
+		{name: "Should select the current payment", req: &pb.GetPaymentRequest{Id: "abcd"}, want: GetPaymentRequest{PaymentID: "abcd"}},
+		{name: "Should select the first version", req: &pb.GetPaymentRequest{Id: "abcd", Version: &version}, want: GetPaymentRequest{PaymentID: "abcd", Version: new(uint)}},
+		{name: "Should select the version current at a time", req: &pb.GetPaymentRequest{Id: "abcd", AsOf: "2019-01-18T12:00:00Z"}, want: GetPaymentRequest{PaymentID: "abcd", AsOf: &asOf}},
+		{name: "Should reject an invalid time", req: &pb.GetPaymentRequest{Id: "abcd", AsOf: "yesterday"}, wantErr: ErrInvalidPaymentVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			req, err := decodeGRPCGetPaymentRequest(context.Background(), tt.req)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req)
+		})
+	}
+}
+
+func Test_GRPC_ListPayments(t *testing.T) {
+	// Arrange
+	pays := []Payment{mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"), mockNewPayment("6ef6057f-0ed4-48c9-a128-f85b8f024519")}
+	mockService := &MockService{}
+	mockService.On("GetListOfPayments", GetListOfPaymentsRequest{Page: 2, PageSize: 2}).Return(&GetListOfPaymentsResponse{Data: pays, Meta: PageMeta{Page: 2, PageSize: 2}}, nil)
+	client := newGRPCTestClient(t, mockService)
+
+	// Act
+	res, err := client.ListPayments(context.Background(), &pb.ListPaymentsRequest{Page: 2, PageSize: 2})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, res.Payments, 2)
+	assert.Equal(t, int32(2), res.Page)
+	assert.Equal(t, pays[1].ID.String(), res.Payments[1].Id)
+}
+
+func Test_GRPC_ListPayments_IncludeDeleted(t *testing.T) {
+	// Arrange
+	deleted := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	deleted.Deleted = true
+	mockService := &MockService{}
+	mockService.On("GetListOfPayments", GetListOfPaymentsRequest{IncludeDeleted: true}).Return(&GetListOfPaymentsResponse{Data: []Payment{deleted}}, nil)
+	mockService.On("RestorePayment", RestorePaymentRequest{PaymentID: deleted.ID.String(), Actor: Actor{UserID: "alice", SourceIP: "bufconn"}}).
+		Return(&RestorePaymentResponse{PaymentID: deleted.ID.String()}, nil)
+	client := newGRPCTestClient(t, mockService)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), userIDMetadata, "alice")
+
+	// Act
+	res, err := client.ListPayments(ctx, &pb.ListPaymentsRequest{IncludeDeleted: true})
+	restored, restoreErr := client.RestorePayment(ctx, &pb.RestorePaymentRequest{Id: deleted.ID.String()})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, res.Payments, 1)
+	assert.True(t, res.Payments[0].Deleted)
+	require.NoError(t, restoreErr)
+	assert.Equal(t, deleted.ID.String(), restored.Id)
+}
+
+func Test_GRPC_ListPayments_AccountNumber(t *testing.T) {
+	// Arrange
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	mockService := &MockService{}
+	mockService.On("GetListOfPayments", GetListOfPaymentsRequest{AccountNumber: "GB29XABC10161234567801"}).Return(&GetListOfPaymentsResponse{Data: []Payment{p}}, nil)
+	client := newGRPCTestClient(t, mockService)
+
+	// Act
+	res, err := client.ListPayments(context.Background(), &pb.ListPaymentsRequest{AccountNumber: "GB29XABC10161234567801"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, res.Payments, 1)
+	mockService.AssertExpectations(t)
+}
+
+func Test_GRPC_CreatePayment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	mockService := &MockService{}
+	warning := Warning{Code: WarningPossibleDuplicate, Message: "possible duplicate", PaymentID: "0d5f5f3a-64e8-4c2e-8f4a-1b2b3c4d5e6f"}
+	mockService.On("PostPayment", CreatePaymentRequest{Payment: p, Actor: Actor{RequestID: "req-1", SourceIP: "bufconn"}}).Return(&CreatePaymentResponse{PaymentID: id, Warnings: []Warning{warning}}, nil)
+	client := newGRPCTestClient(t, mockService)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDMetadata, "req-1")
+
+	// Act
+	res, err := client.CreatePayment(ctx, &pb.CreatePaymentRequest{Payment: paymentToPB(p)})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, id, res.Id)
+	require.Len(t, res.Warnings, 1)
+	assert.Equal(t, warning.Code, res.Warnings[0].Code)
+	assert.Equal(t, warning.PaymentID, res.Warnings[0].PaymentId)
+}
+
+func Test_GRPC_ReviewPayment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	req := ReviewPaymentRequest{PaymentID: id, Decision: ReviewRelease, Reason: "false positive", Actor: Actor{SourceIP: "bufconn"}}
+	mockService := &MockService{}
+	mockService.On("ReviewPayment", req).Return(&ReviewPaymentResponse{PaymentID: id, Status: StatusSubmitted}, nil)
+	client := newGRPCTestClient(t, mockService)
+
+	// Act
+	res, err := client.ReviewPayment(context.Background(), &pb.ReviewPaymentRequest{Id: id, Decision: "release", Reason: "false positive"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, id, res.Id)
+	assert.Equal(t, "submitted", res.Status)
+}
+
+func Test_GRPC_ApprovePayment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	req := ApprovePaymentRequest{PaymentID: id, Approver: "bob", Decision: ApprovalApprove, Comment: "checked", Actor: Actor{UserID: "bob", SourceIP: "bufconn"}}
+	approval := Approval{PaymentID: uuid.FromStringOrNil(id), Approver: "bob", Decision: ApprovalApprove, CreatedAt: time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)}
+	mockService := &MockService{}
+	mockService.On("ApprovePayment", req).Return(&ApprovePaymentResponse{Approval: approval, Status: StatusSubmitted}, nil)
+	mockService.On("GetPaymentApprovals", GetPaymentApprovalsRequest{PaymentID: id}).Return(&GetPaymentApprovalsResponse{Data: []Approval{approval}}, nil)
+	client := newGRPCTestClient(t, mockService)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), userIDMetadata, "bob")
+
+	// Act
+	res, err := client.ApprovePayment(ctx, &pb.ApprovePaymentRequest{Id: id, Decision: "approve", Comment: "checked"})
+	list, listErr := client.ListApprovals(context.Background(), &pb.ListApprovalsRequest{Id: id})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "submitted", res.Status)
+	assert.Equal(t, "bob", res.Approval.Approver)
+	assert.Equal(t, "2019-01-18T12:00:00Z", res.Approval.CreatedAt)
+	require.NoError(t, listErr)
+	require.Len(t, list.Approvals, 1)
+	assert.Equal(t, id, list.Approvals[0].PaymentId)
+}
+
+func Test_GRPC_UpdateOrganisationLimits(t *testing.T) {
+	organisationID := "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	limits := OrganisationLimits{AllowedSchemes: []string{"FPS"}, Currencies: []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000.00"}}}
+	tests := []struct {
+		name     string
+		roles    string
+		wantCode codes.Code
+	}{
+		{name: "Should replace the limits for an administrator", roles: "payments:admin", wantCode: codes.OK},
+		{name: "Should deny the other callers", roles: "payments:read", wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			saved := limits
+			saved.OrganisationID = uuid.FromStringOrNil(organisationID)
+			mockService := &MockService{}
+			mockService.On("UpdateOrganisationLimits", UpdateOrganisationLimitsRequest{OrganisationID: organisationID, Limits: limits}).
+				Return(&UpdateOrganisationLimitsResponse{OrganisationLimits: saved}, nil)
+			client := newGRPCTestClient(t, mockService, WithAdminCheck(RolePrivilegeCheck("payments:admin")))
+			ctx := metadata.AppendToOutgoingContext(context.Background(), rolesMetadata, tt.roles)
+
+			// Act
+			res, err := client.UpdateOrganisationLimits(ctx, &pb.UpdateOrganisationLimitsRequest{OrganisationId: organisationID, Limits: limitsToPB(limits)})
+
+			// Assert
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				mockService.AssertNotCalled(t, "UpdateOrganisationLimits", mock.Anything)
+				return
+			}
+			assert.Equal(t, organisationID, res.Limits.OrganisationId)
+			assert.Equal(t, limits, limitsFromPB(res.Limits))
+		})
+	}
+}
+
+func Test_GRPC_CreateReturn(t *testing.T) {
+	// Arrange
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	ret := Return{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(paymentID), Type: ReturnTypeReversal, ReasonCode: "FRAD", Amount: "10.00", Currency: "GBP", Status: ReturnStatusPending, CreatedBy: "alice"}
+	mockService := &MockService{}
+	mockService.On("CreateReturn", CreateReturnRequest{PaymentID: paymentID, Type: ReturnTypeReversal, UserID: "alice", Amount: "10.00", ReasonCode: "FRAD"}).
+		Return(&CreateReturnResponse{Return: ret}, nil)
+	client := newGRPCTestClient(t, mockService)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), userIDMetadata, "alice")
+
+	// Act
+	res, err := client.CreateReturn(ctx, &pb.CreateReturnRequest{PaymentId: paymentID, Type: "reversal", Amount: "10.00", ReasonCode: "FRAD"})
+	_, typeErr := client.CreateReturn(ctx, &pb.CreateReturnRequest{PaymentId: paymentID, Type: "refund", ReasonCode: "FRAD"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ret.ID.String(), res.Return.Id)
+	assert.Equal(t, "reversal", res.Return.Type)
+	assert.Equal(t, "pending", res.Return.Status)
+	assert.Equal(t, codes.InvalidArgument, status.Code(typeErr))
+	mockService.AssertNumberOfCalls(t, "CreateReturn", 1)
+}
+
+func Test_GRPC_DecideRecall(t *testing.T) {
+	// Arrange
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	recallID := uuid.NewV4()
+	decidedAt := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	recall := Recall{ID: recallID, PaymentID: uuid.FromStringOrNil(paymentID), ReasonCode: "DUPL", Status: RecallStatusAccepted, DecisionReason: "returned", DecidedAt: &decidedAt}
+	mockService := &MockService{}
+	mockService.On("DecideRecall", DecideRecallRequest{PaymentID: paymentID, RecallID: recallID.String(), Decision: RecallAccept, Reason: "returned",
+		Actor: Actor{UserID: "alice", SourceIP: "bufconn"}}).
+		Return(&DecideRecallResponse{Recall: recall}, nil)
+	client := newGRPCTestClient(t, mockService)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), userIDMetadata, "alice")
+
+	// Act
+	res, err := client.DecideRecall(ctx, &pb.DecideRecallRequest{PaymentId: paymentID, RecallId: recallID.String(), Decision: "accept", Reason: "returned"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, recallID.String(), res.Recall.Id)
+	assert.Equal(t, "accepted", res.Recall.Status)
+	assert.Equal(t, "2019-01-18T12:00:00Z", res.Recall.DecidedAt)
+}
+
+func Test_GRPC_GetAccountBalance(t *testing.T) {
+	// Arrange
+	account := "charges:NWBKGB22"
+	mockService := &MockService{}
+	mockService.On("GetAccountBalance", GetAccountBalanceRequest{Account: account}).
+		Return(&GetAccountBalanceResponse{Account: account, Balances: []ledger.Balance{ledger.NewBalance("GBP", "0", "5", 2)}}, nil)
+	client := newGRPCTestClient(t, mockService)
+
+	// Act
+	res, err := client.GetAccountBalance(context.Background(), &pb.GetAccountBalanceRequest{Account: account})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, account, res.Account)
+	require.Len(t, res.Balances, 1)
+	assert.Equal(t, "5.00", res.Balances[0].Balance)
+}
+
+func Test_GRPC_ListAuditEntries_MasksAccountNumbers(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	entry := AuditEntry{PaymentID: uuid.FromStringOrNil(id), Operation: AuditUpdate, Actor: "alice", Changes: AuditChanges{
+		{Field: "attributes.debtor_party.account_number", Before: "GB29XABC10161234567801", After: "GB29XABC10161234567802"},
+		{Field: "attributes.amount", Before: "10.00", After: nil},
+	}}
+	mockService := &MockService{}
+	mockService.On("GetPaymentAudit", GetPaymentAuditRequest{PaymentID: id}).Return(&GetPaymentAuditResponse{Data: []AuditEntry{entry}}, nil)
+	client := newGRPCTestClient(t, mockService, WithPrivilegeCheck(RolePrivilegeCheck("payments:pii")))
+
+	// Act
+	res, err := client.ListAuditEntries(context.Background(), &pb.ListAuditEntriesRequest{PaymentId: id})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, res.Entries, 1)
+	changes := res.Entries[0].Changes
+	assert.Equal(t, `"******************7801"`, changes[0].Before)
+	assert.Equal(t, `"******************7802"`, changes[0].After)
+	assert.Equal(t, `"10.00"`, changes[1].Before)
+	assert.Equal(t, "null", changes[1].After)
+}
+
+func Test_GRPC_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name:     "Should map a not found APIError to NotFound",
+			err:      ErrNotFound,
+			wantCode: codes.NotFound,
+			wantMsg:  ErrNotFound.Message,
+		},
+		{
+			name:     "Should map a bad request APIError to InvalidArgument",
+			err:      ErrInvalidPaymentID,
+			wantCode: codes.InvalidArgument,
+			wantMsg:  ErrInvalidPaymentID.Message,
+		},
+		{
+			name:     "Should map an unexpected error to Internal",
+			err:      assert.AnError,
+			wantCode: codes.Internal,
+			wantMsg:  assert.AnError.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			mockService.On("DeletePayment", mock.Anything).Return(nil, tt.err)
+			client := newGRPCTestClient(t, mockService)
+
+			// Act
+			_, err := client.DeletePayment(context.Background(), &pb.DeletePaymentRequest{Id: "abcd"})
+
+			// Assert
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, tt.wantMsg, st.Message())
+		})
+	}
+}
+
+func Test_paymentFromPB_InvalidOrganisationID(t *testing.T) {
+	// Act
+	_, err := paymentFromPB(&pb.Payment{OrganisationId: "not-a-uuid"})
+
+	// Assert
+	assert.Equal(t, ErrInvalidBody, err)
+}
@@ -0,0 +1,201 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elkousy/payments-api/utility/config"
+)
+
+type contextKey int
+
+const (
+	// contextKeyBaseURL holds the public base URL of the API, e.g. https://api.example.com
+	contextKeyBaseURL contextKey = iota
+	// contextKeyRoles holds the roles of the caller listed by the X-User-Roles header
+	contextKeyRoles
+	// contextKeyPrivileged holds whether the caller sees the account numbers in full
+	contextKeyPrivileged
+	// contextKeySourceIP holds the address of the client of the request
+	contextKeySourceIP
+)
+
+const collectionPath = "/v1/payments/"
+
+// returnsSegments are the path segments of the returns and of the reversals of a payment
+var returnsSegments = map[ReturnType]string{
+	ReturnTypeReturn:   "returns",
+	ReturnTypeReversal: "reversals",
+}
+
+// populateBaseURL is a go-kit ServerBefore function saving into the context the base URL
+// used to build the HATEOAS links of the responses
+func populateBaseURL(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, contextKeyBaseURL, baseURL(r))
+}
+
+// baseURL returns the public base URL of the API. The configured public base URL wins,
+// otherwise the scheme, host and prefix are derived from the request and the proxy headers.
+func baseURL(r *http.Request) string {
+	if config.PublicBaseURL != "" {
+		return strings.TrimRight(config.PublicBaseURL, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := forwardedHeader(r, "X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	host := r.Host
+	if fwdHost := forwardedHeader(r, "X-Forwarded-Host"); fwdHost != "" {
+		host = fwdHost
+	}
+
+	prefix := strings.Trim(forwardedHeader(r, "X-Forwarded-Prefix"), "/")
+	if prefix != "" {
+		prefix = "/" + prefix
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, prefix)
+}
+
+// forwardedHeader returns the value set by the proxy closest to the client
+// when the header has been appended to by several proxies
+func forwardedHeader(r *http.Request, name string) string {
+	value := r.Header.Get(name)
+	if i := strings.Index(value, ","); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
+
+// linkBuilder builds the HATEOAS links of the payments resources
+type linkBuilder struct {
+	baseURL string
+}
+
+func newLinkBuilder(ctx context.Context) linkBuilder {
+	base, _ := ctx.Value(contextKeyBaseURL).(string)
+	return linkBuilder{baseURL: base}
+}
+
+func (l linkBuilder) collection() string {
+	return l.baseURL + collectionPath
+}
+
+func (l linkBuilder) payment(id string) string {
+	return l.collection() + id + "/"
+}
+
+func (l linkBuilder) page(page int, pageSize int) string {
+	return fmt.Sprintf("%s?page=%d&page_size=%d", l.collection(), page, pageSize)
+}
+
+func (l linkBuilder) returns(paymentID string, t ReturnType) string {
+	return l.payment(paymentID) + returnsSegments[t] + "/"
+}
+
+func (l linkBuilder) paymentReturn(r Return) string {
+	return l.returns(r.PaymentID.String(), r.Type) + r.ID.String() + "/"
+}
+
+func (l linkBuilder) recalls(paymentID string) string {
+	return l.payment(paymentID) + "recalls/"
+}
+
+func (l linkBuilder) recall(r Recall) string {
+	return l.recalls(r.PaymentID.String()) + r.ID.String() + "/"
+}
+
+// paymentLinks returns the links of a single payment along with its lifecycle actions, its returns, reversals and
+// recalls and its audit log
+func (l linkBuilder) paymentLinks(id string) HateoasLink {
+	return HateoasLink{
+		Self:       l.payment(id),
+		Collection: l.collection(),
+		Update:     l.payment(id),
+		Delete:     l.payment(id),
+		Returns:    l.returns(id, ReturnTypeReturn),
+		Reversals:  l.returns(id, ReturnTypeReversal),
+		Recalls:    l.recalls(id),
+		Audit:      l.payment(id) + "audit/",
+	}
+}
+
+// returnLinks returns the links of a return or a reversal, along with its lifecycle actions while it is pending
+func (l linkBuilder) returnLinks(r Return) HateoasLink {
+	links := HateoasLink{
+		Self:       l.paymentReturn(r),
+		Collection: l.returns(r.PaymentID.String(), r.Type),
+		Payment:    l.payment(r.PaymentID.String()),
+	}
+	if r.Status == ReturnStatusPending {
+		links.Complete = l.paymentReturn(r) + "complete/"
+		links.Fail = l.paymentReturn(r) + "fail/"
+	}
+	return links
+}
+
+// recallLinks returns the links of a recall, along with the decisions while it is requested
+func (l linkBuilder) recallLinks(r Recall) HateoasLink {
+	links := HateoasLink{
+		Self:       l.recall(r),
+		Collection: l.recalls(r.PaymentID.String()),
+		Payment:    l.payment(r.PaymentID.String()),
+	}
+	if r.Status == RecallStatusRequested {
+		links.Accept = l.recall(r) + "accept/"
+		links.Reject = l.recall(r) + "reject/"
+	}
+	return links
+}
+
+// listLinks returns the links of a page of payments.
+// The next link is only set when the page is full, as the total number of payments is unknown.
+func (l linkBuilder) listLinks(meta PageMeta, count int) HateoasLink {
+	links := HateoasLink{
+		Self:  l.page(meta.Page, meta.PageSize),
+		First: l.page(1, meta.PageSize),
+	}
+	if meta.Page > 1 {
+		links.Prev = l.page(meta.Page-1, meta.PageSize)
+	}
+	if count >= meta.PageSize {
+		links.Next = l.page(meta.Page+1, meta.PageSize)
+	}
+	return links
+}
+
+// decorateLinks fills in the HATEOAS links of the service responses
+func decorateLinks(ctx context.Context, response interface{}) {
+	links := newLinkBuilder(ctx)
+	switch res := response.(type) {
+	case *GetPaymentResponse:
+		res.HateoasLink = links.paymentLinks(res.ID.String())
+	case *GetListOfPaymentsResponse:
+		res.HateoasLink = links.listLinks(res.Meta, len(res.Data))
+	case *CreatePaymentResponse:
+		res.HateoasLink = links.paymentLinks(res.PaymentID)
+	case *ReviewPaymentResponse:
+		res.HateoasLink = links.paymentLinks(res.PaymentID)
+	case *ApprovePaymentResponse:
+		res.HateoasLink = links.paymentLinks(res.PaymentID.String())
+	case *CreateReturnResponse:
+		res.HateoasLink = links.returnLinks(res.Return)
+	case *GetReturnResponse:
+		res.HateoasLink = links.returnLinks(res.Return)
+	case *UpdateReturnStatusResponse:
+		res.HateoasLink = links.returnLinks(res.Return)
+	case *CreateRecallResponse:
+		res.HateoasLink = links.recallLinks(res.Recall)
+	case *GetRecallResponse:
+		res.HateoasLink = links.recallLinks(res.Recall)
+	case *DecideRecallResponse:
+		res.HateoasLink = links.recallLinks(res.Recall)
+	}
+}
@@ -0,0 +1,197 @@
+package payments
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elkousy/payments-api/utility/config"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_baseURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		publicBaseURL string
+		headers       map[string]string
+		tls           bool
+		want          string
+	}{
+		{
+			name: "Should use the request host",
+			want: "http://example.com",
+		},
+		{
+			name: "Should use https when the request is served over tls",
+			tls:  true,
+			want: "https://example.com",
+		},
+		{
+			name: "Should respect the X-Forwarded headers",
+			headers: map[string]string{
+				"X-Forwarded-Proto":  "https",
+				"X-Forwarded-Host":   "api.example.com, proxy.internal",
+				"X-Forwarded-Prefix": "/payments-api/",
+			},
+			want: "https://api.example.com/payments-api",
+		},
+		{
+			name:          "Should prefer the configured public base URL",
+			publicBaseURL: "https://public.example.com/",
+			headers: map[string]string{
+				"X-Forwarded-Host": "api.example.com",
+			},
+			want: "https://public.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			defer func(previous string) { config.PublicBaseURL = previous }(config.PublicBaseURL)
+			config.PublicBaseURL = tt.publicBaseURL
+			r := httptest.NewRequest("GET", "http://example.com/v1/payments/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if tt.tls {
+				r.TLS = &tls.ConnectionState{}
+			}
+			// Act
+			got := baseURL(r)
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_decorateLinks_Payment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	ctx := context.WithValue(context.Background(), contextKeyBaseURL, "https://api.example.com")
+	res := &GetPaymentResponse{Payment: mockNewPayment(id)}
+	// Act
+	decorateLinks(ctx, res)
+	// Assert
+	assert.Equal(t, HateoasLink{
+		Self:       "https://api.example.com/v1/payments/" + id + "/",
+		Collection: "https://api.example.com/v1/payments/",
+		Update:     "https://api.example.com/v1/payments/" + id + "/",
+		Delete:     "https://api.example.com/v1/payments/" + id + "/",
+		Returns:    "https://api.example.com/v1/payments/" + id + "/returns/",
+		Reversals:  "https://api.example.com/v1/payments/" + id + "/reversals/",
+		Recalls:    "https://api.example.com/v1/payments/" + id + "/recalls/",
+		Audit:      "https://api.example.com/v1/payments/" + id + "/audit/",
+	}, res.HateoasLink)
+}
+
+func Test_decorateLinks_Return(t *testing.T) {
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	returnID := "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b"
+	reversal := Return{ID: uuid.FromStringOrNil(returnID), PaymentID: uuid.FromStringOrNil(paymentID), Type: ReturnTypeReversal}
+	tests := []struct {
+		name   string
+		status ReturnStatus
+		want   HateoasLink
+	}{
+		{
+			name:   "Should link to the lifecycle actions of a pending reversal",
+			status: ReturnStatusPending,
+			want: HateoasLink{
+				Self:       "/v1/payments/" + paymentID + "/reversals/" + returnID + "/",
+				Collection: "/v1/payments/" + paymentID + "/reversals/",
+				Payment:    "/v1/payments/" + paymentID + "/",
+				Complete:   "/v1/payments/" + paymentID + "/reversals/" + returnID + "/complete/",
+				Fail:       "/v1/payments/" + paymentID + "/reversals/" + returnID + "/fail/",
+			},
+		},
+		{
+			name:   "Should not link to the lifecycle actions of a completed reversal",
+			status: ReturnStatusCompleted,
+			want: HateoasLink{
+				Self:       "/v1/payments/" + paymentID + "/reversals/" + returnID + "/",
+				Collection: "/v1/payments/" + paymentID + "/reversals/",
+				Payment:    "/v1/payments/" + paymentID + "/",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			reversal.Status = tt.status
+			res := &GetReturnResponse{Return: reversal}
+			// Act
+			decorateLinks(context.Background(), res)
+			// Assert
+			assert.Equal(t, tt.want, res.HateoasLink)
+		})
+	}
+}
+
+func Test_decorateLinks_Recall(t *testing.T) {
+	// Arrange
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	recallID := "3f6d2a9b-1c4e-4b8a-9d7f-6e5c4b3a2d1e"
+	recall := Recall{ID: uuid.FromStringOrNil(recallID), PaymentID: uuid.FromStringOrNil(paymentID), Status: RecallStatusRequested}
+	requested := &GetRecallResponse{Recall: recall}
+	recall.Status = RecallStatusAccepted
+	accepted := &DecideRecallResponse{Recall: recall}
+	// Act
+	decorateLinks(context.Background(), requested)
+	decorateLinks(context.Background(), accepted)
+	// Assert
+	self := "/v1/payments/" + paymentID + "/recalls/" + recallID + "/"
+	assert.Equal(t, HateoasLink{
+		Self:       self,
+		Collection: "/v1/payments/" + paymentID + "/recalls/",
+		Payment:    "/v1/payments/" + paymentID + "/",
+		Accept:     self + "accept/",
+		Reject:     self + "reject/",
+	}, requested.HateoasLink)
+	assert.Equal(t, HateoasLink{
+		Self:       self,
+		Collection: "/v1/payments/" + paymentID + "/recalls/",
+		Payment:    "/v1/payments/" + paymentID + "/",
+	}, accepted.HateoasLink)
+}
+
+func Test_decorateLinks_List(t *testing.T) {
+	tests := []struct {
+		name  string
+		meta  PageMeta
+		count int
+		want  HateoasLink
+	}{
+		{
+			name:  "Should not link to a previous or next page on a partial first page",
+			meta:  PageMeta{Page: 1, PageSize: 2},
+			count: 1,
+			want: HateoasLink{
+				Self:  "/v1/payments/?page=1&page_size=2",
+				First: "/v1/payments/?page=1&page_size=2",
+			},
+		},
+		{
+			name:  "Should link to the previous and next pages on a full page",
+			meta:  PageMeta{Page: 2, PageSize: 2},
+			count: 2,
+			want: HateoasLink{
+				Self:  "/v1/payments/?page=2&page_size=2",
+				First: "/v1/payments/?page=1&page_size=2",
+				Prev:  "/v1/payments/?page=1&page_size=2",
+				Next:  "/v1/payments/?page=3&page_size=2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			res := &GetListOfPaymentsResponse{Data: make([]Payment, tt.count), Meta: tt.meta}
+			// Act
+			decorateLinks(context.Background(), res)
+			// Assert
+			assert.Equal(t, tt.want, res.HateoasLink)
+		})
+	}
+}
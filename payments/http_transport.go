@@ -3,8 +3,12 @@ package payments
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-kit/kit/endpoint"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 
@@ -14,11 +18,22 @@ import (
 
 const componentName = "payments"
 
-// MakeHTTPHandler returns all http handler for the payments service
-func MakeHTTPHandler(endpoints Endpoints, router *mux.Router) http.Handler {
+// idempotencyKeyHeader is the header used by clients to safely retry payment creations
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// userIDHeader identifies the user creating or approving a payment, it is set by the gateway authenticating the users
+const userIDHeader = "X-User-ID"
+
+// requestIDHeader identifies a request in the audit log of the payments it changes
+const requestIDHeader = "X-Request-ID"
+
+// MakeHTTPHandler returns all http handler for the payments service, including the stream of the events broker.
+// The account numbers are masked for the callers not granted by the privilege check, all of them unless one is given.
+func MakeHTTPHandler(endpoints Endpoints, events *EventBroker, router *mux.Router, opts ...TransportOption) http.Handler {
+	o := newTransportOptions(opts)
 
 	options := []kithttp.ServerOption{
-		kithttp.ServerBefore(kithttp.PopulateRequestContext),
+		kithttp.ServerBefore(kithttp.PopulateRequestContext, populateBaseURL, populatePrivilege(o.privileged), populateSourceIP(o.trustedProxies)),
 		kithttp.ServerErrorEncoder(apierrors.LoggingErrorEncoder),
 	}
 
@@ -57,34 +72,238 @@ func MakeHTTPHandler(endpoints Endpoints, router *mux.Router) http.Handler {
 		options...,
 	))
 
+	restorePaymentHandler := instrumenting.Middleware(componentName, "restore_payment", kithttp.NewServer(
+		endpoints.RestorePayment,
+		decodeRestorePaymentRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	releasePaymentHandler := instrumenting.Middleware(componentName, "release_payment", kithttp.NewServer(
+		endpoints.ReviewPayment,
+		makeDecodeReviewPaymentRequest(ReviewRelease),
+		encodeOKResponse,
+		options...,
+	))
+
+	rejectPaymentHandler := instrumenting.Middleware(componentName, "reject_payment", kithttp.NewServer(
+		endpoints.ReviewPayment,
+		makeDecodeReviewPaymentRequest(ReviewReject),
+		encodeOKResponse,
+		options...,
+	))
+
+	approvePaymentHandler := instrumenting.Middleware(componentName, "post_payment_approval", kithttp.NewServer(
+		endpoints.ApprovePayment,
+		decodeApprovePaymentRequest,
+		encodeCreatedResponse,
+		options...,
+	))
+
+	getPaymentApprovalsHandler := instrumenting.Middleware(componentName, "get_payment_approvals", kithttp.NewServer(
+		endpoints.GetPaymentApprovals,
+		decodeGetPaymentApprovalsRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	getPaymentAuditHandler := instrumenting.Middleware(componentName, "get_payment_audit", kithttp.NewServer(
+		endpoints.GetPaymentAudit,
+		decodeGetPaymentAuditRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	getOrganisationLimitsHandler := instrumenting.Middleware(componentName, "get_organisation_limits", kithttp.NewServer(
+		endpoints.GetOrganisationLimits,
+		decodeGetOrganisationLimitsRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	// only the administrators change the limits, the organisations could lift their own limits otherwise
+	requireAdmin := requirePrivilege(o.admin, ErrLimitsForbidden)
+	updateOrganisationLimitsHandler := instrumenting.Middleware(componentName, "put_organisation_limits", kithttp.NewServer(
+		requireAdmin(endpoints.UpdateOrganisationLimits),
+		decodeUpdateOrganisationLimitsRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	deleteOrganisationLimitsHandler := instrumenting.Middleware(componentName, "delete_organisation_limits", kithttp.NewServer(
+		requireAdmin(endpoints.DeleteOrganisationLimits),
+		decodeDeleteOrganisationLimitsRequest,
+		encodeAcceptedResponse,
+		options...,
+	))
+
+	getAccountBalanceHandler := instrumenting.Middleware(componentName, "get_ledger_account_balance", kithttp.NewServer(
+		endpoints.GetAccountBalance,
+		decodeGetAccountBalanceRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	getAccountEntriesHandler := instrumenting.Middleware(componentName, "get_ledger_account_entries", kithttp.NewServer(
+		endpoints.GetAccountEntries,
+		decodeGetAccountEntriesRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	checkLedgerHandler := instrumenting.Middleware(componentName, "check_ledger", kithttp.NewServer(
+		endpoints.CheckLedger,
+		decodeCheckLedgerRequest,
+		encodeOKResponse,
+		options...,
+	))
+
+	// the events stream is not instrumented by the request metrics, its connections are counted instead
+	eventsHandler := makeEventsHandler(events, o.admin)
+
+	router.Handle(openAPIPath, instrumenting.Middleware(componentName, "get_openapi_spec", http.HandlerFunc(serveOpenAPISpec))).Methods(http.MethodGet)
+	router.Handle(docsPath, instrumenting.Middleware(componentName, "get_docs", http.HandlerFunc(serveDocs))).Methods(http.MethodGet)
+	router.PathPrefix(docsAssetsPath).Handler(instrumenting.Middleware(componentName, "get_docs_assets", docsAssetsHandler())).Methods(http.MethodGet)
+	router.Handle(currenciesPath, instrumenting.Middleware(componentName, "get_currencies", http.HandlerFunc(serveCurrencies))).Methods(http.MethodGet)
+	router.Handle(calendarsPath, instrumenting.Middleware(componentName, "get_calendar", http.HandlerFunc(serveCalendar))).Methods(http.MethodGet)
+
+	admin := router.PathPrefix("/v1/admin/organisations").Subrouter().StrictSlash(true)
+	{
+		admin.Handle("/{organisation_id}/limits/", getOrganisationLimitsHandler).Methods(http.MethodGet)
+		admin.Handle("/{organisation_id}/limits/", updateOrganisationLimitsHandler).Methods(http.MethodPut)
+		admin.Handle("/{organisation_id}/limits/", deleteOrganisationLimitsHandler).Methods(http.MethodDelete)
+	}
+
+	l := router.PathPrefix("/v1/ledger").Subrouter().StrictSlash(true)
+	{
+		l.Handle("/accounts/{account}/balance/", getAccountBalanceHandler).Methods(http.MethodGet)
+		l.Handle("/accounts/{account}/entries/", getAccountEntriesHandler).Methods(http.MethodGet)
+		l.Handle("/check/", checkLedgerHandler).Methods(http.MethodGet)
+	}
+
 	r := router.PathPrefix("/v1/payments").Subrouter().StrictSlash(true)
 	{
+		// registered before the payment routes, which would match events as a payment ID
+		r.Handle("/events/", eventsHandler).Methods(http.MethodGet)
 		r.Handle("/{id}/", getPaymentHandler).Methods(http.MethodGet)
 		r.Handle("/", getListOfPaymentsHandler).Methods(http.MethodGet)
 		r.Handle("/", postPaymentHandler).Methods(http.MethodPost)
 		r.Handle("/{id}/", updatePaymentHandler).Methods(http.MethodPut)
 		r.Handle("/{id}/", deletePaymentHandler).Methods(http.MethodDelete)
+		r.Handle("/{id}/restore/", restorePaymentHandler).Methods(http.MethodPost)
+		r.Handle("/{id}/release/", releasePaymentHandler).Methods(http.MethodPost)
+		r.Handle("/{id}/reject/", rejectPaymentHandler).Methods(http.MethodPost)
+		r.Handle("/{id}/approvals/", approvePaymentHandler).Methods(http.MethodPost)
+		r.Handle("/{id}/approvals/", getPaymentApprovalsHandler).Methods(http.MethodGet)
+		r.Handle("/{id}/audit/", getPaymentAuditHandler).Methods(http.MethodGet)
+		registerReturnsRoutes(r, endpoints, ReturnTypeReturn, options)
+		registerReturnsRoutes(r, endpoints, ReturnTypeReversal, options)
+		registerRecallsRoutes(r, endpoints, options)
 	}
 
-	return r
+	return router
+}
+
+// registerReturnsRoutes registers the routes of the returns or of the reversals of the payments, e.g. /{id}/returns/
+func registerReturnsRoutes(r *mux.Router, endpoints Endpoints, t ReturnType, options []kithttp.ServerOption) {
+	collection := "/{id}/" + returnsSegments[t] + "/"
+	handlers := []struct {
+		path     string
+		method   string
+		name     string
+		endpoint endpoint.Endpoint
+		decode   kithttp.DecodeRequestFunc
+		encode   kithttp.EncodeResponseFunc
+	}{
+		{collection, http.MethodPost, "post_payment_" + string(t), endpoints.CreateReturn, makeDecodeCreateReturnRequest(t), encodeCreatedResponse},
+		{collection, http.MethodGet, "get_payment_" + returnsSegments[t], endpoints.GetPaymentReturns, makeDecodeGetPaymentReturnsRequest(t), encodeOKResponse},
+		{collection + "{return_id}/", http.MethodGet, "get_payment_" + string(t), endpoints.GetReturn, makeDecodeGetReturnRequest(t), encodeOKResponse},
+		{collection + "{return_id}/complete/", http.MethodPost, "complete_payment_" + string(t), endpoints.UpdateReturnStatus, makeDecodeUpdateReturnStatusRequest(t, ReturnStatusCompleted), encodeOKResponse},
+		{collection + "{return_id}/fail/", http.MethodPost, "fail_payment_" + string(t), endpoints.UpdateReturnStatus, makeDecodeUpdateReturnStatusRequest(t, ReturnStatusFailed), encodeOKResponse},
+	}
+	for _, h := range handlers {
+		handler := instrumenting.Middleware(componentName, h.name, kithttp.NewServer(h.endpoint, h.decode, h.encode, options...))
+		r.Handle(h.path, handler).Methods(h.method)
+	}
+}
+
+// registerRecallsRoutes registers the routes of the recalls of the payments, /{id}/recalls/
+func registerRecallsRoutes(r *mux.Router, endpoints Endpoints, options []kithttp.ServerOption) {
+	collection := "/{id}/recalls/"
+	handlers := []struct {
+		path     string
+		method   string
+		name     string
+		endpoint endpoint.Endpoint
+		decode   kithttp.DecodeRequestFunc
+		encode   kithttp.EncodeResponseFunc
+	}{
+		{collection, http.MethodPost, "post_payment_recall", endpoints.CreateRecall, decodeCreateRecallRequest, encodeCreatedResponse},
+		{collection, http.MethodGet, "get_payment_recalls", endpoints.GetPaymentRecalls, decodeGetPaymentRecallsRequest, encodeOKResponse},
+		{collection + "{recall_id}/", http.MethodGet, "get_payment_recall", endpoints.GetRecall, decodeGetRecallRequest, encodeOKResponse},
+		{collection + "{recall_id}/accept/", http.MethodPost, "accept_payment_recall", endpoints.DecideRecall, makeDecodeDecideRecallRequest(RecallAccept), encodeOKResponse},
+		{collection + "{recall_id}/reject/", http.MethodPost, "reject_payment_recall", endpoints.DecideRecall, makeDecodeDecideRecallRequest(RecallReject), encodeOKResponse},
+	}
+	for _, h := range handlers {
+		handler := instrumenting.Middleware(componentName, h.name, kithttp.NewServer(h.endpoint, h.decode, h.encode, options...))
+		r.Handle(h.path, handler).Methods(h.method)
+	}
 }
 
 func decodeGetPaymentRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	vars := mux.Vars(r)
-	id := vars["id"]
-	return GetPaymentRequest{PaymentID: id}, nil
+	req := GetPaymentRequest{PaymentID: vars["id"]}
+	query := r.URL.Query()
+	if version := query.Get("version"); version != "" {
+		v, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidPaymentVersion
+		}
+		n := uint(v)
+		req.Version = &n
+	}
+	if asOf := query.Get("as_of"); asOf != "" {
+		t, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			return nil, ErrInvalidPaymentVersion
+		}
+		req.AsOf = &t
+	}
+	return req, nil
 }
 
 func decodeGetListOfPaymentsRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := GetListOfPaymentsRequest{}
+	var err error
+	query := r.URL.Query()
+	if page := query.Get("page"); page != "" {
+		if req.Page, err = strconv.Atoi(page); err != nil {
+			return nil, ErrInvalidPagination
+		}
+	}
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		if req.PageSize, err = strconv.Atoi(pageSize); err != nil {
+			return nil, ErrInvalidPagination
+		}
+	}
+	switch query.Get("include") {
+	case "":
+	case "deleted":
+		req.IncludeDeleted = true
+	default:
+		return nil, ErrInvalidInclude
+	}
+	req.AccountNumber = query.Get("account_number")
 	return req, nil
 }
 
-func decodePostPaymentRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+func decodePostPaymentRequest(ctx context.Context, r *http.Request) (request interface{}, err error) {
 	var req CreatePaymentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, ErrInvalidBody
 	}
+	req.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
+	req.Actor = actorOf(ctx, r)
 	return req, nil
 }
 
@@ -95,16 +314,171 @@ func decodeUpdatePaymentRequest(ctx context.Context, r *http.Request) (request i
 	}
 	vars := mux.Vars(r)
 	req.PaymentID = vars["id"]
+	req.Actor = actorOf(ctx, r)
 	return req, nil
 }
 
-func decodeDeletePaymentRequest(_ context.Context, r *http.Request) (interface{}, error) {
+func decodeDeletePaymentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	vars := mux.Vars(r)
 	id := vars["id"]
-	return DeletePaymentRequest{PaymentID: id}, nil
+	return DeletePaymentRequest{PaymentID: id, Actor: actorOf(ctx, r)}, nil
+}
+
+func decodeRestorePaymentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	return RestorePaymentRequest{PaymentID: mux.Vars(r)["id"], Actor: actorOf(ctx, r)}, nil
+}
+
+// makeDecodeReviewPaymentRequest returns the decoder of the review requests of a decision, the reason is optional
+func makeDecodeReviewPaymentRequest(decision ReviewDecision) kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req ReviewPaymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			return nil, ErrInvalidBody
+		}
+		req.PaymentID = mux.Vars(r)["id"]
+		req.Decision = decision
+		req.Actor = actorOf(ctx, r)
+		return req, nil
+	}
+}
+
+func decodeApprovePaymentRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	var req ApprovePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+	req.PaymentID = mux.Vars(r)["id"]
+	req.Actor = actorOf(ctx, r)
+	req.Approver = req.Actor.UserID
+	return req, nil
+}
+
+func decodeGetPaymentApprovalsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return GetPaymentApprovalsRequest{PaymentID: mux.Vars(r)["id"]}, nil
+}
+
+func decodeGetPaymentAuditRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return GetPaymentAuditRequest{PaymentID: mux.Vars(r)["id"]}, nil
+}
+
+// actorOf returns the user, the request ID and the source IP of a request, recorded by populateSourceIP
+func actorOf(ctx context.Context, r *http.Request) Actor {
+	ip, _ := ctx.Value(contextKeySourceIP).(string)
+	return Actor{UserID: r.Header.Get(userIDHeader), RequestID: r.Header.Get(requestIDHeader), SourceIP: ip}
+}
+
+// makeDecodeCreateReturnRequest returns the decoder of the creation requests of a type of returns
+func makeDecodeCreateReturnRequest(t ReturnType) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		var req CreateReturnRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, ErrInvalidBody
+		}
+		req.PaymentID = mux.Vars(r)["id"]
+		req.Type = t
+		req.UserID = r.Header.Get(userIDHeader)
+		return req, nil
+	}
+}
+
+func makeDecodeGetPaymentReturnsRequest(t ReturnType) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		return GetPaymentReturnsRequest{PaymentID: mux.Vars(r)["id"], Type: t}, nil
+	}
+}
+
+func makeDecodeGetReturnRequest(t ReturnType) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		vars := mux.Vars(r)
+		return GetReturnRequest{PaymentID: vars["id"], Type: t, ReturnID: vars["return_id"]}, nil
+	}
+}
+
+// makeDecodeUpdateReturnStatusRequest returns the decoder of the requests moving a type of returns to a status,
+// the reason is optional
+func makeDecodeUpdateReturnStatusRequest(t ReturnType, status ReturnStatus) kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req UpdateReturnStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			return nil, ErrInvalidBody
+		}
+		vars := mux.Vars(r)
+		req.PaymentID, req.Type, req.ReturnID, req.Status = vars["id"], t, vars["return_id"], status
+		req.Actor = actorOf(ctx, r)
+		return req, nil
+	}
+}
+
+func decodeCreateRecallRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req CreateRecallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+	req.PaymentID = mux.Vars(r)["id"]
+	req.UserID = r.Header.Get(userIDHeader)
+	return req, nil
+}
+
+func decodeGetPaymentRecallsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return GetPaymentRecallsRequest{PaymentID: mux.Vars(r)["id"]}, nil
+}
+
+func decodeGetRecallRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	return GetRecallRequest{PaymentID: vars["id"], RecallID: vars["recall_id"]}, nil
+}
+
+// makeDecodeDecideRecallRequest returns the decoder of the decisions on the recalls, the reason is optional
+func makeDecodeDecideRecallRequest(decision RecallDecision) kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var req DecideRecallRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			return nil, ErrInvalidBody
+		}
+		vars := mux.Vars(r)
+		req.PaymentID, req.RecallID, req.Decision = vars["id"], vars["recall_id"], decision
+		req.Actor = actorOf(ctx, r)
+		return req, nil
+	}
+}
+
+func decodeGetAccountBalanceRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return GetAccountBalanceRequest{Account: mux.Vars(r)["account"]}, nil
+}
+
+func decodeGetAccountEntriesRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	list, err := decodeGetListOfPaymentsRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	page := list.(GetListOfPaymentsRequest)
+	return GetAccountEntriesRequest{Account: mux.Vars(r)["account"], Page: page.Page, PageSize: page.PageSize}, nil
+}
+
+func decodeCheckLedgerRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return CheckLedgerRequest{}, nil
+}
+
+func decodeGetOrganisationLimitsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return GetOrganisationLimitsRequest{OrganisationID: mux.Vars(r)["organisation_id"]}, nil
+}
+
+func decodeUpdateOrganisationLimitsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req UpdateOrganisationLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req.Limits); err != nil {
+		return nil, ErrInvalidBody
+	}
+	req.OrganisationID = mux.Vars(r)["organisation_id"]
+	return req, nil
+}
+
+func decodeDeleteOrganisationLimitsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	return DeleteOrganisationLimitsRequest{OrganisationID: mux.Vars(r)["organisation_id"]}, nil
 }
 
 func encodeOKResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	decorateLinks(ctx, response)
+	maskResponse(ctx, response)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(response)
@@ -116,6 +490,15 @@ func encodeAcceptedResponse(ctx context.Context, w http.ResponseWriter, response
 }
 
 func encodeCreatedResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	decorateLinks(ctx, response)
+	switch res := response.(type) {
+	case *CreatePaymentResponse:
+		w.Header().Set("Location", res.Self)
+	case *CreateReturnResponse:
+		w.Header().Set("Location", res.Self)
+	case *CreateRecallResponse:
+		w.Header().Set("Location", res.Self)
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusCreated)
 	return json.NewEncoder(w).Encode(response)
@@ -3,9 +3,11 @@ package payments
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -14,22 +16,70 @@ import (
 
 func Test_MakeHTTPHandler(t *testing.T) {
 	router := mux.NewRouter()
-	h := MakeHTTPHandler(Endpoints{}, router)
+	h := MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
 	assert.NotNil(t, h)
 }
 
 func Test_decodePostPaymentRequest(t *testing.T) {
 	//Arrange
-	expected := CreatePaymentRequest{}
+	expected := CreatePaymentRequest{Actor: Actor{UserID: "alice", RequestID: "req-1", SourceIP: "192.0.2.1"}}
 	r := httptest.NewRequest("POST", "/v1/payments/", bytes.NewBufferString("{}"))
+	r.Header.Set(userIDHeader, "alice")
+	r.Header.Set(requestIDHeader, "req-1")
 	//Act
-	req, err := decodePostPaymentRequest(context.Background(), r)
+	req, err := decodePostPaymentRequest(requestContext(r), r)
 	//Assert
 	tt := assert.New(t)
 	tt.Nil(err)
 	tt.Equal(expected, req.(CreatePaymentRequest))
 }
 
+// requestContext returns the context of a request populated as by the http transport, without trusted proxies
+func requestContext(r *http.Request) context.Context {
+	return populateSourceIP(nil)(context.Background(), r)
+}
+
+func Test_sourceIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("192.0.2.1, 10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		expected   string
+	}{
+		{"not forwarded", "192.0.2.1:1234", "", "192.0.2.1"},
+		{"forwarded by an untrusted client", "198.51.100.9:1234", "203.0.113.7", "198.51.100.9"},
+		{"forged entries before the trusted hops", "192.0.2.1:1234", "198.51.100.66, 203.0.113.7, 10.0.0.1", "203.0.113.7"},
+		{"only trusted hops", "192.0.2.1:1234", "10.0.0.2, 10.0.0.1", "10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			r := httptest.NewRequest("DELETE", "/v1/payments/7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			//Act
+			actor := actorOf(populateSourceIP(proxies)(context.Background(), r), r)
+			//Assert
+			assert.Equal(t, Actor{SourceIP: tt.expected}, actor)
+		})
+	}
+}
+
+func Test_actorOf_UntrustedForwarded(t *testing.T) {
+	//Arrange
+	r := httptest.NewRequest("DELETE", "/v1/payments/7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3/", nil)
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	//Act
+	actor := actorOf(requestContext(r), r)
+	//Assert
+	assert.Equal(t, Actor{SourceIP: "192.0.2.1"}, actor, "the X-Forwarded-For header is ignored without trusted proxies")
+}
+
 func Test_decodeGetListOfPaymentsRequest(t *testing.T) {
 	//Arrange
 	expected := GetListOfPaymentsRequest{}
@@ -42,6 +92,73 @@ func Test_decodeGetListOfPaymentsRequest(t *testing.T) {
 	tt.Equal(expected, req.(GetListOfPaymentsRequest))
 }
 
+func Test_decodeGetListOfPaymentsRequest_Pagination(t *testing.T) {
+	//Arrange
+	expected := GetListOfPaymentsRequest{Page: 3, PageSize: 25}
+	r := httptest.NewRequest("GET", "/v1/payments/?page=3&page_size=25", nil)
+	//Act
+	req, err := decodeGetListOfPaymentsRequest(context.Background(), r)
+	//Assert
+	tt := assert.New(t)
+	tt.Nil(err)
+	tt.Equal(expected, req.(GetListOfPaymentsRequest))
+
+	//Arrange
+	r = httptest.NewRequest("GET", "/v1/payments/?page=abc", nil)
+	//Act
+	_, err = decodeGetListOfPaymentsRequest(context.Background(), r)
+	//Assert
+	tt.Equal(ErrInvalidPagination, err)
+}
+
+func Test_HTTP_GetListOfPayments_Pagination(t *testing.T) {
+	//Arrange
+	mockService := &MockService{}
+	mockService.On("GetListOfPayments", GetListOfPaymentsRequest{Page: 2, PageSize: 1}).Return(&GetListOfPaymentsResponse{
+		Data: []Payment{mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")},
+		Meta: PageMeta{Page: 2, PageSize: 1},
+	}, nil)
+	h := MakeHTTPHandler(MakeEndpoints(mockService), NewEventBroker(10, 10), mux.NewRouter())
+	r := httptest.NewRequest(http.MethodGet, "http://api.example.com/v1/payments/?page=2&page_size=1", nil)
+	w := httptest.NewRecorder()
+	//Act
+	h.ServeHTTP(w, r)
+	//Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var res GetListOfPaymentsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	assert.Equal(t, PageMeta{Page: 2, PageSize: 1}, res.Meta)
+	assert.Equal(t, "http://api.example.com/v1/payments/?page=1&page_size=1", res.Prev)
+	assert.Equal(t, "http://api.example.com/v1/payments/?page=3&page_size=1", res.Next)
+	mockService.AssertExpectations(t)
+}
+
+func Test_decodeGetListOfPaymentsRequest_Include(t *testing.T) {
+	//Arrange
+	r := httptest.NewRequest("GET", "/v1/payments/?include=deleted", nil)
+	//Act
+	req, err := decodeGetListOfPaymentsRequest(context.Background(), r)
+	//Assert
+	tt := assert.New(t)
+	tt.Nil(err)
+	tt.Equal(GetListOfPaymentsRequest{IncludeDeleted: true}, req.(GetListOfPaymentsRequest))
+
+	//Arrange
+	r = httptest.NewRequest("GET", "/v1/payments/?include=rejected", nil)
+	//Act
+	_, err = decodeGetListOfPaymentsRequest(context.Background(), r)
+	//Assert
+	tt.Equal(ErrInvalidInclude, err)
+
+	//Arrange
+	r = httptest.NewRequest("GET", "/v1/payments/?account_number=GB29XABC10161234567801", nil)
+	//Act
+	req, err = decodeGetListOfPaymentsRequest(context.Background(), r)
+	//Assert
+	tt.Nil(err)
+	tt.Equal(GetListOfPaymentsRequest{AccountNumber: "GB29XABC10161234567801"}, req.(GetListOfPaymentsRequest))
+}
+
 func Test_decodeGetPaymentRequest(t *testing.T) {
 	//Arrange
 	expectedResult := GetPaymentRequest{
@@ -56,6 +173,36 @@ func Test_decodeGetPaymentRequest(t *testing.T) {
 	require.Equal(t, expectedResult, req)
 }
 
+func Test_decodeGetPaymentRequest_Version(t *testing.T) {
+	version, at := uint(3), time.Date(2019, 3, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		query   string
+		want    GetPaymentRequest
+		wantErr error
+	}{
+		{query: "version=3", want: GetPaymentRequest{PaymentID: "abcd", Version: &version}},
+		{query: "as_of=2019-03-01T12:30:00Z", want: GetPaymentRequest{PaymentID: "abcd", AsOf: &at}},
+		{query: "version=-1", wantErr: ErrInvalidPaymentVersion},
+		{query: "as_of=2019-03-01", wantErr: ErrInvalidPaymentVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			//Arrange
+			httpRequest := httptest.NewRequest("GET", "/v1/payments/abcd/?"+tt.query, nil)
+			httpRequest = mux.SetURLVars(httpRequest, map[string]string{"id": "abcd"})
+			//Act
+			req, err := decodeGetPaymentRequest(context.Background(), httpRequest)
+			//Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req)
+		})
+	}
+}
+
 func Test_decodeUpdatePaymentRequest(t *testing.T) {
 	//Arrange
 	expectedResult := UpdatePaymentRequest{
@@ -82,6 +229,64 @@ func Test_decodeDeletePaymentRequest(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedResult, req)
 }
+func Test_decodeReviewPaymentRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision ReviewDecision
+		body     string
+		want     ReviewPaymentRequest
+		wantErr  error
+	}{
+		{name: "Should decode a release without body", decision: ReviewRelease, want: ReviewPaymentRequest{PaymentID: "abcd", Decision: ReviewRelease, Actor: Actor{SourceIP: "192.0.2.1"}}},
+		{name: "Should decode the reason of a reject", decision: ReviewReject, body: `{"reason":"confirmed match"}`, want: ReviewPaymentRequest{PaymentID: "abcd", Decision: ReviewReject, Reason: "confirmed match", Actor: Actor{SourceIP: "192.0.2.1"}}},
+		{name: "Should return invalid body", decision: ReviewReject, body: "{", wantErr: ErrInvalidBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			httpRequest := httptest.NewRequest("POST", "/v1/payments/abcd/"+string(tt.decision)+"/", bytes.NewBufferString(tt.body))
+			httpRequest = mux.SetURLVars(httpRequest, map[string]string{"id": "abcd"})
+			//Act
+			req, err := makeDecodeReviewPaymentRequest(tt.decision)(requestContext(httpRequest), httpRequest)
+			//Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req)
+		})
+	}
+}
+
+func Test_decodeApprovePaymentRequest(t *testing.T) {
+	//Arrange
+	httpRequest := httptest.NewRequest("POST", "/v1/payments/abcd/approvals/", bytes.NewBufferString(`{"decision":"approve","comment":"checked"}`))
+	httpRequest.Header.Set(userIDHeader, "bob")
+	httpRequest = mux.SetURLVars(httpRequest, map[string]string{"id": "abcd"})
+	//Act
+	req, err := decodeApprovePaymentRequest(requestContext(httpRequest), httpRequest)
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, ApprovePaymentRequest{PaymentID: "abcd", Approver: "bob", Decision: ApprovalApprove, Comment: "checked", Actor: Actor{UserID: "bob", SourceIP: "192.0.2.1"}}, req)
+}
+
+func Test_decodeUpdateOrganisationLimitsRequest(t *testing.T) {
+	//Arrange
+	expectedResult := UpdateOrganisationLimitsRequest{
+		OrganisationID: "abcd",
+		Limits:         OrganisationLimits{AllowedSchemes: []string{"FPS"}, Currencies: []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000.00"}}},
+	}
+	body := `{"allowed_schemes":["FPS"],"currencies":[{"currency":"GBP","daily_total":"1000.00"}]}`
+	httpRequest := httptest.NewRequest("PUT", "/v1/admin/organisations/abcd/limits/", bytes.NewBufferString(body))
+	httpRequest = mux.SetURLVars(httpRequest, map[string]string{"organisation_id": "abcd"})
+	//Act
+	req, err := decodeUpdateOrganisationLimitsRequest(context.Background(), httpRequest)
+	//Assert
+	require.NoError(t, err)
+	require.Equal(t, expectedResult, req)
+}
+
 func Test_encodeOKResponse(t *testing.T) {
 	// Arrange
 	rr := httptest.NewRecorder()
@@ -110,3 +315,15 @@ func Test_encodeCreatedResponse(t *testing.T) {
 	assert.Equal(t, rr.Code, http.StatusCreated)
 	assert.Nil(t, err)
 }
+func Test_encodeCreatedResponse_Links(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+	ctx := context.WithValue(context.Background(), contextKeyBaseURL, "https://api.example.com")
+	// Act
+	err := encodeCreatedResponse(ctx, rr, &CreatePaymentResponse{PaymentID: "abcd"})
+	//Assert
+	assert.Nil(t, err)
+	assert.Equal(t, "https://api.example.com/v1/payments/abcd/", rr.Header().Get("Location"))
+	assert.Contains(t, rr.Body.String(), `"self":"https://api.example.com/v1/payments/abcd/"`)
+	assert.Contains(t, rr.Body.String(), `"collection":"https://api.example.com/v1/payments/"`)
+}
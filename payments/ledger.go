@@ -0,0 +1,114 @@
+package payments
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/ledger"
+	"github.com/jinzhu/gorm"
+)
+
+// Descriptions of the ledger transactions of the payments
+const (
+	ledgerPaymentCreated  = "payment created"
+	ledgerPaymentUpdated  = "payment updated"
+	ledgerPaymentDeleted  = "payment deleted"
+	ledgerPaymentRestored = "payment restored"
+	ledgerPaymentRejected = "payment rejected"
+)
+
+// paymentTransaction returns the ledger transaction of a payment: its amount moves from the account of the debtor to
+// the account of the beneficiary, its sender charges to the charges account of the bank of the debtor from the account
+// of the party bearing them: the beneficiary for the BEN bearer code, the debtor otherwise. A rejected payment moves no
+// money, it has no transaction.
+func (r *paymentRepository) paymentTransaction(p Payment, description string) *ledger.Transaction {
+	if p.Status == StatusRejected {
+		return nil
+	}
+	debtor, beneficiary := p.Attributes.DebtorParty, p.Attributes.BeneficiaryParty
+	debtorAccount := r.partyAccount(debtor.BankID, debtor.AccountNumber)
+	beneficiaryAccount := r.partyAccount(beneficiary.BankID, beneficiary.AccountNumber)
+	payer := debtorAccount
+	if charges.Bearer(p.Attributes.ChargesInformation.BearerCode) == charges.BearerBeneficiary {
+		payer = beneficiaryAccount
+	}
+
+	t := ledger.NewTransaction(p.ID.String(), description)
+	t.Transfer(debtorAccount, beneficiaryAccount, p.Attributes.Amount, p.Attributes.Currency)
+	for _, charge := range p.Attributes.ChargesInformation.SenderCharges {
+		t.Transfer(payer, ledger.ChargesAccount(debtor.BankID), charge.Amount, charge.Currency)
+	}
+	return t
+}
+
+// blindIndexPattern matches the blind index of an account number
+var blindIndexPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// partyAccount returns the ledger account of the account of a payment party at its bank, keyed by the blind index of
+// the account number when the parties are encrypted so that the ledger holds no account number
+func (r *paymentRepository) partyAccount(bankID string, accountNumber string) string {
+	if r.keys == nil {
+		return ledger.PartyAccount(bankID, accountNumber)
+	}
+	return ledger.PartyAccount(bankID, encryption.BlindIndex(r.keys.IndexKey(), normaliseAccount(accountNumber)))
+}
+
+// ledgerAccount returns the ledger account of an account looked up: a party account is keyed by the blind index of its
+// account number when the parties are encrypted, unless it already is
+func (r *paymentRepository) ledgerAccount(account string) string {
+	parts := strings.SplitN(account, ":", 3)
+	if r.keys == nil || len(parts) != 3 || parts[0] != "party" || blindIndexPattern.MatchString(parts[2]) {
+		return account
+	}
+	return r.partyAccount(parts[1], parts[2])
+}
+
+// indexLedgerAccounts keys by the blind index of their account number up to limit party accounts of the ledger posted
+// before the encryption was enabled. It returns the number of accounts keyed.
+func (r *paymentRepository) indexLedgerAccounts(limit int) (int, error) {
+	var accounts []string
+	err := r.db.Model(&ledger.Entry{}).Where("account LIKE 'party:%' AND account !~ ':[0-9a-f]{64}$'").
+		Order("account").Limit(limit).Pluck("DISTINCT account", &accounts).Error
+	if err != nil {
+		return 0, err
+	}
+	for i, account := range accounts {
+		err := r.db.Model(&ledger.Entry{}).Where("account = ?", account).UpdateColumn("account", r.ledgerAccount(account)).Error
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(accounts), nil
+}
+
+// postPaymentTransaction reverses the ledger transaction of a payment still in effect, if any, then posts its new
+// transaction unless it is nil. It runs in the database transaction changing the payment, so the ledger follows it.
+func postPaymentTransaction(tx *gorm.DB, paymentID string, t *ledger.Transaction, description string) error {
+	var current []ledger.Transaction
+	err := tx.Preload("Entries").
+		Where("reference = ? AND reverses IS NULL", paymentID).
+		Where("NOT EXISTS (SELECT 1 FROM ledger_transactions r WHERE r.reverses = ledger_transactions.id)").
+		Find(&current).Error
+	if err != nil {
+		return err
+	}
+	for i := range current {
+		if err := postTransaction(tx, current[i].Reversal(description)); err != nil {
+			return err
+		}
+	}
+	if t == nil {
+		return nil
+	}
+	return postTransaction(tx, t)
+}
+
+// postTransaction inserts a ledger transaction with its entries, it refuses the transactions which do not balance
+func postTransaction(tx *gorm.DB, t *ledger.Transaction) error {
+	if err := t.Check(); err != nil {
+		return err
+	}
+	return tx.Create(t).Error
+}
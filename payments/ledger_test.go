@@ -0,0 +1,208 @@
+package payments
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/ledger"
+)
+
+func Test_paymentTransaction(t *testing.T) {
+	//Arrange
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	p.Attributes.BeneficiaryParty.BankID = "403000"
+	p.Attributes.BeneficiaryParty.AccountNumber = "71268996"
+
+	//Act
+	tx := (&paymentRepository{}).paymentTransaction(p, ledgerPaymentCreated)
+
+	//Assert
+	assert.NoError(t, tx.Check())
+	assert.Equal(t, p.ID.String(), tx.Reference)
+	debtor := "party:134667:GB29NWBK60161331926819"
+	var got []ledger.Entry
+	for _, e := range tx.Entries {
+		got = append(got, ledger.Entry{Account: e.Account, Direction: e.Direction, Amount: e.Amount, Currency: e.Currency})
+	}
+	assert.Equal(t, []ledger.Entry{
+		{Account: debtor, Direction: ledger.Debit, Amount: "100.21", Currency: "GBP"},
+		{Account: "party:403000:71268996", Direction: ledger.Credit, Amount: "100.21", Currency: "GBP"},
+		{Account: debtor, Direction: ledger.Debit, Amount: "5.00", Currency: "GBP"},
+		{Account: "charges:134667", Direction: ledger.Credit, Amount: "5.00", Currency: "GBP"},
+		{Account: debtor, Direction: ledger.Debit, Amount: "10.00", Currency: "USD"},
+		{Account: "charges:134667", Direction: ledger.Credit, Amount: "10.00", Currency: "USD"},
+	}, got)
+}
+
+func Test_paymentTransaction_BeneficiaryBearsCharges(t *testing.T) {
+	//Arrange
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	p.Attributes.BeneficiaryParty.BankID = "403000"
+	p.Attributes.BeneficiaryParty.AccountNumber = "71268996"
+	p.Attributes.ChargesInformation.BearerCode = "BEN"
+
+	//Act
+	tx := (&paymentRepository{}).paymentTransaction(p, ledgerPaymentCreated)
+
+	//Assert
+	assert.NoError(t, tx.Check())
+	for _, e := range tx.Entries[2:] {
+		if e.Direction == ledger.Debit {
+			assert.Equal(t, "party:403000:71268996", e.Account)
+		}
+	}
+}
+
+func Test_paymentTransaction_Encrypted(t *testing.T) {
+	//Arrange
+	keys := newTestKeys(t)
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	r := &paymentRepository{keys: keys}
+
+	//Act
+	tx := r.paymentTransaction(p, ledgerPaymentCreated)
+
+	//Assert
+	assert.NoError(t, tx.Check())
+	index := encryption.BlindIndex(keys.IndexKey(), "GB29NWBK60161331926819")
+	assert.Equal(t, "party:134667:"+index, tx.Entries[0].Account)
+	for _, e := range tx.Entries {
+		assert.NotContains(t, e.Account, "GB29NWBK60161331926819")
+	}
+	assert.Equal(t, "party:134667:"+index, r.ledgerAccount("party:134667:GB29 nwbk 60161331926819"))
+	assert.Equal(t, "party:134667:"+index, r.ledgerAccount("party:134667:"+index))
+	assert.Equal(t, "charges:134667", r.ledgerAccount("charges:134667"))
+}
+
+func Test_GetAccountBalances_Encrypted(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	keys := newTestKeys(t)
+	var args []driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "FROM \"ledger_entries\"",
+			Callback: func(_ string, a []driver.NamedValue) { args = a },
+		},
+	})
+	r := NewPaymentRepository(db, WithEncryption(keys))
+
+	//Act
+	_, err := r.GetAccountBalances("party:134667:GB29NWBK60161331926819")
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, args)
+	assert.Equal(t, "party:134667:"+encryption.BlindIndex(keys.IndexKey(), "GB29NWBK60161331926819"), args[len(args)-1].Value)
+}
+
+func Test_DeletePayment_ReversesLedgerTransaction(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	current := "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b"
+	var entries [][]driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"ledger_transactions\"",
+			Response: []map[string]interface{}{{"id": current, "reference": id}},
+		},
+		{
+			Pattern: "SELECT * FROM \"ledger_entries\"",
+			Response: []map[string]interface{}{
+				{"transaction_id": current, "account": "party:134667:1", "direction": "debit", "amount": "100.21", "currency": "GBP"},
+				{"transaction_id": current, "account": "party:403000:2", "direction": "credit", "amount": "100.21", "currency": "GBP"},
+			},
+		},
+		{
+			Pattern:  "INSERT INTO \"ledger_entries\"",
+			Callback: func(_ string, args []driver.NamedValue) { entries = append(entries, args) },
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	err := r.DeletePayment(id, Actor{UserID: "alice"})
+
+	//Assert
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	var directions []string
+	for _, args := range entries {
+		for _, arg := range args {
+			if s, ok := arg.Value.(string); ok && (s == "debit" || s == "credit") {
+				directions = append(directions, s)
+			}
+		}
+	}
+	assert.Equal(t, "credit,debit", strings.Join(directions, ","), "the entries are posted in the other direction")
+}
+
+func Test_TransitionPaymentStatus_Rejected_BalancesLedger(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	current := "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b"
+	posted := []map[string]interface{}{
+		{"transaction_id": current, "account": "party:134667:1", "direction": "debit", "amount": "100.21", "currency": "GBP"},
+		{"transaction_id": current, "account": "party:403000:2", "direction": "credit", "amount": "100.21", "currency": "GBP"},
+	}
+	var inserted []map[string]interface{}
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "status": StatusHeldForReview}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"ledger_transactions\"",
+			Response: []map[string]interface{}{{"id": current, "reference": id}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"ledger_entries\"",
+			Response: posted,
+		},
+		{
+			Pattern: "INSERT INTO \"ledger_entries\"",
+			Callback: func(query string, args []driver.NamedValue) {
+				columns := strings.Split(query[strings.Index(query, "(")+1:strings.Index(query, ")")], ",")
+				entry := map[string]interface{}{}
+				for i, column := range columns {
+					entry[strings.Trim(column, ` "`)] = args[i].Value
+				}
+				inserted = append(inserted, entry)
+			},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	ok, err := r.TransitionPaymentStatus(id, StatusHeldForReview, StatusRejected, "confirmed match", Actor{UserID: "bob"})
+
+	//Assert
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, inserted, 2, "the transaction of the rejected payment is reversed")
+	balances := map[string]float64{}
+	for _, e := range append(posted, inserted...) {
+		amount, _ := strconv.ParseFloat(e["amount"].(string), 64)
+		if e["direction"] == string(ledger.Debit) {
+			amount = -amount
+		}
+		balances[e["account"].(string)] += amount
+	}
+	assert.Equal(t, map[string]float64{"party:134667:1": 0, "party:403000:2": 0}, balances, "the rejected payment moves no money")
+}
@@ -0,0 +1,168 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/currency"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+// OrganisationLimits are the payment limits of an organisation, managed through the admin endpoints
+type OrganisationLimits struct {
+	OrganisationID uuid.UUID `json:"organisation_id" gorm:"type:uuid;primary_key"`
+	// AllowedSchemes are the payment schemes of the organisation, all the schemes are allowed when empty
+	AllowedSchemes []string `json:"allowed_schemes,omitempty" gorm:"-"`
+	// Schemes stores the allowed schemes, comma separated
+	Schemes string `json:"-"`
+	// Currencies are the amount limits by currency, the amounts of the other currencies are not limited
+	Currencies []CurrencyLimit `json:"currencies,omitempty" gorm:"-"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// CurrencyLimit limits the amounts of the payments of an organisation in a currency, empty amounts are not limited
+type CurrencyLimit struct {
+	ID             uint      `json:"-" gorm:"primary_key"`
+	OrganisationID uuid.UUID `json:"-" gorm:"type:uuid" sql:"index"`
+	Currency       string    `json:"currency" validate:"required"`
+	// MaxAmount is the maximum amount of a payment
+	MaxAmount string `json:"max_amount,omitempty"`
+	// DailyTotal and MonthlyTotal are the maximum total amounts of the payments created in a day and in a calendar month, in UTC
+	DailyTotal   string `json:"daily_total,omitempty"`
+	MonthlyTotal string `json:"monthly_total,omitempty"`
+	// ApprovalThreshold is the amount above which a payment is approved by a second person before it is submitted
+	ApprovalThreshold string `json:"approval_threshold,omitempty"`
+}
+
+// LimitUsage is the total amount of the payments created by an organisation in a currency on a day
+type LimitUsage struct {
+	OrganisationID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Currency       string    `gorm:"primary_key"`
+	Day            time.Time `gorm:"type:date;primary_key"`
+	Total          string    `gorm:"type:numeric"`
+}
+
+// UsageCheck checks a payment against the total amounts of the payments of its organisation in its currency
+// created on its day and in its month, before it is created or updated
+type UsageCheck func(daily, monthly *big.Rat) error
+
+// currencyLimit returns the limits of a currency, the limits may be nil
+func (l *OrganisationLimits) currencyLimit(code string) (CurrencyLimit, bool) {
+	if l == nil {
+		return CurrencyLimit{}, false
+	}
+	for _, c := range l.Currencies {
+		if c.Currency == code {
+			return c, true
+		}
+	}
+	return CurrencyLimit{}, false
+}
+
+// check returns the limits broken by the payment which do not depend on the other payments:
+// the allowed schemes and the maximum amount
+func (l *OrganisationLimits) check(p Payment) []apierrors.FieldError {
+	if l == nil {
+		return nil
+	}
+	var errs []apierrors.FieldError
+	if len(l.AllowedSchemes) > 0 && !contains(l.AllowedSchemes, p.Attributes.PaymentScheme) {
+		errs = append(errs, apierrors.FieldError{
+			Field:   "attributes.payment_scheme",
+			Message: "is not allowed for the organisation, allowed schemes are " + strings.Join(l.AllowedSchemes, ", "),
+		})
+	}
+	c, ok := l.currencyLimit(p.Attributes.Currency)
+	if !ok || c.MaxAmount == "" {
+		return errs
+	}
+	if amount, max := parseAmount(p.Attributes.Amount), parseAmount(c.MaxAmount); amount.Cmp(max) > 0 {
+		errs = append(errs, apierrors.FieldError{
+			Field:   "attributes.amount",
+			Message: fmt.Sprintf("exceeds the maximum amount of %s %s", c.MaxAmount, c.Currency),
+		})
+	}
+	return errs
+}
+
+// requiresApproval reports whether the payment is above the approval threshold of its organisation
+func (l *OrganisationLimits) requiresApproval(p Payment) bool {
+	c, ok := l.currencyLimit(p.Attributes.Currency)
+	if !ok || c.ApprovalThreshold == "" {
+		return false
+	}
+	return parseAmount(p.Attributes.Amount).Cmp(parseAmount(c.ApprovalThreshold)) > 0
+}
+
+// limitsTotals reports whether the daily or the monthly totals are limited
+func (c CurrencyLimit) limitsTotals() bool {
+	return c.DailyTotal != "" || c.MonthlyTotal != ""
+}
+
+// checkTotals returns the daily and monthly limits broken by an amount, given the totals of the day and of the month,
+// with the remaining allowance
+func (c CurrencyLimit) checkTotals(amount string, daily, monthly *big.Rat) []apierrors.FieldError {
+	totals := []struct {
+		period string
+		limit  string
+		used   *big.Rat
+	}{
+		{"daily", c.DailyTotal, daily},
+		{"monthly", c.MonthlyTotal, monthly},
+	}
+	var errs []apierrors.FieldError
+	for _, t := range totals {
+		if t.limit == "" {
+			continue
+		}
+		limit := parseAmount(t.limit)
+		if new(big.Rat).Add(t.used, parseAmount(amount)).Cmp(limit) <= 0 {
+			continue
+		}
+		remaining := new(big.Rat).Sub(limit, t.used)
+		if remaining.Sign() < 0 {
+			remaining.SetInt64(0)
+		}
+		errs = append(errs, apierrors.FieldError{
+			Field: "attributes.amount",
+			Message: fmt.Sprintf("exceeds the %s limit of %s %s, the remaining allowance is %s %s",
+				t.period, t.limit, c.Currency, formatAmount(remaining, c.Currency), c.Currency),
+		})
+	}
+	return errs
+}
+
+// parseAmount parses a validated decimal amount
+func parseAmount(amount string) *big.Rat {
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// formatAmount formats an amount with the minor units of its currency
+func formatAmount(amount *big.Rat, code string) string {
+	return amount.FloatString(minorUnits(code))
+}
+
+// minorUnits returns the number of decimals of the amounts of a currency, 2 for the unknown currencies
+func minorUnits(code string) int {
+	if c, ok := currency.Lookup(code); ok {
+		return c.MinorUnits
+	}
+	return 2
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
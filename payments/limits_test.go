@@ -0,0 +1,86 @@
+package payments
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+func Test_OrganisationLimits_check(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits *OrganisationLimits
+		want   []apierrors.FieldError
+	}{
+		{name: "Should not limit an organisation without limits"},
+		{name: "Should allow a scheme and an amount within the limits", limits: &OrganisationLimits{
+			AllowedSchemes: []string{"Bacs", "FPS"},
+			Currencies:     []CurrencyLimit{{Currency: "GBP", MaxAmount: "100.21"}},
+		}},
+		{name: "Should not limit the other currencies", limits: &OrganisationLimits{
+			Currencies: []CurrencyLimit{{Currency: "EUR", MaxAmount: "10.00"}},
+		}},
+		{
+			name: "Should return the schemes and amounts beyond the limits",
+			limits: &OrganisationLimits{
+				AllowedSchemes: []string{"Bacs", "SEPA"},
+				Currencies:     []CurrencyLimit{{Currency: "GBP", MaxAmount: "100.2"}},
+			},
+			want: []apierrors.FieldError{
+				{Field: "attributes.payment_scheme", Message: "is not allowed for the organisation, allowed schemes are Bacs, SEPA"},
+				{Field: "attributes.amount", Message: "exceeds the maximum amount of 100.2 GBP"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			errs := tt.limits.check(mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"))
+
+			//Assert
+			assert.Equal(t, tt.want, errs)
+		})
+	}
+}
+
+func Test_CurrencyLimit_checkTotals(t *testing.T) {
+	limit := CurrencyLimit{Currency: "GBP", DailyTotal: "1000", MonthlyTotal: "5000.00"}
+	tests := []struct {
+		name    string
+		daily   string
+		monthly string
+		want    []apierrors.FieldError
+	}{
+		{name: "Should allow an amount reaching the limits", daily: "899.79", monthly: "4899.79"},
+		{
+			name:  "Should return the remaining daily allowance",
+			daily: "900", monthly: "900",
+			want: []apierrors.FieldError{{Field: "attributes.amount", Message: "exceeds the daily limit of 1000 GBP, the remaining allowance is 100.00 GBP"}},
+		},
+		{
+			name:  "Should return the remaining allowances of both limits",
+			daily: "1200", monthly: "4950.5",
+			want: []apierrors.FieldError{
+				{Field: "attributes.amount", Message: "exceeds the daily limit of 1000 GBP, the remaining allowance is 0.00 GBP"},
+				{Field: "attributes.amount", Message: "exceeds the monthly limit of 5000.00 GBP, the remaining allowance is 49.50 GBP"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			errs := limit.checkTotals("100.21", parseAmount(tt.daily), parseAmount(tt.monthly))
+
+			//Assert
+			assert.Equal(t, tt.want, errs)
+		})
+	}
+}
+
+func Test_formatAmount(t *testing.T) {
+	assert.Equal(t, "12", formatAmount(big.NewRat(23, 2), "JPY"))
+	assert.Equal(t, "11.500", formatAmount(big.NewRat(23, 2), "BHD"))
+}
@@ -5,26 +5,43 @@
 package payments
 
 import mock "github.com/stretchr/testify/mock"
+import ledger "github.com/elkousy/payments-api/ledger"
+import time "time"
+import uuid "github.com/satori/go.uuid"
 
 // MockRepository is an autogenerated mock type for the Repository type
 type MockRepository struct {
 	mock.Mock
 }
 
-// CreatePayment provides a mock function with given fields: p
-func (_m *MockRepository) CreatePayment(p Payment) (string, error) {
-	ret := _m.Called(p)
+// ArchivePayments provides a mock function with given fields: file, ids, actor
+func (_m *MockRepository) ArchivePayments(file ArchiveFile, ids []string, actor Actor) error {
+	ret := _m.Called(file, ids, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ArchiveFile, []string, Actor) error); ok {
+		r0 = rf(file, ids, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePayment provides a mock function with given fields: p, actor
+func (_m *MockRepository) CreatePayment(p Payment, actor Actor) (string, error) {
+	ret := _m.Called(p, actor)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(Payment) string); ok {
-		r0 = rf(p)
+	if rf, ok := ret.Get(0).(func(Payment, Actor) string); ok {
+		r0 = rf(p, actor)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(Payment) error); ok {
-		r1 = rf(p)
+	if rf, ok := ret.Get(1).(func(Payment, Actor) error); ok {
+		r1 = rf(p, actor)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -32,13 +49,97 @@ func (_m *MockRepository) CreatePayment(p Payment) (string, error) {
 	return r0, r1
 }
 
-// DeletePayment provides a mock function with given fields: id
-func (_m *MockRepository) DeletePayment(id string) error {
-	ret := _m.Called(id)
+// CreatePaymentWithinLimits provides a mock function with given fields: p, actor, day, check
+func (_m *MockRepository) CreatePaymentWithinLimits(p Payment, actor Actor, day time.Time, check UsageCheck) (string, error) {
+	ret := _m.Called(p, actor, day, check)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(Payment, Actor, time.Time, UsageCheck) string); ok {
+		r0 = rf(p, actor, day, check)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Payment, Actor, time.Time, UsageCheck) error); ok {
+		r1 = rf(p, actor, day, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRecall provides a mock function with given fields: r
+func (_m *MockRepository) CreateRecall(r Recall) (bool, error) {
+	ret := _m.Called(r)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(Recall) bool); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Recall) error); ok {
+		r1 = rf(r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReturn provides a mock function with given fields: r, check
+func (_m *MockRepository) CreateReturn(r Return, check ReturnCheck) (Return, error) {
+	ret := _m.Called(r, check)
+
+	var r0 Return
+	if rf, ok := ret.Get(0).(func(Return, ReturnCheck) Return); ok {
+		r0 = rf(r, check)
+	} else {
+		r0 = ret.Get(0).(Return)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Return, ReturnCheck) error); ok {
+		r1 = rf(r, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideRecall provides a mock function with given fields: id, status, reason, decidedAt, actor
+func (_m *MockRepository) DecideRecall(id string, status RecallStatus, reason string, decidedAt time.Time, actor Actor) (bool, error) {
+	ret := _m.Called(id, status, reason, decidedAt, actor)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, RecallStatus, string, time.Time, Actor) bool); ok {
+		r0 = rf(id, status, reason, decidedAt, actor)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, RecallStatus, string, time.Time, Actor) error); ok {
+		r1 = rf(id, status, reason, decidedAt, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOrganisationLimits provides a mock function with given fields: organisationID
+func (_m *MockRepository) DeleteOrganisationLimits(organisationID uuid.UUID) error {
+	ret := _m.Called(organisationID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(organisationID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,19 +147,171 @@ func (_m *MockRepository) DeletePayment(id string) error {
 	return r0
 }
 
-// GetListOfPayments provides a mock function with given fields:
-func (_m *MockRepository) GetListOfPayments() ([]Payment, error) {
-	ret := _m.Called()
+// DeletePayment provides a mock function with given fields: id, actor
+func (_m *MockRepository) DeletePayment(id string, actor Actor) error {
+	ret := _m.Called(id, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, Actor) error); ok {
+		r0 = rf(id, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccountBalances provides a mock function with given fields: account
+func (_m *MockRepository) GetAccountBalances(account string) ([]ledger.Balance, error) {
+	ret := _m.Called(account)
+
+	var r0 []ledger.Balance
+	if rf, ok := ret.Get(0).(func(string) []ledger.Balance); ok {
+		r0 = rf(account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ledger.Balance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountEntries provides a mock function with given fields: account, q
+func (_m *MockRepository) GetAccountEntries(account string, q ListQuery) ([]ledger.Entry, error) {
+	ret := _m.Called(account, q)
+
+	var r0 []ledger.Entry
+	if rf, ok := ret.Get(0).(func(string, ListQuery) []ledger.Entry); ok {
+		r0 = rf(account, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ledger.Entry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, ListQuery) error); ok {
+		r1 = rf(account, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApprovals provides a mock function with given fields: paymentID
+func (_m *MockRepository) GetApprovals(paymentID string) ([]Approval, error) {
+	ret := _m.Called(paymentID)
+
+	var r0 []Approval
+	if rf, ok := ret.Get(0).(func(string) []Approval); ok {
+		r0 = rf(paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Approval)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArchiveFile provides a mock function with given fields: paymentID
+func (_m *MockRepository) GetArchiveFile(paymentID string) (*ArchiveFile, error) {
+	ret := _m.Called(paymentID)
+
+	var r0 *ArchiveFile
+	if rf, ok := ret.Get(0).(func(string) *ArchiveFile); ok {
+		r0 = rf(paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ArchiveFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEntries provides a mock function with given fields: paymentID
+func (_m *MockRepository) GetAuditEntries(paymentID string) ([]AuditEntry, error) {
+	ret := _m.Called(paymentID)
+
+	var r0 []AuditEntry
+	if rf, ok := ret.Get(0).(func(string) []AuditEntry); ok {
+		r0 = rf(paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuePayments provides a mock function with given fields: day
+func (_m *MockRepository) GetDuePayments(day time.Time) ([]Payment, error) {
+	ret := _m.Called(day)
 
 	var r0 []Payment
-	if rf, ok := ret.Get(0).(func() []Payment); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(time.Time) []Payment); ok {
+		r0 = rf(day)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]Payment)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLedgerImbalances provides a mock function with given fields:
+func (_m *MockRepository) GetLedgerImbalances() ([]ledger.Imbalance, error) {
+	ret := _m.Called()
+
+	var r0 []ledger.Imbalance
+	if rf, ok := ret.Get(0).(func() []ledger.Imbalance); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ledger.Imbalance)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func() error); ok {
 		r1 = rf()
@@ -69,6 +322,52 @@ func (_m *MockRepository) GetListOfPayments() ([]Payment, error) {
 	return r0, r1
 }
 
+// GetListOfPayments provides a mock function with given fields: q
+func (_m *MockRepository) GetListOfPayments(q ListQuery) ([]Payment, error) {
+	ret := _m.Called(q)
+
+	var r0 []Payment
+	if rf, ok := ret.Get(0).(func(ListQuery) []Payment); ok {
+		r0 = rf(q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ListQuery) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrganisationLimits provides a mock function with given fields: organisationID
+func (_m *MockRepository) GetOrganisationLimits(organisationID uuid.UUID) (*OrganisationLimits, error) {
+	ret := _m.Called(organisationID)
+
+	var r0 *OrganisationLimits
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *OrganisationLimits); ok {
+		r0 = rf(organisationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*OrganisationLimits)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(organisationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPayment provides a mock function with given fields: id
 func (_m *MockRepository) GetPayment(id string) (Payment, error) {
 	ret := _m.Called(id)
@@ -90,13 +389,380 @@ func (_m *MockRepository) GetPayment(id string) (Payment, error) {
 	return r0, r1
 }
 
-// UpdatePayment provides a mock function with given fields: id, p
-func (_m *MockRepository) UpdatePayment(id string, p Payment) error {
-	ret := _m.Called(id, p)
+// GetPaymentAsOf provides a mock function with given fields: id, at
+func (_m *MockRepository) GetPaymentAsOf(id string, at time.Time) (Payment, error) {
+	ret := _m.Called(id, at)
+
+	var r0 Payment
+	if rf, ok := ret.Get(0).(func(string, time.Time) Payment); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Get(0).(Payment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentByFingerprint provides a mock function with given fields: fingerprint, since
+func (_m *MockRepository) GetPaymentByFingerprint(fingerprint string, since time.Time) (*Payment, error) {
+	ret := _m.Called(fingerprint, since)
+
+	var r0 *Payment
+	if rf, ok := ret.Get(0).(func(string, time.Time) *Payment); ok {
+		r0 = rf(fingerprint, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(fingerprint, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentByIdempotencyKey provides a mock function with given fields: organisationID, key
+func (_m *MockRepository) GetPaymentByIdempotencyKey(organisationID uuid.UUID, key string) (*Payment, error) {
+	ret := _m.Called(organisationID, key)
+
+	var r0 *Payment
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) *Payment); ok {
+		r0 = rf(organisationID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string) error); ok {
+		r1 = rf(organisationID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentVersion provides a mock function with given fields: id, version
+func (_m *MockRepository) GetPaymentVersion(id string, version uint) (Payment, error) {
+	ret := _m.Called(id, version)
+
+	var r0 Payment
+	if rf, ok := ret.Get(0).(func(string, uint) Payment); ok {
+		r0 = rf(id, version)
+	} else {
+		r0 = ret.Get(0).(Payment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = rf(id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentsToArchive provides a mock function with given fields: before, limit
+func (_m *MockRepository) GetPaymentsToArchive(before time.Time, limit int) ([]Payment, error) {
+	ret := _m.Called(before, limit)
+
+	var r0 []Payment
+	if rf, ok := ret.Get(0).(func(time.Time, int) []Payment); ok {
+		r0 = rf(before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecall provides a mock function with given fields: paymentID, id
+func (_m *MockRepository) GetRecall(paymentID string, id string) (Recall, error) {
+	ret := _m.Called(paymentID, id)
+
+	var r0 Recall
+	if rf, ok := ret.Get(0).(func(string, string) Recall); ok {
+		r0 = rf(paymentID, id)
+	} else {
+		r0 = ret.Get(0).(Recall)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(paymentID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecalls provides a mock function with given fields: paymentID
+func (_m *MockRepository) GetRecalls(paymentID string) ([]Recall, error) {
+	ret := _m.Called(paymentID)
+
+	var r0 []Recall
+	if rf, ok := ret.Get(0).(func(string) []Recall); ok {
+		r0 = rf(paymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Recall)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturn provides a mock function with given fields: paymentID, t, id
+func (_m *MockRepository) GetReturn(paymentID string, t ReturnType, id string) (Return, error) {
+	ret := _m.Called(paymentID, t, id)
+
+	var r0 Return
+	if rf, ok := ret.Get(0).(func(string, ReturnType, string) Return); ok {
+		r0 = rf(paymentID, t, id)
+	} else {
+		r0 = ret.Get(0).(Return)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, ReturnType, string) error); ok {
+		r1 = rf(paymentID, t, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturns provides a mock function with given fields: paymentID, t
+func (_m *MockRepository) GetReturns(paymentID string, t ReturnType) ([]Return, error) {
+	ret := _m.Called(paymentID, t)
+
+	var r0 []Return
+	if rf, ok := ret.Get(0).(func(string, ReturnType) []Return); ok {
+		r0 = rf(paymentID, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Return)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, ReturnType) error); ok {
+		r1 = rf(paymentID, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPaymentHandled provides a mock function with given fields: id, at
+func (_m *MockRepository) MarkPaymentHandled(id string, at time.Time) error {
+	ret := _m.Called(id, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeDeletedPayments provides a mock function with given fields: before, actor
+func (_m *MockRepository) PurgeDeletedPayments(before time.Time, actor Actor) (int, error) {
+	ret := _m.Called(before, actor)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(time.Time, Actor) int); ok {
+		r0 = rf(before, actor)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, Actor) error); ok {
+		r1 = rf(before, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordApproval provides a mock function with given fields: a, to, actor
+func (_m *MockRepository) RecordApproval(a Approval, to PaymentStatus, actor Actor) (bool, error) {
+	ret := _m.Called(a, to, actor)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(Approval, PaymentStatus, Actor) bool); ok {
+		r0 = rf(a, to, actor)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Approval, PaymentStatus, Actor) error); ok {
+		r1 = rf(a, to, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReencryptParties provides a mock function with given fields: limit
+func (_m *MockRepository) ReencryptParties(limit int) (int, error) {
+	ret := _m.Called(limit)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestorePayment provides a mock function with given fields: id, actor
+func (_m *MockRepository) RestorePayment(id string, actor Actor) (Payment, error) {
+	ret := _m.Called(id, actor)
+
+	var r0 Payment
+	if rf, ok := ret.Get(0).(func(string, Actor) Payment); ok {
+		r0 = rf(id, actor)
+	} else {
+		r0 = ret.Get(0).(Payment)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, Actor) error); ok {
+		r1 = rf(id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveOrganisationLimits provides a mock function with given fields: l
+func (_m *MockRepository) SaveOrganisationLimits(l OrganisationLimits) error {
+	ret := _m.Called(l)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(OrganisationLimits) error); ok {
+		r0 = rf(l)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransitionPaymentStatus provides a mock function with given fields: id, from, to, reason, actor
+func (_m *MockRepository) TransitionPaymentStatus(id string, from PaymentStatus, to PaymentStatus, reason string, actor Actor) (bool, error) {
+	ret := _m.Called(id, from, to, reason, actor)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, PaymentStatus, PaymentStatus, string, Actor) bool); ok {
+		r0 = rf(id, from, to, reason, actor)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, PaymentStatus, PaymentStatus, string, Actor) error); ok {
+		r1 = rf(id, from, to, reason, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionReturnStatus provides a mock function with given fields: id, from, to, reason, actor
+func (_m *MockRepository) TransitionReturnStatus(id string, from ReturnStatus, to ReturnStatus, reason string, actor Actor) (bool, error) {
+	ret := _m.Called(id, from, to, reason, actor)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, ReturnStatus, ReturnStatus, string, Actor) bool); ok {
+		r0 = rf(id, from, to, reason, actor)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, ReturnStatus, ReturnStatus, string, Actor) error); ok {
+		r1 = rf(id, from, to, reason, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePayment provides a mock function with given fields: id, p, actor
+func (_m *MockRepository) UpdatePayment(id string, p Payment, actor Actor) error {
+	ret := _m.Called(id, p, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, Payment, Actor) error); ok {
+		r0 = rf(id, p, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePaymentWithinLimits provides a mock function with given fields: id, p, actor, check
+func (_m *MockRepository) UpdatePaymentWithinLimits(id string, p Payment, actor Actor, check UsageCheck) error {
+	ret := _m.Called(id, p, actor, check)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, Payment) error); ok {
-		r0 = rf(id, p)
+	if rf, ok := ret.Get(0).(func(string, Payment, Actor, UsageCheck) error); ok {
+		r0 = rf(id, p, actor, check)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -11,6 +11,144 @@ type MockService struct {
 	mock.Mock
 }
 
+// ApprovePayment provides a mock function with given fields: req
+func (_m *MockService) ApprovePayment(req ApprovePaymentRequest) (*ApprovePaymentResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *ApprovePaymentResponse
+	if rf, ok := ret.Get(0).(func(ApprovePaymentRequest) *ApprovePaymentResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ApprovePaymentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ApprovePaymentRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckLedger provides a mock function with given fields: req
+func (_m *MockService) CheckLedger(req CheckLedgerRequest) (*CheckLedgerResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *CheckLedgerResponse
+	if rf, ok := ret.Get(0).(func(CheckLedgerRequest) *CheckLedgerResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*CheckLedgerResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(CheckLedgerRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRecall provides a mock function with given fields: req
+func (_m *MockService) CreateRecall(req CreateRecallRequest) (*CreateRecallResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *CreateRecallResponse
+	if rf, ok := ret.Get(0).(func(CreateRecallRequest) *CreateRecallResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*CreateRecallResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(CreateRecallRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReturn provides a mock function with given fields: req
+func (_m *MockService) CreateReturn(req CreateReturnRequest) (*CreateReturnResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *CreateReturnResponse
+	if rf, ok := ret.Get(0).(func(CreateReturnRequest) *CreateReturnResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*CreateReturnResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(CreateReturnRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecideRecall provides a mock function with given fields: req
+func (_m *MockService) DecideRecall(req DecideRecallRequest) (*DecideRecallResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *DecideRecallResponse
+	if rf, ok := ret.Get(0).(func(DecideRecallRequest) *DecideRecallResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DecideRecallResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(DecideRecallRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOrganisationLimits provides a mock function with given fields: req
+func (_m *MockService) DeleteOrganisationLimits(req DeleteOrganisationLimitsRequest) (*DeleteOrganisationLimitsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *DeleteOrganisationLimitsResponse
+	if rf, ok := ret.Get(0).(func(DeleteOrganisationLimitsRequest) *DeleteOrganisationLimitsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DeleteOrganisationLimitsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(DeleteOrganisationLimitsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeletePayment provides a mock function with given fields: req
 func (_m *MockService) DeletePayment(req DeletePaymentRequest) (*DeletePaymentResponse, error) {
 	ret := _m.Called(req)
@@ -34,6 +172,52 @@ func (_m *MockService) DeletePayment(req DeletePaymentRequest) (*DeletePaymentRe
 	return r0, r1
 }
 
+// GetAccountBalance provides a mock function with given fields: req
+func (_m *MockService) GetAccountBalance(req GetAccountBalanceRequest) (*GetAccountBalanceResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetAccountBalanceResponse
+	if rf, ok := ret.Get(0).(func(GetAccountBalanceRequest) *GetAccountBalanceResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetAccountBalanceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetAccountBalanceRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountEntries provides a mock function with given fields: req
+func (_m *MockService) GetAccountEntries(req GetAccountEntriesRequest) (*GetAccountEntriesResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetAccountEntriesResponse
+	if rf, ok := ret.Get(0).(func(GetAccountEntriesRequest) *GetAccountEntriesResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetAccountEntriesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetAccountEntriesRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetListOfPayments provides a mock function with given fields: req
 func (_m *MockService) GetListOfPayments(req GetListOfPaymentsRequest) (*GetListOfPaymentsResponse, error) {
 	ret := _m.Called(req)
@@ -57,6 +241,29 @@ func (_m *MockService) GetListOfPayments(req GetListOfPaymentsRequest) (*GetList
 	return r0, r1
 }
 
+// GetOrganisationLimits provides a mock function with given fields: req
+func (_m *MockService) GetOrganisationLimits(req GetOrganisationLimitsRequest) (*GetOrganisationLimitsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetOrganisationLimitsResponse
+	if rf, ok := ret.Get(0).(func(GetOrganisationLimitsRequest) *GetOrganisationLimitsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetOrganisationLimitsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetOrganisationLimitsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPayment provides a mock function with given fields: req
 func (_m *MockService) GetPayment(req GetPaymentRequest) (*GetPaymentResponse, error) {
 	ret := _m.Called(req)
@@ -80,6 +287,144 @@ func (_m *MockService) GetPayment(req GetPaymentRequest) (*GetPaymentResponse, e
 	return r0, r1
 }
 
+// GetPaymentApprovals provides a mock function with given fields: req
+func (_m *MockService) GetPaymentApprovals(req GetPaymentApprovalsRequest) (*GetPaymentApprovalsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetPaymentApprovalsResponse
+	if rf, ok := ret.Get(0).(func(GetPaymentApprovalsRequest) *GetPaymentApprovalsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetPaymentApprovalsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetPaymentApprovalsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentAudit provides a mock function with given fields: req
+func (_m *MockService) GetPaymentAudit(req GetPaymentAuditRequest) (*GetPaymentAuditResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetPaymentAuditResponse
+	if rf, ok := ret.Get(0).(func(GetPaymentAuditRequest) *GetPaymentAuditResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetPaymentAuditResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetPaymentAuditRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentRecalls provides a mock function with given fields: req
+func (_m *MockService) GetPaymentRecalls(req GetPaymentRecallsRequest) (*GetPaymentRecallsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetPaymentRecallsResponse
+	if rf, ok := ret.Get(0).(func(GetPaymentRecallsRequest) *GetPaymentRecallsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetPaymentRecallsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetPaymentRecallsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentReturns provides a mock function with given fields: req
+func (_m *MockService) GetPaymentReturns(req GetPaymentReturnsRequest) (*GetPaymentReturnsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetPaymentReturnsResponse
+	if rf, ok := ret.Get(0).(func(GetPaymentReturnsRequest) *GetPaymentReturnsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetPaymentReturnsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetPaymentReturnsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecall provides a mock function with given fields: req
+func (_m *MockService) GetRecall(req GetRecallRequest) (*GetRecallResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetRecallResponse
+	if rf, ok := ret.Get(0).(func(GetRecallRequest) *GetRecallResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetRecallResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetRecallRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReturn provides a mock function with given fields: req
+func (_m *MockService) GetReturn(req GetReturnRequest) (*GetReturnResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *GetReturnResponse
+	if rf, ok := ret.Get(0).(func(GetReturnRequest) *GetReturnResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*GetReturnResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(GetReturnRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PostPayment provides a mock function with given fields: req
 func (_m *MockService) PostPayment(req CreatePaymentRequest) (*CreatePaymentResponse, error) {
 	ret := _m.Called(req)
@@ -103,6 +448,75 @@ func (_m *MockService) PostPayment(req CreatePaymentRequest) (*CreatePaymentResp
 	return r0, r1
 }
 
+// RestorePayment provides a mock function with given fields: req
+func (_m *MockService) RestorePayment(req RestorePaymentRequest) (*RestorePaymentResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *RestorePaymentResponse
+	if rf, ok := ret.Get(0).(func(RestorePaymentRequest) *RestorePaymentResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*RestorePaymentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(RestorePaymentRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewPayment provides a mock function with given fields: req
+func (_m *MockService) ReviewPayment(req ReviewPaymentRequest) (*ReviewPaymentResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *ReviewPaymentResponse
+	if rf, ok := ret.Get(0).(func(ReviewPaymentRequest) *ReviewPaymentResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ReviewPaymentResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ReviewPaymentRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrganisationLimits provides a mock function with given fields: req
+func (_m *MockService) UpdateOrganisationLimits(req UpdateOrganisationLimitsRequest) (*UpdateOrganisationLimitsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *UpdateOrganisationLimitsResponse
+	if rf, ok := ret.Get(0).(func(UpdateOrganisationLimitsRequest) *UpdateOrganisationLimitsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*UpdateOrganisationLimitsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(UpdateOrganisationLimitsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePayment provides a mock function with given fields: req
 func (_m *MockService) UpdatePayment(req UpdatePaymentRequest) (*UpdatePaymentResponse, error) {
 	ret := _m.Called(req)
@@ -125,3 +539,26 @@ func (_m *MockService) UpdatePayment(req UpdatePaymentRequest) (*UpdatePaymentRe
 
 	return r0, r1
 }
+
+// UpdateReturnStatus provides a mock function with given fields: req
+func (_m *MockService) UpdateReturnStatus(req UpdateReturnStatusRequest) (*UpdateReturnStatusResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *UpdateReturnStatusResponse
+	if rf, ok := ret.Get(0).(func(UpdateReturnStatusRequest) *UpdateReturnStatusResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*UpdateReturnStatusResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(UpdateReturnStatusRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
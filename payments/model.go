@@ -3,6 +3,8 @@ package payments
 import (
 	"time"
 
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/ledger"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -18,15 +20,78 @@ type Model struct {
 	ModelBase
 }
 
+// PaymentStatus is the state of a payment in its lifecycle
+type PaymentStatus string
+
+const (
+	// StatusSubmitted payments are accepted for processing
+	StatusSubmitted PaymentStatus = "submitted"
+	// StatusHeldForReview payments have parties matching the sanctions lists, a reviewer releases or rejects them
+	StatusHeldForReview PaymentStatus = "held_for_review"
+	// StatusPendingApproval payments are above the approval threshold of their organisation, a second person approves or rejects them
+	StatusPendingApproval PaymentStatus = "pending_approval"
+	// StatusScheduled payments have a future processing date, the scheduler marks them due on that date
+	StatusScheduled PaymentStatus = "scheduled"
+	// StatusDue payments reached their processing date, they were handed to the due payment handler
+	StatusDue PaymentStatus = "due"
+	// StatusRejected payments were rejected by a reviewer or an approver
+	StatusRejected PaymentStatus = "rejected"
+)
+
+// updatable tells whether a payment in the status can be updated, the due payments were handed over and the rejected
+// payments are final
+func (s PaymentStatus) updatable() bool {
+	switch s {
+	case StatusSubmitted, StatusHeldForReview, StatusPendingApproval, StatusScheduled:
+		return true
+	}
+	return false
+}
+
 // Payment reprensents a payment resource
 type Payment struct {
 	ModelBase
-	ID             uuid.UUID  `json:"id" gorm:"type:uuid; primary_key"`
-	Type           string     `json:"type" validate:"required"`
+	ID   uuid.UUID `json:"id" gorm:"type:uuid; primary_key"`
+	Type string    `json:"type" validate:"required"`
+	// Version is set by the repository, 0 when created and incremented by every update and change of status
 	Version        uint       `json:"version" binding:"exists"`
-	OrganisationID uuid.UUID  `json:"organisation_id" validate:"required"`
+	OrganisationID uuid.UUID  `json:"organisation_id" gorm:"unique_index:idx_payments_idempotency_key" validate:"required"`
 	Attributes     Attributes `json:"attributes" gorm:"auto_preload" validate:"required"`
 	AttributesID   uint       `json:"-" sql:"index"`
+	IdempotencyKey *string    `json:"-" gorm:"unique_index:idx_payments_idempotency_key"`
+	Fingerprint    string     `json:"-"`
+	// FingerprintKeyed tells the fingerprint is keyed with the index key of the encryption
+	FingerprintKeyed bool `json:"-"`
+	// Status, StatusReason, ScreeningHits, CreatedBy and ApprovalRequired are set by the service, they are ignored in the requests
+	Status        PaymentStatus  `json:"status" gorm:"default:'submitted'"`
+	StatusReason  string         `json:"status_reason,omitempty"`
+	ScreeningHits []ScreeningHit `json:"screening_hits,omitempty" gorm:"foreignkey:PaymentID"`
+	// CreatedBy is the user who created the payment, who cannot approve it
+	CreatedBy string `json:"created_by,omitempty"`
+	// ApprovalRequired is set when the amount is above the approval threshold of the organisation
+	ApprovalRequired bool `json:"approval_required,omitempty"`
+	// LimitUsageCounted is set when the amount of the payment is counted in the limit usages of its organisation, on the
+	// day it was created
+	LimitUsageCounted bool `json:"-"`
+	// HandledAt is when the due payment handler processed the payment, nil until it succeeds
+	HandledAt *time.Time `json:"-"`
+	// Deleted is set on the soft deleted payments listed with include=deleted
+	Deleted bool `json:"deleted,omitempty" gorm:"-"`
+	// Archived is set on the payments read from the archive
+	Archived bool `json:"archived,omitempty" gorm:"-"`
+}
+
+// ScreeningHit is an entry of the sanctions lists matching a party of a payment
+type ScreeningHit struct {
+	Model
+	PaymentID uuid.UUID `json:"-" gorm:"type:uuid" sql:"index"`
+	// Party is debtor_party or beneficiary_party, Field is the name or the address of the party
+	Party     string  `json:"party"`
+	Field     string  `json:"field"`
+	EntryUID  string  `json:"entry_uid"`
+	EntryName string  `json:"entry_name"`
+	Matched   string  `json:"matched"`
+	Score     float64 `json:"score"`
 }
 
 // Attributes ...
@@ -65,25 +130,32 @@ type BeneficiaryParty struct {
 // DebtorParty ...
 type DebtorParty struct {
 	SponsorParty
-	AccountName       string `json:"account_name" validate:"required"`
+	AccountName       string `json:"account_name" validate:"required" sql:"type:text"`
 	AccountNumberCode string `json:"account_number_code" validate:"required"`
-	Address           string `json:"address" validate:"required"`
-	Name              string `json:"name" validate:"required"`
+	Address           string `json:"address" validate:"required" sql:"type:text"`
+	Name              string `json:"name" validate:"required" sql:"type:text"`
 }
 
 // SponsorParty ...
 type SponsorParty struct {
 	Model
-	AccountNumber string `json:"account_number" validate:"required"`
+	AccountNumber string `json:"account_number" validate:"required" sql:"type:text"`
 	BankID        string `json:"bank_id" validate:"required"`
 	BankIDCode    string `json:"bank_id_code" validate:"required"`
+	// KeyID is the id of the key encrypting the data key of an encrypted party, empty when the party is not encrypted
+	KeyID string `json:"-"`
+	// DataKey is the base64 encoded data key encrypting the names, addresses and account numbers of the party
+	DataKey string `json:"-"`
+	// AccountNumberIndex is the blind index of the account number of an encrypted party
+	AccountNumberIndex string `json:"-" sql:"index"`
 }
 
 // ChargesInformation ...
 type ChargesInformation struct {
 	Model
-	BearerCode              string   `json:"bearer_code" validate:"required"`
-	SenderCharges           []Charge `json:"sender_charges" gorm:"auto_preload" validate:"required"`
+	BearerCode string `json:"bearer_code" validate:"required"`
+	// SenderCharges default to the fee of the payment scheme when omitted
+	SenderCharges           []Charge `json:"sender_charges" gorm:"auto_preload"`
 	ReceiverChargesAmount   string   `json:"receiver_charges_amount" validate:"required"`
 	ReceiverChargesCurrency string   `json:"receiver_charges_currency" validate:"required"`
 }
@@ -110,31 +182,61 @@ type Forex struct {
 // GetPaymentRequest is the request parameter used to retrieve a specific payment
 type GetPaymentRequest struct {
 	PaymentID string
+	// Version or AsOf select a previous version of the payment, the version current at the given time for AsOf
+	Version *uint
+	AsOf    *time.Time
 }
 
 // GetPaymentResponse is the response object returned by the get payment endpoint.
 type GetPaymentResponse struct {
 	Payment
+	// SenderChargesTotals is the total of the sender charges in each currency
+	SenderChargesTotals []charges.Amount `json:"sender_charges_totals"`
+	// TotalDebitAmount is the amount debited from the debtor, the amount and the charges it bears in the currency of the payment
+	TotalDebitAmount string `json:"total_debit_amount,omitempty"`
+	// NetCreditAmount is the amount credited to the beneficiary, the amount less the charges it bears in the currency of the payment
+	NetCreditAmount string `json:"net_credit_amount,omitempty"`
+	HateoasLink     `json:"links"`
 }
 
-// GetListOfPaymentsRequest is the request parameter used to retrieve a specific payment
-type GetListOfPaymentsRequest struct{}
+// GetListOfPaymentsRequest is the request parameter used to retrieve a page of payments
+type GetListOfPaymentsRequest struct {
+	Page     int
+	PageSize int
+	// IncludeDeleted lists the soft deleted payments too
+	IncludeDeleted bool
+	// AccountNumber lists the payments of a debtor or beneficiary account number only
+	AccountNumber string
+}
 
 // GetListOfPaymentsResponse is the response object returned by the get payment endpoint.
 // Data enveloped, a top level object is secure and succinctif you do not envelope JSON arrays.
 type GetListOfPaymentsResponse struct {
 	Data        []Payment `json:"data"`
 	HateoasLink `json:"links"`
+	Meta        PageMeta `json:"meta"`
+}
+
+// PageMeta describes the page of payments returned by the list endpoint
+type PageMeta struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
 }
 
-// CreatePaymentRequest represents the request parameters used for inserting a new payment
+// CreatePaymentRequest represents the request parameters used for inserting a new payment.
+// Requests retried with the same idempotency key return the payment created by the first attempt.
 type CreatePaymentRequest struct {
 	Payment
+	IdempotencyKey string `json:"-"`
+	// Actor identifies the user creating the payment
+	Actor `json:"-"`
 }
 
 // CreatePaymentResponse represents the response returned after inserting a new payment
 type CreatePaymentResponse struct {
-	PaymentID   string `json:"id"`
+	PaymentID   string        `json:"id"`
+	Status      PaymentStatus `json:"status"`
+	Warnings    []Warning     `json:"warnings,omitempty"`
 	HateoasLink `json:"links"`
 }
 
@@ -142,6 +244,7 @@ type CreatePaymentResponse struct {
 type UpdatePaymentRequest struct {
 	PaymentID string
 	Payment
+	Actor `json:"-"`
 }
 
 // UpdatePaymentResponse is the response object returned by the update payment endpoint.
@@ -152,6 +255,7 @@ type UpdatePaymentResponse struct {
 // DeletePaymentRequest represents the request parameter needed to delete a payment
 type DeletePaymentRequest struct {
 	PaymentID string
+	Actor
 }
 
 // DeletePaymentResponse represents the response sent when a payment is deleted
@@ -159,7 +263,368 @@ type DeletePaymentResponse struct {
 	PaymentID string `json:"id"`
 }
 
-// HateoasLink represents the HATEOS links along with the response
+// RestorePaymentRequest represents the request parameter needed to restore a deleted payment
+type RestorePaymentRequest struct {
+	PaymentID string
+	Actor
+}
+
+// RestorePaymentResponse represents the response sent when a payment is restored
+type RestorePaymentResponse struct {
+	PaymentID string `json:"id"`
+}
+
+// ReviewDecision is the decision of a reviewer on a payment held for review
+type ReviewDecision string
+
+const (
+	// ReviewRelease submits the payment
+	ReviewRelease ReviewDecision = "release"
+	// ReviewReject rejects the payment
+	ReviewReject ReviewDecision = "reject"
+)
+
+// ReviewPaymentRequest represents the decision of a reviewer on a payment held for review
+type ReviewPaymentRequest struct {
+	PaymentID string         `json:"-"`
+	Decision  ReviewDecision `json:"-"`
+	Reason    string         `json:"reason"`
+	Actor     `json:"-"`
+}
+
+// ReviewPaymentResponse represents the response returned after reviewing a payment
+type ReviewPaymentResponse struct {
+	PaymentID   string        `json:"id"`
+	Status      PaymentStatus `json:"status"`
+	HateoasLink `json:"links"`
+}
+
+// ApprovalDecision is the decision of an approver on a payment pending approval
+type ApprovalDecision string
+
+const (
+	// ApprovalApprove submits the payment
+	ApprovalApprove ApprovalDecision = "approve"
+	// ApprovalReject rejects the payment
+	ApprovalReject ApprovalDecision = "reject"
+)
+
+// Approval records the decision of an approver on a payment, approvals are never updated
+type Approval struct {
+	ID        uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	PaymentID uuid.UUID        `json:"payment_id" gorm:"type:uuid" sql:"index"`
+	Approver  string           `json:"approver"`
+	Decision  ApprovalDecision `json:"decision"`
+	Comment   string           `json:"comment,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// ApprovePaymentRequest represents the decision of an approver on a payment pending approval
+type ApprovePaymentRequest struct {
+	PaymentID string           `json:"-"`
+	Approver  string           `json:"-"`
+	Decision  ApprovalDecision `json:"decision"`
+	Comment   string           `json:"comment"`
+	// Actor identifies the approver and where the decision comes from
+	Actor `json:"-"`
+}
+
+// ApprovePaymentResponse is the approval recorded, along with the new status of the payment
+type ApprovePaymentResponse struct {
+	Approval
+	Status      PaymentStatus `json:"status"`
+	HateoasLink `json:"links"`
+}
+
+// GetPaymentApprovalsRequest is the request parameter used to retrieve the approvals of a payment
+type GetPaymentApprovalsRequest struct {
+	PaymentID string
+}
+
+// GetPaymentApprovalsResponse is the response object returned by the get payment approvals endpoint
+type GetPaymentApprovalsResponse struct {
+	Data []Approval `json:"data"`
+}
+
+// GetPaymentAuditRequest is the request parameter used to retrieve the audit log of a payment
+type GetPaymentAuditRequest struct {
+	PaymentID string
+}
+
+// GetPaymentAuditResponse is the response object returned by the get payment audit endpoint
+type GetPaymentAuditResponse struct {
+	Data []AuditEntry `json:"data"`
+}
+
+// ReturnType tells whether the funds of a payment are sent back by the beneficiary bank or reversed by us
+type ReturnType string
+
+const (
+	// ReturnTypeReturn is a payment returned by the beneficiary bank, e.g. to a closed account
+	ReturnTypeReturn ReturnType = "return"
+	// ReturnTypeReversal is a payment reversed by us, e.g. a duplicate
+	ReturnTypeReversal ReturnType = "reversal"
+)
+
+// ReturnStatus is the state of a return or a reversal in its lifecycle
+type ReturnStatus string
+
+const (
+	// ReturnStatusPending returns are waiting for their settlement
+	ReturnStatusPending ReturnStatus = "pending"
+	// ReturnStatusCompleted returns were settled, their amount went back to the debtor
+	ReturnStatusCompleted ReturnStatus = "completed"
+	// ReturnStatusFailed returns could not be settled, their amount can be returned again
+	ReturnStatusFailed ReturnStatus = "failed"
+)
+
+// Return is a return or a reversal of all or part of a payment which has gone out, linked to the payment
+type Return struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	PaymentID uuid.UUID  `json:"payment_id" gorm:"type:uuid" sql:"index"`
+	Type      ReturnType `json:"type"`
+	// ReasonCode is the ISO 20022 reason code of the return, e.g. AC04 for a closed account
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason,omitempty"`
+	// Amount returned, in the currency of the payment, at most the amount of the payment not yet returned
+	Amount       string       `json:"amount" gorm:"type:numeric"`
+	Currency     string       `json:"currency"`
+	Status       ReturnStatus `json:"status"`
+	StatusReason string       `json:"status_reason,omitempty"`
+	CreatedBy    string       `json:"created_by,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// CreateReturnRequest represents a return or a reversal of a payment, the whole amount not yet returned when the
+// amount is empty
+type CreateReturnRequest struct {
+	PaymentID  string     `json:"-"`
+	Type       ReturnType `json:"-"`
+	UserID     string     `json:"-"`
+	Amount     string     `json:"amount,omitempty"`
+	ReasonCode string     `json:"reason_code"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+// CreateReturnResponse is the return or the reversal created
+type CreateReturnResponse struct {
+	Return
+	HateoasLink `json:"links"`
+}
+
+// GetReturnRequest is the request parameter used to retrieve a return or a reversal of a payment
+type GetReturnRequest struct {
+	PaymentID string
+	Type      ReturnType
+	ReturnID  string
+}
+
+// GetReturnResponse is the response object returned by the get return and get reversal endpoints
+type GetReturnResponse struct {
+	Return
+	HateoasLink `json:"links"`
+}
+
+// GetPaymentReturnsRequest is the request parameter used to retrieve the returns or the reversals of a payment
+type GetPaymentReturnsRequest struct {
+	PaymentID string
+	Type      ReturnType
+}
+
+// GetPaymentReturnsResponse is the response object returned by the list returns and list reversals endpoints
+type GetPaymentReturnsResponse struct {
+	Data []Return `json:"data"`
+}
+
+// UpdateReturnStatusRequest completes or fails a pending return or reversal
+type UpdateReturnStatusRequest struct {
+	PaymentID string       `json:"-"`
+	Type      ReturnType   `json:"-"`
+	ReturnID  string       `json:"-"`
+	Status    ReturnStatus `json:"-"`
+	Reason    string       `json:"reason"`
+	// Actor identifies who completes or fails the return and from where
+	Actor `json:"-"`
+}
+
+// UpdateReturnStatusResponse is the return or the reversal in its new status
+type UpdateReturnStatusResponse struct {
+	Return
+	HateoasLink `json:"links"`
+}
+
+// RecallStatus is the state of a recall request in its lifecycle
+type RecallStatus string
+
+const (
+	// RecallStatusRequested recalls wait for the decision of the beneficiary bank
+	RecallStatusRequested RecallStatus = "requested"
+	// RecallStatusAccepted recalls are accepted by the beneficiary bank, which returns the funds
+	RecallStatusAccepted RecallStatus = "accepted"
+	// RecallStatusRejected recalls are rejected by the beneficiary bank
+	RecallStatusRejected RecallStatus = "rejected"
+)
+
+// RecallDecision is the decision of the beneficiary bank on a recall request
+type RecallDecision string
+
+const (
+	// RecallAccept accepts the recall
+	RecallAccept RecallDecision = "accept"
+	// RecallReject rejects the recall
+	RecallReject RecallDecision = "reject"
+)
+
+// Recall asks the beneficiary bank to recall a payment sent in error, a payment has at most one recall requested
+type Recall struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	PaymentID uuid.UUID `json:"payment_id" gorm:"type:uuid" sql:"index"`
+	// ReasonCode is the ISO 20022 cancellation reason code of the recall, e.g. DUPL for a duplicate payment
+	ReasonCode  string       `json:"reason_code"`
+	Reason      string       `json:"reason,omitempty"`
+	Status      RecallStatus `json:"status"`
+	RequestedBy string       `json:"requested_by,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	// DecisionReason and DecidedAt are set by the decision of the beneficiary bank
+	DecisionReason string     `json:"decision_reason,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+}
+
+// CreateRecallRequest represents the recall request of a payment
+type CreateRecallRequest struct {
+	PaymentID  string `json:"-"`
+	UserID     string `json:"-"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// CreateRecallResponse is the recall requested
+type CreateRecallResponse struct {
+	Recall
+	HateoasLink `json:"links"`
+}
+
+// GetRecallRequest is the request parameter used to retrieve a recall of a payment
+type GetRecallRequest struct {
+	PaymentID string
+	RecallID  string
+}
+
+// GetRecallResponse is the response object returned by the get recall endpoint
+type GetRecallResponse struct {
+	Recall
+	HateoasLink `json:"links"`
+}
+
+// GetPaymentRecallsRequest is the request parameter used to retrieve the recalls of a payment
+type GetPaymentRecallsRequest struct {
+	PaymentID string
+}
+
+// GetPaymentRecallsResponse is the response object returned by the list recalls endpoint
+type GetPaymentRecallsResponse struct {
+	Data []Recall `json:"data"`
+}
+
+// DecideRecallRequest records the decision of the beneficiary bank on a requested recall
+type DecideRecallRequest struct {
+	PaymentID string         `json:"-"`
+	RecallID  string         `json:"-"`
+	Decision  RecallDecision `json:"-"`
+	Reason    string         `json:"reason"`
+	// Actor identifies who records the decision and from where
+	Actor `json:"-"`
+}
+
+// DecideRecallResponse is the recall in its new status
+type DecideRecallResponse struct {
+	Recall
+	HateoasLink `json:"links"`
+}
+
+// GetAccountBalanceRequest is the request parameter used to retrieve the balance of a ledger account
+type GetAccountBalanceRequest struct {
+	Account string
+}
+
+// GetAccountBalanceResponse is the balance of a ledger account in each currency of its entries
+type GetAccountBalanceResponse struct {
+	Account  string           `json:"account"`
+	Balances []ledger.Balance `json:"balances"`
+}
+
+// GetAccountEntriesRequest is the request parameter used to retrieve a page of the entries of a ledger account
+type GetAccountEntriesRequest struct {
+	Account  string
+	Page     int
+	PageSize int
+}
+
+// GetAccountEntriesResponse is a page of the entries of a ledger account, oldest first
+type GetAccountEntriesResponse struct {
+	Data []ledger.Entry `json:"data"`
+	Meta PageMeta       `json:"meta"`
+}
+
+// CheckLedgerRequest is the request of the invariant check of the ledger
+type CheckLedgerRequest struct{}
+
+// CheckLedgerResponse tells whether every ledger transaction sums to zero in each currency, and lists those which do not
+type CheckLedgerResponse struct {
+	Balanced   bool               `json:"balanced"`
+	Imbalances []ledger.Imbalance `json:"imbalances"`
+}
+
+// GetOrganisationLimitsRequest is the request parameter used to retrieve the limits of an organisation
+type GetOrganisationLimitsRequest struct {
+	OrganisationID string
+}
+
+// GetOrganisationLimitsResponse is the response object returned by the get organisation limits endpoint
+type GetOrganisationLimitsResponse struct {
+	OrganisationLimits
+}
+
+// UpdateOrganisationLimitsRequest is the request object passed to the update organisation limits endpoint,
+// the limits replace the current limits of the organisation
+type UpdateOrganisationLimitsRequest struct {
+	OrganisationID string
+	Limits         OrganisationLimits
+}
+
+// UpdateOrganisationLimitsResponse is the response object returned by the update organisation limits endpoint
+type UpdateOrganisationLimitsResponse struct {
+	OrganisationLimits
+}
+
+// DeleteOrganisationLimitsRequest is the request parameter used to remove the limits of an organisation
+type DeleteOrganisationLimitsRequest struct {
+	OrganisationID string
+}
+
+// DeleteOrganisationLimitsResponse is the response object returned by the delete organisation limits endpoint
+type DeleteOrganisationLimitsResponse struct {
+	OrganisationID string `json:"organisation_id"`
+}
+
+// HateoasLink represents the HATEOS links along with the response.
+// Links are filled in by the transport layer, the service only returns the resources.
 type HateoasLink struct {
-	Self string `json:"self"`
+	Self       string `json:"self"`
+	Collection string `json:"collection,omitempty"`
+	Update     string `json:"update,omitempty"`
+	Delete     string `json:"delete,omitempty"`
+	Payment    string `json:"payment,omitempty"`
+	Returns    string `json:"returns,omitempty"`
+	Reversals  string `json:"reversals,omitempty"`
+	Complete   string `json:"complete,omitempty"`
+	Fail       string `json:"fail,omitempty"`
+	Recalls    string `json:"recalls,omitempty"`
+	Audit      string `json:"audit,omitempty"`
+	Accept     string `json:"accept,omitempty"`
+	Reject     string `json:"reject,omitempty"`
+	First      string `json:"first,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	Next       string `json:"next,omitempty"`
 }
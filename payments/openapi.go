@@ -0,0 +1,746 @@
+package payments
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/currency"
+	"github.com/elkousy/payments-api/schemes"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+const (
+	openAPIPath    = "/v1/openapi.json"
+	docsPath       = "/v1/docs"
+	docsAssetsPath = "/v1/docs/assets/"
+)
+
+// swaggerUI holds the assets of the docs page, served by the API rather than a CDN
+//
+//go:embed data/swagger-ui/swagger-ui.css data/swagger-ui/swagger-ui-bundle.js
+var swaggerUI embed.FS
+
+// parameter describes a path, query or header parameter of an operation
+type parameter struct {
+	name        string
+	in          string
+	description string
+	schema      map[string]interface{}
+}
+
+// operation documents a route registered in MakeHTTPHandler.
+// Every route must have a matching operation, this is enforced by the tests.
+type operation struct {
+	method      string
+	path        string
+	id          string
+	summary     string
+	parameters  []parameter
+	requestBody interface{}
+	status      int
+	response    interface{}
+	// contentType of the response, application/json when empty
+	contentType string
+	errors      []apierrors.APIError
+}
+
+var paymentIDParameter = parameter{
+	name:        "id",
+	in:          "path",
+	description: "payment ID",
+	schema:      map[string]interface{}{"type": "string", "format": "uuid"},
+}
+
+var organisationIDParameter = parameter{
+	name:        "organisation_id",
+	in:          "path",
+	description: "organisation ID",
+	schema:      map[string]interface{}{"type": "string", "format": "uuid"},
+}
+
+var accountParameter = parameter{
+	name:        "account",
+	in:          "path",
+	description: "ledger account, party:<bank_id>:<account_number> or charges:<bank_id>",
+	schema:      map[string]interface{}{"type": "string"},
+}
+
+var userIDParameter = parameter{
+	name:        userIDHeader,
+	in:          "header",
+	description: "user creating or approving the payment, required to create a payment above the approval threshold of its organisation",
+	schema:      map[string]interface{}{"type": "string"},
+}
+
+var operations = append([]operation{
+	{
+		method:  http.MethodGet,
+		path:    "/v1/payments/",
+		id:      "getListOfPayments",
+		summary: "List payments",
+		parameters: []parameter{
+			{name: "page", in: "query", description: "page number, starting at 1", schema: map[string]interface{}{"type": "integer", "minimum": 1, "default": 1}},
+			{name: "page_size", in: "query", description: "number of payments per page", schema: map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxPageSize, "default": defaultPageSize}},
+			{name: "include", in: "query", description: "deleted lists the deleted payments too, flagged as deleted", schema: map[string]interface{}{"type": "string", "enum": []string{"deleted"}}},
+			{name: "account_number", in: "query", description: "lists the payments of a debtor or beneficiary account number only", schema: map[string]interface{}{"type": "string"}},
+		},
+		status:   http.StatusOK,
+		response: GetListOfPaymentsResponse{},
+		errors:   []apierrors.APIError{ErrInvalidPagination, ErrInvalidInclude, ErrInternalServer},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/v1/payments/",
+		id:      "postPayment",
+		summary: "Create a payment",
+		parameters: []parameter{
+			{name: idempotencyKeyHeader, in: "header", description: "key identifying retries of the same creation, scoped by organisation", schema: map[string]interface{}{"type": "string", "maxLength": maxIdempotencyKeyLength}},
+			userIDParameter,
+		},
+		requestBody: Payment{},
+		status:      http.StatusCreated,
+		response:    CreatePaymentResponse{},
+		errors:      []apierrors.APIError{ErrInvalidIdempotencyKey, ErrInvalidBody, ErrInvalidPaymentPayload, ErrSchemeRulesViolation, ErrInvalidProcessingDate, ErrLimitExceeded, ErrMissingUserID, ErrDuplicatePayment, ErrInternalServer},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/v1/payments/events/",
+		id:      "streamPaymentEvents",
+		summary: "Stream the payment events as Server-Sent Events, a reset event asks the client to reload the payments when the stream cannot be resumed",
+		parameters: []parameter{
+			{name: "organisation_id", in: "query", description: "only stream the events of this organisation, that of the caller unless it is an administrator", schema: map[string]interface{}{"type": "string", "format": "uuid"}},
+			{name: "type", in: "query", description: "comma separated event types to stream", schema: map[string]interface{}{"type": "string", "enum": []EventType{EventPaymentCreated, EventPaymentUpdated, EventPaymentDeleted, EventPaymentRestored, EventPaymentStateChanged}}},
+			{name: lastEventIDHeader, in: "header", description: "resume the stream after this event", schema: map[string]interface{}{"type": "integer", "minimum": 1}},
+			{name: organisationHeader, in: "header", description: "organisation of the caller, set by the gateway", schema: map[string]interface{}{"type": "string", "format": "uuid"}},
+		},
+		status:      http.StatusOK,
+		response:    Event{},
+		contentType: "text/event-stream",
+		errors:      []apierrors.APIError{ErrInvalidEventFilter, ErrInvalidLastEventID, ErrEventsForbidden, ErrInternalServer},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/v1/payments/{id}/",
+		id:      "getPayment",
+		summary: "Get a payment, or one of its previous versions",
+		parameters: []parameter{
+			paymentIDParameter,
+			{name: "version", in: "query", description: "return this version of the payment", schema: map[string]interface{}{"type": "integer", "minimum": 0}},
+			{name: "as_of", in: "query", description: "return the version of the payment current at this time, exclusive with version", schema: map[string]interface{}{"type": "string", "format": "date-time"}},
+		},
+		status:   http.StatusOK,
+		response: GetPaymentResponse{},
+		errors:   []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidPaymentVersion, ErrNotFound, ErrVersionNotFound, ErrInternalServer},
+	},
+	{
+		method:      http.MethodPut,
+		path:        "/v1/payments/{id}/",
+		id:          "updatePayment",
+		summary:     "Update a payment",
+		parameters:  []parameter{paymentIDParameter},
+		requestBody: Payment{},
+		status:      http.StatusAccepted,
+		errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrInvalidPaymentPayload, ErrSchemeRulesViolation, ErrInvalidProcessingDate, ErrLimitExceeded, ErrNotFound, ErrPaymentNotUpdatable, ErrInternalServer},
+	},
+	{
+		method:     http.MethodDelete,
+		path:       "/v1/payments/{id}/",
+		id:         "deletePayment",
+		summary:    "Delete a payment",
+		parameters: []parameter{paymentIDParameter},
+		status:     http.StatusAccepted,
+		errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrInternalServer},
+	},
+	{
+		method:     http.MethodPost,
+		path:       "/v1/payments/{id}/restore/",
+		id:         "restorePayment",
+		summary:    "Restore a deleted payment, until it is purged at the end of the retention period",
+		parameters: []parameter{paymentIDParameter},
+		status:     http.StatusOK,
+		response:   RestorePaymentResponse{},
+		errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrPaymentNotDeleted, ErrLimitExceeded, ErrInternalServer},
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/payments/{id}/release/",
+		id:          "releasePayment",
+		summary:     "Release a payment held for review, it is submitted",
+		parameters:  []parameter{paymentIDParameter},
+		requestBody: ReviewPaymentRequest{},
+		status:      http.StatusOK,
+		response:    ReviewPaymentResponse{},
+		errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrInvalidReviewDecision, ErrNotFound, ErrPaymentNotHeldForReview, ErrInternalServer},
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/payments/{id}/reject/",
+		id:          "rejectPayment",
+		summary:     "Reject a payment held for review",
+		parameters:  []parameter{paymentIDParameter},
+		requestBody: ReviewPaymentRequest{},
+		status:      http.StatusOK,
+		response:    ReviewPaymentResponse{},
+		errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrInvalidReviewDecision, ErrNotFound, ErrPaymentNotHeldForReview, ErrInternalServer},
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/payments/{id}/approvals/",
+		id:          "approvePayment",
+		summary:     "Approve or reject a payment pending approval, the creator of the payment cannot approve it",
+		parameters:  []parameter{paymentIDParameter, userIDParameter},
+		requestBody: ApprovePaymentRequest{},
+		status:      http.StatusCreated,
+		response:    ApprovePaymentResponse{},
+		errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrMissingUserID, ErrInvalidApprovalDecision, ErrSelfApproval, ErrNotFound, ErrPaymentNotPendingApproval, ErrInternalServer},
+	},
+	{
+		method:     http.MethodGet,
+		path:       "/v1/payments/{id}/approvals/",
+		id:         "getPaymentApprovals",
+		summary:    "List the approvals of a payment, oldest first",
+		parameters: []parameter{paymentIDParameter},
+		status:     http.StatusOK,
+		response:   GetPaymentApprovalsResponse{},
+		errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrInternalServer},
+	},
+	{
+		method:     http.MethodGet,
+		path:       "/v1/payments/{id}/audit/",
+		id:         "getPaymentAudit",
+		summary:    "List the audit log of a payment, oldest first, deleted payments included",
+		parameters: []parameter{paymentIDParameter},
+		status:     http.StatusOK,
+		response:   GetPaymentAuditResponse{},
+		errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrInternalServer},
+	},
+	{
+		method:     http.MethodGet,
+		path:       "/v1/admin/organisations/{organisation_id}/limits/",
+		id:         "getOrganisationLimits",
+		summary:    "Get the payment limits of an organisation",
+		parameters: []parameter{organisationIDParameter},
+		status:     http.StatusOK,
+		response:   GetOrganisationLimitsResponse{},
+		errors:     []apierrors.APIError{ErrInvalidOrganisationID, ErrLimitsNotFound, ErrInternalServer},
+	},
+	{
+		method:     http.MethodGet,
+		path:       "/v1/ledger/accounts/{account}/balance/",
+		id:         "getLedgerAccountBalance",
+		summary:    "Get the balance of a ledger account in each currency of its entries: the credits less the debits",
+		parameters: []parameter{accountParameter},
+		status:     http.StatusOK,
+		response:   GetAccountBalanceResponse{},
+		errors:     []apierrors.APIError{ErrInvalidAccount, ErrAccountNotFound, ErrInternalServer},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/v1/ledger/accounts/{account}/entries/",
+		id:      "getLedgerAccountEntries",
+		summary: "List the entries of a ledger account, oldest first",
+		parameters: []parameter{
+			accountParameter,
+			{name: "page", in: "query", description: "page number, starting at 1", schema: map[string]interface{}{"type": "integer", "minimum": 1, "default": 1}},
+			{name: "page_size", in: "query", description: "number of entries per page", schema: map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxPageSize, "default": defaultPageSize}},
+		},
+		status:   http.StatusOK,
+		response: GetAccountEntriesResponse{},
+		errors:   []apierrors.APIError{ErrInvalidAccount, ErrInvalidPagination, ErrInternalServer},
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/v1/ledger/check/",
+		id:       "checkLedger",
+		summary:  "Check every ledger transaction sums to zero in each currency, list the transactions which do not",
+		status:   http.StatusOK,
+		response: CheckLedgerResponse{},
+		errors:   []apierrors.APIError{ErrInternalServer},
+	},
+	{
+		method:      http.MethodPut,
+		path:        "/v1/admin/organisations/{organisation_id}/limits/",
+		id:          "updateOrganisationLimits",
+		summary:     "Replace the payment limits of an organisation",
+		parameters:  []parameter{organisationIDParameter},
+		requestBody: OrganisationLimits{},
+		status:      http.StatusOK,
+		response:    UpdateOrganisationLimitsResponse{},
+		errors:      []apierrors.APIError{ErrInvalidOrganisationID, ErrInvalidBody, ErrLimitsForbidden, ErrInvalidLimits, ErrInternalServer},
+	},
+	{
+		method:     http.MethodDelete,
+		path:       "/v1/admin/organisations/{organisation_id}/limits/",
+		id:         "deleteOrganisationLimits",
+		summary:    "Remove the payment limits of an organisation",
+		parameters: []parameter{organisationIDParameter},
+		status:     http.StatusAccepted,
+		errors:     []apierrors.APIError{ErrInvalidOrganisationID, ErrLimitsForbidden, ErrLimitsNotFound, ErrInternalServer},
+	},
+	{
+		method:   http.MethodGet,
+		path:     currenciesPath,
+		id:       "getCurrencies",
+		summary:  "List the ISO 4217 currencies accepted in the payments, with their minor units",
+		status:   http.StatusOK,
+		response: CurrenciesResponse{},
+	},
+	{
+		method:  http.MethodGet,
+		path:    calendarsPath,
+		id:      "getCalendar",
+		summary: "Get the business-day calendar of a payment scheme: its holidays, cut-off time and next processing date",
+		parameters: []parameter{
+			{name: "scheme", in: "path", description: "payment scheme", schema: map[string]interface{}{"type": "string", "enum": []string{"FPS", "Bacs", "SEPA"}}},
+			{name: "year", in: "query", description: "only return the holidays of this year", schema: map[string]interface{}{"type": "integer"}},
+		},
+		status:   http.StatusOK,
+		response: CalendarResponse{},
+		errors:   []apierrors.APIError{ErrCalendarNotFound, ErrInvalidCalendarYear},
+	},
+}, append(append(returnsOperations(ReturnTypeReturn), returnsOperations(ReturnTypeReversal)...), recallsOperations()...)...)
+
+var returnIDParameter = parameter{
+	name:        "return_id",
+	in:          "path",
+	description: "return or reversal ID",
+	schema:      map[string]interface{}{"type": "string", "format": "uuid"},
+}
+
+// returnsOperations documents the routes of the returns or of the reversals of the payments
+func returnsOperations(t ReturnType) []operation {
+	collection := "/v1/payments/{id}/" + returnsSegments[t] + "/"
+	name := map[ReturnType]string{ReturnTypeReturn: "Return", ReturnTypeReversal: "Reversal"}[t]
+	return []operation{
+		{
+			method:      http.MethodPost,
+			path:        collection,
+			id:          "createPayment" + name,
+			summary:     fmt.Sprintf("Create a %s of all or part of a payment which has gone out, of the whole amount not yet returned when the amount is empty", t),
+			parameters:  []parameter{paymentIDParameter, userIDParameter},
+			requestBody: CreateReturnRequest{},
+			status:      http.StatusCreated,
+			response:    CreateReturnResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrInvalidReturn, ErrNotFound, ErrPaymentNotReturnable, ErrReturnAmountExceeded, ErrInternalServer},
+		},
+		{
+			method:     http.MethodGet,
+			path:       collection,
+			id:         "getPayment" + name + "s",
+			summary:    fmt.Sprintf("List the %ss of a payment, oldest first", t),
+			parameters: []parameter{paymentIDParameter},
+			status:     http.StatusOK,
+			response:   GetPaymentReturnsResponse{},
+			errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrInternalServer},
+		},
+		{
+			method:     http.MethodGet,
+			path:       collection + "{return_id}/",
+			id:         "getPayment" + name,
+			summary:    fmt.Sprintf("Get a %s of a payment", t),
+			parameters: []parameter{paymentIDParameter, returnIDParameter},
+			status:     http.StatusOK,
+			response:   GetReturnResponse{},
+			errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidReturnID, ErrReturnNotFound, ErrInternalServer},
+		},
+		{
+			method:      http.MethodPost,
+			path:        collection + "{return_id}/complete/",
+			id:          "completePayment" + name,
+			summary:     fmt.Sprintf("Complete a pending %s once settled", t),
+			parameters:  []parameter{paymentIDParameter, returnIDParameter},
+			requestBody: UpdateReturnStatusRequest{},
+			status:      http.StatusOK,
+			response:    UpdateReturnStatusResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidReturnID, ErrInvalidBody, ErrReturnNotFound, ErrReturnNotPending, ErrInternalServer},
+		},
+		{
+			method:      http.MethodPost,
+			path:        collection + "{return_id}/fail/",
+			id:          "failPayment" + name,
+			summary:     fmt.Sprintf("Fail a pending %s, its amount can be returned again", t),
+			parameters:  []parameter{paymentIDParameter, returnIDParameter},
+			requestBody: UpdateReturnStatusRequest{},
+			status:      http.StatusOK,
+			response:    UpdateReturnStatusResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidReturnID, ErrInvalidBody, ErrReturnNotFound, ErrReturnNotPending, ErrInternalServer},
+		},
+	}
+}
+
+// specDescription tells the validation rules of the payments the schemas cannot express
+const specDescription = "The schemas document the formats of the fields. The rules depending on several fields are only " +
+	"checked by the API: the account numbers and bank ids according to their codes, the amounts precision according to " +
+	"their currency, the payment against the rules of its scheme and the fx details against the amount."
+
+// openAPISpec generates the OpenAPI 3 document of the payments API.
+// Schemas are generated from the model and its validation tags.
+func openAPISpec(serverURL string) (map[string]interface{}, error) {
+	g := &schemaGenerator{components: map[string]interface{}{}}
+
+	paths := map[string]interface{}{}
+	for _, op := range operations {
+		o, err := g.operation(op)
+		if err != nil {
+			return nil, err
+		}
+		item, ok := paths[op.path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = o
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Payments API",
+			"version":     "1.0.0",
+			"description": specDescription,
+		},
+		"servers":    []interface{}{map[string]interface{}{"url": serverURL}},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": g.components},
+	}, nil
+}
+
+func (g *schemaGenerator) operation(op operation) (map[string]interface{}, error) {
+	o := map[string]interface{}{
+		"operationId": op.id,
+		"summary":     op.summary,
+	}
+
+	if len(op.parameters) > 0 {
+		params := []interface{}{}
+		for _, p := range op.parameters {
+			params = append(params, map[string]interface{}{
+				"name":        p.name,
+				"in":          p.in,
+				"description": p.description,
+				"required":    p.in == "path",
+				"schema":      p.schema,
+			})
+		}
+		o["parameters"] = params
+	}
+
+	if op.requestBody != nil {
+		schema, err := g.schemaOf(reflect.TypeOf(op.requestBody))
+		if err != nil {
+			return nil, err
+		}
+		o["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}},
+		}
+	}
+
+	responses := map[string]interface{}{}
+	success := map[string]interface{}{"description": http.StatusText(op.status)}
+	if op.response != nil {
+		schema, err := g.schemaOf(reflect.TypeOf(op.response))
+		if err != nil {
+			return nil, err
+		}
+		contentType := op.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		success["content"] = map[string]interface{}{contentType: map[string]interface{}{"schema": schema}}
+	}
+	responses[fmt.Sprint(op.status)] = success
+
+	// several errors can share the same status code
+	messages := map[int][]string{}
+	for _, e := range op.errors {
+		messages[e.ResponseCode] = append(messages[e.ResponseCode], e.Message)
+	}
+	errorSchema, err := g.schemaOf(reflect.TypeOf(apierrors.APIError{}))
+	if err != nil {
+		return nil, err
+	}
+	for code, msgs := range messages {
+		sort.Strings(msgs)
+		responses[fmt.Sprint(code)] = map[string]interface{}{
+			"description": strings.Join(msgs, ", "),
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": errorSchema}},
+		}
+	}
+	o["responses"] = responses
+
+	return o, nil
+}
+
+// schemaGenerator generates JSON schemas from go types, named structs are added to the components
+type schemaGenerator struct {
+	components map[string]interface{}
+}
+
+var (
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+func (g *schemaGenerator) schemaOf(t reflect.Type) (map[string]interface{}, error) {
+	switch t {
+	case uuidType:
+		return map[string]interface{}{"type": "string", "format": "uuid"}, nil
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaOf(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		// any json value
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+		if _, ok := g.components[t.Name()]; ok {
+			return ref, nil
+		}
+		// register the component before walking the fields to support recursive types
+		g.components[t.Name()] = nil
+		schema, err := g.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		g.components[t.Name()] = schema
+		return ref, nil
+	}
+
+	return nil, fmt.Errorf("openapi: unsupported type %s", t)
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	required := []string{}
+	if err := g.collectFields(t, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// collectFields walks the json fields of a struct, embedded structs without json name are flattened
+func (g *schemaGenerator) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			if err := g.collectFields(f.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		schema, err := g.schemaOf(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %v", t.Name(), f.Name, err)
+		}
+		for k, v := range fieldConstraints(t.Name() + "." + name) {
+			schema[k] = v
+		}
+		properties[name] = schema
+
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				*required = append(*required, name)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldConstraints returns the formats of a field, named after its struct and its json name, checked by the validation
+// of the payments and of the limits. They are added to the schema of the field.
+func fieldConstraints(field string) map[string]interface{} {
+	amount := map[string]interface{}{
+		"pattern":     currency.AmountPattern,
+		"description": "decimal amount, with at most the minor units of its currency as decimals",
+	}
+	currencies := []string{}
+	for _, c := range currency.All() {
+		currencies = append(currencies, c.Code)
+	}
+	code := map[string]interface{}{"enum": currencies, "description": "ISO 4217 currency code"}
+
+	switch field {
+	case "Attributes.amount", "ChargesInformation.receiver_charges_amount", "Charge.amount", "Forex.original_amount",
+		"CurrencyLimit.max_amount", "CurrencyLimit.daily_total", "CurrencyLimit.monthly_total", "CurrencyLimit.approval_threshold":
+		return amount
+	case "Attributes.currency", "ChargesInformation.receiver_charges_currency", "Charge.currency", "Forex.original_currency",
+		"CurrencyLimit.currency":
+		return code
+	case "Attributes.payment_scheme":
+		return map[string]interface{}{"enum": schemes.Schemes()}
+	case "Attributes.processing_date":
+		return map[string]interface{}{"format": "date"}
+	case "ChargesInformation.bearer_code":
+		return map[string]interface{}{"enum": []string{string(charges.BearerShared), string(charges.BearerOurs), string(charges.BearerBeneficiary)}}
+	case "DebtorParty.account_number_code":
+		return map[string]interface{}{"description": "IBAN or BBAN, the account number is validated accordingly"}
+	case "SponsorParty.account_number":
+		return map[string]interface{}{"description": "an IBAN, with its check digits, or a BBAN of up to 30 letters and digits, as per account_number_code"}
+	case "SponsorParty.bank_id":
+		return map[string]interface{}{"description": "a UK sort code passing the modulus checks when bank_id_code is GBDSC, a BIC when it is SWBIC"}
+	}
+	return nil
+}
+
+// serveOpenAPISpec serves the OpenAPI document, the server URL is derived from the request
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec(baseURL(r))
+	if err != nil {
+		apierrors.LoggingErrorEncoder(r.Context(), ErrInternalServer.FromError(err), w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(spec)
+}
+
+// serveDocs serves a swagger UI page rendering the OpenAPI document
+func serveDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	base := baseURL(r)
+	fmt.Fprintf(w, docsPage, base+docsAssetsPath, base+openAPIPath)
+}
+
+// docsAssetsHandler serves the swagger UI assets bundled with the API
+func docsAssetsHandler() http.Handler {
+	assets, err := fs.Sub(swaggerUI, "data/swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix(docsAssetsPath, http.FileServer(http.FS(assets)))
+}
+
+const docsPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Payments API</title>
+  <meta charset="utf-8"/>
+  <link rel="stylesheet" href="%[1]sswagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%[1]sswagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: %[2]q, dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`
+
+var recallIDParameter = parameter{
+	name:        "recall_id",
+	in:          "path",
+	description: "recall ID",
+	schema:      map[string]interface{}{"type": "string", "format": "uuid"},
+}
+
+// recallsOperations documents the routes of the recalls of the payments
+func recallsOperations() []operation {
+	collection := "/v1/payments/{id}/recalls/"
+	return []operation{
+		{
+			method:      http.MethodPost,
+			path:        collection,
+			id:          "createPaymentRecall",
+			summary:     "Request the recall of a payment which has gone out, within the recall window after its processing date",
+			parameters:  []parameter{paymentIDParameter, userIDParameter},
+			requestBody: CreateRecallRequest{},
+			status:      http.StatusCreated,
+			response:    CreateRecallResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidBody, ErrInvalidRecall, ErrNotFound, ErrRecallNotEligible, ErrRecallAlreadyRequested, ErrInternalServer},
+		},
+		{
+			method:     http.MethodGet,
+			path:       collection,
+			id:         "getPaymentRecalls",
+			summary:    "List the recalls of a payment, oldest first",
+			parameters: []parameter{paymentIDParameter},
+			status:     http.StatusOK,
+			response:   GetPaymentRecallsResponse{},
+			errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrNotFound, ErrInternalServer},
+		},
+		{
+			method:     http.MethodGet,
+			path:       collection + "{recall_id}/",
+			id:         "getPaymentRecall",
+			summary:    "Get a recall of a payment",
+			parameters: []parameter{paymentIDParameter, recallIDParameter},
+			status:     http.StatusOK,
+			response:   GetRecallResponse{},
+			errors:     []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidRecallID, ErrRecallNotFound, ErrInternalServer},
+		},
+		{
+			method:      http.MethodPost,
+			path:        collection + "{recall_id}/accept/",
+			id:          "acceptPaymentRecall",
+			summary:     "Accept a requested recall, the funds are then returned with the FOCR reason code",
+			parameters:  []parameter{paymentIDParameter, recallIDParameter},
+			requestBody: DecideRecallRequest{},
+			status:      http.StatusOK,
+			response:    DecideRecallResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidRecallID, ErrInvalidBody, ErrRecallNotFound, ErrRecallNotRequested, ErrInternalServer},
+		},
+		{
+			method:      http.MethodPost,
+			path:        collection + "{recall_id}/reject/",
+			id:          "rejectPaymentRecall",
+			summary:     "Reject a requested recall",
+			parameters:  []parameter{paymentIDParameter, recallIDParameter},
+			requestBody: DecideRecallRequest{},
+			status:      http.StatusOK,
+			response:    DecideRecallResponse{},
+			errors:      []apierrors.APIError{ErrInvalidPaymentID, ErrInvalidRecallID, ErrInvalidBody, ErrRecallNotFound, ErrRecallNotRequested, ErrInternalServer},
+		},
+	}
+}
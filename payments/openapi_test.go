@@ -0,0 +1,196 @@
+package payments
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_openAPISpec_Routes fails when a route registered in MakeHTTPHandler is not documented or the other way around
+func Test_openAPISpec_Routes(t *testing.T) {
+	// Arrange
+	router := mux.NewRouter()
+	MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+
+	registered := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || path == openAPIPath || path == docsPath || path == docsAssetsPath {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, m := range methods {
+			registered[m+" "+path] = true
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	spec, err := openAPISpec("")
+	require.NoError(t, err)
+
+	// Assert
+	documented := map[string]bool{}
+	for path, item := range spec["paths"].(map[string]interface{}) {
+		for method := range item.(map[string]interface{}) {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	assert.Equal(t, registered, documented)
+}
+
+// Test_openAPISpec_Errors fails when an APIError declared in errors.go is not documented by any operation
+func Test_openAPISpec_Errors(t *testing.T) {
+	// Arrange
+	file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	require.NoError(t, err)
+
+	declared := map[string]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Message" {
+			if lit, ok := kv.Value.(*ast.BasicLit); ok {
+				msg, _ := strconv.Unquote(lit.Value)
+				declared[msg] = msg
+			}
+		}
+		return false
+	})
+	require.NotEmpty(t, declared)
+
+	documented := map[string]string{}
+	for _, op := range operations {
+		for _, e := range op.errors {
+			documented[e.Message] = e.Message
+		}
+	}
+
+	// Act & Assert
+	assert.Equal(t, declared, documented)
+}
+
+// Test_openAPISpec_Payment fails when a field of the payment model is not described by the generated schema
+func Test_openAPISpec_Payment(t *testing.T) {
+	// Arrange
+	var payload map[string]interface{}
+	b, err := json.Marshal(mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &payload))
+
+	// Act
+	spec, err := openAPISpec("")
+	require.NoError(t, err)
+
+	// Assert
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assertDocumented(t, schemas, "Payment", payload)
+}
+
+func Test_openAPISpec_Constraints(t *testing.T) {
+	// Act
+	spec, err := openAPISpec("")
+	require.NoError(t, err)
+
+	// Assert
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	property := func(schema, name string) map[string]interface{} {
+		return schemas[schema].(map[string]interface{})["properties"].(map[string]interface{})[name].(map[string]interface{})
+	}
+	assert.Contains(t, property("Attributes", "currency")["enum"], "GBP")
+	assert.Equal(t, `^\d+(\.\d+)?$`, property("Attributes", "amount")["pattern"])
+	assert.Equal(t, "date", property("Attributes", "processing_date")["format"])
+	assert.Contains(t, property("Attributes", "payment_scheme")["enum"], "FPS")
+	assert.Equal(t, []string{"SHAR", "OUR", "BEN"}, property("ChargesInformation", "bearer_code")["enum"])
+	assert.Equal(t, `^\d+(\.\d+)?$`, property("CurrencyLimit", "daily_total")["pattern"])
+	assert.NotEmpty(t, spec["info"].(map[string]interface{})["description"])
+}
+
+// assertDocumented checks recursively that every field of the json document is a property of the schema
+func assertDocumented(t *testing.T, schemas map[string]interface{}, name string, doc map[string]interface{}) {
+	schema := schemas[name].(map[string]interface{})
+	properties := schema["properties"].(map[string]interface{})
+	for field, value := range doc {
+		property, ok := properties[field].(map[string]interface{})
+		if !assert.True(t, ok, "%s.%s is not documented", name, field) {
+			continue
+		}
+		if ref, ok := property["$ref"].(string); ok {
+			if nested, ok := value.(map[string]interface{}); ok {
+				assertDocumented(t, schemas, strings.TrimPrefix(ref, "#/components/schemas/"), nested)
+			}
+		}
+	}
+	required, _ := schema["required"].([]string)
+	for _, field := range required {
+		_, ok := doc[field]
+		assert.True(t, ok, "%s.%s is required but not part of the model", name, field)
+	}
+}
+
+func Test_serveOpenAPISpec(t *testing.T) {
+	// Arrange
+	router := mux.NewRouter()
+	MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/v1/openapi.json", nil)
+
+	// Act
+	router.ServeHTTP(rr, r)
+
+	// Assert
+	var spec map[string]interface{}
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "http://example.com"}}, spec["servers"])
+}
+
+func Test_serveDocs(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/v1/docs", nil)
+
+	// Act
+	serveDocs(rr, r)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"http://example.com/v1/openapi.json"`)
+	assert.Contains(t, rr.Body.String(), `src="http://example.com/v1/docs/assets/swagger-ui-bundle.js"`)
+	assert.NotContains(t, rr.Body.String(), "unpkg.com")
+}
+
+func Test_docsAssets(t *testing.T) {
+	// Arrange
+	router := mux.NewRouter()
+	MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+
+	for _, asset := range []string{"swagger-ui.css", "swagger-ui-bundle.js"} {
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/v1/docs/assets/"+asset, nil)
+
+		// Act
+		router.ServeHTTP(rr, r)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code, asset)
+		assert.NotEmpty(t, rr.Body.Bytes(), asset)
+	}
+}
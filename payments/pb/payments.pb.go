@@ -0,0 +1,4785 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: payments.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Payment reprensents a payment resource
+type Payment struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Version          uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	OrganisationId   string                 `protobuf:"bytes,4,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	Attributes       *Attributes            `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	StatusReason     string                 `protobuf:"bytes,7,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	ScreeningHits    []*ScreeningHit        `protobuf:"bytes,8,rep,name=screening_hits,json=screeningHits,proto3" json:"screening_hits,omitempty"`
+	CreatedBy        string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ApprovalRequired bool                   `protobuf:"varint,10,opt,name=approval_required,json=approvalRequired,proto3" json:"approval_required,omitempty"`
+	// deleted is set on the deleted payments listed with include_deleted
+	Deleted       bool `protobuf:"varint,11,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_payments_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Payment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Payment) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Payment) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Payment) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+func (x *Payment) GetAttributes() *Attributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Payment) GetScreeningHits() []*ScreeningHit {
+	if x != nil {
+		return x.ScreeningHits
+	}
+	return nil
+}
+
+func (x *Payment) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Payment) GetApprovalRequired() bool {
+	if x != nil {
+		return x.ApprovalRequired
+	}
+	return false
+}
+
+func (x *Payment) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// ScreeningHit is an entry of the sanctions lists matching a party of the payment
+type ScreeningHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Party         string                 `protobuf:"bytes,1,opt,name=party,proto3" json:"party,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	EntryUid      string                 `protobuf:"bytes,3,opt,name=entry_uid,json=entryUid,proto3" json:"entry_uid,omitempty"`
+	EntryName     string                 `protobuf:"bytes,4,opt,name=entry_name,json=entryName,proto3" json:"entry_name,omitempty"`
+	Matched       string                 `protobuf:"bytes,5,opt,name=matched,proto3" json:"matched,omitempty"`
+	Score         float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScreeningHit) Reset() {
+	*x = ScreeningHit{}
+	mi := &file_payments_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScreeningHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScreeningHit) ProtoMessage() {}
+
+func (x *ScreeningHit) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScreeningHit.ProtoReflect.Descriptor instead.
+func (*ScreeningHit) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScreeningHit) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetEntryUid() string {
+	if x != nil {
+		return x.EntryUid
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetEntryName() string {
+	if x != nil {
+		return x.EntryName
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetMatched() string {
+	if x != nil {
+		return x.Matched
+	}
+	return ""
+}
+
+func (x *ScreeningHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type Attributes struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Amount               string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	BeneficiaryParty     *BeneficiaryParty      `protobuf:"bytes,2,opt,name=beneficiary_party,json=beneficiaryParty,proto3" json:"beneficiary_party,omitempty"`
+	ChargesInformation   *ChargesInformation    `protobuf:"bytes,3,opt,name=charges_information,json=chargesInformation,proto3" json:"charges_information,omitempty"`
+	Currency             string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	DebtorParty          *DebtorParty           `protobuf:"bytes,5,opt,name=debtor_party,json=debtorParty,proto3" json:"debtor_party,omitempty"`
+	EndToEndReference    string                 `protobuf:"bytes,6,opt,name=end_to_end_reference,json=endToEndReference,proto3" json:"end_to_end_reference,omitempty"`
+	Fx                   *Forex                 `protobuf:"bytes,7,opt,name=fx,proto3" json:"fx,omitempty"`
+	NumericReference     string                 `protobuf:"bytes,8,opt,name=numeric_reference,json=numericReference,proto3" json:"numeric_reference,omitempty"`
+	PaymentId            string                 `protobuf:"bytes,9,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	PaymentPurpose       string                 `protobuf:"bytes,10,opt,name=payment_purpose,json=paymentPurpose,proto3" json:"payment_purpose,omitempty"`
+	PaymentScheme        string                 `protobuf:"bytes,11,opt,name=payment_scheme,json=paymentScheme,proto3" json:"payment_scheme,omitempty"`
+	PaymentType          string                 `protobuf:"bytes,12,opt,name=payment_type,json=paymentType,proto3" json:"payment_type,omitempty"`
+	ProcessingDate       string                 `protobuf:"bytes,13,opt,name=processing_date,json=processingDate,proto3" json:"processing_date,omitempty"`
+	Reference            string                 `protobuf:"bytes,14,opt,name=reference,proto3" json:"reference,omitempty"`
+	SchemePaymentSubType string                 `protobuf:"bytes,15,opt,name=scheme_payment_sub_type,json=schemePaymentSubType,proto3" json:"scheme_payment_sub_type,omitempty"`
+	SchemePaymentType    string                 `protobuf:"bytes,16,opt,name=scheme_payment_type,json=schemePaymentType,proto3" json:"scheme_payment_type,omitempty"`
+	SponsorParty         *SponsorParty          `protobuf:"bytes,17,opt,name=sponsor_party,json=sponsorParty,proto3" json:"sponsor_party,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Attributes) Reset() {
+	*x = Attributes{}
+	mi := &file_payments_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attributes) ProtoMessage() {}
+
+func (x *Attributes) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attributes.ProtoReflect.Descriptor instead.
+func (*Attributes) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attributes) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Attributes) GetBeneficiaryParty() *BeneficiaryParty {
+	if x != nil {
+		return x.BeneficiaryParty
+	}
+	return nil
+}
+
+func (x *Attributes) GetChargesInformation() *ChargesInformation {
+	if x != nil {
+		return x.ChargesInformation
+	}
+	return nil
+}
+
+func (x *Attributes) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Attributes) GetDebtorParty() *DebtorParty {
+	if x != nil {
+		return x.DebtorParty
+	}
+	return nil
+}
+
+func (x *Attributes) GetEndToEndReference() string {
+	if x != nil {
+		return x.EndToEndReference
+	}
+	return ""
+}
+
+func (x *Attributes) GetFx() *Forex {
+	if x != nil {
+		return x.Fx
+	}
+	return nil
+}
+
+func (x *Attributes) GetNumericReference() string {
+	if x != nil {
+		return x.NumericReference
+	}
+	return ""
+}
+
+func (x *Attributes) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Attributes) GetPaymentPurpose() string {
+	if x != nil {
+		return x.PaymentPurpose
+	}
+	return ""
+}
+
+func (x *Attributes) GetPaymentScheme() string {
+	if x != nil {
+		return x.PaymentScheme
+	}
+	return ""
+}
+
+func (x *Attributes) GetPaymentType() string {
+	if x != nil {
+		return x.PaymentType
+	}
+	return ""
+}
+
+func (x *Attributes) GetProcessingDate() string {
+	if x != nil {
+		return x.ProcessingDate
+	}
+	return ""
+}
+
+func (x *Attributes) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Attributes) GetSchemePaymentSubType() string {
+	if x != nil {
+		return x.SchemePaymentSubType
+	}
+	return ""
+}
+
+func (x *Attributes) GetSchemePaymentType() string {
+	if x != nil {
+		return x.SchemePaymentType
+	}
+	return ""
+}
+
+func (x *Attributes) GetSponsorParty() *SponsorParty {
+	if x != nil {
+		return x.SponsorParty
+	}
+	return nil
+}
+
+type BeneficiaryParty struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AccountName       string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	AccountNumber     string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountNumberCode string                 `protobuf:"bytes,3,opt,name=account_number_code,json=accountNumberCode,proto3" json:"account_number_code,omitempty"`
+	AccountType       int32                  `protobuf:"varint,4,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	Address           string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	BankId            string                 `protobuf:"bytes,6,opt,name=bank_id,json=bankId,proto3" json:"bank_id,omitempty"`
+	BankIdCode        string                 `protobuf:"bytes,7,opt,name=bank_id_code,json=bankIdCode,proto3" json:"bank_id_code,omitempty"`
+	Name              string                 `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BeneficiaryParty) Reset() {
+	*x = BeneficiaryParty{}
+	mi := &file_payments_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeneficiaryParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeneficiaryParty) ProtoMessage() {}
+
+func (x *BeneficiaryParty) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeneficiaryParty.ProtoReflect.Descriptor instead.
+func (*BeneficiaryParty) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BeneficiaryParty) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetAccountNumberCode() string {
+	if x != nil {
+		return x.AccountNumberCode
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetAccountType() int32 {
+	if x != nil {
+		return x.AccountType
+	}
+	return 0
+}
+
+func (x *BeneficiaryParty) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetBankId() string {
+	if x != nil {
+		return x.BankId
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetBankIdCode() string {
+	if x != nil {
+		return x.BankIdCode
+	}
+	return ""
+}
+
+func (x *BeneficiaryParty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DebtorParty struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AccountName       string                 `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	AccountNumber     string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountNumberCode string                 `protobuf:"bytes,3,opt,name=account_number_code,json=accountNumberCode,proto3" json:"account_number_code,omitempty"`
+	Address           string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	BankId            string                 `protobuf:"bytes,5,opt,name=bank_id,json=bankId,proto3" json:"bank_id,omitempty"`
+	BankIdCode        string                 `protobuf:"bytes,6,opt,name=bank_id_code,json=bankIdCode,proto3" json:"bank_id_code,omitempty"`
+	Name              string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DebtorParty) Reset() {
+	*x = DebtorParty{}
+	mi := &file_payments_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebtorParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebtorParty) ProtoMessage() {}
+
+func (x *DebtorParty) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebtorParty.ProtoReflect.Descriptor instead.
+func (*DebtorParty) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DebtorParty) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetAccountNumberCode() string {
+	if x != nil {
+		return x.AccountNumberCode
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetBankId() string {
+	if x != nil {
+		return x.BankId
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetBankIdCode() string {
+	if x != nil {
+		return x.BankIdCode
+	}
+	return ""
+}
+
+func (x *DebtorParty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SponsorParty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountNumber string                 `protobuf:"bytes,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	BankId        string                 `protobuf:"bytes,2,opt,name=bank_id,json=bankId,proto3" json:"bank_id,omitempty"`
+	BankIdCode    string                 `protobuf:"bytes,3,opt,name=bank_id_code,json=bankIdCode,proto3" json:"bank_id_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SponsorParty) Reset() {
+	*x = SponsorParty{}
+	mi := &file_payments_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SponsorParty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SponsorParty) ProtoMessage() {}
+
+func (x *SponsorParty) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SponsorParty.ProtoReflect.Descriptor instead.
+func (*SponsorParty) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SponsorParty) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *SponsorParty) GetBankId() string {
+	if x != nil {
+		return x.BankId
+	}
+	return ""
+}
+
+func (x *SponsorParty) GetBankIdCode() string {
+	if x != nil {
+		return x.BankIdCode
+	}
+	return ""
+}
+
+type ChargesInformation struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	BearerCode              string                 `protobuf:"bytes,1,opt,name=bearer_code,json=bearerCode,proto3" json:"bearer_code,omitempty"`
+	SenderCharges           []*Charge              `protobuf:"bytes,2,rep,name=sender_charges,json=senderCharges,proto3" json:"sender_charges,omitempty"`
+	ReceiverChargesAmount   string                 `protobuf:"bytes,3,opt,name=receiver_charges_amount,json=receiverChargesAmount,proto3" json:"receiver_charges_amount,omitempty"`
+	ReceiverChargesCurrency string                 `protobuf:"bytes,4,opt,name=receiver_charges_currency,json=receiverChargesCurrency,proto3" json:"receiver_charges_currency,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ChargesInformation) Reset() {
+	*x = ChargesInformation{}
+	mi := &file_payments_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargesInformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargesInformation) ProtoMessage() {}
+
+func (x *ChargesInformation) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargesInformation.ProtoReflect.Descriptor instead.
+func (*ChargesInformation) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChargesInformation) GetBearerCode() string {
+	if x != nil {
+		return x.BearerCode
+	}
+	return ""
+}
+
+func (x *ChargesInformation) GetSenderCharges() []*Charge {
+	if x != nil {
+		return x.SenderCharges
+	}
+	return nil
+}
+
+func (x *ChargesInformation) GetReceiverChargesAmount() string {
+	if x != nil {
+		return x.ReceiverChargesAmount
+	}
+	return ""
+}
+
+func (x *ChargesInformation) GetReceiverChargesCurrency() string {
+	if x != nil {
+		return x.ReceiverChargesCurrency
+	}
+	return ""
+}
+
+type Charge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Charge) Reset() {
+	*x = Charge{}
+	mi := &file_payments_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Charge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Charge) ProtoMessage() {}
+
+func (x *Charge) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Charge.ProtoReflect.Descriptor instead.
+func (*Charge) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Charge) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Charge) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Forex struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ContractReference string                 `protobuf:"bytes,1,opt,name=contract_reference,json=contractReference,proto3" json:"contract_reference,omitempty"`
+	ExchangeRate      string                 `protobuf:"bytes,2,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	OriginalAmount    string                 `protobuf:"bytes,3,opt,name=original_amount,json=originalAmount,proto3" json:"original_amount,omitempty"`
+	OriginalCurrency  string                 `protobuf:"bytes,4,opt,name=original_currency,json=originalCurrency,proto3" json:"original_currency,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Forex) Reset() {
+	*x = Forex{}
+	mi := &file_payments_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Forex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Forex) ProtoMessage() {}
+
+func (x *Forex) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Forex.ProtoReflect.Descriptor instead.
+func (*Forex) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Forex) GetContractReference() string {
+	if x != nil {
+		return x.ContractReference
+	}
+	return ""
+}
+
+func (x *Forex) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *Forex) GetOriginalAmount() string {
+	if x != nil {
+		return x.OriginalAmount
+	}
+	return ""
+}
+
+func (x *Forex) GetOriginalCurrency() string {
+	if x != nil {
+		return x.OriginalCurrency
+	}
+	return ""
+}
+
+// GetPaymentRequest returns the current payment, or a previous version selected by its number or by the RFC 3339 time
+// it was current at, as_of
+type GetPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       *uint32                `protobuf:"varint,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	AsOf          string                 `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentRequest) Reset() {
+	*x = GetPaymentRequest{}
+	mi := &file_payments_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetPaymentRequest) GetVersion() uint32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *GetPaymentRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+type GetPaymentResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Payment *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	// The total of the sender charges in each currency
+	SenderChargesTotals []*Charge `protobuf:"bytes,2,rep,name=sender_charges_totals,json=senderChargesTotals,proto3" json:"sender_charges_totals,omitempty"`
+	// The amount debited from the debtor and credited to the beneficiary, net of the charges they bear
+	TotalDebitAmount string `protobuf:"bytes,3,opt,name=total_debit_amount,json=totalDebitAmount,proto3" json:"total_debit_amount,omitempty"`
+	NetCreditAmount  string `protobuf:"bytes,4,opt,name=net_credit_amount,json=netCreditAmount,proto3" json:"net_credit_amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetPaymentResponse) Reset() {
+	*x = GetPaymentResponse{}
+	mi := &file_payments_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentResponse) ProtoMessage() {}
+
+func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetSenderChargesTotals() []*Charge {
+	if x != nil {
+		return x.SenderChargesTotals
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetTotalDebitAmount() string {
+	if x != nil {
+		return x.TotalDebitAmount
+	}
+	return ""
+}
+
+func (x *GetPaymentResponse) GetNetCreditAmount() string {
+	if x != nil {
+		return x.NetCreditAmount
+	}
+	return ""
+}
+
+type ListPaymentsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Lists the payments of a debtor or beneficiary account number only
+	AccountNumber string `protobuf:"bytes,4,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_payments_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPaymentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListPaymentsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_payments_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPaymentsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type CreatePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentRequest) Reset() {
+	*x = CreatePaymentRequest{}
+	mi := &file_payments_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentRequest) ProtoMessage() {}
+
+func (x *CreatePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreatePaymentRequest) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type CreatePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Warnings      []*Warning             `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentResponse) Reset() {
+	*x = CreatePaymentResponse{}
+	mi := &file_payments_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentResponse) ProtoMessage() {}
+
+func (x *CreatePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentResponse.ProtoReflect.Descriptor instead.
+func (*CreatePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreatePaymentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreatePaymentResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *CreatePaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Warning is returned along with a payment accepted despite looking suspicious
+type Warning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_payments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Warning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Warning) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type UpdatePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payment       *Payment               `protobuf:"bytes,2,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePaymentRequest) Reset() {
+	*x = UpdatePaymentRequest{}
+	mi := &file_payments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePaymentRequest) ProtoMessage() {}
+
+func (x *UpdatePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePaymentRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdatePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePaymentRequest) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type UpdatePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePaymentResponse) Reset() {
+	*x = UpdatePaymentResponse{}
+	mi := &file_payments_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePaymentResponse) ProtoMessage() {}
+
+func (x *UpdatePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePaymentResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdatePaymentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePaymentRequest) Reset() {
+	*x = DeletePaymentRequest{}
+	mi := &file_payments_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePaymentRequest) ProtoMessage() {}
+
+func (x *DeletePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePaymentRequest.ProtoReflect.Descriptor instead.
+func (*DeletePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeletePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePaymentResponse) Reset() {
+	*x = DeletePaymentResponse{}
+	mi := &file_payments_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePaymentResponse) ProtoMessage() {}
+
+func (x *DeletePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePaymentResponse.ProtoReflect.Descriptor instead.
+func (*DeletePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeletePaymentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestorePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePaymentRequest) Reset() {
+	*x = RestorePaymentRequest{}
+	mi := &file_payments_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePaymentRequest) ProtoMessage() {}
+
+func (x *RestorePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePaymentRequest.ProtoReflect.Descriptor instead.
+func (*RestorePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestorePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestorePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePaymentResponse) Reset() {
+	*x = RestorePaymentResponse{}
+	mi := &file_payments_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePaymentResponse) ProtoMessage() {}
+
+func (x *RestorePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePaymentResponse.ProtoReflect.Descriptor instead.
+func (*RestorePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RestorePaymentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ReviewPaymentRequest releases or rejects a payment held for review, decision is release or reject
+type ReviewPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentRequest) Reset() {
+	*x = ReviewPaymentRequest{}
+	mi := &file_payments_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentRequest) ProtoMessage() {}
+
+func (x *ReviewPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReviewPaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewPaymentRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ReviewPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReviewPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentResponse) Reset() {
+	*x = ReviewPaymentResponse{}
+	mi := &file_payments_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentResponse) ProtoMessage() {}
+
+func (x *ReviewPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReviewPaymentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewPaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ApprovePaymentRequest approves or rejects a payment pending approval, decision is approve or reject
+type ApprovePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePaymentRequest) Reset() {
+	*x = ApprovePaymentRequest{}
+	mi := &file_payments_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePaymentRequest) ProtoMessage() {}
+
+func (x *ApprovePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePaymentRequest.ProtoReflect.Descriptor instead.
+func (*ApprovePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ApprovePaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApprovePaymentRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ApprovePaymentRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ApprovePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *Approval              `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovePaymentResponse) Reset() {
+	*x = ApprovePaymentResponse{}
+	mi := &file_payments_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovePaymentResponse) ProtoMessage() {}
+
+func (x *ApprovePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovePaymentResponse.ProtoReflect.Descriptor instead.
+func (*ApprovePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ApprovePaymentResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+func (x *ApprovePaymentResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Approval is the decision of an approver on a payment, created_at is RFC 3339
+type Approval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Approver      string                 `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
+	Decision      string                 `protobuf:"bytes,4,opt,name=decision,proto3" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_payments_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Approval) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Approval) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Approval) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *Approval) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *Approval) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Approval) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApprovalsRequest) Reset() {
+	*x = ListApprovalsRequest{}
+	mi := &file_payments_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApprovalsRequest) ProtoMessage() {}
+
+func (x *ListApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListApprovalsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListApprovalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approvals     []*Approval            `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApprovalsResponse) Reset() {
+	*x = ListApprovalsResponse{}
+	mi := &file_payments_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApprovalsResponse) ProtoMessage() {}
+
+func (x *ListApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListApprovalsResponse) GetApprovals() []*Approval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+// OrganisationLimits are the payment limits of an organisation, all the schemes are allowed when allowed_schemes is
+// empty, updated_at is RFC 3339
+type OrganisationLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganisationId string                 `protobuf:"bytes,1,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	AllowedSchemes []string               `protobuf:"bytes,2,rep,name=allowed_schemes,json=allowedSchemes,proto3" json:"allowed_schemes,omitempty"`
+	Currencies     []*CurrencyLimit       `protobuf:"bytes,3,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrganisationLimits) Reset() {
+	*x = OrganisationLimits{}
+	mi := &file_payments_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganisationLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganisationLimits) ProtoMessage() {}
+
+func (x *OrganisationLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganisationLimits.ProtoReflect.Descriptor instead.
+func (*OrganisationLimits) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OrganisationLimits) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+func (x *OrganisationLimits) GetAllowedSchemes() []string {
+	if x != nil {
+		return x.AllowedSchemes
+	}
+	return nil
+}
+
+func (x *OrganisationLimits) GetCurrencies() []*CurrencyLimit {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *OrganisationLimits) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// CurrencyLimit limits the amounts of the payments of an organisation in a currency, empty amounts are not limited
+type CurrencyLimit struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Currency          string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	MaxAmount         string                 `protobuf:"bytes,2,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	DailyTotal        string                 `protobuf:"bytes,3,opt,name=daily_total,json=dailyTotal,proto3" json:"daily_total,omitempty"`
+	MonthlyTotal      string                 `protobuf:"bytes,4,opt,name=monthly_total,json=monthlyTotal,proto3" json:"monthly_total,omitempty"`
+	ApprovalThreshold string                 `protobuf:"bytes,5,opt,name=approval_threshold,json=approvalThreshold,proto3" json:"approval_threshold,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CurrencyLimit) Reset() {
+	*x = CurrencyLimit{}
+	mi := &file_payments_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyLimit) ProtoMessage() {}
+
+func (x *CurrencyLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyLimit.ProtoReflect.Descriptor instead.
+func (*CurrencyLimit) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CurrencyLimit) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CurrencyLimit) GetMaxAmount() string {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return ""
+}
+
+func (x *CurrencyLimit) GetDailyTotal() string {
+	if x != nil {
+		return x.DailyTotal
+	}
+	return ""
+}
+
+func (x *CurrencyLimit) GetMonthlyTotal() string {
+	if x != nil {
+		return x.MonthlyTotal
+	}
+	return ""
+}
+
+func (x *CurrencyLimit) GetApprovalThreshold() string {
+	if x != nil {
+		return x.ApprovalThreshold
+	}
+	return ""
+}
+
+type GetOrganisationLimitsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganisationId string                 `protobuf:"bytes,1,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOrganisationLimitsRequest) Reset() {
+	*x = GetOrganisationLimitsRequest{}
+	mi := &file_payments_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganisationLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganisationLimitsRequest) ProtoMessage() {}
+
+func (x *GetOrganisationLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganisationLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrganisationLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetOrganisationLimitsRequest) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+type GetOrganisationLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *OrganisationLimits    `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrganisationLimitsResponse) Reset() {
+	*x = GetOrganisationLimitsResponse{}
+	mi := &file_payments_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrganisationLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrganisationLimitsResponse) ProtoMessage() {}
+
+func (x *GetOrganisationLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrganisationLimitsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrganisationLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetOrganisationLimitsResponse) GetLimits() *OrganisationLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// UpdateOrganisationLimitsRequest replaces the limits of the organisation, the organisation_id of the limits is ignored
+type UpdateOrganisationLimitsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganisationId string                 `protobuf:"bytes,1,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	Limits         *OrganisationLimits    `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateOrganisationLimitsRequest) Reset() {
+	*x = UpdateOrganisationLimitsRequest{}
+	mi := &file_payments_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganisationLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganisationLimitsRequest) ProtoMessage() {}
+
+func (x *UpdateOrganisationLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganisationLimitsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganisationLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateOrganisationLimitsRequest) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+func (x *UpdateOrganisationLimitsRequest) GetLimits() *OrganisationLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type UpdateOrganisationLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        *OrganisationLimits    `protobuf:"bytes,1,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrganisationLimitsResponse) Reset() {
+	*x = UpdateOrganisationLimitsResponse{}
+	mi := &file_payments_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganisationLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganisationLimitsResponse) ProtoMessage() {}
+
+func (x *UpdateOrganisationLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganisationLimitsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrganisationLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateOrganisationLimitsResponse) GetLimits() *OrganisationLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type DeleteOrganisationLimitsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganisationId string                 `protobuf:"bytes,1,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteOrganisationLimitsRequest) Reset() {
+	*x = DeleteOrganisationLimitsRequest{}
+	mi := &file_payments_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrganisationLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrganisationLimitsRequest) ProtoMessage() {}
+
+func (x *DeleteOrganisationLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrganisationLimitsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrganisationLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteOrganisationLimitsRequest) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+type DeleteOrganisationLimitsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrganisationId string                 `protobuf:"bytes,1,opt,name=organisation_id,json=organisationId,proto3" json:"organisation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteOrganisationLimitsResponse) Reset() {
+	*x = DeleteOrganisationLimitsResponse{}
+	mi := &file_payments_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrganisationLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrganisationLimitsResponse) ProtoMessage() {}
+
+func (x *DeleteOrganisationLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrganisationLimitsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrganisationLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteOrganisationLimitsResponse) GetOrganisationId() string {
+	if x != nil {
+		return x.OrganisationId
+	}
+	return ""
+}
+
+// Return is a return or a reversal of a payment, created_at and updated_at are RFC 3339
+type Return struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,4,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount        string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	StatusReason  string                 `protobuf:"bytes,9,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_payments_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Return) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Return) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Return) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Return) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *Return) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Return) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Return) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Return) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Return) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Return) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// CreateReturnRequest returns or reverses a payment, the whole amount not yet returned when the amount is empty
+type CreateReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,4,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnRequest) Reset() {
+	*x = CreateReturnRequest{}
+	mi := &file_payments_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnRequest) ProtoMessage() {}
+
+func (x *CreateReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnRequest.ProtoReflect.Descriptor instead.
+func (*CreateReturnRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateReturnRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnResponse) Reset() {
+	*x = CreateReturnResponse{}
+	mi := &file_payments_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnResponse) ProtoMessage() {}
+
+func (x *CreateReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnResponse.ProtoReflect.Descriptor instead.
+func (*CreateReturnResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type GetReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ReturnId      string                 `protobuf:"bytes,3,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_payments_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetReturnRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetReturnRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetReturnRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+type GetReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnResponse) Reset() {
+	*x = GetReturnResponse{}
+	mi := &file_payments_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnResponse) ProtoMessage() {}
+
+func (x *GetReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_payments_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListReturnsRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_payments_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+// UpdateReturnStatusRequest completes or fails a pending return, status is completed or failed
+type UpdateReturnStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ReturnId      string                 `protobuf:"bytes,3,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReturnStatusRequest) Reset() {
+	*x = UpdateReturnStatusRequest{}
+	mi := &file_payments_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnStatusRequest) ProtoMessage() {}
+
+func (x *UpdateReturnStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReturnStatusRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateReturnStatusRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateReturnStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReturnStatusResponse) Reset() {
+	*x = UpdateReturnStatusResponse{}
+	mi := &file_payments_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnStatusResponse) ProtoMessage() {}
+
+func (x *UpdateReturnStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReturnStatusResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateReturnStatusResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// Recall asks the beneficiary bank to recall a payment sent in error, created_at and decided_at are RFC 3339,
+// decided_at is empty until the decision of the beneficiary bank
+type Recall struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId      string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	ReasonCode     string                 `protobuf:"bytes,3,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	RequestedBy    string                 `protobuf:"bytes,6,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DecisionReason string                 `protobuf:"bytes,8,opt,name=decision_reason,json=decisionReason,proto3" json:"decision_reason,omitempty"`
+	DecidedAt      string                 `protobuf:"bytes,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Recall) Reset() {
+	*x = Recall{}
+	mi := &file_payments_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recall) ProtoMessage() {}
+
+func (x *Recall) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recall.ProtoReflect.Descriptor instead.
+func (*Recall) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Recall) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Recall) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Recall) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *Recall) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Recall) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Recall) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *Recall) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Recall) GetDecisionReason() string {
+	if x != nil {
+		return x.DecisionReason
+	}
+	return ""
+}
+
+func (x *Recall) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+type CreateRecallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,2,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecallRequest) Reset() {
+	*x = CreateRecallRequest{}
+	mi := &file_payments_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecallRequest) ProtoMessage() {}
+
+func (x *CreateRecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecallRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecallRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateRecallRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CreateRecallRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *CreateRecallRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateRecallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recall        *Recall                `protobuf:"bytes,1,opt,name=recall,proto3" json:"recall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecallResponse) Reset() {
+	*x = CreateRecallResponse{}
+	mi := &file_payments_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecallResponse) ProtoMessage() {}
+
+func (x *CreateRecallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecallResponse.ProtoReflect.Descriptor instead.
+func (*CreateRecallResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateRecallResponse) GetRecall() *Recall {
+	if x != nil {
+		return x.Recall
+	}
+	return nil
+}
+
+type GetRecallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	RecallId      string                 `protobuf:"bytes,2,opt,name=recall_id,json=recallId,proto3" json:"recall_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecallRequest) Reset() {
+	*x = GetRecallRequest{}
+	mi := &file_payments_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecallRequest) ProtoMessage() {}
+
+func (x *GetRecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecallRequest.ProtoReflect.Descriptor instead.
+func (*GetRecallRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRecallRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *GetRecallRequest) GetRecallId() string {
+	if x != nil {
+		return x.RecallId
+	}
+	return ""
+}
+
+type GetRecallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recall        *Recall                `protobuf:"bytes,1,opt,name=recall,proto3" json:"recall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecallResponse) Reset() {
+	*x = GetRecallResponse{}
+	mi := &file_payments_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecallResponse) ProtoMessage() {}
+
+func (x *GetRecallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecallResponse.ProtoReflect.Descriptor instead.
+func (*GetRecallResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetRecallResponse) GetRecall() *Recall {
+	if x != nil {
+		return x.Recall
+	}
+	return nil
+}
+
+type ListRecallsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecallsRequest) Reset() {
+	*x = ListRecallsRequest{}
+	mi := &file_payments_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecallsRequest) ProtoMessage() {}
+
+func (x *ListRecallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecallsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecallsRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListRecallsRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+type ListRecallsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recalls       []*Recall              `protobuf:"bytes,1,rep,name=recalls,proto3" json:"recalls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecallsResponse) Reset() {
+	*x = ListRecallsResponse{}
+	mi := &file_payments_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecallsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecallsResponse) ProtoMessage() {}
+
+func (x *ListRecallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecallsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecallsResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListRecallsResponse) GetRecalls() []*Recall {
+	if x != nil {
+		return x.Recalls
+	}
+	return nil
+}
+
+// DecideRecallRequest records the decision of the beneficiary bank on a requested recall, decision is accept or reject
+type DecideRecallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	RecallId      string                 `protobuf:"bytes,2,opt,name=recall_id,json=recallId,proto3" json:"recall_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,3,opt,name=decision,proto3" json:"decision,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideRecallRequest) Reset() {
+	*x = DecideRecallRequest{}
+	mi := &file_payments_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideRecallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideRecallRequest) ProtoMessage() {}
+
+func (x *DecideRecallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideRecallRequest.ProtoReflect.Descriptor instead.
+func (*DecideRecallRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DecideRecallRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *DecideRecallRequest) GetRecallId() string {
+	if x != nil {
+		return x.RecallId
+	}
+	return ""
+}
+
+func (x *DecideRecallRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *DecideRecallRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DecideRecallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recall        *Recall                `protobuf:"bytes,1,opt,name=recall,proto3" json:"recall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideRecallResponse) Reset() {
+	*x = DecideRecallResponse{}
+	mi := &file_payments_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideRecallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideRecallResponse) ProtoMessage() {}
+
+func (x *DecideRecallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideRecallResponse.ProtoReflect.Descriptor instead.
+func (*DecideRecallResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DecideRecallResponse) GetRecall() *Recall {
+	if x != nil {
+		return x.Recall
+	}
+	return nil
+}
+
+// Balance is the position of a ledger account in a currency, balance is the credits less the debits
+type Balance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Debits        string                 `protobuf:"bytes,2,opt,name=debits,proto3" json:"debits,omitempty"`
+	Credits       string                 `protobuf:"bytes,3,opt,name=credits,proto3" json:"credits,omitempty"`
+	Balance       string                 `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_payments_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Balance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Balance) GetDebits() string {
+	if x != nil {
+		return x.Debits
+	}
+	return ""
+}
+
+func (x *Balance) GetCredits() string {
+	if x != nil {
+		return x.Credits
+	}
+	return ""
+}
+
+func (x *Balance) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+// Entry is a debit or a credit of a ledger account, direction is debit or credit and created_at is RFC 3339
+type Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	Amount        string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_payments_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Entry) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *Entry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Entry) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Entry) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Entry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Entry) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Entry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Imbalance is a ledger transaction whose debits and credits differ in a currency, difference is the debits less the
+// credits
+type Imbalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Difference    string                 `protobuf:"bytes,3,opt,name=difference,proto3" json:"difference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Imbalance) Reset() {
+	*x = Imbalance{}
+	mi := &file_payments_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Imbalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Imbalance) ProtoMessage() {}
+
+func (x *Imbalance) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Imbalance.ProtoReflect.Descriptor instead.
+func (*Imbalance) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Imbalance) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *Imbalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Imbalance) GetDifference() string {
+	if x != nil {
+		return x.Difference
+	}
+	return ""
+}
+
+type GetAccountBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalanceRequest) Reset() {
+	*x = GetAccountBalanceRequest{}
+	mi := &file_payments_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalanceRequest) ProtoMessage() {}
+
+func (x *GetAccountBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetAccountBalanceRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type GetAccountBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Balances      []*Balance             `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalanceResponse) Reset() {
+	*x = GetAccountBalanceResponse{}
+	mi := &file_payments_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalanceResponse) ProtoMessage() {}
+
+func (x *GetAccountBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetAccountBalanceResponse) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *GetAccountBalanceResponse) GetBalances() []*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type ListAccountEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountEntriesRequest) Reset() {
+	*x = ListAccountEntriesRequest{}
+	mi := &file_payments_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountEntriesRequest) ProtoMessage() {}
+
+func (x *ListAccountEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListAccountEntriesRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListAccountEntriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAccountEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListAccountEntriesResponse is a page of the entries of a ledger account, oldest first
+type ListAccountEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountEntriesResponse) Reset() {
+	*x = ListAccountEntriesResponse{}
+	mi := &file_payments_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountEntriesResponse) ProtoMessage() {}
+
+func (x *ListAccountEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListAccountEntriesResponse) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAccountEntriesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAccountEntriesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type CheckLedgerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLedgerRequest) Reset() {
+	*x = CheckLedgerRequest{}
+	mi := &file_payments_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLedgerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLedgerRequest) ProtoMessage() {}
+
+func (x *CheckLedgerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLedgerRequest.ProtoReflect.Descriptor instead.
+func (*CheckLedgerRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{62}
+}
+
+type CheckLedgerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balanced      bool                   `protobuf:"varint,1,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	Imbalances    []*Imbalance           `protobuf:"bytes,2,rep,name=imbalances,proto3" json:"imbalances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLedgerResponse) Reset() {
+	*x = CheckLedgerResponse{}
+	mi := &file_payments_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLedgerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLedgerResponse) ProtoMessage() {}
+
+func (x *CheckLedgerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLedgerResponse.ProtoReflect.Descriptor instead.
+func (*CheckLedgerResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CheckLedgerResponse) GetBalanced() bool {
+	if x != nil {
+		return x.Balanced
+	}
+	return false
+}
+
+func (x *CheckLedgerResponse) GetImbalances() []*Imbalance {
+	if x != nil {
+		return x.Imbalances
+	}
+	return nil
+}
+
+// AuditChange is a field of a payment changed by an operation, before and after are its JSON values, null for a created
+// or a removed field
+type AuditChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditChange) Reset() {
+	*x = AuditChange{}
+	mi := &file_payments_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChange) ProtoMessage() {}
+
+func (x *AuditChange) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChange.ProtoReflect.Descriptor instead.
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AuditChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AuditChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// AuditEntry records who changed a payment, when and how, created_at is RFC 3339
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	SourceIp      string                 `protobuf:"bytes,5,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	Changes       []*AuditChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_payments_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *AuditEntry) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetChanges() []*AuditChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListAuditEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesRequest) Reset() {
+	*x = ListAuditEntriesRequest{}
+	mi := &file_payments_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesRequest) ProtoMessage() {}
+
+func (x *ListAuditEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListAuditEntriesRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+// ListAuditEntriesResponse lists the audit log of a payment in chronological order
+type ListAuditEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesResponse) Reset() {
+	*x = ListAuditEntriesResponse{}
+	mi := &file_payments_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesResponse) ProtoMessage() {}
+
+func (x *ListAuditEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payments_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_payments_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListAuditEntriesResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_payments_proto protoreflect.FileDescriptor
+
+const file_payments_proto_rawDesc = "" +
+	"\n" +
+	"\x0epayments.proto\x12\vpayments.v1\"\x8e\x03\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\x12'\n" +
+	"\x0forganisation_id\x18\x04 \x01(\tR\x0eorganisationId\x127\n" +
+	"\n" +
+	"attributes\x18\x05 \x01(\v2\x17.payments.v1.AttributesR\n" +
+	"attributes\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\a \x01(\tR\fstatusReason\x12@\n" +
+	"\x0escreening_hits\x18\b \x03(\v2\x19.payments.v1.ScreeningHitR\rscreeningHits\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x12+\n" +
+	"\x11approval_required\x18\n" +
+	" \x01(\bR\x10approvalRequired\x12\x18\n" +
+	"\adeleted\x18\v \x01(\bR\adeleted\"\xa6\x01\n" +
+	"\fScreeningHit\x12\x14\n" +
+	"\x05party\x18\x01 \x01(\tR\x05party\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x1b\n" +
+	"\tentry_uid\x18\x03 \x01(\tR\bentryUid\x12\x1d\n" +
+	"\n" +
+	"entry_name\x18\x04 \x01(\tR\tentryName\x12\x18\n" +
+	"\amatched\x18\x05 \x01(\tR\amatched\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\"\x9d\x06\n" +
+	"\n" +
+	"Attributes\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12J\n" +
+	"\x11beneficiary_party\x18\x02 \x01(\v2\x1d.payments.v1.BeneficiaryPartyR\x10beneficiaryParty\x12P\n" +
+	"\x13charges_information\x18\x03 \x01(\v2\x1f.payments.v1.ChargesInformationR\x12chargesInformation\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12;\n" +
+	"\fdebtor_party\x18\x05 \x01(\v2\x18.payments.v1.DebtorPartyR\vdebtorParty\x12/\n" +
+	"\x14end_to_end_reference\x18\x06 \x01(\tR\x11endToEndReference\x12\"\n" +
+	"\x02fx\x18\a \x01(\v2\x12.payments.v1.ForexR\x02fx\x12+\n" +
+	"\x11numeric_reference\x18\b \x01(\tR\x10numericReference\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\t \x01(\tR\tpaymentId\x12'\n" +
+	"\x0fpayment_purpose\x18\n" +
+	" \x01(\tR\x0epaymentPurpose\x12%\n" +
+	"\x0epayment_scheme\x18\v \x01(\tR\rpaymentScheme\x12!\n" +
+	"\fpayment_type\x18\f \x01(\tR\vpaymentType\x12'\n" +
+	"\x0fprocessing_date\x18\r \x01(\tR\x0eprocessingDate\x12\x1c\n" +
+	"\treference\x18\x0e \x01(\tR\treference\x125\n" +
+	"\x17scheme_payment_sub_type\x18\x0f \x01(\tR\x14schemePaymentSubType\x12.\n" +
+	"\x13scheme_payment_type\x18\x10 \x01(\tR\x11schemePaymentType\x12>\n" +
+	"\rsponsor_party\x18\x11 \x01(\v2\x19.payments.v1.SponsorPartyR\fsponsorParty\"\x98\x02\n" +
+	"\x10BeneficiaryParty\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12.\n" +
+	"\x13account_number_code\x18\x03 \x01(\tR\x11accountNumberCode\x12!\n" +
+	"\faccount_type\x18\x04 \x01(\x05R\vaccountType\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\x12\x17\n" +
+	"\abank_id\x18\x06 \x01(\tR\x06bankId\x12 \n" +
+	"\fbank_id_code\x18\a \x01(\tR\n" +
+	"bankIdCode\x12\x12\n" +
+	"\x04name\x18\b \x01(\tR\x04name\"\xf0\x01\n" +
+	"\vDebtorParty\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12.\n" +
+	"\x13account_number_code\x18\x03 \x01(\tR\x11accountNumberCode\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x17\n" +
+	"\abank_id\x18\x05 \x01(\tR\x06bankId\x12 \n" +
+	"\fbank_id_code\x18\x06 \x01(\tR\n" +
+	"bankIdCode\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\"p\n" +
+	"\fSponsorParty\x12%\n" +
+	"\x0eaccount_number\x18\x01 \x01(\tR\raccountNumber\x12\x17\n" +
+	"\abank_id\x18\x02 \x01(\tR\x06bankId\x12 \n" +
+	"\fbank_id_code\x18\x03 \x01(\tR\n" +
+	"bankIdCode\"\xe5\x01\n" +
+	"\x12ChargesInformation\x12\x1f\n" +
+	"\vbearer_code\x18\x01 \x01(\tR\n" +
+	"bearerCode\x12:\n" +
+	"\x0esender_charges\x18\x02 \x03(\v2\x13.payments.v1.ChargeR\rsenderCharges\x126\n" +
+	"\x17receiver_charges_amount\x18\x03 \x01(\tR\x15receiverChargesAmount\x12:\n" +
+	"\x19receiver_charges_currency\x18\x04 \x01(\tR\x17receiverChargesCurrency\"<\n" +
+	"\x06Charge\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xb1\x01\n" +
+	"\x05Forex\x12-\n" +
+	"\x12contract_reference\x18\x01 \x01(\tR\x11contractReference\x12#\n" +
+	"\rexchange_rate\x18\x02 \x01(\tR\fexchangeRate\x12'\n" +
+	"\x0foriginal_amount\x18\x03 \x01(\tR\x0eoriginalAmount\x12+\n" +
+	"\x11original_currency\x18\x04 \x01(\tR\x10originalCurrency\"c\n" +
+	"\x11GetPaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\aversion\x18\x02 \x01(\rH\x00R\aversion\x88\x01\x01\x12\x13\n" +
+	"\x05as_of\x18\x03 \x01(\tR\x04asOfB\n" +
+	"\n" +
+	"\b_version\"\xe7\x01\n" +
+	"\x12GetPaymentResponse\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\x12G\n" +
+	"\x15sender_charges_totals\x18\x02 \x03(\v2\x13.payments.v1.ChargeR\x13senderChargesTotals\x12,\n" +
+	"\x12total_debit_amount\x18\x03 \x01(\tR\x10totalDebitAmount\x12*\n" +
+	"\x11net_credit_amount\x18\x04 \x01(\tR\x0fnetCreditAmount\"\x96\x01\n" +
+	"\x13ListPaymentsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\x12%\n" +
+	"\x0eaccount_number\x18\x04 \x01(\tR\raccountNumber\"y\n" +
+	"\x14ListPaymentsResponse\x120\n" +
+	"\bpayments\x18\x01 \x03(\v2\x14.payments.v1.PaymentR\bpayments\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"F\n" +
+	"\x14CreatePaymentRequest\x12.\n" +
+	"\apayment\x18\x01 \x01(\v2\x14.payments.v1.PaymentR\apayment\"q\n" +
+	"\x15CreatePaymentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x14.payments.v1.WarningR\bwarnings\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"V\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\tR\tpaymentId\"V\n" +
+	"\x14UpdatePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\apayment\x18\x02 \x01(\v2\x14.payments.v1.PaymentR\apayment\"'\n" +
+	"\x15UpdatePaymentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14DeletePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15DeletePaymentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15RestorePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
+	"\x16RestorePaymentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Z\n" +
+	"\x14ReviewPaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"?\n" +
+	"\x15ReviewPaymentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"]\n" +
+	"\x15ApprovePaymentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"c\n" +
+	"\x16ApprovePaymentResponse\x121\n" +
+	"\bapproval\x18\x01 \x01(\v2\x15.payments.v1.ApprovalR\bapproval\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xaa\x01\n" +
+	"\bApproval\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x1a\n" +
+	"\bapprover\x18\x03 \x01(\tR\bapprover\x12\x1a\n" +
+	"\bdecision\x18\x04 \x01(\tR\bdecision\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"&\n" +
+	"\x14ListApprovalsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x15ListApprovalsResponse\x123\n" +
+	"\tapprovals\x18\x01 \x03(\v2\x15.payments.v1.ApprovalR\tapprovals\"\xc1\x01\n" +
+	"\x12OrganisationLimits\x12'\n" +
+	"\x0forganisation_id\x18\x01 \x01(\tR\x0eorganisationId\x12'\n" +
+	"\x0fallowed_schemes\x18\x02 \x03(\tR\x0eallowedSchemes\x12:\n" +
+	"\n" +
+	"currencies\x18\x03 \x03(\v2\x1a.payments.v1.CurrencyLimitR\n" +
+	"currencies\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"\xbf\x01\n" +
+	"\rCurrencyLimit\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x02 \x01(\tR\tmaxAmount\x12\x1f\n" +
+	"\vdaily_total\x18\x03 \x01(\tR\n" +
+	"dailyTotal\x12#\n" +
+	"\rmonthly_total\x18\x04 \x01(\tR\fmonthlyTotal\x12-\n" +
+	"\x12approval_threshold\x18\x05 \x01(\tR\x11approvalThreshold\"G\n" +
+	"\x1cGetOrganisationLimitsRequest\x12'\n" +
+	"\x0forganisation_id\x18\x01 \x01(\tR\x0eorganisationId\"X\n" +
+	"\x1dGetOrganisationLimitsResponse\x127\n" +
+	"\x06limits\x18\x01 \x01(\v2\x1f.payments.v1.OrganisationLimitsR\x06limits\"\x83\x01\n" +
+	"\x1fUpdateOrganisationLimitsRequest\x12'\n" +
+	"\x0forganisation_id\x18\x01 \x01(\tR\x0eorganisationId\x127\n" +
+	"\x06limits\x18\x02 \x01(\v2\x1f.payments.v1.OrganisationLimitsR\x06limits\"[\n" +
+	" UpdateOrganisationLimitsResponse\x127\n" +
+	"\x06limits\x18\x01 \x01(\v2\x1f.payments.v1.OrganisationLimitsR\x06limits\"J\n" +
+	"\x1fDeleteOrganisationLimitsRequest\x12'\n" +
+	"\x0forganisation_id\x18\x01 \x01(\tR\x0eorganisationId\"K\n" +
+	" DeleteOrganisationLimitsResponse\x12'\n" +
+	"\x0forganisation_id\x18\x01 \x01(\tR\x0eorganisationId\"\xd2\x02\n" +
+	"\x06Return\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1f\n" +
+	"\vreason_code\x18\x04 \x01(\tR\n" +
+	"reasonCode\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\t \x01(\tR\fstatusReason\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\"\x99\x01\n" +
+	"\x13CreateReturnRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1f\n" +
+	"\vreason_code\x18\x04 \x01(\tR\n" +
+	"reasonCode\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"C\n" +
+	"\x14CreateReturnResponse\x12+\n" +
+	"\x06return\x18\x01 \x01(\v2\x13.payments.v1.ReturnR\x06return\"b\n" +
+	"\x10GetReturnRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\treturn_id\x18\x03 \x01(\tR\breturnId\"@\n" +
+	"\x11GetReturnResponse\x12+\n" +
+	"\x06return\x18\x01 \x01(\v2\x13.payments.v1.ReturnR\x06return\"G\n" +
+	"\x12ListReturnsRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"D\n" +
+	"\x13ListReturnsResponse\x12-\n" +
+	"\areturns\x18\x01 \x03(\v2\x13.payments.v1.ReturnR\areturns\"\x9b\x01\n" +
+	"\x19UpdateReturnStatusRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\treturn_id\x18\x03 \x01(\tR\breturnId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"I\n" +
+	"\x1aUpdateReturnStatusResponse\x12+\n" +
+	"\x06return\x18\x01 \x01(\v2\x13.payments.v1.ReturnR\x06return\"\x92\x02\n" +
+	"\x06Recall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x1f\n" +
+	"\vreason_code\x18\x03 \x01(\tR\n" +
+	"reasonCode\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\frequested_by\x18\x06 \x01(\tR\vrequestedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12'\n" +
+	"\x0fdecision_reason\x18\b \x01(\tR\x0edecisionReason\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\tR\tdecidedAt\"m\n" +
+	"\x13CreateRecallRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1f\n" +
+	"\vreason_code\x18\x02 \x01(\tR\n" +
+	"reasonCode\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"C\n" +
+	"\x14CreateRecallResponse\x12+\n" +
+	"\x06recall\x18\x01 \x01(\v2\x13.payments.v1.RecallR\x06recall\"N\n" +
+	"\x10GetRecallRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1b\n" +
+	"\trecall_id\x18\x02 \x01(\tR\brecallId\"@\n" +
+	"\x11GetRecallResponse\x12+\n" +
+	"\x06recall\x18\x01 \x01(\v2\x13.payments.v1.RecallR\x06recall\"3\n" +
+	"\x12ListRecallsRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"D\n" +
+	"\x13ListRecallsResponse\x12-\n" +
+	"\arecalls\x18\x01 \x03(\v2\x13.payments.v1.RecallR\arecalls\"\x85\x01\n" +
+	"\x13DecideRecallRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1b\n" +
+	"\trecall_id\x18\x02 \x01(\tR\brecallId\x12\x1a\n" +
+	"\bdecision\x18\x03 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"C\n" +
+	"\x14DecideRecallResponse\x12+\n" +
+	"\x06recall\x18\x01 \x01(\v2\x13.payments.v1.RecallR\x06recall\"q\n" +
+	"\aBalance\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06debits\x18\x02 \x01(\tR\x06debits\x12\x18\n" +
+	"\acredits\x18\x03 \x01(\tR\acredits\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\tR\abalance\"\xd7\x01\n" +
+	"\x05Entry\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"n\n" +
+	"\tImbalance\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"difference\x18\x03 \x01(\tR\n" +
+	"difference\"4\n" +
+	"\x18GetAccountBalanceRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"g\n" +
+	"\x19GetAccountBalanceResponse\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x120\n" +
+	"\bbalances\x18\x02 \x03(\v2\x14.payments.v1.BalanceR\bbalances\"f\n" +
+	"\x19ListAccountEntriesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"{\n" +
+	"\x1aListAccountEntriesResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.payments.v1.EntryR\aentries\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x14\n" +
+	"\x12CheckLedgerRequest\"i\n" +
+	"\x13CheckLedgerResponse\x12\x1a\n" +
+	"\bbalanced\x18\x01 \x01(\bR\bbalanced\x126\n" +
+	"\n" +
+	"imbalances\x18\x02 \x03(\v2\x16.payments.v1.ImbalanceR\n" +
+	"imbalances\"Q\n" +
+	"\vAuditChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xee\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\x12\x1b\n" +
+	"\tsource_ip\x18\x05 \x01(\tR\bsourceIp\x122\n" +
+	"\achanges\x18\x06 \x03(\v2\x18.payments.v1.AuditChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"8\n" +
+	"\x17ListAuditEntriesRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"M\n" +
+	"\x18ListAuditEntriesResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.payments.v1.AuditEntryR\aentries2\x9e\x11\n" +
+	"\bPayments\x12M\n" +
+	"\n" +
+	"GetPayment\x12\x1e.payments.v1.GetPaymentRequest\x1a\x1f.payments.v1.GetPaymentResponse\x12S\n" +
+	"\fListPayments\x12 .payments.v1.ListPaymentsRequest\x1a!.payments.v1.ListPaymentsResponse\x12V\n" +
+	"\rCreatePayment\x12!.payments.v1.CreatePaymentRequest\x1a\".payments.v1.CreatePaymentResponse\x12V\n" +
+	"\rUpdatePayment\x12!.payments.v1.UpdatePaymentRequest\x1a\".payments.v1.UpdatePaymentResponse\x12V\n" +
+	"\rDeletePayment\x12!.payments.v1.DeletePaymentRequest\x1a\".payments.v1.DeletePaymentResponse\x12Y\n" +
+	"\x0eRestorePayment\x12\".payments.v1.RestorePaymentRequest\x1a#.payments.v1.RestorePaymentResponse\x12V\n" +
+	"\rReviewPayment\x12!.payments.v1.ReviewPaymentRequest\x1a\".payments.v1.ReviewPaymentResponse\x12Y\n" +
+	"\x0eApprovePayment\x12\".payments.v1.ApprovePaymentRequest\x1a#.payments.v1.ApprovePaymentResponse\x12V\n" +
+	"\rListApprovals\x12!.payments.v1.ListApprovalsRequest\x1a\".payments.v1.ListApprovalsResponse\x12n\n" +
+	"\x15GetOrganisationLimits\x12).payments.v1.GetOrganisationLimitsRequest\x1a*.payments.v1.GetOrganisationLimitsResponse\x12w\n" +
+	"\x18UpdateOrganisationLimits\x12,.payments.v1.UpdateOrganisationLimitsRequest\x1a-.payments.v1.UpdateOrganisationLimitsResponse\x12w\n" +
+	"\x18DeleteOrganisationLimits\x12,.payments.v1.DeleteOrganisationLimitsRequest\x1a-.payments.v1.DeleteOrganisationLimitsResponse\x12S\n" +
+	"\fCreateReturn\x12 .payments.v1.CreateReturnRequest\x1a!.payments.v1.CreateReturnResponse\x12J\n" +
+	"\tGetReturn\x12\x1d.payments.v1.GetReturnRequest\x1a\x1e.payments.v1.GetReturnResponse\x12P\n" +
+	"\vListReturns\x12\x1f.payments.v1.ListReturnsRequest\x1a .payments.v1.ListReturnsResponse\x12e\n" +
+	"\x12UpdateReturnStatus\x12&.payments.v1.UpdateReturnStatusRequest\x1a'.payments.v1.UpdateReturnStatusResponse\x12S\n" +
+	"\fCreateRecall\x12 .payments.v1.CreateRecallRequest\x1a!.payments.v1.CreateRecallResponse\x12J\n" +
+	"\tGetRecall\x12\x1d.payments.v1.GetRecallRequest\x1a\x1e.payments.v1.GetRecallResponse\x12P\n" +
+	"\vListRecalls\x12\x1f.payments.v1.ListRecallsRequest\x1a .payments.v1.ListRecallsResponse\x12S\n" +
+	"\fDecideRecall\x12 .payments.v1.DecideRecallRequest\x1a!.payments.v1.DecideRecallResponse\x12b\n" +
+	"\x11GetAccountBalance\x12%.payments.v1.GetAccountBalanceRequest\x1a&.payments.v1.GetAccountBalanceResponse\x12e\n" +
+	"\x12ListAccountEntries\x12&.payments.v1.ListAccountEntriesRequest\x1a'.payments.v1.ListAccountEntriesResponse\x12P\n" +
+	"\vCheckLedger\x12\x1f.payments.v1.CheckLedgerRequest\x1a .payments.v1.CheckLedgerResponse\x12_\n" +
+	"\x10ListAuditEntries\x12$.payments.v1.ListAuditEntriesRequest\x1a%.payments.v1.ListAuditEntriesResponseB0Z.github.com/elkousy/payments-api/payments/pb;pbb\x06proto3"
+
+var (
+	file_payments_proto_rawDescOnce sync.Once
+	file_payments_proto_rawDescData []byte
+)
+
+func file_payments_proto_rawDescGZIP() []byte {
+	file_payments_proto_rawDescOnce.Do(func() {
+		file_payments_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payments_proto_rawDesc), len(file_payments_proto_rawDesc)))
+	})
+	return file_payments_proto_rawDescData
+}
+
+var file_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
+var file_payments_proto_goTypes = []any{
+	(*Payment)(nil),                          // 0: payments.v1.Payment
+	(*ScreeningHit)(nil),                     // 1: payments.v1.ScreeningHit
+	(*Attributes)(nil),                       // 2: payments.v1.Attributes
+	(*BeneficiaryParty)(nil),                 // 3: payments.v1.BeneficiaryParty
+	(*DebtorParty)(nil),                      // 4: payments.v1.DebtorParty
+	(*SponsorParty)(nil),                     // 5: payments.v1.SponsorParty
+	(*ChargesInformation)(nil),               // 6: payments.v1.ChargesInformation
+	(*Charge)(nil),                           // 7: payments.v1.Charge
+	(*Forex)(nil),                            // 8: payments.v1.Forex
+	(*GetPaymentRequest)(nil),                // 9: payments.v1.GetPaymentRequest
+	(*GetPaymentResponse)(nil),               // 10: payments.v1.GetPaymentResponse
+	(*ListPaymentsRequest)(nil),              // 11: payments.v1.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),             // 12: payments.v1.ListPaymentsResponse
+	(*CreatePaymentRequest)(nil),             // 13: payments.v1.CreatePaymentRequest
+	(*CreatePaymentResponse)(nil),            // 14: payments.v1.CreatePaymentResponse
+	(*Warning)(nil),                          // 15: payments.v1.Warning
+	(*UpdatePaymentRequest)(nil),             // 16: payments.v1.UpdatePaymentRequest
+	(*UpdatePaymentResponse)(nil),            // 17: payments.v1.UpdatePaymentResponse
+	(*DeletePaymentRequest)(nil),             // 18: payments.v1.DeletePaymentRequest
+	(*DeletePaymentResponse)(nil),            // 19: payments.v1.DeletePaymentResponse
+	(*RestorePaymentRequest)(nil),            // 20: payments.v1.RestorePaymentRequest
+	(*RestorePaymentResponse)(nil),           // 21: payments.v1.RestorePaymentResponse
+	(*ReviewPaymentRequest)(nil),             // 22: payments.v1.ReviewPaymentRequest
+	(*ReviewPaymentResponse)(nil),            // 23: payments.v1.ReviewPaymentResponse
+	(*ApprovePaymentRequest)(nil),            // 24: payments.v1.ApprovePaymentRequest
+	(*ApprovePaymentResponse)(nil),           // 25: payments.v1.ApprovePaymentResponse
+	(*Approval)(nil),                         // 26: payments.v1.Approval
+	(*ListApprovalsRequest)(nil),             // 27: payments.v1.ListApprovalsRequest
+	(*ListApprovalsResponse)(nil),            // 28: payments.v1.ListApprovalsResponse
+	(*OrganisationLimits)(nil),               // 29: payments.v1.OrganisationLimits
+	(*CurrencyLimit)(nil),                    // 30: payments.v1.CurrencyLimit
+	(*GetOrganisationLimitsRequest)(nil),     // 31: payments.v1.GetOrganisationLimitsRequest
+	(*GetOrganisationLimitsResponse)(nil),    // 32: payments.v1.GetOrganisationLimitsResponse
+	(*UpdateOrganisationLimitsRequest)(nil),  // 33: payments.v1.UpdateOrganisationLimitsRequest
+	(*UpdateOrganisationLimitsResponse)(nil), // 34: payments.v1.UpdateOrganisationLimitsResponse
+	(*DeleteOrganisationLimitsRequest)(nil),  // 35: payments.v1.DeleteOrganisationLimitsRequest
+	(*DeleteOrganisationLimitsResponse)(nil), // 36: payments.v1.DeleteOrganisationLimitsResponse
+	(*Return)(nil),                           // 37: payments.v1.Return
+	(*CreateReturnRequest)(nil),              // 38: payments.v1.CreateReturnRequest
+	(*CreateReturnResponse)(nil),             // 39: payments.v1.CreateReturnResponse
+	(*GetReturnRequest)(nil),                 // 40: payments.v1.GetReturnRequest
+	(*GetReturnResponse)(nil),                // 41: payments.v1.GetReturnResponse
+	(*ListReturnsRequest)(nil),               // 42: payments.v1.ListReturnsRequest
+	(*ListReturnsResponse)(nil),              // 43: payments.v1.ListReturnsResponse
+	(*UpdateReturnStatusRequest)(nil),        // 44: payments.v1.UpdateReturnStatusRequest
+	(*UpdateReturnStatusResponse)(nil),       // 45: payments.v1.UpdateReturnStatusResponse
+	(*Recall)(nil),                           // 46: payments.v1.Recall
+	(*CreateRecallRequest)(nil),              // 47: payments.v1.CreateRecallRequest
+	(*CreateRecallResponse)(nil),             // 48: payments.v1.CreateRecallResponse
+	(*GetRecallRequest)(nil),                 // 49: payments.v1.GetRecallRequest
+	(*GetRecallResponse)(nil),                // 50: payments.v1.GetRecallResponse
+	(*ListRecallsRequest)(nil),               // 51: payments.v1.ListRecallsRequest
+	(*ListRecallsResponse)(nil),              // 52: payments.v1.ListRecallsResponse
+	(*DecideRecallRequest)(nil),              // 53: payments.v1.DecideRecallRequest
+	(*DecideRecallResponse)(nil),             // 54: payments.v1.DecideRecallResponse
+	(*Balance)(nil),                          // 55: payments.v1.Balance
+	(*Entry)(nil),                            // 56: payments.v1.Entry
+	(*Imbalance)(nil),                        // 57: payments.v1.Imbalance
+	(*GetAccountBalanceRequest)(nil),         // 58: payments.v1.GetAccountBalanceRequest
+	(*GetAccountBalanceResponse)(nil),        // 59: payments.v1.GetAccountBalanceResponse
+	(*ListAccountEntriesRequest)(nil),        // 60: payments.v1.ListAccountEntriesRequest
+	(*ListAccountEntriesResponse)(nil),       // 61: payments.v1.ListAccountEntriesResponse
+	(*CheckLedgerRequest)(nil),               // 62: payments.v1.CheckLedgerRequest
+	(*CheckLedgerResponse)(nil),              // 63: payments.v1.CheckLedgerResponse
+	(*AuditChange)(nil),                      // 64: payments.v1.AuditChange
+	(*AuditEntry)(nil),                       // 65: payments.v1.AuditEntry
+	(*ListAuditEntriesRequest)(nil),          // 66: payments.v1.ListAuditEntriesRequest
+	(*ListAuditEntriesResponse)(nil),         // 67: payments.v1.ListAuditEntriesResponse
+}
+var file_payments_proto_depIdxs = []int32{
+	2,  // 0: payments.v1.Payment.attributes:type_name -> payments.v1.Attributes
+	1,  // 1: payments.v1.Payment.screening_hits:type_name -> payments.v1.ScreeningHit
+	3,  // 2: payments.v1.Attributes.beneficiary_party:type_name -> payments.v1.BeneficiaryParty
+	6,  // 3: payments.v1.Attributes.charges_information:type_name -> payments.v1.ChargesInformation
+	4,  // 4: payments.v1.Attributes.debtor_party:type_name -> payments.v1.DebtorParty
+	8,  // 5: payments.v1.Attributes.fx:type_name -> payments.v1.Forex
+	5,  // 6: payments.v1.Attributes.sponsor_party:type_name -> payments.v1.SponsorParty
+	7,  // 7: payments.v1.ChargesInformation.sender_charges:type_name -> payments.v1.Charge
+	0,  // 8: payments.v1.GetPaymentResponse.payment:type_name -> payments.v1.Payment
+	7,  // 9: payments.v1.GetPaymentResponse.sender_charges_totals:type_name -> payments.v1.Charge
+	0,  // 10: payments.v1.ListPaymentsResponse.payments:type_name -> payments.v1.Payment
+	0,  // 11: payments.v1.CreatePaymentRequest.payment:type_name -> payments.v1.Payment
+	15, // 12: payments.v1.CreatePaymentResponse.warnings:type_name -> payments.v1.Warning
+	0,  // 13: payments.v1.UpdatePaymentRequest.payment:type_name -> payments.v1.Payment
+	26, // 14: payments.v1.ApprovePaymentResponse.approval:type_name -> payments.v1.Approval
+	26, // 15: payments.v1.ListApprovalsResponse.approvals:type_name -> payments.v1.Approval
+	30, // 16: payments.v1.OrganisationLimits.currencies:type_name -> payments.v1.CurrencyLimit
+	29, // 17: payments.v1.GetOrganisationLimitsResponse.limits:type_name -> payments.v1.OrganisationLimits
+	29, // 18: payments.v1.UpdateOrganisationLimitsRequest.limits:type_name -> payments.v1.OrganisationLimits
+	29, // 19: payments.v1.UpdateOrganisationLimitsResponse.limits:type_name -> payments.v1.OrganisationLimits
+	37, // 20: payments.v1.CreateReturnResponse.return:type_name -> payments.v1.Return
+	37, // 21: payments.v1.GetReturnResponse.return:type_name -> payments.v1.Return
+	37, // 22: payments.v1.ListReturnsResponse.returns:type_name -> payments.v1.Return
+	37, // 23: payments.v1.UpdateReturnStatusResponse.return:type_name -> payments.v1.Return
+	46, // 24: payments.v1.CreateRecallResponse.recall:type_name -> payments.v1.Recall
+	46, // 25: payments.v1.GetRecallResponse.recall:type_name -> payments.v1.Recall
+	46, // 26: payments.v1.ListRecallsResponse.recalls:type_name -> payments.v1.Recall
+	46, // 27: payments.v1.DecideRecallResponse.recall:type_name -> payments.v1.Recall
+	55, // 28: payments.v1.GetAccountBalanceResponse.balances:type_name -> payments.v1.Balance
+	56, // 29: payments.v1.ListAccountEntriesResponse.entries:type_name -> payments.v1.Entry
+	57, // 30: payments.v1.CheckLedgerResponse.imbalances:type_name -> payments.v1.Imbalance
+	64, // 31: payments.v1.AuditEntry.changes:type_name -> payments.v1.AuditChange
+	65, // 32: payments.v1.ListAuditEntriesResponse.entries:type_name -> payments.v1.AuditEntry
+	9,  // 33: payments.v1.Payments.GetPayment:input_type -> payments.v1.GetPaymentRequest
+	11, // 34: payments.v1.Payments.ListPayments:input_type -> payments.v1.ListPaymentsRequest
+	13, // 35: payments.v1.Payments.CreatePayment:input_type -> payments.v1.CreatePaymentRequest
+	16, // 36: payments.v1.Payments.UpdatePayment:input_type -> payments.v1.UpdatePaymentRequest
+	18, // 37: payments.v1.Payments.DeletePayment:input_type -> payments.v1.DeletePaymentRequest
+	20, // 38: payments.v1.Payments.RestorePayment:input_type -> payments.v1.RestorePaymentRequest
+	22, // 39: payments.v1.Payments.ReviewPayment:input_type -> payments.v1.ReviewPaymentRequest
+	24, // 40: payments.v1.Payments.ApprovePayment:input_type -> payments.v1.ApprovePaymentRequest
+	27, // 41: payments.v1.Payments.ListApprovals:input_type -> payments.v1.ListApprovalsRequest
+	31, // 42: payments.v1.Payments.GetOrganisationLimits:input_type -> payments.v1.GetOrganisationLimitsRequest
+	33, // 43: payments.v1.Payments.UpdateOrganisationLimits:input_type -> payments.v1.UpdateOrganisationLimitsRequest
+	35, // 44: payments.v1.Payments.DeleteOrganisationLimits:input_type -> payments.v1.DeleteOrganisationLimitsRequest
+	38, // 45: payments.v1.Payments.CreateReturn:input_type -> payments.v1.CreateReturnRequest
+	40, // 46: payments.v1.Payments.GetReturn:input_type -> payments.v1.GetReturnRequest
+	42, // 47: payments.v1.Payments.ListReturns:input_type -> payments.v1.ListReturnsRequest
+	44, // 48: payments.v1.Payments.UpdateReturnStatus:input_type -> payments.v1.UpdateReturnStatusRequest
+	47, // 49: payments.v1.Payments.CreateRecall:input_type -> payments.v1.CreateRecallRequest
+	49, // 50: payments.v1.Payments.GetRecall:input_type -> payments.v1.GetRecallRequest
+	51, // 51: payments.v1.Payments.ListRecalls:input_type -> payments.v1.ListRecallsRequest
+	53, // 52: payments.v1.Payments.DecideRecall:input_type -> payments.v1.DecideRecallRequest
+	58, // 53: payments.v1.Payments.GetAccountBalance:input_type -> payments.v1.GetAccountBalanceRequest
+	60, // 54: payments.v1.Payments.ListAccountEntries:input_type -> payments.v1.ListAccountEntriesRequest
+	62, // 55: payments.v1.Payments.CheckLedger:input_type -> payments.v1.CheckLedgerRequest
+	66, // 56: payments.v1.Payments.ListAuditEntries:input_type -> payments.v1.ListAuditEntriesRequest
+	10, // 57: payments.v1.Payments.GetPayment:output_type -> payments.v1.GetPaymentResponse
+	12, // 58: payments.v1.Payments.ListPayments:output_type -> payments.v1.ListPaymentsResponse
+	14, // 59: payments.v1.Payments.CreatePayment:output_type -> payments.v1.CreatePaymentResponse
+	17, // 60: payments.v1.Payments.UpdatePayment:output_type -> payments.v1.UpdatePaymentResponse
+	19, // 61: payments.v1.Payments.DeletePayment:output_type -> payments.v1.DeletePaymentResponse
+	21, // 62: payments.v1.Payments.RestorePayment:output_type -> payments.v1.RestorePaymentResponse
+	23, // 63: payments.v1.Payments.ReviewPayment:output_type -> payments.v1.ReviewPaymentResponse
+	25, // 64: payments.v1.Payments.ApprovePayment:output_type -> payments.v1.ApprovePaymentResponse
+	28, // 65: payments.v1.Payments.ListApprovals:output_type -> payments.v1.ListApprovalsResponse
+	32, // 66: payments.v1.Payments.GetOrganisationLimits:output_type -> payments.v1.GetOrganisationLimitsResponse
+	34, // 67: payments.v1.Payments.UpdateOrganisationLimits:output_type -> payments.v1.UpdateOrganisationLimitsResponse
+	36, // 68: payments.v1.Payments.DeleteOrganisationLimits:output_type -> payments.v1.DeleteOrganisationLimitsResponse
+	39, // 69: payments.v1.Payments.CreateReturn:output_type -> payments.v1.CreateReturnResponse
+	41, // 70: payments.v1.Payments.GetReturn:output_type -> payments.v1.GetReturnResponse
+	43, // 71: payments.v1.Payments.ListReturns:output_type -> payments.v1.ListReturnsResponse
+	45, // 72: payments.v1.Payments.UpdateReturnStatus:output_type -> payments.v1.UpdateReturnStatusResponse
+	48, // 73: payments.v1.Payments.CreateRecall:output_type -> payments.v1.CreateRecallResponse
+	50, // 74: payments.v1.Payments.GetRecall:output_type -> payments.v1.GetRecallResponse
+	52, // 75: payments.v1.Payments.ListRecalls:output_type -> payments.v1.ListRecallsResponse
+	54, // 76: payments.v1.Payments.DecideRecall:output_type -> payments.v1.DecideRecallResponse
+	59, // 77: payments.v1.Payments.GetAccountBalance:output_type -> payments.v1.GetAccountBalanceResponse
+	61, // 78: payments.v1.Payments.ListAccountEntries:output_type -> payments.v1.ListAccountEntriesResponse
+	63, // 79: payments.v1.Payments.CheckLedger:output_type -> payments.v1.CheckLedgerResponse
+	67, // 80: payments.v1.Payments.ListAuditEntries:output_type -> payments.v1.ListAuditEntriesResponse
+	57, // [57:81] is the sub-list for method output_type
+	33, // [33:57] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_payments_proto_init() }
+func file_payments_proto_init() {
+	if File_payments_proto != nil {
+		return
+	}
+	file_payments_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payments_proto_rawDesc), len(file_payments_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   68,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_payments_proto_goTypes,
+		DependencyIndexes: file_payments_proto_depIdxs,
+		MessageInfos:      file_payments_proto_msgTypes,
+	}.Build()
+	File_payments_proto = out.File
+	file_payments_proto_goTypes = nil
+	file_payments_proto_depIdxs = nil
+}
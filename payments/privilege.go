@@ -0,0 +1,180 @@
+package payments
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/elkousy/payments-api/utility/redact"
+)
+
+// rolesHeader lists the roles of the user, comma separated, it is set by the gateway authenticating the users which
+// must drop the header sent by a client
+const rolesHeader = "X-User-Roles"
+
+// rolesMetadata is the gRPC metadata listing the roles of the user, like the X-User-Roles header of the http transport
+const rolesMetadata = "x-user-roles"
+
+// PrivilegeCheck tells whether the caller of a request is granted a privilege, e.g. to see the account numbers of the
+// parties in full, they are masked but for their last 4 characters otherwise
+type PrivilegeCheck func(ctx context.Context) bool
+
+// RolePrivilegeCheck grants the callers having a role, listed by the X-User-Roles header or the x-user-roles metadata
+func RolePrivilegeCheck(role string) PrivilegeCheck {
+	return func(ctx context.Context) bool {
+		for _, r := range callerRoles(ctx) {
+			if r == role {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// denyPrivilege is the privilege check of the transports when none is given, no caller is granted
+func denyPrivilege(context.Context) bool {
+	return false
+}
+
+// TransportOption configures the http and gRPC transports
+type TransportOption func(*transportOptions)
+
+type transportOptions struct {
+	privileged     PrivilegeCheck
+	admin          PrivilegeCheck
+	trustedProxies []*net.IPNet
+}
+
+// WithPrivilegeCheck decides which callers see the account numbers in full
+func WithPrivilegeCheck(check PrivilegeCheck) TransportOption {
+	return func(o *transportOptions) {
+		o.privileged = check
+	}
+}
+
+// WithAdminCheck decides which callers change or remove the limits of the organisations, none unless it is given
+func WithAdminCheck(check PrivilegeCheck) TransportOption {
+	return func(o *transportOptions) {
+		o.admin = check
+	}
+}
+
+func newTransportOptions(opts []TransportOption) transportOptions {
+	o := transportOptions{privileged: denyPrivilege, admin: denyPrivilege}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// callerRoles returns the roles of the caller of an http request populated by populatePrivilege, or of a gRPC request
+func callerRoles(ctx context.Context) []string {
+	if roles, ok := ctx.Value(contextKeyRoles).([]string); ok {
+		return roles
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return splitRoles(strings.Join(md.Get(rolesMetadata), ","))
+}
+
+func splitRoles(s string) []string {
+	roles := []string{}
+	for _, r := range strings.Split(s, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// populatePrivilege returns the http request func recording the roles of the caller and whether it is privileged
+func populatePrivilege(check PrivilegeCheck) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		ctx = context.WithValue(ctx, contextKeyRoles, splitRoles(r.Header.Get(rolesHeader)))
+		return context.WithValue(ctx, contextKeyPrivileged, check(ctx))
+	}
+}
+
+// populateGRPCPrivilege returns the gRPC request func recording whether the caller is privileged
+func populateGRPCPrivilege(check PrivilegeCheck) kitgrpc.ServerRequestFunc {
+	return func(ctx context.Context, _ metadata.MD) context.Context {
+		return context.WithValue(ctx, contextKeyPrivileged, check(ctx))
+	}
+}
+
+// requirePrivilege returns the endpoint middleware rejecting with an error the callers not granted by a check
+func requirePrivilege(check PrivilegeCheck, err error) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if !check(ctx) {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// isPrivileged reports whether the caller of a request was granted to see the account numbers in full
+func isPrivileged(ctx context.Context) bool {
+	privileged, _ := ctx.Value(contextKeyPrivileged).(bool)
+	return privileged
+}
+
+// maskResponse masks the account numbers of the payments, of the audit log and of the ledger party accounts of a
+// response, unless the caller is privileged
+func maskResponse(ctx context.Context, response interface{}) {
+	if isPrivileged(ctx) {
+		return
+	}
+	switch res := response.(type) {
+	case *GetPaymentResponse:
+		maskPayment(&res.Payment)
+	case *GetListOfPaymentsResponse:
+		for i := range res.Data {
+			maskPayment(&res.Data[i])
+		}
+	case *GetPaymentAuditResponse:
+		for _, entry := range res.Data {
+			for i, change := range entry.Changes {
+				if strings.HasSuffix(change.Field, ".account_number") {
+					entry.Changes[i].Before, entry.Changes[i].After = maskValue(change.Before), maskValue(change.After)
+				}
+			}
+		}
+	case *GetAccountBalanceResponse:
+		res.Account = maskAccount(res.Account)
+	case *GetAccountEntriesResponse:
+		for i := range res.Data {
+			res.Data[i].Account = maskAccount(res.Data[i].Account)
+		}
+	}
+}
+
+// maskAccount masks the account number, or its blind index, of a ledger party account
+func maskAccount(account string) string {
+	parts := strings.SplitN(account, ":", 3)
+	if len(parts) != 3 || parts[0] != "party" {
+		return account
+	}
+	return parts[0] + ":" + parts[1] + ":" + redact.Mask(parts[2])
+}
+
+// maskPayment masks the account numbers of the parties of a payment
+func maskPayment(p *Payment) {
+	a := &p.Attributes
+	for _, n := range []*string{&a.BeneficiaryParty.AccountNumber, &a.DebtorParty.AccountNumber, &a.SponsorParty.AccountNumber} {
+		*n = redact.Mask(*n)
+	}
+}
+
+func maskValue(v interface{}) interface{} {
+	if s, ok := v.(string); ok {
+		return redact.Mask(s)
+	}
+	return v
+}
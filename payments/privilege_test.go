@@ -0,0 +1,111 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/ledger"
+)
+
+func Test_HTTP_GetPayment_MasksAccountNumbers(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name  string
+		roles string
+		want  string
+	}{
+		{name: "Should show the account numbers to a privileged caller", roles: "payments:read, payments:pii", want: "GB29XABC10161234567801"},
+		{name: "Should mask the account numbers for the other callers", roles: "payments:read", want: "******************7801"},
+		{name: "Should mask the account numbers for an anonymous caller", want: "******************7801"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Attributes.DebtorParty.AccountNumber = "GB29XABC10161234567801"
+			mockService := &MockService{}
+			mockService.On("GetPayment", GetPaymentRequest{PaymentID: id}).Return(&GetPaymentResponse{Payment: p}, nil)
+			h := MakeHTTPHandler(MakeEndpoints(mockService), NewEventBroker(10, 10), mux.NewRouter(), WithPrivilegeCheck(RolePrivilegeCheck("payments:pii")))
+			r := httptest.NewRequest(http.MethodGet, "/v1/payments/"+id+"/", nil)
+			r.Header.Set(rolesHeader, tt.roles)
+			w := httptest.NewRecorder()
+
+			//Act
+			h.ServeHTTP(w, r)
+
+			//Assert
+			require.Equal(t, http.StatusOK, w.Code)
+			var res GetPaymentResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			assert.Equal(t, tt.want, res.Attributes.DebtorParty.AccountNumber)
+			assert.Equal(t, p.Attributes.DebtorParty.Name, res.Attributes.DebtorParty.Name)
+		})
+	}
+}
+
+func Test_HTTP_DeleteOrganisationLimits_RequiresAdmin(t *testing.T) {
+	organisationID := "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb"
+	tests := []struct {
+		name  string
+		roles string
+		want  int
+	}{
+		{name: "Should remove the limits for an administrator", roles: "payments:read, payments:admin", want: http.StatusAccepted},
+		{name: "Should forbid the other callers", roles: "payments:read", want: http.StatusForbidden},
+		{name: "Should forbid an anonymous caller", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			mockService.On("DeleteOrganisationLimits", DeleteOrganisationLimitsRequest{OrganisationID: organisationID}).Return(&DeleteOrganisationLimitsResponse{}, nil)
+			h := MakeHTTPHandler(MakeEndpoints(mockService), NewEventBroker(10, 10), mux.NewRouter(), WithAdminCheck(RolePrivilegeCheck("payments:admin")))
+			r := httptest.NewRequest(http.MethodDelete, "/v1/admin/organisations/"+organisationID+"/limits/", nil)
+			r.Header.Set(rolesHeader, tt.roles)
+			w := httptest.NewRecorder()
+
+			//Act
+			h.ServeHTTP(w, r)
+
+			//Assert
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusForbidden {
+				mockService.AssertNotCalled(t, "DeleteOrganisationLimits", DeleteOrganisationLimitsRequest{OrganisationID: organisationID})
+			}
+		})
+	}
+}
+
+func Test_maskResponse(t *testing.T) {
+	// Arrange
+	list := &GetListOfPaymentsResponse{Data: []Payment{mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")}}
+	list.Data[0].Attributes.BeneficiaryParty.AccountNumber = "GB29XABC10161234567801"
+	audit := &GetPaymentAuditResponse{Data: []AuditEntry{{Changes: AuditChanges{
+		{Field: "attributes.beneficiary_party.account_number", Before: "GB29XABC10161234567801", After: "GB29XABC10161234567802"},
+		{Field: "attributes.beneficiary_party.bank_id", Before: "203301", After: "203302"},
+	}}}}
+	balance := &GetAccountBalanceResponse{Account: "party:203301:GB29XABC10161234567801"}
+	entries := &GetAccountEntriesResponse{Data: []ledger.Entry{{Account: "party:203301:GB29XABC10161234567801"}, {Account: "charges:203301"}}}
+
+	//Act
+	maskResponse(context.Background(), list)
+	maskResponse(context.Background(), audit)
+	maskResponse(context.Background(), balance)
+	maskResponse(context.Background(), entries)
+
+	//Assert
+	assert.Equal(t, "******************7801", list.Data[0].Attributes.BeneficiaryParty.AccountNumber)
+	assert.Equal(t, "******************7801", audit.Data[0].Changes[0].Before)
+	assert.Equal(t, "******************7802", audit.Data[0].Changes[0].After)
+	assert.Equal(t, "203302", audit.Data[0].Changes[1].After)
+	assert.Equal(t, "party:203301:******************7801", balance.Account)
+	assert.Equal(t, "party:203301:******************7801", entries.Data[0].Account)
+	assert.Equal(t, "charges:203301", entries.Data[1].Account)
+}
@@ -0,0 +1,82 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+// ParseTrustedProxies parses the comma separated IP addresses and CIDR ranges of the proxies in front of the service
+func ParseTrustedProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, proxy, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %v", p, err)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+// WithTrustedProxies sets the proxies whose X-Forwarded-For entries are trusted, none unless it is given
+func WithTrustedProxies(proxies []*net.IPNet) TransportOption {
+	return func(o *transportOptions) {
+		o.trustedProxies = proxies
+	}
+}
+
+// populateSourceIP returns the http request func recording the source IP of the request
+func populateSourceIP(proxies []*net.IPNet) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return context.WithValue(ctx, contextKeySourceIP, sourceIP(r, proxies))
+	}
+}
+
+// sourceIP returns the address of the client of a request. The X-Forwarded-For entries can be forged by the clients
+// but for those appended by the trusted proxies: they are read from the right, the first address which is not a
+// trusted proxy is the client.
+func sourceIP(r *http.Request, proxies []*net.IPNet) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+	if !trusted(ip, proxies) {
+		return ip
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if ip = hop; !trusted(hop, proxies) {
+			break
+		}
+	}
+	return ip
+}
+
+func trusted(ip string, proxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	for _, proxy := range proxies {
+		if parsed != nil && proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package payments
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/elkousy/payments-api/calendar"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+// defaultRecallWindow is the number of business days after the processing date of a payment in which it can be recalled
+const defaultRecallWindow = 10
+
+// recallReason is an ISO 20022 cancellation reason of the recalls
+type recallReason struct {
+	description string
+	// months is the number of calendar months after the processing date in which the payment can be recalled
+	// for this reason, when the recall window of the service does not apply
+	months int
+}
+
+// recallReasonCodes are the ISO 20022 cancellation reason codes of the recalls
+var recallReasonCodes = map[string]recallReason{
+	"AC03": {description: "invalid creditor account number"},
+	"AM09": {description: "wrong amount"},
+	"CUST": {description: "requested by customer"},
+	"DUPL": {description: "duplicate payment"},
+	"FRAD": {description: "fraudulent origin", months: 13},
+	"TECH": {description: "technical problem"},
+}
+
+// recallCodes returns the reason codes of the recalls, sorted
+func recallCodes() []string {
+	codes := make([]string, 0, len(recallReasonCodes))
+	for code := range recallReasonCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// recallDeadline returns the last day a payment can be recalled for a reason: the given number of business days of
+// the calendar of its scheme after its processing date, or the months of the reason
+func recallDeadline(p Payment, reasonCode string, window int) (time.Time, error) {
+	date, err := time.Parse(calendar.DateFormat, p.Attributes.ProcessingDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if months := recallReasonCodes[reasonCode].months; months > 0 {
+		return date.AddDate(0, months, 0), nil
+	}
+	days := calendar.New("weekdays", nil)
+	if scheme, ok := calendar.ForScheme(p.Attributes.PaymentScheme); ok {
+		days = scheme.Calendar
+	}
+	for i := 0; i < window; i++ {
+		date = days.NextBusinessDay(date)
+	}
+	return date, nil
+}
+
+// checkRecallEligibility returns the reasons why a payment cannot be recalled on the given day: it has not gone out,
+// or the recall window after its processing date is closed
+func checkRecallEligibility(p Payment, reasonCode string, today time.Time, window int) []apierrors.FieldError {
+	if !isReturnable(p) {
+		return []apierrors.FieldError{{Field: "status", Message: fmt.Sprintf("is %s, only a payment which has gone out can be recalled", p.Status)}}
+	}
+	deadline, err := recallDeadline(p, reasonCode, window)
+	if err != nil {
+		return []apierrors.FieldError{{Field: "attributes.processing_date", Message: "is not a date, expected YYYY-MM-DD"}}
+	}
+	if today.After(deadline) {
+		return []apierrors.FieldError{{
+			Field:   "attributes.processing_date",
+			Message: fmt.Sprintf("is too old, the %s recall window closed on %s", reasonCode, deadline.Format(calendar.DateFormat)),
+		}}
+	}
+	return nil
+}
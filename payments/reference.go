@@ -0,0 +1,78 @@
+package payments
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elkousy/payments-api/calendar"
+	"github.com/elkousy/payments-api/currency"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+	"github.com/gorilla/mux"
+)
+
+const (
+	currenciesPath = "/v1/reference/currencies"
+	calendarsPath  = "/v1/reference/calendars/{scheme}"
+)
+
+// CurrenciesResponse is the response object returned by the currencies reference endpoint
+type CurrenciesResponse struct {
+	Data []currency.Currency `json:"data"`
+}
+
+// serveCurrencies serves the ISO 4217 currencies used to validate the payments
+func serveCurrencies(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(CurrenciesResponse{Data: currency.All()})
+}
+
+// SchemeCalendar is the business-day calendar of a payment scheme
+type SchemeCalendar struct {
+	Scheme   string `json:"scheme"`
+	Calendar string `json:"calendar"`
+	// TimeZone of the days and of the cut-off time of the calendar
+	TimeZone string `json:"time_zone"`
+	// CutOff is the local time, HH:MM, after which the payments are processed the next business day
+	CutOff string `json:"cut_off,omitempty"`
+	// NextProcessingDate is the date on which a payment instructed now is processed by the scheme
+	NextProcessingDate string             `json:"next_processing_date"`
+	Holidays           []calendar.Holiday `json:"holidays"`
+}
+
+// CalendarResponse is the response object returned by the calendars reference endpoint
+type CalendarResponse struct {
+	Data SchemeCalendar `json:"data"`
+}
+
+// serveCalendar serves the calendar of a payment scheme, with the holidays of the year query parameter when given
+func serveCalendar(w http.ResponseWriter, r *http.Request) {
+	scheme, ok := calendar.ForScheme(mux.Vars(r)["scheme"])
+	if !ok {
+		apierrors.LoggingErrorEncoder(r.Context(), ErrCalendarNotFound, w)
+		return
+	}
+	year := 0
+	if v := r.URL.Query().Get("year"); v != "" {
+		var err error
+		if year, err = strconv.Atoi(v); err != nil || year < 1 || year > 9999 {
+			apierrors.LoggingErrorEncoder(r.Context(), ErrInvalidCalendarYear, w)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(CalendarResponse{Data: SchemeCalendar{
+		Scheme:             scheme.Name,
+		Calendar:           scheme.Calendar.Name,
+		TimeZone:           scheme.Calendar.Location.String(),
+		CutOff:             scheme.CutOff,
+		NextProcessingDate: scheme.ProcessingDate(time.Now()).Format(calendar.DateFormat),
+		Holidays:           scheme.Calendar.Holidays(year),
+	}})
+}
@@ -0,0 +1,77 @@
+package payments
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_serveCurrencies(t *testing.T) {
+	// Arrange
+	router := mux.NewRouter()
+	MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, currenciesPath, nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code)
+	var res CurrenciesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.NotEmpty(t, res.Data)
+	codes := map[string]int{}
+	for _, c := range res.Data {
+		codes[c.Code] = c.MinorUnits
+	}
+	assert.Equal(t, 2, codes["GBP"])
+	assert.Equal(t, 0, codes["JPY"])
+}
+
+func Test_serveCalendar(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		wantCode     int
+		wantCalendar string
+		wantHolidays []string
+	}{
+		{name: "Should serve the calendar of a scheme", path: "/v1/reference/calendars/Bacs?year=2019", wantCode: http.StatusOK, wantCalendar: "UK",
+			wantHolidays: []string{"2019-01-01", "2019-04-19", "2019-04-22", "2019-05-06", "2019-05-27", "2019-08-26", "2019-12-25", "2019-12-26"}},
+		{name: "Should serve the TARGET2 calendar of SEPA", path: "/v1/reference/calendars/SEPA?year=2019", wantCode: http.StatusOK, wantCalendar: "TARGET2",
+			wantHolidays: []string{"2019-01-01", "2019-04-19", "2019-04-22", "2019-05-01", "2019-12-25", "2019-12-26"}},
+		{name: "Should not find the calendar of an unknown scheme", path: "/v1/reference/calendars/Swift", wantCode: http.StatusNotFound},
+		{name: "Should reject an invalid year", path: "/v1/reference/calendars/FPS?year=last", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := mux.NewRouter()
+			MakeHTTPHandler(Endpoints{}, NewEventBroker(10, 10), router)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			// Assert
+			require.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var res CalendarResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			assert.Equal(t, tt.wantCalendar, res.Data.Calendar)
+			assert.NotEmpty(t, res.Data.NextProcessingDate)
+			dates := []string{}
+			for _, h := range res.Data.Holidays {
+				dates = append(dates, h.Date)
+			}
+			assert.Equal(t, tt.wantHolidays, dates)
+		})
+	}
+}
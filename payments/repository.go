@@ -1,11 +1,19 @@
 package payments
 
 import (
+	"database/sql"
 	"fmt"
+	"math/big"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 
+	"github.com/elkousy/payments-api/calendar"
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/ledger"
 	"github.com/elkousy/payments-api/utility/config"
+	"github.com/elkousy/payments-api/utility/logger"
 	_ "github.com/lib/pq" //pq imports the postgres driver
 	uuid "github.com/satori/go.uuid"
 )
@@ -13,23 +21,70 @@ import (
 // Repository describes a payments repository used to manipulate payments data
 type Repository interface {
 	GetPayment(id string) (Payment, error)
-	GetListOfPayments() ([]Payment, error)
-	CreatePayment(p Payment) (string, error)
-	UpdatePayment(id string, p Payment) error
-	DeletePayment(id string) error
+	GetPaymentVersion(id string, version uint) (Payment, error)
+	GetPaymentAsOf(id string, at time.Time) (Payment, error)
+	GetListOfPayments(q ListQuery) ([]Payment, error)
+	CreatePayment(p Payment, actor Actor) (string, error)
+	GetPaymentByIdempotencyKey(organisationID uuid.UUID, key string) (*Payment, error)
+	GetPaymentByFingerprint(fingerprint string, since time.Time) (*Payment, error)
+	UpdatePayment(id string, p Payment, actor Actor) error
+	TransitionPaymentStatus(id string, from PaymentStatus, to PaymentStatus, reason string, actor Actor) (bool, error)
+	DeletePayment(id string, actor Actor) error
+	RestorePayment(id string, actor Actor) (Payment, error)
+	PurgeDeletedPayments(before time.Time, actor Actor) (int, error)
+	GetPaymentsToArchive(before time.Time, limit int) ([]Payment, error)
+	ArchivePayments(file ArchiveFile, ids []string, actor Actor) error
+	GetArchiveFile(paymentID string) (*ArchiveFile, error)
+	ReencryptParties(limit int) (int, error)
+	GetDuePayments(day time.Time) ([]Payment, error)
+	MarkPaymentHandled(id string, at time.Time) error
+	RecordApproval(a Approval, to PaymentStatus, actor Actor) (bool, error)
+	GetApprovals(paymentID string) ([]Approval, error)
+	GetAuditEntries(paymentID string) ([]AuditEntry, error)
+	CreateReturn(r Return, check ReturnCheck) (Return, error)
+	GetReturn(paymentID string, t ReturnType, id string) (Return, error)
+	GetReturns(paymentID string, t ReturnType) ([]Return, error)
+	TransitionReturnStatus(id string, from ReturnStatus, to ReturnStatus, reason string, actor Actor) (bool, error)
+	CreateRecall(r Recall) (bool, error)
+	GetRecall(paymentID string, id string) (Recall, error)
+	GetRecalls(paymentID string) ([]Recall, error)
+	DecideRecall(id string, status RecallStatus, reason string, decidedAt time.Time, actor Actor) (bool, error)
+	CreatePaymentWithinLimits(p Payment, actor Actor, day time.Time, check UsageCheck) (string, error)
+	UpdatePaymentWithinLimits(id string, p Payment, actor Actor, check UsageCheck) error
+	GetOrganisationLimits(organisationID uuid.UUID) (*OrganisationLimits, error)
+	SaveOrganisationLimits(l OrganisationLimits) error
+	DeleteOrganisationLimits(organisationID uuid.UUID) error
+	GetAccountBalances(account string) ([]ledger.Balance, error)
+	GetAccountEntries(account string, q ListQuery) ([]ledger.Entry, error)
+	GetLedgerImbalances() ([]ledger.Imbalance, error)
+}
+
+// ListQuery holds the options used to select a page of payments
+type ListQuery struct {
+	Offset         int
+	Limit          int
+	IncludeDeleted bool
+	// AccountNumber selects the payments of a debtor or beneficiary account number
+	AccountNumber string
 }
 
 const connectionString = "host=%s port=%d dbname=%s user=%s password=%s sslmode=disable connect_timeout=%d application_name=%s"
 
 type paymentRepository struct {
 	db *gorm.DB
+	// keys encrypt the parties, nil when they are not encrypted
+	keys encryption.KeyProvider
 }
 
 // NewPaymentRepository ...
-func NewPaymentRepository(db *gorm.DB) Repository {
-	return &paymentRepository{
+func NewPaymentRepository(db *gorm.DB, opts ...RepositoryOption) Repository {
+	r := &paymentRepository{
 		db: db,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // DbConnect connects to the db
@@ -39,6 +94,11 @@ func DbConnect() (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	// the statements are logged on demand only, their parameters may be account numbers or names
+	db.SetLogger(logger.SQLLogger{})
+	if config.SQLLog {
+		db.LogMode(true)
+	}
 
 	return db, nil
 }
@@ -47,10 +107,24 @@ func newConnection(host string, port int, name string, user string, password str
 	return fmt.Sprintf(connectionString, host, port, name, user, password, timeout, app)
 }
 
-// DbMigrate initializes db schema with needed tables
+// DbMigrate initializes db schema with needed tables, missing columns and indexes are added to existing tables
 func DbMigrate(db *gorm.DB) {
 	//db.DropTableIfExists(&Payment{}, &Attributes{}, &BeneficiaryParty{}, &DebtorParty{}, &SponsorParty{}, &ChargesInformation{}, &Charge{}, &Forex{})
-	db.CreateTable(&Payment{}, &Attributes{}, &BeneficiaryParty{}, &DebtorParty{}, &SponsorParty{}, &ChargesInformation{}, &Charge{}, &Forex{})
+	db.AutoMigrate(&Payment{}, &Attributes{}, &BeneficiaryParty{}, &DebtorParty{}, &SponsorParty{}, &ChargesInformation{}, &Charge{}, &Forex{}, &ScreeningHit{}, &OrganisationLimits{}, &CurrencyLimit{}, &LimitUsage{}, &Approval{}, &Return{}, &Recall{},
+		&ledger.Transaction{}, &ledger.Entry{}, &AuditEntry{}, &PaymentVersion{}, &ArchiveFile{}, &ArchivedPayment{}, &Event{})
+	// the duplicates of a payment are looked up by fingerprint among the recent payments
+	db.Model(&Payment{}).AddIndex("idx_payments_fingerprint", "fingerprint", "created_at")
+	// the encrypted names, addresses and account numbers of the parties outgrow varchar(255)
+	db.Model(&SponsorParty{}).ModifyColumn("account_number", "text")
+	for _, party := range []interface{}{&DebtorParty{}, &BeneficiaryParty{}} {
+		for _, column := range []string{"account_number", "account_name", "address", "name"} {
+			db.Model(party).ModifyColumn(column, "text")
+		}
+	}
+	// the payments created before their versions were kept start their history with their current version
+	db.Exec(`INSERT INTO payment_versions (payment_id, version, type, organisation_id, attributes_id, status, status_reason, created_by, approval_required, created_at)
+		SELECT id, version, type, organisation_id, attributes_id, status, status_reason, created_by, approval_required, updated_at FROM payments
+		WHERE NOT EXISTS (SELECT 1 FROM payment_versions WHERE payment_versions.payment_id = payments.id)`)
 }
 
 // DbClose closes the connection to the database
@@ -62,66 +136,972 @@ func DbClose(db *gorm.DB) {
 
 // GetPaymentByID ...
 func (r *paymentRepository) GetPayment(id string) (Payment, error) {
+	return findPayment(r.db, id)
+}
+
+// findPayment returns a payment with its nested resources, an unscoped db finds the deleted payments too
+func findPayment(db *gorm.DB, id string) (Payment, error) {
 	p := Payment{}
-	err := r.db.Debug().Model(&p).Where("id = ?", id).Preload("Attributes.BeneficiaryParty").Preload("Attributes.ChargesInformation.SenderCharges").Preload("Attributes.DebtorParty").Preload("Attributes.Forex").Preload("Attributes.SponsorParty").Find(&p).Error
+	// the nested rows are soft deleted with their payment, they are found whenever it is
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	err := db.Model(&p).Where("id = ?", id).Preload("Attributes", unscoped).Preload("Attributes.BeneficiaryParty", unscoped).Preload("Attributes.ChargesInformation", unscoped).Preload("Attributes.ChargesInformation.SenderCharges", unscoped).Preload("Attributes.DebtorParty", unscoped).Preload("Attributes.Forex", unscoped).Preload("Attributes.SponsorParty", unscoped).Preload("ScreeningHits").Find(&p).Error
 	if err != nil {
 		return p, ErrNotFound.FromError(err)
 	}
+	p.Deleted = p.DeletedAt != nil
 	return p, nil
 }
 
-// CreatePayment ...
-func (r *paymentRepository) CreatePayment(p Payment) (string, error) {
+// GetPaymentVersion returns a version of a payment with its attributes as they were then
+func (r *paymentRepository) GetPaymentVersion(id string, version uint) (Payment, error) {
+	return findPaymentVersion(r.db, id, "version = ?", version)
+}
+
+// GetPaymentAsOf returns the version of a payment current at the given time with its attributes as they were then
+func (r *paymentRepository) GetPaymentAsOf(id string, at time.Time) (Payment, error) {
+	return findPaymentVersion(r.db, id, "created_at <= ?", at)
+}
+
+// findPaymentVersion returns the latest version of a payment matching the condition. The screening hits are those of
+// the current version, they are not kept.
+func findPaymentVersion(db *gorm.DB, id string, condition string, value interface{}) (Payment, error) {
+	p, err := findPayment(db, id)
+	if err != nil {
+		return p, err
+	}
+	v := PaymentVersion{}
+	err = db.Where("payment_id = ?", id).Where(condition, value).Order("version desc").First(&v).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return p, ErrVersionNotFound
+	}
+	if err != nil {
+		return p, err
+	}
+	a := Attributes{}
+	err = db.Where("id = ?", v.AttributesID).Preload("BeneficiaryParty").Preload("ChargesInformation.SenderCharges").Preload("DebtorParty").Preload("Forex").Preload("SponsorParty").First(&a).Error
+	if err != nil {
+		return p, err
+	}
+	v.apply(&p, a)
+	return p, nil
+}
+
+// CreatePayment creates a payment, posts its ledger transaction and records its creation in the audit log
+func (r *paymentRepository) CreatePayment(p Payment, actor Actor) (string, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return "", tx.Error
+	}
+	defer tx.Rollback()
+
 	paymentID := uuid.NewV4()
-	p.ID = paymentID
-	err := r.db.Debug().Save(&p).Error
+	p.ID, p.Version = paymentID, 0
+	err := tx.Save(&p).Error
 	if err != nil {
 		return "", err
 	}
+	if err := recordVersion(tx, p); err != nil {
+		return "", err
+	}
+	if err := postPaymentTransaction(tx, p.ID.String(), r.paymentTransaction(p, ledgerPaymentCreated), ledgerPaymentCreated); err != nil {
+		return "", err
+	}
+	if err := recordAudit(tx, AuditCreate, p.ID, actor, nil, &p); err != nil {
+		return "", err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return "", err
+	}
 	return paymentID.String(), nil
 }
 
-// UpdatePayment ...
-func (r *paymentRepository) UpdatePayment(id string, p Payment) error {
+// GetPaymentByIdempotencyKey returns the payment created by an organisation with the given idempotency key, nil if none
+func (r *paymentRepository) GetPaymentByIdempotencyKey(organisationID uuid.UUID, key string) (*Payment, error) {
+	p := Payment{}
+	err := r.db.Where("organisation_id = ? AND idempotency_key = ?", organisationID, key).First(&p).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetPaymentByFingerprint returns the latest payment with the given fingerprint created since the given time, nil if none
+func (r *paymentRepository) GetPaymentByFingerprint(fingerprint string, since time.Time) (*Payment, error) {
+	p := Payment{}
+	err := r.db.Where("fingerprint = ? AND created_at >= ?", fingerprint, since).Order("created_at desc").First(&p).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpdatePayment saves a new version of a payment, its ledger transaction is reversed and the transaction of the new
+// payment posted, the changes are recorded in the audit log. The payment is locked until then, so concurrent updates
+// cannot save the same version and a payment cannot become due or rejected meanwhile, the due and rejected payments are
+// not updated. The amount of the payment is no longer counted in the limit usages.
+func (r *paymentRepository) UpdatePayment(id string, p Payment, actor Actor) error {
+	return r.updatePayment(id, p, actor, nil)
+}
+
+// UpdatePaymentWithinLimits updates a payment like UpdatePayment if its new amount passes the check of the totals of
+// its organisation in its currency, on the day it was created and in its month. The amount of the previous version is
+// left out of the totals. The limits of the organisation are locked until the payment is updated, so concurrent payments
+// cannot exceed them.
+func (r *paymentRepository) UpdatePaymentWithinLimits(id string, p Payment, actor Actor, check UsageCheck) error {
+	return r.updatePayment(id, p, actor, check)
+}
+
+// updatePayment updates a payment, its new amount is counted in the limit usages when there is a check of the totals
+func (r *paymentRepository) updatePayment(id string, p Payment, actor Actor, check UsageCheck) error {
 	pid, err := uuid.FromString(id)
 	if err != nil {
 		return err
 	}
 	p.ID = pid
-	pa := &Payment{}
-	if err := r.db.Debug().Model(&p).Where("id = ?", p.ID).Find(&pa).Error; err != nil {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&Payment{}).Error; err != nil {
 		return ErrNotFound.FromError(err)
 	}
+	before, err := findPayment(tx, id)
+	if err != nil {
+		return err
+	}
+	if !before.Status.updatable() {
+		return ErrPaymentNotUpdatable
+	}
+
+	// the amount of the previous version is released, the totals are then checked with the new amount
+	day := usageDay(before.CreatedAt)
+	if before.LimitUsageCounted {
+		if err := addUsage(tx, before.OrganisationID, before.Attributes.Currency, day, "-"+before.Attributes.Amount); err != nil {
+			return err
+		}
+	}
+	p.LimitUsageCounted = check != nil
+	if check != nil {
+		if err := checkUsage(tx, p.OrganisationID, p.Attributes.Currency, day, check); err != nil {
+			return err
+		}
+		if err := addUsage(tx, p.OrganisationID, p.Attributes.Currency, day, p.Attributes.Amount); err != nil {
+			return err
+		}
+	}
 
-	err = r.db.Debug().Model(&p).Save(&p).Error
+	// the hits of the new screening replace the previous ones
+	if err := tx.Where("payment_id = ?", p.ID).Delete(&ScreeningHit{}).Error; err != nil {
+		return err
+	}
+	// the status only changes through TransitionPaymentStatus, the creator and the idempotency key of the creation never
+	// change. The attributes of the previous version are kept, the new version gets its own.
+	p.Version = before.Version + 1
+	detachAttributes(&p)
+	err = tx.Model(&p).Omit("status", "status_reason", "created_by", "idempotency_key").Save(&p).Error
 	if err != nil {
 		return err
 	}
-	return nil
+	p.Status, p.StatusReason, p.CreatedBy, p.IdempotencyKey = before.Status, before.StatusReason, before.CreatedBy, before.IdempotencyKey
+	if err := recordVersion(tx, p); err != nil {
+		return err
+	}
+	if err := postPaymentTransaction(tx, id, r.paymentTransaction(p, ledgerPaymentUpdated), ledgerPaymentUpdated); err != nil {
+		return err
+	}
+	if err := recordAudit(tx, AuditUpdate, p.ID, actor, &before, &p); err != nil {
+		return err
+	}
+	return tx.Commit().Error
 }
 
-// DeletePayment ...
-func (r *paymentRepository) DeletePayment(id string) error {
-	pa := &Payment{}
-	if err := r.db.Debug().Model(pa).Where("id = ?", id).Find(pa).Error; err != nil {
-		return ErrNotFound.FromError(err)
+// TransitionPaymentStatus moves a payment from a status to another, it returns false when the payment is not in the from status.
+// The payment is locked until the change is recorded in the audit log, so concurrent transitions cannot both succeed.
+// The ledger transaction of a payment moved to rejected is reversed.
+func (r *paymentRepository) TransitionPaymentStatus(id string, from PaymentStatus, to PaymentStatus, reason string, actor Actor) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	before, err := lockPayment(tx, id)
+	if err != nil || before.Status != from {
+		return false, err
+	}
+	after := before
+	after.Version, after.Status, after.StatusReason = before.Version+1, to, reason
+	err = tx.Model(&Payment{}).Where("id = ?", id).Updates(map[string]interface{}{"version": after.Version, "status": to, "status_reason": reason}).Error
+	if err != nil {
+		return false, err
+	}
+	if err := recordVersion(tx, after); err != nil {
+		return false, err
+	}
+	if err := postRejection(tx, after); err != nil {
+		return false, err
+	}
+	if err := recordAudit(tx, AuditStatusChange, before.ID, actor, &before, &after); err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// postRejection reverses the ledger transaction of a payment moved to rejected, the money never moves
+func postRejection(tx *gorm.DB, p Payment) error {
+	if p.Status != StatusRejected {
+		return nil
+	}
+	return postPaymentTransaction(tx, p.ID.String(), nil, ledgerPaymentRejected)
+}
+
+// lockPayment locks a payment until the end of the database transaction and returns it, a zero payment when it does
+// not exist
+func lockPayment(tx *gorm.DB, id string) (Payment, error) {
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&Payment{}).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return Payment{}, nil
+	}
+	if err != nil {
+		return Payment{}, err
+	}
+	return findPayment(tx, id)
+}
+
+// GetDuePayments returns the scheduled payments whose processing date is the given day or before, and the due payments
+// not handled yet, oldest first
+func (r *paymentRepository) GetDuePayments(day time.Time) ([]Payment, error) {
+	var payments []Payment
+	err := r.db.Joins("JOIN attributes ON attributes.id = payments.attributes_id").
+		Where("(payments.status = ? OR (payments.status = ? AND payments.handled_at IS NULL)) AND attributes.processing_date <= ?",
+			StatusScheduled, StatusDue, day.Format(calendar.DateFormat)).
+		Order("attributes.processing_date, payments.created_at").Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range payments {
+		if payments[i], err = r.GetPayment(p.ID.String()); err != nil {
+			return nil, err
+		}
+	}
+	return payments, nil
+}
+
+// MarkPaymentHandled records when the due payment handler processed a payment
+func (r *paymentRepository) MarkPaymentHandled(id string, at time.Time) error {
+	return r.db.Model(&Payment{}).Where("id = ?", id).UpdateColumn("handled_at", at).Error
+}
+
+// RecordApproval moves a payment pending approval to the status decided by the approval and records the approval,
+// it returns false when the payment is not pending approval anymore. The ledger transaction of a rejected payment is
+// reversed.
+func (r *paymentRepository) RecordApproval(a Approval, to PaymentStatus, actor Actor) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	before, err := lockPayment(tx, a.PaymentID.String())
+	if err != nil || before.Status != StatusPendingApproval {
+		return false, err
+	}
+	after := before
+	after.Version, after.Status, after.StatusReason = before.Version+1, to, a.Comment
+	err = tx.Model(&Payment{}).Where("id = ?", a.PaymentID).Updates(map[string]interface{}{"version": after.Version, "status": to, "status_reason": a.Comment}).Error
+	if err != nil {
+		return false, err
+	}
+	if err := recordVersion(tx, after); err != nil {
+		return false, err
+	}
+	if err := tx.Create(&a).Error; err != nil {
+		return false, err
+	}
+	if err := postRejection(tx, after); err != nil {
+		return false, err
+	}
+	if err := recordAudit(tx, AuditApproval, a.PaymentID, actor, &before, &after); err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetApprovals returns the approvals of a payment, oldest first
+func (r *paymentRepository) GetApprovals(paymentID string) ([]Approval, error) {
+	approvals := []Approval{}
+	if err := r.db.Where("payment_id = ?", paymentID).Order("created_at, id").Find(&approvals).Error; err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+// GetAuditEntries returns the audit log of a payment, oldest first, deleted payments included
+func (r *paymentRepository) GetAuditEntries(paymentID string) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+	if err := r.db.Where("payment_id = ?", paymentID).Order("created_at, id").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CreateReturn creates a return or a reversal if it passes the check of the amount of its payment already returned.
+// The payment is locked until the return is created, so concurrent returns cannot exceed its amount.
+func (r *paymentRepository) CreateReturn(ret Return, check ReturnCheck) (Return, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return ret, tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", ret.PaymentID).First(&Payment{}).Error; err != nil {
+		return ret, ErrNotFound.FromError(err)
+	}
+	var total sql.NullString
+	err := tx.Model(&Return{}).Where("payment_id = ? AND status <> ?", ret.PaymentID, ReturnStatusFailed).
+		Select("COALESCE(SUM(amount), 0)").Row().Scan(&total)
+	if err != nil {
+		return ret, err
+	}
+	if err := check(&ret, parseAmount(total.String)); err != nil {
+		return ret, err
+	}
+	if err := tx.Create(&ret).Error; err != nil {
+		return ret, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
+// GetReturn returns a return or a reversal of a payment
+func (r *paymentRepository) GetReturn(paymentID string, t ReturnType, id string) (Return, error) {
+	ret := Return{}
+	err := r.db.Where("id = ? AND payment_id = ? AND type = ?", id, paymentID, t).First(&ret).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return ret, ErrReturnNotFound
+	}
+	return ret, err
+}
+
+// GetReturns returns the returns or the reversals of a payment, oldest first
+func (r *paymentRepository) GetReturns(paymentID string, t ReturnType) ([]Return, error) {
+	returns := []Return{}
+	if err := r.db.Where("payment_id = ? AND type = ?", paymentID, t).Order("created_at, id").Find(&returns).Error; err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+// TransitionReturnStatus moves a return from a status to another, it returns false when the return is not in the from
+// status. The transition is recorded in the audit log of the payment.
+func (r *paymentRepository) TransitionReturnStatus(id string, from ReturnStatus, to ReturnStatus, reason string, actor Actor) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	before := Return{}
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&before).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if before.Status != from {
+		return false, nil
+	}
+	after := before
+	after.Status, after.StatusReason = to, reason
+	if err := tx.Model(&Return{}).Where("id = ?", id).Updates(map[string]interface{}{"status": to, "status_reason": reason}).Error; err != nil {
+		return false, err
+	}
+	path := returnsSegments[before.Type] + "." + before.ID.String()
+	if err := recordTransitionAudit(tx, AuditReturnStatusChange, before.PaymentID, actor, path, before, after); err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateRecall creates a recall, it returns false when a recall of the payment is already requested.
+// The payment is locked until the recall is created, so concurrent recalls cannot both be requested.
+func (r *paymentRepository) CreateRecall(recall Recall) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", recall.PaymentID).First(&Payment{}).Error; err != nil {
+		return false, ErrNotFound.FromError(err)
+	}
+	var requested int
+	if err := tx.Model(&Recall{}).Where("payment_id = ? AND status = ?", recall.PaymentID, RecallStatusRequested).Count(&requested).Error; err != nil {
+		return false, err
+	}
+	if requested > 0 {
+		return false, nil
+	}
+	if err := tx.Create(&recall).Error; err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetRecall returns a recall of a payment
+func (r *paymentRepository) GetRecall(paymentID string, id string) (Recall, error) {
+	recall := Recall{}
+	err := r.db.Where("id = ? AND payment_id = ?", id, paymentID).First(&recall).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return recall, ErrRecallNotFound
+	}
+	return recall, err
+}
+
+// GetRecalls returns the recalls of a payment, oldest first
+func (r *paymentRepository) GetRecalls(paymentID string) ([]Recall, error) {
+	recalls := []Recall{}
+	if err := r.db.Where("payment_id = ?", paymentID).Order("created_at, id").Find(&recalls).Error; err != nil {
+		return nil, err
+	}
+	return recalls, nil
+}
+
+// DecideRecall records the decision on a requested recall, it returns false when the recall is not requested anymore.
+// The decision is recorded in the audit log of the payment.
+func (r *paymentRepository) DecideRecall(id string, status RecallStatus, reason string, decidedAt time.Time, actor Actor) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	before := Recall{}
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&before).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if before.Status != RecallStatusRequested {
+		return false, nil
+	}
+	after := before
+	after.Status, after.DecisionReason, after.DecidedAt = status, reason, &decidedAt
+	err = tx.Model(&Recall{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "decision_reason": reason, "decided_at": decidedAt}).Error
+	if err != nil {
+		return false, err
+	}
+	if err := recordTransitionAudit(tx, AuditRecallDecision, before.PaymentID, actor, "recalls."+before.ID.String(), before, after); err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreatePaymentWithinLimits creates a payment if it passes the check of the totals of its organisation in its currency,
+// on the given day and in its month. The limits of the organisation are locked until the payment is created,
+// so concurrent payments cannot exceed them.
+func (r *paymentRepository) CreatePaymentWithinLimits(p Payment, actor Actor, day time.Time, check UsageCheck) (string, error) {
+	day = usageDay(day)
+
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return "", tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := checkUsage(tx, p.OrganisationID, p.Attributes.Currency, day, check); err != nil {
+		return "", err
+	}
+	if err := addUsage(tx, p.OrganisationID, p.Attributes.Currency, day, p.Attributes.Amount); err != nil {
+		return "", err
+	}
+	p.ID, p.Version, p.LimitUsageCounted = uuid.NewV4(), 0, true
+	if err := tx.Save(&p).Error; err != nil {
+		return "", err
+	}
+	if err := recordVersion(tx, p); err != nil {
+		return "", err
+	}
+	if err := postPaymentTransaction(tx, p.ID.String(), r.paymentTransaction(p, ledgerPaymentCreated), ledgerPaymentCreated); err != nil {
+		return "", err
+	}
+	if err := recordAudit(tx, AuditCreate, p.ID, actor, nil, &p); err != nil {
+		return "", err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return "", err
+	}
+	return p.ID.String(), nil
+}
+
+// usageDay returns the day, in UTC, the payments created at a time are counted on
+func usageDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// checkUsage locks the limits of an organisation until the end of the database transaction, and checks the totals of
+// its payments in a currency on a day and in its month
+func checkUsage(tx *gorm.DB, organisationID uuid.UUID, code string, day time.Time, check UsageCheck) error {
+	monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("organisation_id = ?", organisationID).First(&OrganisationLimits{}).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+	daily, err := sumUsage(tx.Where("organisation_id = ? AND currency = ? AND day = ?", organisationID, code, day))
+	if err != nil {
+		return err
+	}
+	monthly, err := sumUsage(tx.Where("organisation_id = ? AND currency = ? AND day >= ? AND day <= ?", organisationID, code, monthStart, day))
+	if err != nil {
+		return err
+	}
+	return check(daily, monthly)
+}
+
+// addUsage adds an amount, negative to release it, to the total of the payments of an organisation in a currency on a day
+func addUsage(tx *gorm.DB, organisationID uuid.UUID, code string, day time.Time, amount string) error {
+	return tx.Exec(`INSERT INTO limit_usages (organisation_id, currency, day, total) VALUES (?, ?, ?, ?)
+		ON CONFLICT (organisation_id, currency, day) DO UPDATE SET total = limit_usages.total + EXCLUDED.total`,
+		organisationID, code, day, amount).Error
+}
+
+// releaseUsage releases the amount of a payment from the totals of its organisation, when it is counted in them
+func releaseUsage(tx *gorm.DB, p *Payment) error {
+	if !p.LimitUsageCounted {
+		return nil
+	}
+	if err := addUsage(tx, p.OrganisationID, p.Attributes.Currency, usageDay(p.CreatedAt), "-"+p.Attributes.Amount); err != nil {
+		return err
+	}
+	p.LimitUsageCounted = false
+	return tx.Unscoped().Model(&Payment{}).Where("id = ?", p.ID).UpdateColumn("limit_usage_counted", false).Error
+}
+
+// recountUsage counts again the amount of a payment in the totals of its organisation on the day it was created, when
+// its currency has daily or monthly limits, it returns ErrLimitExceeded when the totals would exceed them
+func recountUsage(tx *gorm.DB, p *Payment) error {
+	// a payment deleted before its amount was released on deletion is still counted
+	if err := releaseUsage(tx, p); err != nil {
+		return err
+	}
+	limits, err := findOrganisationLimits(tx, p.OrganisationID)
+	if err != nil {
+		return err
+	}
+	limit, ok := limits.currencyLimit(p.Attributes.Currency)
+	if !ok || !limit.limitsTotals() {
+		return nil
+	}
+	day := usageDay(p.CreatedAt)
+	if err := checkUsage(tx, p.OrganisationID, p.Attributes.Currency, day, totalsCheck(limit, p.Attributes.Amount)); err != nil {
+		return err
+	}
+	if err := addUsage(tx, p.OrganisationID, p.Attributes.Currency, day, p.Attributes.Amount); err != nil {
+		return err
+	}
+	p.LimitUsageCounted = true
+	return tx.Unscoped().Model(&Payment{}).Where("id = ?", p.ID).UpdateColumn("limit_usage_counted", true).Error
+}
+
+// sumUsage returns the total amount of the usages selected by the query
+func sumUsage(query *gorm.DB) (*big.Rat, error) {
+	var total sql.NullString
+	if err := query.Model(&LimitUsage{}).Select("COALESCE(SUM(total), 0)").Row().Scan(&total); err != nil {
+		return nil, err
+	}
+	sum, ok := new(big.Rat).SetString(total.String)
+	if !ok {
+		return new(big.Rat), nil
+	}
+	return sum, nil
+}
+
+// GetOrganisationLimits returns the limits of an organisation, nil if it has none
+func (r *paymentRepository) GetOrganisationLimits(organisationID uuid.UUID) (*OrganisationLimits, error) {
+	return findOrganisationLimits(r.db, organisationID)
+}
+
+func findOrganisationLimits(db *gorm.DB, organisationID uuid.UUID) (*OrganisationLimits, error) {
+	l := OrganisationLimits{}
+	err := db.Where("organisation_id = ?", organisationID).First(&l).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Where("organisation_id = ?", organisationID).Order("currency").Find(&l.Currencies).Error; err != nil {
+		return nil, err
+	}
+	if l.Schemes != "" {
+		l.AllowedSchemes = strings.Split(l.Schemes, ",")
+	}
+	return &l, nil
+}
+
+// SaveOrganisationLimits replaces the limits of an organisation
+func (r *paymentRepository) SaveOrganisationLimits(l OrganisationLimits) error {
+	l.Schemes = strings.Join(l.AllowedSchemes, ",")
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Save(&l).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("organisation_id = ?", l.OrganisationID).Delete(&CurrencyLimit{}).Error; err != nil {
+		return err
+	}
+	for _, c := range l.Currencies {
+		c.ID, c.OrganisationID = 0, l.OrganisationID
+		if err := tx.Create(&c).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// DeleteOrganisationLimits removes the limits of an organisation, the usages are kept
+func (r *paymentRepository) DeleteOrganisationLimits(organisationID uuid.UUID) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	res := tx.Where("organisation_id = ?", organisationID).Delete(&OrganisationLimits{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrLimitsNotFound
+	}
+	if err := tx.Where("organisation_id = ?", organisationID).Delete(&CurrencyLimit{}).Error; err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// DeletePayment soft deletes a payment with its nested rows, reverses its ledger transaction, releases its amount from
+// the totals of its organisation and records its deletion in the audit log
+func (r *paymentRepository) DeletePayment(id string, actor Actor) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	pa, err := findPayment(tx, id)
+	if err != nil {
+		return err
+	}
+	rows, err := findPaymentRows(tx, pa)
+	if err != nil {
+		return err
+	}
+	if err := rows.softDelete(tx); err != nil {
+		return err
 	}
 	// Delete payment by ID `Soft Delete`
-	if err := r.db.Debug().Model(pa).Where("id = ?", id).Delete(pa).Error; err != nil {
+	if err := tx.Model(&pa).Where("id = ?", id).Delete(&pa).Error; err != nil {
 		return err
 	}
-	return nil
+	// the ledger transaction of the payment is reversed
+	if err := postPaymentTransaction(tx, id, nil, ledgerPaymentDeleted); err != nil {
+		return err
+	}
+	if err := releaseUsage(tx, &pa); err != nil {
+		return err
+	}
+	if err := recordAudit(tx, AuditDelete, pa.ID, actor, &pa, nil); err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// RestorePayment restores a soft deleted payment with its nested rows, posts its ledger transaction again, counts its
+// amount again in the totals of its organisation and records its restoration in the audit log. It returns
+// ErrLimitExceeded when the totals would exceed the limits of the organisation.
+func (r *paymentRepository) RestorePayment(id string, actor Actor) (Payment, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return Payment{}, tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Unscoped().Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&Payment{}).Error; err != nil {
+		return Payment{}, ErrNotFound.FromError(err)
+	}
+	p, err := findPayment(tx.Unscoped(), id)
+	if err != nil {
+		return p, err
+	}
+	if !p.Deleted {
+		return p, ErrPaymentNotDeleted
+	}
+	if err := recountUsage(tx, &p); err != nil {
+		return p, err
+	}
+	rows, err := findPaymentRows(tx, p)
+	if err != nil {
+		return p, err
+	}
+	if err := rows.restore(tx); err != nil {
+		return p, err
+	}
+	if err := tx.Unscoped().Model(&Payment{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+		return p, err
+	}
+	p.DeletedAt, p.Deleted = nil, false
+	if err := postPaymentTransaction(tx, id, r.paymentTransaction(p, ledgerPaymentRestored), ledgerPaymentRestored); err != nil {
+		return p, err
+	}
+	if err := recordAudit(tx, AuditRestore, p.ID, actor, nil, &p); err != nil {
+		return p, err
+	}
+	return p, tx.Commit().Error
+}
+
+// PurgeDeletedPayments hard deletes the payments soft deleted before the given time with their versions, their nested
+// rows and their screening hits, and records the purges in the audit log. Their ledger transactions, approvals,
+// returns, recalls and audit log are kept. It returns the number of payments purged.
+func (r *paymentRepository) PurgeDeletedPayments(before time.Time, actor Actor) (int, error) {
+	var ids []string
+	if err := r.db.Unscoped().Model(&Payment{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, id := range ids {
+		ok, err := r.purgePayment(id, actor)
+		if err != nil {
+			return purged, err
+		}
+		if ok {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// purgePayment hard deletes a soft deleted payment, it returns false when the payment was restored meanwhile
+func (r *paymentRepository) purgePayment(id string, actor Actor) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	defer tx.Rollback()
+
+	p := Payment{}
+	err := tx.Unscoped().Set("gorm:query_option", "FOR UPDATE").Where("id = ? AND deleted_at IS NOT NULL", id).First(&p).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := removePayment(tx, p); err != nil {
+		return false, err
+	}
+	if err := recordAudit(tx, AuditPurge, p.ID, actor, nil, nil); err != nil {
+		return false, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removePayment hard deletes a payment with its versions, its nested rows and its screening hits
+func removePayment(tx *gorm.DB, p Payment) error {
+	rows, err := findPaymentRows(tx, p)
+	if err != nil {
+		return err
+	}
+	if err := rows.purge(tx); err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("payment_id = ?", p.ID).Delete(&ScreeningHit{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("payment_id = ?", p.ID).Delete(&PaymentVersion{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id = ?", p.ID).Delete(&Payment{}).Error
+}
+
+// GetPaymentsToArchive returns the payments created before the given time with their nested resources, oldest first,
+// at most limit
+func (r *paymentRepository) GetPaymentsToArchive(before time.Time, limit int) ([]Payment, error) {
+	var payments []Payment
+	db := r.db
+	if err := db.Where("created_at < ?", before).Order("created_at, id").Limit(limit).Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	for i, p := range payments {
+		var err error
+		if payments[i], err = findPayment(db, p.ID.String()); err != nil {
+			return nil, err
+		}
+	}
+	return payments, nil
+}
+
+// ArchivePayments adds an archive file to the manifest and hard deletes its payments as GetPaymentsToArchive returned
+// them, with their versions, nested rows and screening hits. The moves to the archive are recorded in the audit log, the
+// ledger transactions, approvals, returns, recalls and audit log of the payments are kept.
+func (r *paymentRepository) ArchivePayments(file ArchiveFile, ids []string, actor Actor) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	if err := tx.Create(&file).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		// a payment deleted meanwhile is archived too, a payment purged meanwhile is gone
+		p := Payment{}
+		err := tx.Unscoped().Set("gorm:query_option", "FOR UPDATE").Where("id = ?", id).First(&p).Error
+		if gorm.IsRecordNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := removePayment(tx, p); err != nil {
+			return err
+		}
+		if err := tx.Create(&ArchivedPayment{PaymentID: p.ID, ArchiveFileID: file.ID, ArchivedAt: file.CreatedAt}).Error; err != nil {
+			return err
+		}
+		if err := recordAudit(tx, AuditArchive, p.ID, actor, nil, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// GetArchiveFile returns the archive file of a payment, nil when the payment is not archived
+func (r *paymentRepository) GetArchiveFile(paymentID string) (*ArchiveFile, error) {
+	file := ArchiveFile{}
+	err := r.db.Joins("JOIN archived_payments ON archived_payments.archive_file_id = archive_files.id").
+		Where("archived_payments.payment_id = ?", paymentID).First(&file).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
 }
 
 // GetListOfPayments ...
-func (r *paymentRepository) GetListOfPayments() ([]Payment, error) {
+func (r *paymentRepository) GetListOfPayments(q ListQuery) ([]Payment, error) {
 	var payments []Payment
-	err := r.db.Debug().Find(&payments).Error
+	db := r.db
+	if q.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	query := db
+	if q.AccountNumber != "" {
+		column, value := r.accountNumberCondition(q.AccountNumber)
+		query = query.Where(fmt.Sprintf(`attributes_id IN (SELECT attributes.id FROM attributes
+			LEFT JOIN debtor_parties ON debtor_parties.id = attributes.debtor_party_id
+			LEFT JOIN beneficiary_parties ON beneficiary_parties.id = attributes.beneficiary_party_id
+			WHERE debtor_parties.%[1]s = ? OR beneficiary_parties.%[1]s = ?)`, column), value, value)
+	}
+	err := query.Order("created_at, id").Offset(q.Offset).Limit(q.Limit).Find(&payments).Error
 	if err != nil {
 		return nil, err
 	}
 	for i, p := range payments {
-		payments[i], _ = r.GetPayment(p.ID.String())
+		payments[i], _ = findPayment(db, p.ID.String())
 	}
 	return payments, nil
 }
+
+// GetAccountBalances returns the balances of a ledger account by currency, none when it has no entries
+func (r *paymentRepository) GetAccountBalances(account string) ([]ledger.Balance, error) {
+	rows, err := r.db.Model(&ledger.Entry{}).
+		Select("currency, SUM(CASE WHEN direction = ? THEN amount ELSE 0 END), SUM(CASE WHEN direction = ? THEN amount ELSE 0 END)", ledger.Debit, ledger.Credit).
+		Where("account = ?", r.ledgerAccount(account)).Group("currency").Order("currency").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := []ledger.Balance{}
+	for rows.Next() {
+		var code, debits, credits string
+		if err := rows.Scan(&code, &debits, &credits); err != nil {
+			return nil, err
+		}
+		balances = append(balances, ledger.NewBalance(code, debits, credits, minorUnits(code)))
+	}
+	return balances, rows.Err()
+}
+
+// GetAccountEntries returns a page of the entries of a ledger account, oldest first
+func (r *paymentRepository) GetAccountEntries(account string, q ListQuery) ([]ledger.Entry, error) {
+	entries := []ledger.Entry{}
+	err := r.db.Where("account = ?", r.ledgerAccount(account)).Order("created_at, id").Offset(q.Offset).Limit(q.Limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLedgerImbalances returns the ledger transactions whose debits and credits differ in a currency, none when the
+// ledger balances
+func (r *paymentRepository) GetLedgerImbalances() ([]ledger.Imbalance, error) {
+	difference := "SUM(CASE WHEN direction = 'debit' THEN amount ELSE -amount END)"
+	rows, err := r.db.Model(&ledger.Entry{}).Select("transaction_id, currency, " + difference).
+		Group("transaction_id, currency").Having(difference + " <> 0").Order("transaction_id, currency").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imbalances := []ledger.Imbalance{}
+	for rows.Next() {
+		var i ledger.Imbalance
+		if err := rows.Scan(&i.TransactionID, &i.Currency, &i.Difference); err != nil {
+			return nil, err
+		}
+		imbalances = append(imbalances, i)
+	}
+	return imbalances, rows.Err()
+}
@@ -1,13 +1,19 @@
 package payments
 
 import (
+	"database/sql/driver"
 	"log"
+	"math/big"
 	"testing"
+	"time"
 
 	mocket "github.com/Selvatico/go-mocket"
 	"github.com/jinzhu/gorm"
 	"github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/calendar"
 )
 
 func Test_newConnection(t *testing.T){
@@ -35,10 +41,7 @@ func SetupDBTests() *gorm.DB {
 func Test_CreatePayment(t *testing.T) {
 	//Arrange
 	idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
-	pid, _ := uuid.FromString(idStr)
-	p := Payment{
-		ID: pid,
-	}
+	p := mockNewPayment(idStr)
 
 	db := SetupDBTests()
 	defer db.Close()
@@ -47,7 +50,7 @@ func Test_CreatePayment(t *testing.T) {
 	r := NewPaymentRepository(db)
 
 	//Act
-	id, err := r.CreatePayment(p)
+	id, err := r.CreatePayment(p, Actor{UserID: "alice"})
 
 	//Assert
 	assert.NoError(t, err)
@@ -57,11 +60,8 @@ func Test_CreatePayment(t *testing.T) {
 func Test_UpdatePayment(t *testing.T) {
 	//Arrange
 	idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
-	pid, _ := uuid.FromString(idStr)
 	mockReply := []map[string]interface{}{{}}
-	p := Payment{
-		ID: pid,
-	}
+	p := mockNewPayment(idStr)
 
 	db := SetupDBTests()
 	defer db.Close()
@@ -72,23 +72,57 @@ func Test_UpdatePayment(t *testing.T) {
 	mocket.Catcher.Attach([]*mocket.FakeResponse{
 		{
 			Pattern:  "SELECT * FROM \"payments\"",
-			Response: mockReply,
+			Response: []map[string]interface{}{{"status": "submitted"}},
 		},
 	})
+	var update string
 	mocket.Catcher.Attach([]*mocket.FakeResponse{
 		{
 			Pattern:  "UPDATE \"payments\"",
 			Response: mockReply,
+			Callback: func(query string, _ []driver.NamedValue) { update = query },
 		},
 	})
 
 	r := NewPaymentRepository(db)
 
 	//Act
-	err := r.UpdatePayment(idStr, p)
+	err := r.UpdatePayment(idStr, p, Actor{UserID: "alice"})
 
 	//Assert
 	assert.NoError(t, err)
+	assert.NotContains(t, update, "idempotency_key", "the idempotency key of the creation is kept")
+	assert.Contains(t, update, "fingerprint")
+}
+
+func Test_UpdatePayment_NotUpdatable(t *testing.T) {
+	for _, status := range []PaymentStatus{StatusDue, StatusRejected} {
+		t.Run(string(status), func(t *testing.T) {
+			//Arrange
+			idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+			db := SetupDBTests()
+			defer db.Close()
+			var updated bool
+			mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+				{
+					Pattern:  "SELECT * FROM \"payments\"",
+					Response: []map[string]interface{}{{"status": string(status)}},
+				},
+				{
+					Pattern:  "UPDATE \"payments\"",
+					Callback: func(string, []driver.NamedValue) { updated = true },
+				},
+			})
+			r := NewPaymentRepository(db)
+
+			//Act
+			err := r.UpdatePayment(idStr, mockNewPayment(idStr), Actor{UserID: "alice"})
+
+			//Assert
+			assert.Equal(t, ErrPaymentNotUpdatable, err)
+			assert.False(t, updated)
+		})
+	}
 }
 
 func Test_DeletePayment(t *testing.T) {
@@ -115,7 +149,7 @@ func Test_DeletePayment(t *testing.T) {
 	r := NewPaymentRepository(db)
 
 	//Act
-	err := r.DeletePayment(idStr)
+	err := r.DeletePayment(idStr, Actor{UserID: "alice"})
 
 	//Assert
 	assert.NoError(t, err)
@@ -193,7 +227,7 @@ func Test_GetListOfPayments(t *testing.T) {
 	r := NewPaymentRepository(db)
 
 	//Act
-	p, err := r.GetListOfPayments()
+	p, err := r.GetListOfPayments(ListQuery{Limit: 10})
 	println(len(p))
 	//Assert
 	assert.NotNil(t, p)
@@ -217,7 +251,7 @@ func mockNewPayment(id string) Payment {
 					Address:           "34 frfrf ded",
 					Name:              "ING Dfh",
 					SponsorParty: SponsorParty{
-						AccountNumber: "5678923",
+						AccountNumber: "GB29NWBK60161331926819",
 						BankID:        "134667",
 						BankIDCode:    "GSDFE",
 					},
@@ -239,7 +273,7 @@ func mockNewPayment(id string) Payment {
 				Address:           "1 dhhde ded",
 				Name:              "alspnfh",
 				SponsorParty: SponsorParty{
-					AccountNumber: "5678923",
+					AccountNumber: "GB29NWBK60161331926819",
 					BankID:        "134667",
 					BankIDCode:    "GSDFE",
 				},
@@ -247,7 +281,7 @@ func mockNewPayment(id string) Payment {
 			EndToEndReference: "Wil def ee",
 			Forex: Forex{
 				ContractReference: "FX123",
-				ExchangeRate:      "2.0000",
+				ExchangeRate:      "0.5000",
 				OriginalAmount:    "200.42",
 				OriginalCurrency:  "USD",
 			},
@@ -256,10 +290,10 @@ func mockNewPayment(id string) Payment {
 			PaymentPurpose:       "course",
 			PaymentScheme:        "FPS",
 			PaymentType:          "Credit",
-			ProcessingDate:      "2017-01-18",
+			ProcessingDate:       time.Now().UTC().Format(calendar.DateFormat),
 			Reference:            "PAYmen",
 			SchemePaymentSubType: "InternetBanking",
-			SchemePaymentType:    "Immediate Pay",
+			SchemePaymentType:    "ImmediatePayment",
 			SponsorParty: SponsorParty{
 				AccountNumber: "5678923",
 				BankID:        "134667",
@@ -304,7 +338,7 @@ func mockNewPaymentMissingFields(id string) Payment {
 			EndToEndReference: "Wil def ee",
 			Forex: Forex{
 				ContractReference: "FX123",
-				ExchangeRate:      "2.0000",
+				ExchangeRate:      "0.5000",
 				OriginalAmount:    "200.42",
 				OriginalCurrency:  "USD",
 			},
@@ -313,10 +347,10 @@ func mockNewPaymentMissingFields(id string) Payment {
 			PaymentPurpose:       "course",
 			PaymentScheme:        "FPS",
 			PaymentType:          "Credit",
-			ProcessingDate:       "2017-01-18",
+			ProcessingDate:       time.Now().UTC().Format(calendar.DateFormat),
 			Reference:            "PAYmen",
 			SchemePaymentSubType: "InternetBanking",
-			SchemePaymentType:    "Immediate Pay",
+			SchemePaymentType:    "ImmediatePayment",
 			SponsorParty: SponsorParty{
 				AccountNumber: "5678923",
 				BankID:        "134667",
@@ -327,3 +361,405 @@ func mockNewPaymentMissingFields(id string) Payment {
 
 	return p
 }
+
+func Test_GetPaymentByFingerprint(t *testing.T) {
+	//Arrange
+	idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "fingerprint = ? AND created_at >= ?",
+			Response: []map[string]interface{}{{"id": idStr, "fingerprint": "f1"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	p, err := r.GetPaymentByFingerprint("f1", time.Now().Add(-time.Hour))
+
+	//Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, p) {
+		assert.Equal(t, idStr, p.ID.String())
+	}
+}
+
+func Test_GetPaymentByFingerprint_None(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset()
+	r := NewPaymentRepository(db)
+
+	//Act
+	p, err := r.GetPaymentByFingerprint("f1", time.Now().Add(-time.Hour))
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func Test_TransitionPaymentStatus(t *testing.T) {
+	//Arrange
+	idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": idStr, "status": StatusHeldForReview}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	ok, err := r.TransitionPaymentStatus(idStr, StatusHeldForReview, StatusRejected, "confirmed match", Actor{UserID: "bob"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	//Arrange
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": idStr, "status": StatusRejected}},
+		},
+	})
+
+	//Act
+	ok, err = r.TransitionPaymentStatus(idStr, StatusHeldForReview, StatusRejected, "confirmed match", Actor{UserID: "bob"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.False(t, ok, "the payment was not held for review")
+}
+
+func Test_CreatePaymentWithinLimits(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "day = ?",
+			Response: []map[string]interface{}{{"total": "900.00"}},
+		},
+		{
+			Pattern:  "day >= ? AND day <= ?",
+			Response: []map[string]interface{}{{"total": "4900.00"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	var daily, monthly *big.Rat
+
+	//Act
+	id, err := r.CreatePaymentWithinLimits(p, Actor{UserID: "alice"}, time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC), func(d, m *big.Rat) error {
+		daily, monthly = d, m
+		return nil
+	})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "900.00", daily.FloatString(2))
+	assert.Equal(t, "4900.00", monthly.FloatString(2))
+
+	//Act
+	_, err = r.CreatePaymentWithinLimits(p, Actor{UserID: "alice"}, time.Now(), func(d, m *big.Rat) error {
+		return ErrLimitExceeded
+	})
+
+	//Assert
+	assert.Equal(t, ErrLimitExceeded, err)
+}
+
+func Test_UpdatePaymentWithinLimits(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	createdAt := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	var usages [][]driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "version": 2, "attributes_id": 7, "status": "submitted", "limit_usage_counted": true, "created_at": createdAt}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"attributes\"",
+			Response: []map[string]interface{}{{"id": 7, "amount": "1.00", "currency": "GBP"}},
+		},
+		{
+			Pattern:  "day = ?",
+			Response: []map[string]interface{}{{"total": "900.00"}},
+		},
+		{
+			Pattern:  "day >= ? AND day <= ?",
+			Response: []map[string]interface{}{{"total": "4900.00"}},
+		},
+		{
+			Pattern:  "INSERT INTO limit_usages",
+			Callback: func(_ string, args []driver.NamedValue) { usages = append(usages, args) },
+		},
+	})
+	r := NewPaymentRepository(db)
+	p := mockNewPayment(id)
+	var daily *big.Rat
+
+	//Act
+	err := r.UpdatePaymentWithinLimits(id, p, Actor{UserID: "alice"}, func(d, m *big.Rat) error {
+		daily = d
+		return nil
+	})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, "900.00", daily.FloatString(2))
+	require.Len(t, usages, 2)
+	assert.Equal(t, "-1.00", usages[0][3].Value, "the previous amount is released")
+	assert.Equal(t, usageDay(createdAt), usages[0][2].Value, "on the day the payment was created")
+	assert.Equal(t, p.Attributes.Amount, usages[1][3].Value)
+	assert.Equal(t, usageDay(createdAt), usages[1][2].Value)
+
+	//Act
+	usages = nil
+	err = r.UpdatePaymentWithinLimits(id, p, Actor{UserID: "alice"}, func(d, m *big.Rat) error {
+		return ErrLimitExceeded
+	})
+
+	//Assert
+	assert.Equal(t, ErrLimitExceeded, err)
+	assert.Len(t, usages, 1, "the new amount is not counted, the release is rolled back with the transaction")
+}
+
+func Test_GetOrganisationLimits(t *testing.T) {
+	//Arrange
+	organisationID := uuid.NewV4()
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"organisation_limits\"",
+			Response: []map[string]interface{}{{"organisation_id": organisationID.String(), "schemes": "FPS,Bacs"}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"currency_limits\"",
+			Response: []map[string]interface{}{{"currency": "GBP", "daily_total": "1000.00"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	l, err := r.GetOrganisationLimits(organisationID)
+
+	//Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, l) {
+		assert.Equal(t, []string{"FPS", "Bacs"}, l.AllowedSchemes)
+		assert.Equal(t, []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000.00"}}, l.Currencies)
+	}
+
+	//Arrange
+	mocket.Catcher.Reset()
+
+	//Act
+	l, err = r.GetOrganisationLimits(organisationID)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+}
+
+func Test_SaveAndDeleteOrganisationLimits(t *testing.T) {
+	//Arrange
+	organisationID := uuid.NewV4()
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:      "DELETE FROM \"organisation_limits\"",
+			RowsAffected: 1,
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	saveErr := r.SaveOrganisationLimits(OrganisationLimits{
+		OrganisationID: organisationID,
+		AllowedSchemes: []string{"FPS"},
+		Currencies:     []CurrencyLimit{{Currency: "GBP", MaxAmount: "100.00"}},
+	})
+	deleteErr := r.DeleteOrganisationLimits(organisationID)
+	mocket.Catcher.Reset()
+	notFoundErr := r.DeleteOrganisationLimits(organisationID)
+
+	//Assert
+	assert.NoError(t, saveErr)
+	assert.NoError(t, deleteErr)
+	assert.Equal(t, ErrLimitsNotFound, notFoundErr)
+}
+
+func Test_RecordApproval(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+
+	a := Approval{ID: uuid.NewV4(), PaymentID: uuid.NewV4(), Approver: "bob", Decision: ApprovalApprove}
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": a.PaymentID.String(), "status": StatusPendingApproval}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	ok, err := r.RecordApproval(a, StatusSubmitted, Actor{UserID: "bob"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	//Arrange
+	mocket.Catcher.Reset()
+
+	//Act
+	ok, err = r.RecordApproval(a, StatusSubmitted, Actor{UserID: "bob"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.False(t, ok, "the payment was not pending approval")
+}
+
+func Test_GetApprovals(t *testing.T) {
+	//Arrange
+	idStr := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"approvals\"",
+			Response: []map[string]interface{}{{"payment_id": idStr, "approver": "bob", "decision": "reject", "comment": "wrong beneficiary"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	approvals, err := r.GetApprovals(idStr)
+
+	//Assert
+	assert.NoError(t, err)
+	if assert.Len(t, approvals, 1) {
+		assert.Equal(t, "bob", approvals[0].Approver)
+		assert.Equal(t, ApprovalReject, approvals[0].Decision)
+	}
+}
+
+func Test_CreateReturn(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"}},
+		},
+		{
+			Pattern:  "COALESCE(SUM(amount), 0)",
+			Response: []map[string]interface{}{{"coalesce": "40.21"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+	ret := Return{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"), Type: ReturnTypeReturn, Status: ReturnStatusPending}
+	var returned *big.Rat
+
+	//Act
+	created, err := r.CreateReturn(ret, func(r *Return, total *big.Rat) error {
+		returned = total
+		r.Amount = "60.00"
+		return nil
+	})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "60.00", created.Amount)
+	assert.Equal(t, "40.21", returned.FloatString(2))
+
+	//Act
+	_, err = r.CreateReturn(ret, func(*Return, *big.Rat) error { return ErrReturnAmountExceeded })
+
+	//Assert
+	assert.Equal(t, ErrReturnAmountExceeded, err)
+}
+
+func Test_GetReturn_NotFound(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	mocket.Catcher.Reset()
+	r := NewPaymentRepository(db)
+
+	//Act
+	_, err := r.GetReturn("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", ReturnTypeReversal, "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b")
+
+	//Assert
+	assert.Equal(t, ErrReturnNotFound, err)
+}
+
+func Test_CreateRecall(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"}},
+		},
+		{
+			Pattern:  "SELECT count(*) FROM \"recalls\"",
+			Response: []map[string]interface{}{{"count": 0}},
+			Once:     true,
+		},
+		{
+			Pattern:  "SELECT count(*) FROM \"recalls\"",
+			Response: []map[string]interface{}{{"count": 1}},
+		},
+	})
+	r := NewPaymentRepository(db)
+	recall := Recall{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"), ReasonCode: "DUPL", Status: RecallStatusRequested}
+
+	//Act
+	created, err := r.CreateRecall(recall)
+	again, againErr := r.CreateRecall(recall)
+
+	//Assert
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.NoError(t, againErr)
+	assert.False(t, again, "a recall of the payment is already requested")
+}
+
+func Test_GetRecall_NotFound(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	mocket.Catcher.Reset()
+	r := NewPaymentRepository(db)
+
+	//Act
+	_, err := r.GetRecall("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", "3f6d2a9b-1c4e-4b8a-9d7f-6e5c4b3a2d1e")
+
+	//Assert
+	assert.Equal(t, ErrRecallNotFound, err)
+}
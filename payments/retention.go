@@ -0,0 +1,126 @@
+package payments
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/elkousy/payments-api/utility/logger"
+)
+
+// PurgerLockKey is the key of the Postgres advisory lock electing the replica running the purger
+const PurgerLockKey int64 = 0x7075726765
+
+// retentionActor marks the payments purged at the end of their retention period
+var retentionActor = Actor{UserID: "retention"}
+
+// paymentRows selects the rows nested in a payment: the attributes of all its versions and their parties, charges and
+// forex. They are soft deleted, restored and purged with the payment.
+type paymentRows []struct {
+	model  interface{}
+	column string
+	values interface{}
+}
+
+// findPaymentRows returns the rows nested in a payment, deleted or not
+func findPaymentRows(tx *gorm.DB, p Payment) (paymentRows, error) {
+	var ids []uint
+	if err := tx.Model(&PaymentVersion{}).Where("payment_id = ?", p.ID).Pluck("attributes_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	ids = append(ids, p.AttributesID)
+	var attributes []Attributes
+	if err := tx.Unscoped().Where("id IN (?)", ids).Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+
+	var beneficiaries, debtors, sponsors, forexes, charges []uint
+	for _, a := range attributes {
+		beneficiaries = append(beneficiaries, a.BeneficiaryPartyID)
+		debtors = append(debtors, a.DebtorPartyID)
+		sponsors = append(sponsors, a.SponsorPartyID)
+		forexes = append(forexes, a.ForexID)
+		charges = append(charges, a.ChargesInformationID)
+	}
+	return paymentRows{
+		{&Attributes{}, "id", ids},
+		{&BeneficiaryParty{}, "id", beneficiaries},
+		{&DebtorParty{}, "id", debtors},
+		{&SponsorParty{}, "id", sponsors},
+		{&Forex{}, "id", forexes},
+		{&ChargesInformation{}, "id", charges},
+		{&Charge{}, "charges_information_id", charges},
+	}, nil
+}
+
+// softDelete soft deletes the rows
+func (rows paymentRows) softDelete(tx *gorm.DB) error {
+	for _, r := range rows {
+		if err := tx.Where(r.column+" IN (?)", r.values).Delete(r.model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restore restores the soft deleted rows
+func (rows paymentRows) restore(tx *gorm.DB) error {
+	for _, r := range rows {
+		if err := tx.Unscoped().Model(r.model).Where(r.column+" IN (?)", r.values).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purge hard deletes the rows
+func (rows paymentRows) purge(tx *gorm.DB) error {
+	for _, r := range rows {
+		if err := tx.Unscoped().Where(r.column+" IN (?)", r.values).Delete(r.model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Purger hard deletes the payments deleted longer ago than the retention period
+type Purger struct {
+	repository Repository
+	leader     Leader
+	retention  time.Duration
+	now        func() time.Time
+}
+
+// NewPurger returns a purger of the payments of the repository deleted longer ago than the retention, the elected
+// replica only purges
+func NewPurger(repository Repository, leader Leader, retention time.Duration) *Purger {
+	return &Purger{repository: repository, leader: leader, retention: retention, now: time.Now}
+}
+
+// Run fires the purger at every interval until the context is done, the leadership is then given up
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer p.leader.Resign(context.Background())
+	for {
+		if _, err := p.RunOnce(ctx); err != nil {
+			logger.LogStdErr.Errorw("error when purging the deleted payments", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges the payments deleted longer ago than the retention, when this replica is the leader. It returns the
+// number of payments purged.
+func (p *Purger) RunOnce(ctx context.Context) (int, error) {
+	lead, err := p.leader.Lead(ctx)
+	if err != nil || !lead {
+		return 0, err
+	}
+	return p.repository.PurgeDeletedPayments(p.now().Add(-p.retention), retentionActor)
+}
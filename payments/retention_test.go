@@ -0,0 +1,224 @@
+package payments
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Purger_RunOnce(t *testing.T) {
+	// Arrange
+	now := time.Date(2019, 3, 31, 12, 0, 0, 0, time.UTC)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("PurgeDeletedPayments", now.Add(-30*24*time.Hour), retentionActor).Return(2, nil)
+	purger := NewPurger(repositoryMock, &mockLeader{lead: true}, 30*24*time.Hour)
+	purger.now = func() time.Time { return now }
+
+	//Act
+	purged, err := purger.RunOnce(context.Background())
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Purger_RunOnce_Follower(t *testing.T) {
+	// Arrange
+	repositoryMock := &MockRepository{}
+	purger := NewPurger(repositoryMock, &mockLeader{}, time.Hour)
+
+	//Act
+	purged, err := purger.RunOnce(context.Background())
+
+	//Assert
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	repositoryMock.AssertNotCalled(t, "PurgeDeletedPayments")
+}
+
+func Test_DeletePayment_CascadesSoftDelete(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	var updated []string
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "attributes_id": 7}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"attributes\"",
+			Response: []map[string]interface{}{{"id": 7, "debtor_party_id": 8, "forex_id": 9}},
+		},
+		{
+			Pattern: "UPDATE ",
+			Callback: func(query string, _ []driver.NamedValue) {
+				updated = append(updated, strings.Fields(query)[1])
+			},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	err := r.DeletePayment(id, Actor{UserID: "alice"})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{`"attributes"`, `"beneficiary_parties"`, `"debtor_parties"`, `"sponsor_parties"`, `"forexes"`,
+		`"charges_informations"`, `"charges"`, `"payments"`}, updated)
+}
+
+func Test_RestorePayment(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name      string
+		deletedAt interface{}
+		wantErr   error
+	}{
+		{name: "Should restore a deleted payment", deletedAt: time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "Should not restore a payment which is not deleted", wantErr: ErrPaymentNotDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			db := SetupDBTests()
+			defer db.Close()
+			restored := false
+			mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+				{
+					Pattern:  "SELECT * FROM \"payments\"",
+					Response: []map[string]interface{}{{"id": id, "attributes_id": 7, "deleted_at": tt.deletedAt}},
+				},
+				{
+					Pattern:  "SELECT * FROM \"attributes\"",
+					Response: []map[string]interface{}{{"id": 7, "amount": "100.00", "currency": "GBP"}},
+				},
+				{
+					Pattern:  "INSERT INTO \"audit_entries\"",
+					Callback: func(_ string, _ []driver.NamedValue) { restored = true },
+				},
+			})
+			r := NewPaymentRepository(db)
+
+			//Act
+			p, err := r.RestorePayment(id, Actor{UserID: "alice"})
+
+			//Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.False(t, restored)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, p.Deleted)
+			assert.Nil(t, p.DeletedAt)
+			assert.True(t, restored)
+		})
+	}
+}
+
+func Test_DeletePayment_ReleasesUsage(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	createdAt := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	var usages [][]driver.NamedValue
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "attributes_id": 7, "limit_usage_counted": true, "created_at": createdAt}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"attributes\"",
+			Response: []map[string]interface{}{{"id": 7, "amount": "100.00", "currency": "GBP"}},
+		},
+		{
+			Pattern:  "INSERT INTO limit_usages",
+			Callback: func(_ string, args []driver.NamedValue) { usages = append(usages, args) },
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	err := r.DeletePayment(id, Actor{UserID: "alice"})
+
+	//Assert
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, "-100.00", usages[0][3].Value, "the amount of the deleted payment is released")
+	assert.Equal(t, usageDay(createdAt), usages[0][2].Value)
+}
+
+func Test_RestorePayment_Limits(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		daily   string
+		wantErr error
+		counted []string
+	}{
+		{name: "Should count the amount of the restored payment again", daily: "800.00", counted: []string{"100.00"}},
+		{name: "Should not restore a payment exceeding the daily total", daily: "950.00", wantErr: ErrLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			db := SetupDBTests()
+			defer db.Close()
+			var counted []string
+			mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+				{
+					Pattern:  "SELECT * FROM \"payments\"",
+					Response: []map[string]interface{}{{"id": id, "attributes_id": 7, "deleted_at": time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)}},
+				},
+				{
+					Pattern:  "SELECT * FROM \"attributes\"",
+					Response: []map[string]interface{}{{"id": 7, "amount": "100.00", "currency": "GBP"}},
+				},
+				{
+					Pattern:  "SELECT * FROM \"organisation_limits\"",
+					Response: []map[string]interface{}{{"organisation_id": uuid.Nil.String()}},
+				},
+				{
+					Pattern:  "SELECT * FROM \"currency_limits\"",
+					Response: []map[string]interface{}{{"currency": "GBP", "daily_total": "1000.00"}},
+				},
+				{
+					Pattern:  "day = ?",
+					Response: []map[string]interface{}{{"total": tt.daily}},
+				},
+				{
+					Pattern:  "day >= ? AND day <= ?",
+					Response: []map[string]interface{}{{"total": tt.daily}},
+				},
+				{
+					Pattern:  "INSERT INTO limit_usages",
+					Callback: func(_ string, args []driver.NamedValue) { counted = append(counted, args[3].Value.(string)) },
+				},
+			})
+			r := NewPaymentRepository(db)
+
+			//Act
+			_, err := r.RestorePayment(id, Actor{UserID: "alice"})
+
+			//Assert
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Empty(t, counted)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.counted, counted)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package payments
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+// returnReasonCodes are the ISO 20022 reason codes of the returns and of the reversals, with their description
+var returnReasonCodes = map[ReturnType]map[string]string{
+	ReturnTypeReturn: {
+		"AC01": "incorrect account number",
+		"AC04": "closed account number",
+		"AC06": "blocked account",
+		"AG01": "transaction forbidden",
+		"AM04": "insufficient funds",
+		"AM05": "duplication",
+		"BE04": "missing creditor address",
+		"MD07": "end customer deceased",
+		"MS02": "not specified reason customer generated",
+		"MS03": "not specified reason agent generated",
+		"RC01": "bank identifier incorrect",
+		"RR04": "regulatory reason",
+		"FOCR": "following cancellation request",
+	},
+	ReturnTypeReversal: {
+		"AM05": "duplication",
+		"FRAD": "fraudulent origin",
+		"TECH": "technical problem",
+		"MS02": "not specified reason customer generated",
+		"MS03": "not specified reason agent generated",
+	},
+}
+
+// reasonCodes returns the reason codes of a type of returns, sorted
+func reasonCodes(t ReturnType) []string {
+	codes := make([]string, 0, len(returnReasonCodes[t]))
+	for code := range returnReasonCodes[t] {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ReturnCheck checks a return against the amount of its payment already returned, by the returns and the reversals
+// which have not failed, before it is created. It sets the amount of a return of the whole amount not yet returned.
+type ReturnCheck func(r *Return, returned *big.Rat) error
+
+// isReturnable reports whether a payment has gone out, so it can be returned or reversed
+func isReturnable(p Payment) bool {
+	return p.Status == StatusSubmitted || p.Status == StatusDue
+}
+
+// checkReturnAmount returns an error when the amount of a return exceeds the amount of its payment not yet returned.
+// An empty amount is set to the amount not yet returned.
+func checkReturnAmount(p Payment, r *Return, returned *big.Rat) error {
+	remaining := new(big.Rat).Sub(parseAmount(p.Attributes.Amount), returned)
+	if r.Amount == "" {
+		if remaining.Sign() <= 0 {
+			return ErrReturnAmountExceeded.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "the payment is fully returned"})
+		}
+		r.Amount = formatAmount(remaining, p.Attributes.Currency)
+		return nil
+	}
+	if parseAmount(r.Amount).Cmp(remaining) > 0 {
+		if remaining.Sign() < 0 {
+			remaining.SetInt64(0)
+		}
+		return ErrReturnAmountExceeded.WithFieldErrors(apierrors.FieldError{
+			Field:   "amount",
+			Message: fmt.Sprintf("exceeds the amount not yet returned of %s %s", formatAmount(remaining, p.Attributes.Currency), p.Attributes.Currency),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package payments
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/elkousy/payments-api/utility/logger"
+)
+
+// SchedulerLockKey is the key of the Postgres advisory lock electing the replica running the scheduler
+const SchedulerLockKey int64 = 0x7061796d656e7473
+
+// DuePaymentHandler processes the scheduled payments on their processing date, e.g. submits them to their scheme.
+// A payment whose handling fails stays due and is handed again to the handler at the next run of the scheduler.
+type DuePaymentHandler interface {
+	HandleDuePayment(ctx context.Context, p Payment) error
+}
+
+// DuePaymentHandlerFunc adapts a function to a DuePaymentHandler
+type DuePaymentHandlerFunc func(ctx context.Context, p Payment) error
+
+// HandleDuePayment calls f(ctx, p)
+func (f DuePaymentHandlerFunc) HandleDuePayment(ctx context.Context, p Payment) error {
+	return f(ctx, p)
+}
+
+// Leader elects the single replica running the scheduler
+type Leader interface {
+	// Lead reports whether this replica is the leader, trying to become it when it is not
+	Lead(ctx context.Context) (bool, error)
+	// Resign gives up the leadership, if held
+	Resign(ctx context.Context) error
+}
+
+// Scheduler marks the scheduled payments due on their processing date and hands them to the due payment handler
+type Scheduler struct {
+	repository Repository
+	events     *EventBroker
+	handler    DuePaymentHandler
+	leader     Leader
+	now        func() time.Time
+}
+
+// NewScheduler returns a scheduler of the payments of the repository, the elected replica only fires
+func NewScheduler(repository Repository, events *EventBroker, handler DuePaymentHandler, leader Leader) *Scheduler {
+	return &Scheduler{repository: repository, events: events, handler: handler, leader: leader, now: time.Now}
+}
+
+// Run fires the scheduler at every interval until the context is done, the leadership is then given up
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer s.leader.Resign(context.Background())
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			logger.LogStdErr.Errorw("error when marking the due payments", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce marks due the scheduled payments whose processing date is today or before, in UTC, and hands them to the
+// handler with the due payments whose handling failed, when this replica is the leader. It returns the number of
+// payments handled successfully.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	lead, err := s.leader.Lead(ctx)
+	if err != nil || !lead {
+		return 0, err
+	}
+	payments, err := s.repository.GetDuePayments(s.now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	handled := 0
+	for _, p := range payments {
+		if p.Status != StatusDue {
+			// a payment updated or deleted meanwhile may not be scheduled anymore
+			due, err := s.repository.TransitionPaymentStatus(p.ID.String(), StatusScheduled, StatusDue, "", schedulerActor)
+			if err != nil {
+				return handled, err
+			}
+			if !due {
+				continue
+			}
+			p.Status = StatusDue
+			s.events.Publish(Event{Type: EventPaymentStateChanged, PaymentID: p.ID.String(), OrganisationID: p.OrganisationID, Status: StatusDue})
+		}
+		if err := s.handler.HandleDuePayment(ctx, p); err != nil {
+			logger.LogStdErr.Errorw("error when handling a due payment", "payment_id", p.ID.String(), "err", err)
+			continue
+		}
+		if err := s.repository.MarkPaymentHandled(p.ID.String(), s.now().UTC()); err != nil {
+			return handled, err
+		}
+		handled++
+	}
+	return handled, nil
+}
+
+// AdvisoryLockLeader elects a leader through a Postgres session advisory lock. The leader holds the lock on a dedicated
+// connection, another replica takes over when the connection of the leader is lost.
+type AdvisoryLockLeader struct {
+	db   *sql.DB
+	key  int64
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewAdvisoryLockLeader returns a leader election on the advisory lock of the given key
+func NewAdvisoryLockLeader(db *sql.DB, key int64) *AdvisoryLockLeader {
+	return &AdvisoryLockLeader{db: db, key: key}
+}
+
+// Lead reports whether this replica holds the lock, trying to take it when it does not
+func (l *AdvisoryLockLeader) Lead(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn != nil {
+		// the lock lives as long as the session holding it
+		if err := l.conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&locked); err != nil || !locked {
+		conn.Close()
+		return false, err
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Resign releases the lock, if held
+func (l *AdvisoryLockLeader) Resign(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	l.conn.Close()
+	l.conn = nil
+	return err
+}
@@ -0,0 +1,165 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockLeader is a leader election decided by the test
+type mockLeader struct {
+	lead     bool
+	resigned bool
+}
+
+func (l *mockLeader) Lead(context.Context) (bool, error) { return l.lead, nil }
+
+func (l *mockLeader) Resign(context.Context) error {
+	l.resigned = true
+	return nil
+}
+
+func Test_Scheduler_RunOnce(t *testing.T) {
+	// Arrange
+	now := time.Date(2019, 1, 21, 6, 0, 0, 0, time.UTC)
+	due := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	updated := mockNewPayment("0d5f5f3a-64e8-4c2e-8f4a-1b2b3c4d5e6f")
+	failing := mockNewPayment("9a1c3c6e-2f0b-4d1c-8a55-6f1c4c7b8e90")
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetDuePayments", now).Return([]Payment{due, updated, failing}, nil)
+	repositoryMock.On("TransitionPaymentStatus", due.ID.String(), StatusScheduled, StatusDue, "", schedulerActor).Return(true, nil)
+	repositoryMock.On("TransitionPaymentStatus", updated.ID.String(), StatusScheduled, StatusDue, "", schedulerActor).Return(false, nil)
+	repositoryMock.On("TransitionPaymentStatus", failing.ID.String(), StatusScheduled, StatusDue, "", schedulerActor).Return(true, nil)
+	repositoryMock.On("MarkPaymentHandled", due.ID.String(), now).Return(nil)
+	var handled []Payment
+	handler := DuePaymentHandlerFunc(func(_ context.Context, p Payment) error {
+		handled = append(handled, p)
+		if p.ID == failing.ID {
+			return errors.New("scheme unavailable")
+		}
+		return nil
+	})
+	events := NewEventBroker(10, 10)
+	scheduler := NewScheduler(repositoryMock, events, handler, &mockLeader{lead: true})
+	scheduler.now = func() time.Time { return now }
+
+	//Act
+	n, err := scheduler.RunOnce(context.Background())
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	if assert.Len(t, handled, 2, "the payment updated meanwhile is not handled") {
+		assert.Equal(t, due.ID, handled[0].ID)
+		assert.Equal(t, StatusDue, handled[0].Status)
+	}
+	if assert.Len(t, events.retained, 2) {
+		assert.Equal(t, EventPaymentStateChanged, events.retained[0].Type)
+		assert.Equal(t, due.ID.String(), events.retained[0].PaymentID)
+		assert.Equal(t, StatusDue, events.retained[0].Status)
+	}
+	repositoryMock.AssertNotCalled(t, "MarkPaymentHandled", failing.ID.String(), mock.Anything)
+}
+
+func Test_Scheduler_RunOnce_RetriesFailedHandling(t *testing.T) {
+	// Arrange
+	now := time.Date(2019, 1, 21, 6, 0, 0, 0, time.UTC)
+	p := mockNewPayment("9a1c3c6e-2f0b-4d1c-8a55-6f1c4c7b8e90")
+	p.Status = StatusScheduled
+	due := p
+	due.Status = StatusDue
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetDuePayments", now).Return([]Payment{p}, nil).Once()
+	repositoryMock.On("GetDuePayments", now).Return([]Payment{due}, nil).Once()
+	repositoryMock.On("TransitionPaymentStatus", p.ID.String(), StatusScheduled, StatusDue, "", schedulerActor).Return(true, nil).Once()
+	repositoryMock.On("MarkPaymentHandled", p.ID.String(), now).Return(nil).Once()
+	calls := 0
+	handler := DuePaymentHandlerFunc(func(_ context.Context, p Payment) error {
+		calls++
+		if calls == 1 {
+			return errors.New("scheme unavailable")
+		}
+		return nil
+	})
+	scheduler := NewScheduler(repositoryMock, NewEventBroker(10, 10), handler, &mockLeader{lead: true})
+	scheduler.now = func() time.Time { return now }
+
+	//Act
+	failed, failedErr := scheduler.RunOnce(context.Background())
+	retried, retriedErr := scheduler.RunOnce(context.Background())
+
+	//Assert
+	assert.NoError(t, failedErr)
+	assert.Equal(t, 0, failed)
+	assert.NoError(t, retriedErr)
+	assert.Equal(t, 1, retried, "the due payment whose handling failed is handled at the next run")
+	assert.Equal(t, 2, calls)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Scheduler_RunOnce_Follower(t *testing.T) {
+	// Arrange
+	repositoryMock := &MockRepository{}
+	scheduler := NewScheduler(repositoryMock, NewEventBroker(10, 10), DuePaymentHandlerFunc(func(context.Context, Payment) error { return nil }), &mockLeader{})
+
+	//Act
+	n, err := scheduler.RunOnce(context.Background())
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	repositoryMock.AssertNotCalled(t, "GetDuePayments", mock.Anything)
+}
+
+func Test_Scheduler_Run_Resigns(t *testing.T) {
+	// Arrange
+	leader := &mockLeader{}
+	scheduler := NewScheduler(&MockRepository{}, NewEventBroker(10, 10), nil, leader)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//Act
+	scheduler.Run(ctx, time.Hour)
+
+	//Assert
+	assert.True(t, leader.resigned)
+}
+
+func Test_AdvisoryLockLeader(t *testing.T) {
+	// Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "pg_try_advisory_lock",
+			Response: []map[string]interface{}{{"pg_try_advisory_lock": false}},
+			Once:     true,
+		},
+		{
+			Pattern:  "pg_try_advisory_lock",
+			Response: []map[string]interface{}{{"pg_try_advisory_lock": true}},
+		},
+	})
+	leader := NewAdvisoryLockLeader(db.DB(), SchedulerLockKey)
+
+	//Act
+	follower, followerErr := leader.Lead(context.Background())
+	elected, electedErr := leader.Lead(context.Background())
+	still, stillErr := leader.Lead(context.Background())
+	resignErr := leader.Resign(context.Background())
+
+	//Assert
+	assert.NoError(t, followerErr)
+	assert.False(t, follower, "another replica holds the lock")
+	assert.NoError(t, electedErr)
+	assert.True(t, elected)
+	assert.NoError(t, stillErr)
+	assert.True(t, still, "the lock is kept by the leader")
+	assert.NoError(t, resignErr)
+	assert.Nil(t, leader.conn)
+}
@@ -3,6 +3,24 @@ package payments
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/elkousy/payments-api/archive"
+	"github.com/elkousy/payments-api/calendar"
+	"github.com/elkousy/payments-api/currency"
+	"github.com/elkousy/payments-api/encryption"
+	"github.com/elkousy/payments-api/screening"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+)
+
+const (
+	// defaultPageSize is the number of payments returned when no page size is requested
+	defaultPageSize = 100
+	// maxPageSize is the maximum number of payments that can be requested in a single page
+	maxPageSize = 1000
 )
 
 // Service defines the payment service
@@ -12,15 +30,79 @@ type Service interface {
 	PostPayment(req CreatePaymentRequest) (*CreatePaymentResponse, error)
 	UpdatePayment(req UpdatePaymentRequest) (*UpdatePaymentResponse, error)
 	DeletePayment(req DeletePaymentRequest) (*DeletePaymentResponse, error)
+	RestorePayment(req RestorePaymentRequest) (*RestorePaymentResponse, error)
+	ReviewPayment(req ReviewPaymentRequest) (*ReviewPaymentResponse, error)
+	GetOrganisationLimits(req GetOrganisationLimitsRequest) (*GetOrganisationLimitsResponse, error)
+	UpdateOrganisationLimits(req UpdateOrganisationLimitsRequest) (*UpdateOrganisationLimitsResponse, error)
+	DeleteOrganisationLimits(req DeleteOrganisationLimitsRequest) (*DeleteOrganisationLimitsResponse, error)
+	ApprovePayment(req ApprovePaymentRequest) (*ApprovePaymentResponse, error)
+	GetPaymentApprovals(req GetPaymentApprovalsRequest) (*GetPaymentApprovalsResponse, error)
+	GetPaymentAudit(req GetPaymentAuditRequest) (*GetPaymentAuditResponse, error)
+	CreateReturn(req CreateReturnRequest) (*CreateReturnResponse, error)
+	GetReturn(req GetReturnRequest) (*GetReturnResponse, error)
+	GetPaymentReturns(req GetPaymentReturnsRequest) (*GetPaymentReturnsResponse, error)
+	UpdateReturnStatus(req UpdateReturnStatusRequest) (*UpdateReturnStatusResponse, error)
+	CreateRecall(req CreateRecallRequest) (*CreateRecallResponse, error)
+	GetRecall(req GetRecallRequest) (*GetRecallResponse, error)
+	GetPaymentRecalls(req GetPaymentRecallsRequest) (*GetPaymentRecallsResponse, error)
+	DecideRecall(req DecideRecallRequest) (*DecideRecallResponse, error)
+	GetAccountBalance(req GetAccountBalanceRequest) (*GetAccountBalanceResponse, error)
+	GetAccountEntries(req GetAccountEntriesRequest) (*GetAccountEntriesResponse, error)
+	CheckLedger(req CheckLedgerRequest) (*CheckLedgerResponse, error)
 }
 
 type service struct {
 	repository Repository
+	events     *EventBroker
+	duplicates DuplicateCheck
+	// fingerprintKey keys the fingerprints of the payments, nil when the parties are not encrypted
+	fingerprintKey []byte
+	screener       *screening.Screener
+	// recallWindow is the number of business days after the processing date of a payment in which it can be recalled
+	recallWindow int
+	// archive holds the payments moved out of the database, nil when they are not looked up
+	archive archive.Store
+	// archiveKeys decrypt the parties of the archived payments, nil when the archive is not encrypted
+	archiveKeys encryption.KeyProvider
+	now         func() time.Time
+}
+
+// ServiceOption configures the optional checks of the payment service
+type ServiceOption func(*service)
+
+// WithDuplicateCheck flags or rejects the payments looking like a payment recently created by their organisation
+func WithDuplicateCheck(check DuplicateCheck) ServiceOption {
+	return func(s *service) {
+		s.duplicates = check
+	}
+}
+
+// WithFingerprintKey keys the fingerprints of the payments, looked up to find the duplicates, with the index key of
+// the encryption of the parties
+func WithFingerprintKey(key []byte) ServiceOption {
+	return func(s *service) {
+		s.fingerprintKey = key
+	}
+}
+
+// WithScreening holds for review the payments whose parties match the sanctions lists of the screener
+func WithScreening(screener *screening.Screener) ServiceOption {
+	return func(s *service) {
+		s.screener = screener
+	}
+}
+
+// WithRecallWindow sets the number of business days after the processing date of a payment in which it can be recalled,
+// the fraud recalls are allowed for 13 months
+func WithRecallWindow(businessDays int) ServiceOption {
+	return func(s *service) {
+		s.recallWindow = businessDays
+	}
 }
 
-// NewPaymentService returns a new instance of the payment service
-func NewPaymentService(repository Repository) (Service, error) {
-	svc, err := newService(repository)
+// NewPaymentService returns a new instance of the payment service publishing the payment changes to the events broker
+func NewPaymentService(repository Repository, events *EventBroker, opts ...ServiceOption) (Service, error) {
+	svc, err := newService(repository, events, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,61 +116,652 @@ func NewPaymentService(repository Repository) (Service, error) {
 	return svc, nil
 }
 
-func newService(repository Repository) (Service, error) {
+func newService(repository Repository, events *EventBroker, opts ...ServiceOption) (Service, error) {
 	if repository == nil {
 		return nil, errors.New("cannot create new payments service, repository cannot be nil")
 	}
+	if events == nil {
+		return nil, errors.New("cannot create new payments service, events broker cannot be nil")
+	}
 
-	return service{repository: repository}, nil
+	s := service{repository: repository, events: events, recallWindow: defaultRecallWindow, now: time.Now}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s, nil
 }
 
 // GetPayment retrieves a specific payment by ID
 func (s service) GetPayment(req GetPaymentRequest) (*GetPaymentResponse, error) {
-	// get a payment
-	p, err := s.repository.GetPayment(req.PaymentID)
+	// get a payment, or one of its previous versions
+	var p Payment
+	var err error
+	switch {
+	case req.Version != nil:
+		p, err = s.repository.GetPaymentVersion(req.PaymentID, *req.Version)
+	case req.AsOf != nil:
+		p, err = s.repository.GetPaymentAsOf(req.PaymentID, *req.AsOf)
+	default:
+		p, err = s.repository.GetPayment(req.PaymentID)
+		if isNotFound(err) && s.archive != nil {
+			p, err = s.getArchivedPayment(req.PaymentID)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &GetPaymentResponse{Payment: p}, nil
+	return withCharges(p), nil
 }
 
 // GetListOfPayments returns a list of payments
 func (s service) GetListOfPayments(req GetListOfPaymentsRequest) (*GetListOfPaymentsResponse, error) {
-	// get list of payments
-	payments, err := s.repository.GetListOfPayments()
+	page, pageSize := req.Page, req.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	// get a page of payments
+	payments, err := s.repository.GetListOfPayments(ListQuery{Offset: (page - 1) * pageSize, Limit: pageSize, IncludeDeleted: req.IncludeDeleted, AccountNumber: req.AccountNumber})
 	if err != nil {
 		return nil, err
 	}
-	return &GetListOfPaymentsResponse{Data: payments, HateoasLink: HateoasLink{Self: "localhost:8080/v1/payments/"}}, nil
+	return &GetListOfPaymentsResponse{Data: payments, Meta: PageMeta{Page: page, PageSize: pageSize}}, nil
 }
 
 // PostPayment inserts a new payment in DB
 func (s service) PostPayment(req CreatePaymentRequest) (*CreatePaymentResponse, error) {
+	if req.IdempotencyKey != "" {
+		// a retried request returns the payment created by the first attempt
+		p, err := s.repository.GetPaymentByIdempotencyKey(req.OrganisationID, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			return &CreatePaymentResponse{PaymentID: p.ID.String(), Status: p.Status}, nil
+		}
+		req.Payment.IdempotencyKey = &req.IdempotencyKey
+	}
+
+	applySchemeFee(&req.Payment)
+	if errs := s.checkProcessingDate(req.Payment); len(errs) > 0 {
+		return nil, ErrInvalidProcessingDate.WithFieldErrors(errs...)
+	}
+
+	// check the schemes and the maximum amount allowed to the organisation
+	limits, err := s.repository.GetOrganisationLimits(req.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	if errs := limits.check(req.Payment); len(errs) > 0 {
+		return nil, ErrLimitExceeded.WithFieldErrors(errs...)
+	}
+
+	// look for a recent payment with the same accounts, amount and reference
+	req.Payment.Fingerprint, req.Payment.FingerprintKeyed = fingerprint(req.Payment, s.fingerprintKey), s.fingerprintKey != nil
+	var warnings []Warning
+	if policy := s.duplicates.policyOf(req.OrganisationID); policy != DuplicatePolicyOff {
+		duplicate, err := s.repository.GetPaymentByFingerprint(req.Payment.Fingerprint, s.now().Add(-s.duplicates.Window))
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != nil {
+			if policy == DuplicatePolicyReject {
+				return nil, ErrDuplicatePayment
+			}
+			warnings = append(warnings, Warning{
+				Code:      WarningPossibleDuplicate,
+				Message:   "a payment with the same accounts, amount, currency and end to end reference was recently created",
+				PaymentID: duplicate.ID.String(),
+			})
+		}
+	}
+
+	// screen the parties before submitting the payment, the payments above the approval threshold wait for a second person
+	req.Payment.CreatedBy = req.UserID
+	req.Payment.ApprovalRequired = limits.requiresApproval(req.Payment)
+	if req.Payment.ApprovalRequired && req.UserID == "" {
+		// the creator is needed to prevent them from approving their own payment
+		return nil, ErrMissingUserID
+	}
+	req.Payment.ScreeningHits = s.screen(req.Payment)
+	req.Payment.Status, req.Payment.StatusReason = s.initialStatus(req.Payment)
+
 	// create payment
-	id, err := s.repository.CreatePayment(req.Payment)
+	id, err := s.createPayment(req.Payment, req.Actor, limits)
 	if err != nil {
+		if req.IdempotencyKey != "" {
+			// a concurrent attempt may have won the race on the unique idempotency key
+			if p, _ := s.repository.GetPaymentByIdempotencyKey(req.OrganisationID, req.IdempotencyKey); p != nil {
+				return &CreatePaymentResponse{PaymentID: p.ID.String(), Status: p.Status}, nil
+			}
+		}
 		return nil, err
 	}
-	return &CreatePaymentResponse{PaymentID: id, HateoasLink: HateoasLink{Self: fmt.Sprintf("localhost:8080/v1/payments/%s/", id)}}, nil
+	s.publish(EventPaymentCreated, id, req.OrganisationID)
+	if req.Payment.Status != StatusSubmitted {
+		s.publishStatus(id, req.OrganisationID, req.Payment.Status)
+	}
+	return &CreatePaymentResponse{PaymentID: id, Status: req.Payment.Status, Warnings: warnings}, nil
+}
+
+// createPayment creates a payment, atomically with the check of the daily and monthly totals of its organisation
+func (s service) createPayment(p Payment, actor Actor, limits *OrganisationLimits) (string, error) {
+	limit, ok := limits.currencyLimit(p.Attributes.Currency)
+	if !ok || !limit.limitsTotals() {
+		return s.repository.CreatePayment(p, actor)
+	}
+	return s.repository.CreatePaymentWithinLimits(p, actor, s.now(), totalsCheck(limit, p.Attributes.Amount))
+}
+
+// updatePayment updates a payment, atomically with the check of the daily and monthly totals of its organisation on the
+// day the payment was created, its previous amount left out
+func (s service) updatePayment(id string, p Payment, actor Actor, limits *OrganisationLimits) error {
+	limit, ok := limits.currencyLimit(p.Attributes.Currency)
+	if !ok || !limit.limitsTotals() {
+		return s.repository.UpdatePayment(id, p, actor)
+	}
+	return s.repository.UpdatePaymentWithinLimits(id, p, actor, totalsCheck(limit, p.Attributes.Amount))
+}
+
+// totalsCheck returns the check of an amount against the daily and monthly limits of a currency
+func totalsCheck(limit CurrencyLimit, amount string) UsageCheck {
+	return func(daily, monthly *big.Rat) error {
+		if errs := limit.checkTotals(amount, daily, monthly); len(errs) > 0 {
+			return ErrLimitExceeded.WithFieldErrors(errs...)
+		}
+		return nil
+	}
 }
 
 // UpdatePayment update a payment ressource
 func (s service) UpdatePayment(req UpdatePaymentRequest) (*UpdatePaymentResponse, error) {
-	// udpate payment
-	err := s.repository.UpdatePayment(req.PaymentID, req.Payment)
+	applySchemeFee(&req.Payment)
+	if errs := s.checkProcessingDate(req.Payment); len(errs) > 0 {
+		return nil, ErrInvalidProcessingDate.WithFieldErrors(errs...)
+	}
+
+	// check the schemes and the maximum amount allowed to the organisation, the totals are checked with the update
+	limits, err := s.repository.GetOrganisationLimits(req.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	if errs := limits.check(req.Payment); len(errs) > 0 {
+		return nil, ErrLimitExceeded.WithFieldErrors(errs...)
+	}
+
+	// udpate payment, the parties are screened again and the amount compared with the approval threshold
+	req.Payment.Fingerprint, req.Payment.FingerprintKeyed = fingerprint(req.Payment, s.fingerprintKey), s.fingerprintKey != nil
+	req.Payment.ApprovalRequired = limits.requiresApproval(req.Payment)
+	req.Payment.ScreeningHits = s.screen(req.Payment)
+	err = s.updatePayment(req.PaymentID, req.Payment, req.Actor, limits)
 	if err != nil {
 		return nil, err
 	}
+	s.publish(EventPaymentUpdated, req.PaymentID, req.OrganisationID)
+
+	// a submitted or scheduled payment is held when its parties now match the lists, waits for approval when its amount
+	// is now above the threshold and is scheduled when it is now future dated. A held or pending payment stays so until
+	// reviewed or approved, a scheduled payment now dated today is marked due by the scheduler.
+	status, reason := s.initialStatus(req.Payment)
+	for _, from := range []PaymentStatus{StatusSubmitted, StatusScheduled} {
+		if status == StatusSubmitted || status == from {
+			continue
+		}
+		changed, err := s.repository.TransitionPaymentStatus(req.PaymentID, from, status, reason, req.Actor)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			s.publishStatus(req.PaymentID, req.OrganisationID, status)
+			break
+		}
+	}
 	return &UpdatePaymentResponse{PaymentID: req.PaymentID}, nil
 }
 
 // DeletePayment deletes a given payment by ID
 func (s service) DeletePayment(req DeletePaymentRequest) (*DeletePaymentResponse, error) {
+	// the organisation of the deleted payment is needed by the events subscribers
+	p, err := s.repository.GetPayment(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
 	// delete a payment
-	err := s.repository.DeletePayment(req.PaymentID)
+	err = s.repository.DeletePayment(req.PaymentID, req.Actor)
 	if err != nil {
 		return nil, err
 	}
+	s.publish(EventPaymentDeleted, req.PaymentID, p.OrganisationID)
 
 	return &DeletePaymentResponse{PaymentID: req.PaymentID}, err
 }
+
+// RestorePayment restores a deleted payment
+func (s service) RestorePayment(req RestorePaymentRequest) (*RestorePaymentResponse, error) {
+	p, err := s.repository.RestorePayment(req.PaymentID, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(EventPaymentRestored, req.PaymentID, p.OrganisationID)
+	return &RestorePaymentResponse{PaymentID: req.PaymentID}, nil
+}
+
+// ReviewPayment releases or rejects a payment held for review
+func (s service) ReviewPayment(req ReviewPaymentRequest) (*ReviewPaymentResponse, error) {
+	p, err := s.repository.GetPayment(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != StatusHeldForReview {
+		return nil, ErrPaymentNotHeldForReview
+	}
+
+	// a released payment above the approval threshold still waits for approval, a future dated one is scheduled
+	status, reason := s.readyStatus(p), req.Reason
+	switch {
+	case req.Decision == ReviewReject:
+		status = StatusRejected
+	case p.ApprovalRequired:
+		status, reason = StatusPendingApproval, approvalReason
+	}
+	// another reviewer may have decided in the meantime
+	reviewed, err := s.repository.TransitionPaymentStatus(req.PaymentID, StatusHeldForReview, status, reason, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if !reviewed {
+		return nil, ErrPaymentNotHeldForReview
+	}
+	s.publishStatus(req.PaymentID, p.OrganisationID, status)
+	return &ReviewPaymentResponse{PaymentID: req.PaymentID, Status: status}, nil
+}
+
+// ApprovePayment records the decision of an approver on a payment pending approval, the creator of the payment cannot approve it
+func (s service) ApprovePayment(req ApprovePaymentRequest) (*ApprovePaymentResponse, error) {
+	p, err := s.repository.GetPayment(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != StatusPendingApproval {
+		return nil, ErrPaymentNotPendingApproval
+	}
+	if req.Approver == p.CreatedBy {
+		return nil, ErrSelfApproval
+	}
+
+	status := s.readyStatus(p)
+	if req.Decision == ApprovalReject {
+		status = StatusRejected
+	}
+	approval := Approval{
+		ID:        uuid.NewV4(),
+		PaymentID: p.ID,
+		Approver:  req.Approver,
+		Decision:  req.Decision,
+		Comment:   req.Comment,
+		CreatedAt: s.now().UTC(),
+	}
+	// another approver may have decided in the meantime
+	approved, err := s.repository.RecordApproval(approval, status, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		return nil, ErrPaymentNotPendingApproval
+	}
+	s.publishStatus(req.PaymentID, p.OrganisationID, status)
+	return &ApprovePaymentResponse{Approval: approval, Status: status}, nil
+}
+
+// GetPaymentApprovals returns the approvals recorded on a payment, oldest first
+func (s service) GetPaymentApprovals(req GetPaymentApprovalsRequest) (*GetPaymentApprovalsResponse, error) {
+	if _, err := s.repository.GetPayment(req.PaymentID); err != nil {
+		return nil, err
+	}
+	approvals, err := s.repository.GetApprovals(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetPaymentApprovalsResponse{Data: approvals}, nil
+}
+
+// GetPaymentAudit returns the audit log of a payment, oldest first. The log of a deleted payment is kept.
+func (s service) GetPaymentAudit(req GetPaymentAuditRequest) (*GetPaymentAuditResponse, error) {
+	entries, err := s.repository.GetAuditEntries(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		// the payments created before the audit log have no entries
+		if _, err := s.repository.GetPayment(req.PaymentID); err != nil {
+			return nil, err
+		}
+	}
+	return &GetPaymentAuditResponse{Data: entries}, nil
+}
+
+// CreateReturn returns or reverses all or part of a payment which has gone out. The returns and the reversals of
+// a payment which have not failed cannot exceed its amount.
+func (s service) CreateReturn(req CreateReturnRequest) (*CreateReturnResponse, error) {
+	p, err := s.repository.GetPayment(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReturnable(p) {
+		return nil, ErrPaymentNotReturnable
+	}
+	if req.Amount != "" {
+		if c, ok := currency.Lookup(p.Attributes.Currency); ok {
+			if err := c.ValidateAmount(req.Amount); err != nil {
+				return nil, ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: err.Error()})
+			}
+		}
+	}
+
+	now := s.now().UTC()
+	ret := Return{
+		ID:         uuid.NewV4(),
+		PaymentID:  p.ID,
+		Type:       req.Type,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+		Amount:     req.Amount,
+		Currency:   p.Attributes.Currency,
+		Status:     ReturnStatusPending,
+		CreatedBy:  req.UserID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	ret, err = s.repository.CreateReturn(ret, func(r *Return, returned *big.Rat) error {
+		return checkReturnAmount(p, r, returned)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreateReturnResponse{Return: ret}, nil
+}
+
+// GetReturn returns a return or a reversal of a payment
+func (s service) GetReturn(req GetReturnRequest) (*GetReturnResponse, error) {
+	ret, err := s.repository.GetReturn(req.PaymentID, req.Type, req.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetReturnResponse{Return: ret}, nil
+}
+
+// GetPaymentReturns returns the returns or the reversals of a payment, oldest first
+func (s service) GetPaymentReturns(req GetPaymentReturnsRequest) (*GetPaymentReturnsResponse, error) {
+	if _, err := s.repository.GetPayment(req.PaymentID); err != nil {
+		return nil, err
+	}
+	returns, err := s.repository.GetReturns(req.PaymentID, req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &GetPaymentReturnsResponse{Data: returns}, nil
+}
+
+// UpdateReturnStatus completes or fails a pending return or reversal, the amount of a failed return can be returned again
+func (s service) UpdateReturnStatus(req UpdateReturnStatusRequest) (*UpdateReturnStatusResponse, error) {
+	ret, err := s.repository.GetReturn(req.PaymentID, req.Type, req.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != ReturnStatusPending {
+		return nil, ErrReturnNotPending
+	}
+	// the return may have been completed or failed in the meantime
+	updated, err := s.repository.TransitionReturnStatus(req.ReturnID, ReturnStatusPending, req.Status, req.Reason, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrReturnNotPending
+	}
+	ret.Status, ret.StatusReason = req.Status, req.Reason
+	return &UpdateReturnStatusResponse{Return: ret}, nil
+}
+
+// CreateRecall asks the beneficiary bank to recall a payment which has gone out, within the recall window of its reason
+func (s service) CreateRecall(req CreateRecallRequest) (*CreateRecallResponse, error) {
+	p, err := s.repository.GetPayment(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if errs := checkRecallEligibility(p, req.ReasonCode, s.today(), s.recallWindow); len(errs) > 0 {
+		return nil, ErrRecallNotEligible.WithFieldErrors(errs...)
+	}
+
+	recall := Recall{
+		ID:          uuid.NewV4(),
+		PaymentID:   p.ID,
+		ReasonCode:  req.ReasonCode,
+		Reason:      req.Reason,
+		Status:      RecallStatusRequested,
+		RequestedBy: req.UserID,
+		CreatedAt:   s.now().UTC(),
+	}
+	// another recall may have been requested in the meantime
+	created, err := s.repository.CreateRecall(recall)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrRecallAlreadyRequested
+	}
+	return &CreateRecallResponse{Recall: recall}, nil
+}
+
+// GetRecall returns a recall of a payment
+func (s service) GetRecall(req GetRecallRequest) (*GetRecallResponse, error) {
+	recall, err := s.repository.GetRecall(req.PaymentID, req.RecallID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRecallResponse{Recall: recall}, nil
+}
+
+// GetPaymentRecalls returns the recalls of a payment, oldest first
+func (s service) GetPaymentRecalls(req GetPaymentRecallsRequest) (*GetPaymentRecallsResponse, error) {
+	if _, err := s.repository.GetPayment(req.PaymentID); err != nil {
+		return nil, err
+	}
+	recalls, err := s.repository.GetRecalls(req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetPaymentRecallsResponse{Data: recalls}, nil
+}
+
+// DecideRecall records the decision of the beneficiary bank on a requested recall. The funds of an accepted recall
+// are then returned, as a return with the FOCR reason code.
+func (s service) DecideRecall(req DecideRecallRequest) (*DecideRecallResponse, error) {
+	recall, err := s.repository.GetRecall(req.PaymentID, req.RecallID)
+	if err != nil {
+		return nil, err
+	}
+	if recall.Status != RecallStatusRequested {
+		return nil, ErrRecallNotRequested
+	}
+
+	status := RecallStatusAccepted
+	if req.Decision == RecallReject {
+		status = RecallStatusRejected
+	}
+	decidedAt := s.now().UTC()
+	// the decision may have been recorded in the meantime
+	decided, err := s.repository.DecideRecall(req.RecallID, status, req.Reason, decidedAt, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+	if !decided {
+		return nil, ErrRecallNotRequested
+	}
+	recall.Status, recall.DecisionReason, recall.DecidedAt = status, req.Reason, &decidedAt
+	return &DecideRecallResponse{Recall: recall}, nil
+}
+
+// GetAccountBalance returns the balance of a ledger account in each currency of its entries
+func (s service) GetAccountBalance(req GetAccountBalanceRequest) (*GetAccountBalanceResponse, error) {
+	balances, err := s.repository.GetAccountBalances(req.Account)
+	if err != nil {
+		return nil, err
+	}
+	// the accounts are derived from the payments, an account without entries does not exist
+	if len(balances) == 0 {
+		return nil, ErrAccountNotFound
+	}
+	return &GetAccountBalanceResponse{Account: req.Account, Balances: balances}, nil
+}
+
+// GetAccountEntries returns a page of the entries of a ledger account, oldest first
+func (s service) GetAccountEntries(req GetAccountEntriesRequest) (*GetAccountEntriesResponse, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	entries, err := s.repository.GetAccountEntries(req.Account, ListQuery{Offset: (page - 1) * pageSize, Limit: pageSize})
+	if err != nil {
+		return nil, err
+	}
+	return &GetAccountEntriesResponse{Data: entries, Meta: PageMeta{Page: page, PageSize: pageSize}}, nil
+}
+
+// CheckLedger checks the invariant of the ledger: every transaction sums to zero in each currency
+func (s service) CheckLedger(req CheckLedgerRequest) (*CheckLedgerResponse, error) {
+	imbalances, err := s.repository.GetLedgerImbalances()
+	if err != nil {
+		return nil, err
+	}
+	return &CheckLedgerResponse{Balanced: len(imbalances) == 0, Imbalances: imbalances}, nil
+}
+
+// GetOrganisationLimits returns the limits of an organisation
+func (s service) GetOrganisationLimits(req GetOrganisationLimitsRequest) (*GetOrganisationLimitsResponse, error) {
+	limits, err := s.repository.GetOrganisationLimits(uuid.FromStringOrNil(req.OrganisationID))
+	if err != nil {
+		return nil, err
+	}
+	if limits == nil {
+		return nil, ErrLimitsNotFound
+	}
+	return &GetOrganisationLimitsResponse{OrganisationLimits: *limits}, nil
+}
+
+// UpdateOrganisationLimits replaces the limits of an organisation
+func (s service) UpdateOrganisationLimits(req UpdateOrganisationLimitsRequest) (*UpdateOrganisationLimitsResponse, error) {
+	limits := req.Limits
+	limits.OrganisationID = uuid.FromStringOrNil(req.OrganisationID)
+	limits.UpdatedAt = s.now().UTC()
+	if err := s.repository.SaveOrganisationLimits(limits); err != nil {
+		return nil, err
+	}
+	return &UpdateOrganisationLimitsResponse{OrganisationLimits: limits}, nil
+}
+
+// DeleteOrganisationLimits removes the limits of an organisation
+func (s service) DeleteOrganisationLimits(req DeleteOrganisationLimitsRequest) (*DeleteOrganisationLimitsResponse, error) {
+	if err := s.repository.DeleteOrganisationLimits(uuid.FromStringOrNil(req.OrganisationID)); err != nil {
+		return nil, err
+	}
+	return &DeleteOrganisationLimitsResponse{OrganisationID: req.OrganisationID}, nil
+}
+
+// checkProcessingDate returns an error when the processing date of the payment is before today, or when it is a future
+// day which is not a business day of the calendar of its scheme, i.e. a weekend day or a holiday. A payment dated
+// today is submitted straight away whatever the day.
+func (s service) checkProcessingDate(p Payment) []apierrors.FieldError {
+	date, err := time.Parse(calendar.DateFormat, p.Attributes.ProcessingDate)
+	if err != nil {
+		// the format is checked by the validator
+		return nil
+	}
+	today := s.today()
+	if date.Before(today) {
+		return []apierrors.FieldError{{Field: "attributes.processing_date", Message: "is before today, " + today.Format(calendar.DateFormat)}}
+	}
+	if scheme, ok := calendar.ForScheme(p.Attributes.PaymentScheme); ok && date.After(today) && !scheme.Calendar.IsBusinessDay(date) {
+		return []apierrors.FieldError{{
+			Field: "attributes.processing_date",
+			Message: fmt.Sprintf("is not a business day of the %s calendar, the next business day is %s",
+				scheme.Calendar.Name, scheme.Calendar.NextBusinessDay(date).Format(calendar.DateFormat)),
+		}}
+	}
+	return nil
+}
+
+// initialStatus returns the status of a payment created or updated: held when its parties match the sanctions lists,
+// pending approval when its amount is above the approval threshold, else scheduled or submitted
+func (s service) initialStatus(p Payment) (PaymentStatus, string) {
+	switch {
+	case len(p.ScreeningHits) > 0:
+		return StatusHeldForReview, screeningReason
+	case p.ApprovalRequired:
+		return StatusPendingApproval, approvalReason
+	}
+	return s.readyStatus(p), ""
+}
+
+// readyStatus returns the status of a payment cleared by the checks: scheduled when it is future dated, else submitted
+func (s service) readyStatus(p Payment) PaymentStatus {
+	if date, err := time.Parse(calendar.DateFormat, p.Attributes.ProcessingDate); err == nil && date.After(s.today()) {
+		return StatusScheduled
+	}
+	return StatusSubmitted
+}
+
+// today returns the current day, in UTC
+func (s service) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// screeningReason is the status reason of the payments held by the screening
+const screeningReason = "a party matches the sanctions lists"
+
+// approvalReason is the status reason of the payments waiting for approval
+const approvalReason = "the amount is above the approval threshold of the organisation"
+
+// screen returns the entries of the sanctions lists matching the names or the addresses of the parties
+func (s service) screen(p Payment) []ScreeningHit {
+	parties := []struct {
+		name  string
+		party DebtorParty
+	}{
+		{"debtor_party", p.Attributes.DebtorParty},
+		{"beneficiary_party", p.Attributes.BeneficiaryParty.DebtorParty},
+	}
+	var hits []ScreeningHit
+	for _, party := range parties {
+		for _, m := range s.screener.Screen(party.party.Name, party.party.Address) {
+			hits = append(hits, ScreeningHit{
+				Party:     party.name,
+				Field:     m.Field,
+				EntryUID:  m.EntryUID,
+				EntryName: m.EntryName,
+				Matched:   m.Matched,
+				Score:     m.Score,
+			})
+		}
+	}
+	return hits
+}
+
+// publish notifies the events subscribers of a payment change
+func (s service) publish(eventType EventType, paymentID string, organisationID uuid.UUID) {
+	s.events.Publish(Event{Type: eventType, PaymentID: paymentID, OrganisationID: organisationID})
+}
+
+// publishStatus notifies the events subscribers of the new status of a payment
+func (s service) publishStatus(paymentID string, organisationID uuid.UUID, status PaymentStatus) {
+	s.events.Publish(Event{Type: EventPaymentStateChanged, PaymentID: paymentID, OrganisationID: organisationID, Status: status})
+}
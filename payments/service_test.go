@@ -1,21 +1,40 @@
 package payments
 
 import (
-	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/ledger"
+	"github.com/elkousy/payments-api/screening"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
 )
 
+// mockScreener screens the parties against a single sanctioned entry
+func mockScreener() *screening.Screener {
+	return screening.NewScreener([]screening.Entry{{UID: "2674", Name: "DRAVEK, Anton Mirkovic"}}, screening.DefaultThreshold)
+}
+
 func Test_Service_GetPayment(t *testing.T) {
 	// Arrange
 	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
 	p := mockNewPayment(id)
-	expectedRes := GetPaymentResponse{Payment: p}
+	expectedRes := GetPaymentResponse{
+		Payment:             p,
+		SenderChargesTotals: []charges.Amount{{Amount: "5.00", Currency: "GBP"}, {Amount: "10.00", Currency: "USD"}},
+		TotalDebitAmount:    "105.21",
+		NetCreditAmount:     "100.21",
+	}
 	repositoryMock := &MockRepository{}
 	repositoryMock.On("GetPayment", mock.Anything).Return(p, nil)
-	service, _ := NewPaymentService(repositoryMock)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
 
 	//Act
 	res, err := service.GetPayment(GetPaymentRequest{PaymentID: id})
@@ -26,19 +45,78 @@ func Test_Service_GetPayment(t *testing.T) {
 	assert.Equal(t, expectedRes, *res)
 }
 
+func Test_Service_GetPayment_Charges(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		bearer      string
+		debit       string
+		credit      string
+		receiverFee string
+	}{
+		{"SHAR", "105.21", "99.21", "1.00"},
+		{"OUR", "106.21", "100.21", "1.00"},
+		{"BEN", "100.21", "94.21", "1.00"},
+		{"BEN", "100.21", "95.21", "0"},
+		{"", "", "", "1.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.bearer, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Attributes.ChargesInformation.BearerCode = tt.bearer
+			p.Attributes.ChargesInformation.ReceiverChargesAmount = tt.receiverFee
+			p.Attributes.ChargesInformation.ReceiverChargesCurrency = "GBP"
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetPayment", id).Return(p, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.GetPayment(GetPaymentRequest{PaymentID: id})
+
+			//Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.debit, res.TotalDebitAmount)
+			assert.Equal(t, tt.credit, res.NetCreditAmount)
+		})
+	}
+}
+
+func Test_Service_GetPayment_Version(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	version, at := uint(3), time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	v3, v2 := mockNewPayment(id), mockNewPayment(id)
+	v3.Version, v2.Version = 3, 2
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPaymentVersion", id, version).Return(v3, nil)
+	repositoryMock.On("GetPaymentAsOf", id, at).Return(v2, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	byVersion, versionErr := service.GetPayment(GetPaymentRequest{PaymentID: id, Version: &version})
+	byTime, timeErr := service.GetPayment(GetPaymentRequest{PaymentID: id, AsOf: &at})
+
+	//Assert
+	require.NoError(t, versionErr)
+	require.NoError(t, timeErr)
+	assert.Equal(t, uint(3), byVersion.Version)
+	assert.Equal(t, uint(2), byTime.Version)
+	repositoryMock.AssertNotCalled(t, "GetPayment", id)
+}
+
 func Test_Service_GetListOfPayments(t *testing.T) {
 	// Arrange
 
 	id1 := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
 	id2 := "6ef6057f-0ed4-48c9-a128-f85b8f024519"
 	pays := []Payment{mockNewPayment(id1), mockNewPayment(id2)}
-	expectedRes := GetListOfPaymentsResponse{Data: pays, HateoasLink: HateoasLink{Self: "localhost:8080/v1/payments/"}}
+	expectedRes := GetListOfPaymentsResponse{Data: pays, Meta: PageMeta{Page: 2, PageSize: 2}}
 	repositoryMock := &MockRepository{}
-	repositoryMock.On("GetListOfPayments", mock.Anything).Return(pays, nil)
-	service, _ := NewPaymentService(repositoryMock)
+	repositoryMock.On("GetListOfPayments", ListQuery{Offset: 2, Limit: 2}).Return(pays, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
 
 	//Act
-	res, err := service.GetListOfPayments(GetListOfPaymentsRequest{})
+	res, err := service.GetListOfPayments(GetListOfPaymentsRequest{Page: 2, PageSize: 2})
 
 	//Assert
 	assert.NoError(t, err)
@@ -46,14 +124,30 @@ func Test_Service_GetListOfPayments(t *testing.T) {
 	assert.Equal(t, expectedRes, *res)
 }
 
+func Test_Service_GetListOfPayments_DefaultPage(t *testing.T) {
+	// Arrange
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetListOfPayments", ListQuery{Offset: 0, Limit: defaultPageSize}).Return([]Payment{}, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.GetListOfPayments(GetListOfPaymentsRequest{})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, PageMeta{Page: 1, PageSize: defaultPageSize}, res.Meta)
+	repositoryMock.AssertExpectations(t)
+}
+
 func Test_Service_PostPayment(t *testing.T) {
 	// Arrange
 	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
 	p := mockNewPayment(id)
-	expectedRes := CreatePaymentResponse{PaymentID: id, HateoasLink: HateoasLink{Self: fmt.Sprintf("localhost:8080/v1/payments/%s/", id)}}
+	expectedRes := CreatePaymentResponse{PaymentID: id, Status: StatusSubmitted}
 	repositoryMock := &MockRepository{}
-	repositoryMock.On("CreatePayment", mock.Anything).Return(id, nil)
-	service, _ := NewPaymentService(repositoryMock)
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.Anything, mock.Anything).Return(id, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
 
 	//Act
 	res, err := service.PostPayment(CreatePaymentRequest{Payment: p})
@@ -64,14 +158,287 @@ func Test_Service_PostPayment(t *testing.T) {
 	assert.Equal(t, expectedRes, *res)
 }
 
+func Test_Service_PostPayment_SchemeFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+		given  []Charge
+		want   []Charge
+	}{
+		{"omitted charges", "FPS", nil, []Charge{{Amount: "0.35", Currency: "GBP"}}},
+		{"given charges", "FPS", []Charge{{Amount: "5.00", Currency: "GBP"}}, []Charge{{Amount: "5.00", Currency: "GBP"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+			p := mockNewPayment(id)
+			p.Attributes.PaymentScheme = tt.scheme
+			p.Attributes.ChargesInformation.SenderCharges = tt.given
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+			repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+				return assert.ObjectsAreEqual(tt.want, created.Attributes.ChargesInformation.SenderCharges)
+			}), mock.Anything).Return(id, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			_, err := service.PostPayment(CreatePaymentRequest{Payment: p})
+
+			//Assert
+			assert.NoError(t, err)
+			repositoryMock.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Service_PostPayment_IdempotencyKey(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("GetPaymentByIdempotencyKey", p.OrganisationID, "key-1").Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+		return created.IdempotencyKey != nil && *created.IdempotencyKey == "key-1"
+	}), mock.Anything).Return(id, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.PostPayment(CreatePaymentRequest{Payment: p, IdempotencyKey: "key-1"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, CreatePaymentResponse{PaymentID: id, Status: StatusSubmitted}, *res)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Service_PostPayment_IdempotencyKey_Retry(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPaymentByIdempotencyKey", p.OrganisationID, "key-1").Return(&p, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.PostPayment(CreatePaymentRequest{Payment: p, IdempotencyKey: "key-1"})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, CreatePaymentResponse{PaymentID: id}, *res)
+	repositoryMock.AssertNotCalled(t, "CreatePayment", mock.Anything)
+}
+
+func Test_Service_PostPayment_Duplicates(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	duplicateID := "0d5f5f3a-64e8-4c2e-8f4a-1b2b3c4d5e6f"
+	strictOrg := uuid.NewV4()
+	tests := []struct {
+		name         string
+		organisation uuid.UUID
+		duplicate    bool
+		wantErr      error
+		wantWarnings []Warning
+	}{
+		{name: "Should create a payment without duplicate", duplicate: false},
+		{
+			name:      "Should warn when the organisation policy is warn",
+			duplicate: true,
+			wantWarnings: []Warning{{
+				Code:      WarningPossibleDuplicate,
+				Message:   "a payment with the same accounts, amount, currency and end to end reference was recently created",
+				PaymentID: duplicateID,
+			}},
+		},
+		{name: "Should reject when the organisation policy is reject", organisation: strictOrg, duplicate: true, wantErr: ErrDuplicatePayment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			if tt.organisation != uuid.Nil {
+				p.OrganisationID = tt.organisation
+			}
+			now := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+			p.Attributes.ProcessingDate = "2019-01-18"
+			var duplicate *Payment
+			if tt.duplicate {
+				d := mockNewPayment(duplicateID)
+				duplicate = &d
+			}
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+			repositoryMock.On("GetPaymentByFingerprint", fingerprint(p, nil), now.Add(-time.Hour)).Return(duplicate, nil)
+			repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+				return created.Fingerprint == fingerprint(p, nil)
+			}), mock.Anything).Return(id, nil)
+			svc, _ := newService(repositoryMock, NewEventBroker(10, 10), WithDuplicateCheck(DuplicateCheck{
+				Window:        time.Hour,
+				Policy:        DuplicatePolicyWarn,
+				Organisations: map[uuid.UUID]DuplicatePolicy{strictOrg: DuplicatePolicyReject},
+			}))
+			s := svc.(service)
+			s.now = func() time.Time { return now }
+
+			//Act
+			res, err := s.PostPayment(CreatePaymentRequest{Payment: p})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				repositoryMock.AssertNotCalled(t, "CreatePayment", mock.Anything)
+				return
+			}
+			assert.Equal(t, CreatePaymentResponse{PaymentID: id, Status: StatusSubmitted, Warnings: tt.wantWarnings}, *res)
+		})
+	}
+}
+
+func Test_Service_PostPayment_FingerprintKey(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	key := []byte(strings.Repeat("k", 32))
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("GetPaymentByFingerprint", fingerprint(p, key), mock.Anything).Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+		return created.Fingerprint == fingerprint(p, key) && created.FingerprintKeyed
+	}), mock.Anything).Return(id, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithFingerprintKey(key),
+		WithDuplicateCheck(DuplicateCheck{Window: time.Hour, Policy: DuplicatePolicyReject}))
+
+	//Act
+	_, err := service.PostPayment(CreatePaymentRequest{Payment: p})
+
+	//Assert
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Service_PostPayment_DuplicatesOff(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.Anything, mock.Anything).Return(id, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithDuplicateCheck(DuplicateCheck{
+		Window:        time.Hour,
+		Policy:        DuplicatePolicyWarn,
+		Organisations: map[uuid.UUID]DuplicatePolicy{p.OrganisationID: DuplicatePolicyOff},
+	}))
+
+	//Act
+	_, err := service.PostPayment(CreatePaymentRequest{Payment: p})
+
+	//Assert
+	assert.NoError(t, err)
+	repositoryMock.AssertNotCalled(t, "GetPaymentByFingerprint", mock.Anything, mock.Anything)
+}
+
+func Test_Service_PostPayment_Screening(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	p.Attributes.BeneficiaryParty.Name = "Anton Mirkovich Dravek"
+	p.Status = StatusRejected
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+		return created.Status == StatusHeldForReview && len(created.ScreeningHits) == 1 &&
+			created.ScreeningHits[0].Party == "beneficiary_party" && created.ScreeningHits[0].EntryUID == "2674"
+	}), mock.Anything).Return(id, nil)
+	events := NewEventBroker(10, 10)
+	service, _ := NewPaymentService(repositoryMock, events, WithScreening(mockScreener()))
+
+	//Act
+	res, err := service.PostPayment(CreatePaymentRequest{Payment: p})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, CreatePaymentResponse{PaymentID: id, Status: StatusHeldForReview}, *res)
+	repositoryMock.AssertExpectations(t)
+	_, replay, _, _ := events.subscribe(EventFilter{}, 1)
+	assert.Equal(t, EventPaymentStateChanged, replay[0].Type)
+	assert.Equal(t, StatusHeldForReview, replay[0].Status)
+}
+
+func Test_Service_UpdatePayment_Screening(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	p.Attributes.DebtorParty.Name = "Anton Mirkovic Dravek"
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("UpdatePayment", id, mock.MatchedBy(func(updated Payment) bool {
+		return len(updated.ScreeningHits) == 1 && updated.ScreeningHits[0].Party == "debtor_party"
+	}), mock.Anything).Return(nil)
+	repositoryMock.On("TransitionPaymentStatus", id, StatusSubmitted, StatusHeldForReview, screeningReason, mock.Anything).Return(true, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10), WithScreening(mockScreener()))
+
+	//Act
+	_, err := service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+
+	//Assert
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Service_ReviewPayment(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name       string
+		decision   ReviewDecision
+		status     PaymentStatus
+		transition bool
+		want       PaymentStatus
+		wantErr    error
+	}{
+		{name: "Should release a held payment", decision: ReviewRelease, status: StatusHeldForReview, transition: true, want: StatusSubmitted},
+		{name: "Should reject a held payment", decision: ReviewReject, status: StatusHeldForReview, transition: true, want: StatusRejected},
+		{name: "Should not review a payment which is not held", decision: ReviewRelease, status: StatusSubmitted, wantErr: ErrPaymentNotHeldForReview},
+		{name: "Should not review a payment reviewed meanwhile", decision: ReviewReject, status: StatusHeldForReview, transition: false, want: StatusRejected, wantErr: ErrPaymentNotHeldForReview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Status = tt.status
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetPayment", id).Return(p, nil)
+			repositoryMock.On("TransitionPaymentStatus", id, StatusHeldForReview, tt.want, "checked", mock.Anything).Return(tt.transition, nil)
+			events := NewEventBroker(10, 10)
+			sub, _, _, _ := events.subscribe(EventFilter{}, 0)
+			service, _ := NewPaymentService(repositoryMock, events)
+
+			//Act
+			res, err := service.ReviewPayment(ReviewPaymentRequest{PaymentID: id, Decision: tt.decision, Reason: "checked"})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, ReviewPaymentResponse{PaymentID: id, Status: tt.want}, *res)
+			e := <-sub.events
+			assert.Equal(t, EventPaymentStateChanged, e.Type)
+			assert.Equal(t, p.OrganisationID, e.OrganisationID)
+			assert.Equal(t, tt.want, e.Status)
+		})
+	}
+}
+
 func Test_Service_UpdatePayment(t *testing.T) {
 	// Arrange
 	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
 	p := mockNewPayment(id)
 	expectedRes := UpdatePaymentResponse{PaymentID: id}
 	repositoryMock := &MockRepository{}
-	repositoryMock.On("UpdatePayment", mock.Anything, mock.Anything).Return(nil)
-	service, _ := NewPaymentService(repositoryMock)
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("UpdatePayment", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
 
 	//Act
 	res, err := service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
@@ -81,19 +448,800 @@ func Test_Service_UpdatePayment(t *testing.T) {
 	assert.NotNil(t, res, "result should not be nil")
 	assert.Equal(t, expectedRes, *res)
 }
+func Test_Service_PostPayment_Limits(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		limits  *OrganisationLimits
+		daily   string
+		wantErr error
+	}{
+		{name: "Should create a payment within the limits", limits: &OrganisationLimits{
+			Currencies: []CurrencyLimit{{Currency: "GBP", MaxAmount: "1000", DailyTotal: "1000"}},
+		}, daily: "899.79"},
+		{
+			name:   "Should reject a payment with a scheme not allowed",
+			limits: &OrganisationLimits{AllowedSchemes: []string{"Bacs"}},
+			wantErr: ErrLimitExceeded.WithFieldErrors(apierrors.FieldError{
+				Field: "attributes.payment_scheme", Message: "is not allowed for the organisation, allowed schemes are Bacs",
+			}),
+		},
+		{
+			name:   "Should reject a payment beyond the daily limit",
+			limits: &OrganisationLimits{Currencies: []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000"}}},
+			daily:  "950",
+			wantErr: ErrLimitExceeded.WithFieldErrors(apierrors.FieldError{
+				Field: "attributes.amount", Message: "exceeds the daily limit of 1000 GBP, the remaining allowance is 50.00 GBP",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			now := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+			p.Attributes.ProcessingDate = "2019-01-18"
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(tt.limits, nil)
+			repositoryMock.On("CreatePaymentWithinLimits", mock.Anything, mock.Anything, now, mock.Anything).Return(
+				func(_ Payment, _ Actor, _ time.Time, check UsageCheck) string {
+					if check(parseAmount(tt.daily), parseAmount(tt.daily)) != nil {
+						return ""
+					}
+					return id
+				},
+				func(_ Payment, _ Actor, _ time.Time, check UsageCheck) error {
+					return check(parseAmount(tt.daily), parseAmount(tt.daily))
+				})
+			svc, _ := newService(repositoryMock, NewEventBroker(10, 10))
+			s := svc.(service)
+			s.now = func() time.Time { return now }
+
+			//Act
+			res, err := s.PostPayment(CreatePaymentRequest{Payment: p})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, id, res.PaymentID)
+			}
+			repositoryMock.AssertNotCalled(t, "CreatePayment", mock.Anything)
+		})
+	}
+}
+
+func Test_Service_UpdatePayment_Limits(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(&OrganisationLimits{
+		Currencies: []CurrencyLimit{{Currency: "GBP", MaxAmount: "100"}},
+	}, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	_, err := service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+
+	//Assert
+	assert.Equal(t, ErrLimitExceeded.WithFieldErrors(apierrors.FieldError{
+		Field: "attributes.amount", Message: "exceeds the maximum amount of 100 GBP",
+	}), err)
+	repositoryMock.AssertNotCalled(t, "UpdatePayment", mock.Anything, mock.Anything)
+}
+
+func Test_Service_UpdatePayment_DailyLimit(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(&OrganisationLimits{
+		Currencies: []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000"}},
+	}, nil)
+	repositoryMock.On("UpdatePaymentWithinLimits", id, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ string, _ Payment, _ Actor, check UsageCheck) error {
+			// the other payments of the day, the previous amount of the updated payment is left out
+			return check(parseAmount("950"), parseAmount("950"))
+		})
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	_, err := service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+
+	//Assert
+	assert.Equal(t, ErrLimitExceeded.WithFieldErrors(apierrors.FieldError{
+		Field: "attributes.amount", Message: "exceeds the daily limit of 1000 GBP, the remaining allowance is 50.00 GBP",
+	}), err)
+	repositoryMock.AssertNotCalled(t, "UpdatePayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Service_OrganisationLimits(t *testing.T) {
+	// Arrange
+	organisationID := uuid.NewV4()
+	now := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+	limits := OrganisationLimits{
+		OrganisationID: organisationID,
+		AllowedSchemes: []string{"FPS"},
+		Currencies:     []CurrencyLimit{{Currency: "GBP", DailyTotal: "1000"}},
+		UpdatedAt:      now,
+	}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("SaveOrganisationLimits", limits).Return(nil)
+	repositoryMock.On("GetOrganisationLimits", organisationID).Return(&limits, nil).Once()
+	repositoryMock.On("DeleteOrganisationLimits", organisationID).Return(nil)
+	repositoryMock.On("GetOrganisationLimits", organisationID).Return(nil, nil)
+	svc, _ := newService(repositoryMock, NewEventBroker(10, 10))
+	s := svc.(service)
+	s.now = func() time.Time { return now }
+
+	//Act
+	updated, updateErr := s.UpdateOrganisationLimits(UpdateOrganisationLimitsRequest{
+		OrganisationID: organisationID.String(),
+		Limits:         OrganisationLimits{AllowedSchemes: []string{"FPS"}, Currencies: limits.Currencies},
+	})
+	got, getErr := s.GetOrganisationLimits(GetOrganisationLimitsRequest{OrganisationID: organisationID.String()})
+	_, deleteErr := s.DeleteOrganisationLimits(DeleteOrganisationLimitsRequest{OrganisationID: organisationID.String()})
+	_, notFoundErr := s.GetOrganisationLimits(GetOrganisationLimitsRequest{OrganisationID: organisationID.String()})
+
+	//Assert
+	assert.NoError(t, updateErr)
+	assert.Equal(t, limits, updated.OrganisationLimits)
+	assert.NoError(t, getErr)
+	assert.Equal(t, limits, got.OrganisationLimits)
+	assert.NoError(t, deleteErr)
+	assert.Equal(t, ErrLimitsNotFound, notFoundErr)
+}
+
+// mockApprovalLimits requires the approval of the GBP payments above 100
+func mockApprovalLimits() *OrganisationLimits {
+	return &OrganisationLimits{Currencies: []CurrencyLimit{{Currency: "GBP", ApprovalThreshold: "100"}}}
+}
+
+func Test_Service_PostPayment_Approval(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		limits  *OrganisationLimits
+		userID  string
+		want    PaymentStatus
+		wantErr error
+	}{
+		{name: "Should submit a payment without approval threshold", userID: "alice", want: StatusSubmitted},
+		{name: "Should submit a payment below the approval threshold", limits: &OrganisationLimits{
+			Currencies: []CurrencyLimit{{Currency: "GBP", ApprovalThreshold: "100.21"}},
+		}, want: StatusSubmitted},
+		{name: "Should wait for approval above the threshold", limits: mockApprovalLimits(), userID: "alice", want: StatusPendingApproval},
+		{name: "Should require the creator above the threshold", limits: mockApprovalLimits(), wantErr: ErrMissingUserID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(tt.limits, nil)
+			repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool {
+				return created.Status == tt.want && created.CreatedBy == tt.userID &&
+					created.ApprovalRequired == (tt.want == StatusPendingApproval)
+			}), mock.Anything).Return(id, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.PostPayment(CreatePaymentRequest{Payment: p, Actor: Actor{UserID: tt.userID}})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				repositoryMock.AssertNotCalled(t, "CreatePayment", mock.Anything)
+				return
+			}
+			assert.Equal(t, CreatePaymentResponse{PaymentID: id, Status: tt.want}, *res)
+		})
+	}
+}
+
+func Test_Service_UpdatePayment_Approval(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(mockApprovalLimits(), nil)
+	repositoryMock.On("UpdatePayment", id, mock.MatchedBy(func(updated Payment) bool { return updated.ApprovalRequired }), mock.Anything).Return(nil)
+	repositoryMock.On("TransitionPaymentStatus", id, StatusSubmitted, StatusPendingApproval, approvalReason, mock.Anything).Return(true, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	_, err := service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+
+	//Assert
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_Service_ReviewPayment_ApprovalRequired(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	p.Status, p.ApprovalRequired = StatusHeldForReview, true
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPayment", id).Return(p, nil)
+	repositoryMock.On("TransitionPaymentStatus", id, StatusHeldForReview, StatusPendingApproval, approvalReason, mock.Anything).Return(true, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.ReviewPayment(ReviewPaymentRequest{PaymentID: id, Decision: ReviewRelease})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPendingApproval, res.Status)
+}
+
+func Test_Service_ApprovePayment(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name     string
+		approver string
+		decision ApprovalDecision
+		status   PaymentStatus
+		recorded bool
+		want     PaymentStatus
+		wantErr  error
+	}{
+		{name: "Should submit an approved payment", approver: "bob", decision: ApprovalApprove, status: StatusPendingApproval, recorded: true, want: StatusSubmitted},
+		{name: "Should reject a payment", approver: "bob", decision: ApprovalReject, status: StatusPendingApproval, recorded: true, want: StatusRejected},
+		{name: "Should not let the creator approve their payment", approver: "alice", decision: ApprovalApprove, status: StatusPendingApproval, wantErr: ErrSelfApproval},
+		{name: "Should not approve a payment which is not pending", approver: "bob", decision: ApprovalApprove, status: StatusSubmitted, wantErr: ErrPaymentNotPendingApproval},
+		{name: "Should not approve a payment approved meanwhile", approver: "bob", decision: ApprovalApprove, status: StatusPendingApproval, want: StatusSubmitted, wantErr: ErrPaymentNotPendingApproval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Status, p.CreatedBy = tt.status, "alice"
+			now := time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC)
+			p.Attributes.ProcessingDate = "2019-01-18"
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetPayment", id).Return(p, nil)
+			repositoryMock.On("RecordApproval", mock.MatchedBy(func(a Approval) bool {
+				return a.PaymentID == p.ID && a.Approver == tt.approver && a.Decision == tt.decision && a.Comment == "checked" && a.CreatedAt == now
+			}), tt.want, mock.Anything).Return(tt.recorded, nil)
+			events := NewEventBroker(10, 10)
+			sub, _, _, _ := events.subscribe(EventFilter{}, 0)
+			svc, _ := newService(repositoryMock, events)
+			s := svc.(service)
+			s.now = func() time.Time { return now }
+
+			//Act
+			res, err := s.ApprovePayment(ApprovePaymentRequest{PaymentID: id, Approver: tt.approver, Decision: tt.decision, Comment: "checked"})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.want, res.Status)
+			assert.Equal(t, tt.approver, res.Approver)
+			assert.NotEqual(t, uuid.Nil, res.ID)
+			e := <-sub.events
+			assert.Equal(t, EventPaymentStateChanged, e.Type)
+			assert.Equal(t, tt.want, e.Status)
+		})
+	}
+}
+
+func Test_Service_GetPaymentApprovals(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	approvals := []Approval{{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(id), Approver: "bob", Decision: ApprovalApprove}}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPayment", id).Return(mockNewPayment(id), nil)
+	repositoryMock.On("GetApprovals", id).Return(approvals, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.GetPaymentApprovals(GetPaymentApprovalsRequest{PaymentID: id})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, approvals, res.Data)
+}
+
+func Test_Service_GetPaymentAudit(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	entries := []AuditEntry{{PaymentID: uuid.FromStringOrNil(id), Operation: AuditDelete, Actor: "alice"}}
+	tests := []struct {
+		name       string
+		entries    []AuditEntry
+		paymentErr error
+		want       []AuditEntry
+		wantErr    error
+	}{
+		{name: "Should return the audit log of a deleted payment", entries: entries, paymentErr: ErrNotFound, want: entries},
+		{name: "Should return an empty log for a payment created before the audit log", entries: []AuditEntry{}, want: []AuditEntry{}},
+		{name: "Should return not found without entries nor payment", entries: []AuditEntry{}, paymentErr: ErrNotFound, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetAuditEntries", id).Return(tt.entries, nil)
+			repositoryMock.On("GetPayment", id).Return(mockNewPayment(id), tt.paymentErr)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.GetPaymentAudit(GetPaymentAuditRequest{PaymentID: id})
+
+			//Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res.Data)
+		})
+	}
+}
+
+func Test_Service_CreateReturn(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name       string
+		status     PaymentStatus
+		amount     string
+		returned   string
+		wantAmount string
+		wantErr    error
+	}{
+		{name: "Should return part of a payment", status: StatusSubmitted, amount: "40.21", returned: "0", wantAmount: "40.21"},
+		{name: "Should return the amount not yet returned by default", status: StatusDue, returned: "40.21", wantAmount: "60.00"},
+		{name: "Should return the amount not yet returned", status: StatusSubmitted, amount: "60", returned: "40.21", wantAmount: "60"},
+		{name: "Should not return a payment which has not gone out", status: StatusHeldForReview, amount: "10.00", wantErr: ErrPaymentNotReturnable},
+		{
+			name: "Should not return more than the amount not yet returned", status: StatusSubmitted, amount: "60.01", returned: "40.21",
+			wantErr: ErrReturnAmountExceeded.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "exceeds the amount not yet returned of 60.00 GBP"}),
+		},
+		{
+			name: "Should not return a payment fully returned", status: StatusSubmitted, returned: "100.21",
+			wantErr: ErrReturnAmountExceeded.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "the payment is fully returned"}),
+		},
+		{
+			name: "Should not return more decimals than the minor units of the currency", status: StatusSubmitted, amount: "10.001",
+			wantErr: ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "has more than 2 decimals for GBP"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Status = tt.status
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetPayment", id).Return(p, nil)
+			repositoryMock.On("CreateReturn", mock.MatchedBy(func(r Return) bool {
+				return r.PaymentID == p.ID && r.Type == ReturnTypeReturn && r.Status == ReturnStatusPending && r.Currency == "GBP" && r.CreatedBy == "alice"
+			}), mock.Anything).Return(
+				func(r Return, check ReturnCheck) Return {
+					check(&r, parseAmount(tt.returned))
+					return r
+				},
+				func(r Return, check ReturnCheck) error {
+					return check(&r, parseAmount(tt.returned))
+				})
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.CreateReturn(CreateReturnRequest{PaymentID: id, Type: ReturnTypeReturn, UserID: "alice", Amount: tt.amount, ReasonCode: "AC04"})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, tt.wantAmount, res.Amount)
+			assert.Equal(t, "AC04", res.ReasonCode)
+			assert.NotEqual(t, uuid.Nil, res.ID)
+		})
+	}
+}
+
+func Test_Service_UpdateReturnStatus(t *testing.T) {
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	returnID := "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b"
+	tests := []struct {
+		name    string
+		status  ReturnStatus
+		updated bool
+		wantErr error
+	}{
+		{name: "Should complete a pending reversal", status: ReturnStatusPending, updated: true},
+		{name: "Should not complete a failed reversal", status: ReturnStatusFailed, wantErr: ErrReturnNotPending},
+		{name: "Should not complete a reversal completed meanwhile", status: ReturnStatusPending, wantErr: ErrReturnNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ret := Return{ID: uuid.FromStringOrNil(returnID), PaymentID: uuid.FromStringOrNil(paymentID), Type: ReturnTypeReversal, Status: tt.status}
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetReturn", paymentID, ReturnTypeReversal, returnID).Return(ret, nil)
+			repositoryMock.On("TransitionReturnStatus", returnID, ReturnStatusPending, ReturnStatusCompleted, "settled", Actor{UserID: "alice"}).Return(tt.updated, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.UpdateReturnStatus(UpdateReturnStatusRequest{
+				PaymentID: paymentID, Type: ReturnTypeReversal, ReturnID: returnID, Status: ReturnStatusCompleted, Reason: "settled", Actor: Actor{UserID: "alice"},
+			})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.Equal(t, ReturnStatusCompleted, res.Status)
+			assert.Equal(t, "settled", res.StatusReason)
+		})
+	}
+}
+
+func Test_Service_GetPaymentReturns(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	returns := []Return{{ID: uuid.NewV4(), PaymentID: uuid.FromStringOrNil(id), Type: ReturnTypeReturn, ReasonCode: "AC04", Amount: "100.21"}}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetPayment", id).Return(mockNewPayment(id), nil)
+	repositoryMock.On("GetReturns", id, ReturnTypeReturn).Return(returns, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.GetPaymentReturns(GetPaymentReturnsRequest{PaymentID: id, Type: ReturnTypeReturn})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, returns, res.Data)
+}
+
+func Test_Service_CreateRecall(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name       string
+		status     PaymentStatus
+		reasonCode string
+		today      time.Time
+		options    []ServiceOption
+		requested  bool
+		wantErr    error
+	}{
+		{name: "Should recall a payment on the last day of the window", status: StatusSubmitted, reasonCode: "DUPL", today: time.Date(2019, 2, 4, 18, 0, 0, 0, time.UTC)},
+		{
+			name: "Should not recall a payment after the window of 10 business days", status: StatusSubmitted, reasonCode: "DUPL", today: time.Date(2019, 2, 5, 0, 0, 0, 0, time.UTC),
+			wantErr: ErrRecallNotEligible.WithFieldErrors(apierrors.FieldError{Field: "attributes.processing_date", Message: "is too old, the DUPL recall window closed on 2019-02-04"}),
+		},
+		{
+			name: "Should not recall a payment after the configured window", status: StatusDue, reasonCode: "CUST", today: time.Date(2019, 1, 25, 0, 0, 0, 0, time.UTC),
+			options: []ServiceOption{WithRecallWindow(3)},
+			wantErr: ErrRecallNotEligible.WithFieldErrors(apierrors.FieldError{Field: "attributes.processing_date", Message: "is too old, the CUST recall window closed on 2019-01-24"}),
+		},
+		{name: "Should recall a fraudulent payment after the window", status: StatusSubmitted, reasonCode: "FRAD", today: time.Date(2020, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{
+			name: "Should not recall a fraudulent payment after 13 months", status: StatusSubmitted, reasonCode: "FRAD", today: time.Date(2020, 2, 22, 0, 0, 0, 0, time.UTC),
+			wantErr: ErrRecallNotEligible.WithFieldErrors(apierrors.FieldError{Field: "attributes.processing_date", Message: "is too old, the FRAD recall window closed on 2020-02-21"}),
+		},
+		{
+			name: "Should not recall a payment which has not gone out", status: StatusScheduled, reasonCode: "DUPL", today: time.Date(2019, 1, 21, 0, 0, 0, 0, time.UTC),
+			wantErr: ErrRecallNotEligible.WithFieldErrors(apierrors.FieldError{Field: "status", Message: "is scheduled, only a payment which has gone out can be recalled"}),
+		},
+		{name: "Should not recall a payment twice", status: StatusSubmitted, reasonCode: "DUPL", today: time.Date(2019, 1, 22, 0, 0, 0, 0, time.UTC), requested: true, wantErr: ErrRecallAlreadyRequested},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Status = tt.status
+			p.Attributes.ProcessingDate = "2019-01-21"
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetPayment", id).Return(p, nil)
+			repositoryMock.On("CreateRecall", mock.MatchedBy(func(r Recall) bool {
+				return r.PaymentID == p.ID && r.ReasonCode == tt.reasonCode && r.Status == RecallStatusRequested && r.RequestedBy == "alice"
+			})).Return(!tt.requested, nil)
+			svc, _ := newService(repositoryMock, NewEventBroker(10, 10), tt.options...)
+			s := svc.(service)
+			s.now = func() time.Time { return tt.today }
+
+			//Act
+			res, err := s.CreateRecall(CreateRecallRequest{PaymentID: id, UserID: "alice", ReasonCode: tt.reasonCode})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			assert.NotEqual(t, uuid.Nil, res.ID)
+			assert.Equal(t, RecallStatusRequested, res.Status)
+			assert.Equal(t, tt.today, res.CreatedAt)
+		})
+	}
+}
+
+func Test_Service_DecideRecall(t *testing.T) {
+	paymentID := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	recallID := "3f6d2a9b-1c4e-4b8a-9d7f-6e5c4b3a2d1e"
+	now := time.Date(2019, 1, 23, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		status   RecallStatus
+		decision RecallDecision
+		decided  bool
+		want     RecallStatus
+		wantErr  error
+	}{
+		{name: "Should accept a requested recall", status: RecallStatusRequested, decision: RecallAccept, decided: true, want: RecallStatusAccepted},
+		{name: "Should reject a requested recall", status: RecallStatusRequested, decision: RecallReject, decided: true, want: RecallStatusRejected},
+		{name: "Should not decide a rejected recall", status: RecallStatusRejected, decision: RecallAccept, wantErr: ErrRecallNotRequested},
+		{name: "Should not decide a recall decided meanwhile", status: RecallStatusRequested, decision: RecallAccept, wantErr: ErrRecallNotRequested},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recall := Recall{ID: uuid.FromStringOrNil(recallID), PaymentID: uuid.FromStringOrNil(paymentID), ReasonCode: "DUPL", Status: tt.status}
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetRecall", paymentID, recallID).Return(recall, nil)
+			repositoryMock.On("DecideRecall", recallID, mock.Anything, "funds available", now, Actor{UserID: "alice"}).Return(tt.decided, nil)
+			svc, _ := newService(repositoryMock, NewEventBroker(10, 10))
+			s := svc.(service)
+			s.now = func() time.Time { return now }
+
+			//Act
+			res, err := s.DecideRecall(DecideRecallRequest{PaymentID: paymentID, RecallID: recallID, Decision: tt.decision, Reason: "funds available", Actor: Actor{UserID: "alice"}})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			repositoryMock.AssertCalled(t, "DecideRecall", recallID, tt.want, "funds available", now, Actor{UserID: "alice"})
+			assert.Equal(t, tt.want, res.Status)
+			assert.Equal(t, "funds available", res.DecisionReason)
+			assert.Equal(t, &now, res.DecidedAt)
+		})
+	}
+}
+
+func Test_Service_PostPayment_ProcessingDate(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		today   int
+		date    string
+		want    PaymentStatus
+		wantErr error
+	}{
+		{name: "Should submit a payment dated today", today: 18, date: "2019-01-18", want: StatusSubmitted},
+		{name: "Should submit a payment dated today on a weekend", today: 19, date: "2019-01-19", want: StatusSubmitted},
+		{name: "Should schedule a payment dated on a future business day", today: 18, date: "2019-01-21", want: StatusScheduled},
+		{
+			name:  "Should reject a payment dated in the past",
+			today: 18,
+			date:  "2019-01-17",
+			wantErr: ErrInvalidProcessingDate.WithFieldErrors(apierrors.FieldError{
+				Field: "attributes.processing_date", Message: "is before today, 2019-01-18",
+			}),
+		},
+		{
+			name:  "Should reject a payment dated on a future weekend day",
+			today: 18,
+			date:  "2019-01-20",
+			wantErr: ErrInvalidProcessingDate.WithFieldErrors(apierrors.FieldError{
+				Field: "attributes.processing_date", Message: "is not a business day of the UK calendar, the next business day is 2019-01-21",
+			}),
+		},
+		{
+			name:  "Should reject a payment dated on a future bank holiday",
+			today: 18,
+			date:  "2019-04-19",
+			wantErr: ErrInvalidProcessingDate.WithFieldErrors(apierrors.FieldError{
+				Field: "attributes.processing_date", Message: "is not a business day of the UK calendar, the next business day is 2019-04-23",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment(id)
+			p.Attributes.ProcessingDate = tt.date
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(nil, nil)
+			repositoryMock.On("CreatePayment", mock.MatchedBy(func(created Payment) bool { return created.Status == tt.want }), mock.Anything).Return(id, nil)
+			svc, _ := newService(repositoryMock, NewEventBroker(10, 10))
+			s := svc.(service)
+			s.now = func() time.Time { return time.Date(2019, 1, tt.today, 23, 0, 0, 0, time.UTC) }
+
+			//Act
+			res, err := s.PostPayment(CreatePaymentRequest{Payment: p})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				repositoryMock.AssertNotCalled(t, "CreatePayment", mock.Anything)
+				return
+			}
+			assert.Equal(t, tt.want, res.Status)
+		})
+	}
+}
+
+func Test_Service_UpdatePayment_Scheduled(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	p.Attributes.ProcessingDate = "2019-01-21"
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", p.OrganisationID).Return(nil, nil)
+	repositoryMock.On("UpdatePayment", id, mock.Anything, mock.Anything).Return(nil)
+	repositoryMock.On("TransitionPaymentStatus", id, StatusSubmitted, StatusScheduled, "", mock.Anything).Return(true, nil)
+	svc, _ := newService(repositoryMock, NewEventBroker(10, 10))
+	s := svc.(service)
+	s.now = func() time.Time { return time.Date(2019, 1, 18, 12, 0, 0, 0, time.UTC) }
+
+	//Act
+	_, err := s.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+
+	//Assert
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
 func Test_Service_DeletePayment(t *testing.T) {
 	// Arrange
 	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
 	expectedRes := DeletePaymentResponse{PaymentID: id}
 	repositoryMock := &MockRepository{}
-	repositoryMock.On("DeletePayment", mock.Anything).Return(nil)
-	service, _ := NewPaymentService(repositoryMock)
+	repositoryMock.On("GetPayment", id).Return(p, nil)
+	actor := Actor{UserID: "alice", RequestID: "req-1", SourceIP: "203.0.113.7"}
+	repositoryMock.On("DeletePayment", id, actor).Return(nil)
+	events := NewEventBroker(10, 10)
+	sub, _, _, _ := events.subscribe(EventFilter{}, 0)
+	service, _ := NewPaymentService(repositoryMock, events)
 
 	//Act
-	res, err := service.DeletePayment(DeletePaymentRequest{PaymentID: id})
+	res, err := service.DeletePayment(DeletePaymentRequest{PaymentID: id, Actor: actor})
 
 	//Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, res, "result should not be nil")
 	assert.Equal(t, expectedRes, *res)
+	e := <-sub.events
+	assert.Equal(t, EventPaymentDeleted, e.Type)
+	assert.Equal(t, id, e.PaymentID)
+	assert.Equal(t, p.OrganisationID, e.OrganisationID)
+}
+
+func Test_Service_RestorePayment(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	actor := Actor{UserID: "alice"}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("RestorePayment", id, actor).Return(p, nil)
+	repositoryMock.On("RestorePayment", "6ef6057f-0ed4-48c9-a128-f85b8f024519", actor).Return(Payment{}, ErrPaymentNotDeleted)
+	events := NewEventBroker(10, 10)
+	sub, _, _, _ := events.subscribe(EventFilter{}, 0)
+	service, _ := NewPaymentService(repositoryMock, events)
+
+	//Act
+	res, err := service.RestorePayment(RestorePaymentRequest{PaymentID: id, Actor: actor})
+	_, notDeletedErr := service.RestorePayment(RestorePaymentRequest{PaymentID: "6ef6057f-0ed4-48c9-a128-f85b8f024519", Actor: actor})
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, id, res.PaymentID)
+	assert.Equal(t, ErrPaymentNotDeleted, notDeletedErr)
+	e := <-sub.events
+	assert.Equal(t, EventPaymentRestored, e.Type)
+	assert.Equal(t, p.OrganisationID, e.OrganisationID)
+	select {
+	case e := <-sub.events:
+		t.Errorf("unexpected event %v", e)
+	default:
+	}
+}
+
+func Test_Service_PublishEvents(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	p := mockNewPayment(id)
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetOrganisationLimits", mock.Anything).Return(nil, nil)
+	repositoryMock.On("CreatePayment", mock.Anything, mock.Anything).Return(id, nil)
+	repositoryMock.On("UpdatePayment", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	events := NewEventBroker(10, 10)
+	service, _ := NewPaymentService(repositoryMock, events)
+
+	//Act
+	service.PostPayment(CreatePaymentRequest{Payment: p})
+	service.UpdatePayment(UpdatePaymentRequest{Payment: p, PaymentID: id})
+	_, replay, _, _ := events.subscribe(EventFilter{}, 1)
+
+	//Assert
+	assert.Len(t, replay, 1)
+	assert.Equal(t, EventPaymentUpdated, replay[0].Type)
+	assert.Equal(t, uint64(2), replay[0].ID)
+	assert.Equal(t, p.OrganisationID, replay[0].OrganisationID)
+}
+
+func Test_NewPaymentService_NilEvents(t *testing.T) {
+	_, err := NewPaymentService(&MockRepository{}, nil)
+	assert.Error(t, err)
+}
+
+func Test_Service_GetAccountBalance(t *testing.T) {
+	account := "party:134667:GB29NWBK60161331926819"
+	tests := []struct {
+		name     string
+		balances []ledger.Balance
+		want     *GetAccountBalanceResponse
+		wantErr  error
+	}{
+		{
+			name:     "Should return the balances of an account",
+			balances: []ledger.Balance{{Currency: "GBP", Debits: "105.21", Credits: "0.00", Balance: "-105.21"}},
+			want:     &GetAccountBalanceResponse{Account: account, Balances: []ledger.Balance{{Currency: "GBP", Debits: "105.21", Credits: "0.00", Balance: "-105.21"}}},
+		},
+		{name: "Should return not found for an account without entries", balances: []ledger.Balance{}, wantErr: ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetAccountBalances", account).Return(tt.balances, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.GetAccountBalance(GetAccountBalanceRequest{Account: account})
+
+			//Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_Service_GetAccountEntries(t *testing.T) {
+	// Arrange
+	account := "charges:134667"
+	entries := []ledger.Entry{{Account: account, Direction: ledger.Credit, Amount: "5.00", Currency: "GBP"}}
+	repositoryMock := &MockRepository{}
+	repositoryMock.On("GetAccountEntries", account, ListQuery{Offset: 20, Limit: 10}).Return(entries, nil)
+	service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+	//Act
+	res, err := service.GetAccountEntries(GetAccountEntriesRequest{Account: account, Page: 3, PageSize: 10})
+
+	//Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &GetAccountEntriesResponse{Data: entries, Meta: PageMeta{Page: 3, PageSize: 10}}, res)
+}
+
+func Test_Service_CheckLedger(t *testing.T) {
+	tests := []struct {
+		name       string
+		imbalances []ledger.Imbalance
+		want       bool
+	}{
+		{name: "Should report a balanced ledger", imbalances: []ledger.Imbalance{}, want: true},
+		{name: "Should report the unbalanced transactions", imbalances: []ledger.Imbalance{{TransactionID: uuid.NewV4(), Currency: "GBP", Difference: "0.01"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock := &MockRepository{}
+			repositoryMock.On("GetLedgerImbalances").Return(tt.imbalances, nil)
+			service, _ := NewPaymentService(repositoryMock, NewEventBroker(10, 10))
+
+			//Act
+			res, err := service.CheckLedger(CheckLedgerRequest{})
+
+			//Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, res.Balanced)
+			assert.Equal(t, tt.imbalances, res.Imbalances)
+		})
+	}
 }
@@ -0,0 +1,155 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	apierrors "github.com/elkousy/payments-api/utility/errors"
+	"github.com/elkousy/payments-api/utility/instrumenting"
+)
+
+const (
+	eventsStreamName = "payment_events"
+	// lastEventIDHeader is sent by the SSE clients when reconnecting
+	lastEventIDHeader = "Last-Event-ID"
+	// heartbeatInterval keeps the idle streams open through the proxies
+	heartbeatInterval = 15 * time.Second
+	// organisationHeader identifies the organisation of the user, it is set by the gateway authenticating the users
+	organisationHeader = "X-Organisation-ID"
+)
+
+// makeEventsHandler returns the handler streaming the payment events as Server-Sent Events.
+// A reset event is sent when the stream cannot be resumed, the client should then reload the payments.
+// The callers stream the events of their organisation, those granted by the admin check the events of any organisation.
+func makeEventsHandler(events *EventBroker, admin PrivilegeCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := decodeEventFilter(r)
+		if err != nil {
+			apierrors.LoggingErrorEncoder(r.Context(), err, w)
+			return
+		}
+		var lastEventID uint64
+		if id := r.Header.Get(lastEventIDHeader); id != "" {
+			if lastEventID, err = strconv.ParseUint(id, 10, 64); err != nil {
+				apierrors.LoggingErrorEncoder(r.Context(), ErrInvalidLastEventID, w)
+				return
+			}
+		}
+		if filter, err = scopeEventFilter(r, filter, admin); err != nil {
+			apierrors.LoggingErrorEncoder(r.Context(), err, w)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			apierrors.LoggingErrorEncoder(r.Context(), ErrInternalServer, w)
+			return
+		}
+
+		// the server timeouts are meant for regular requests, not for long lived streams
+		rc := http.NewResponseController(w)
+		rc.SetReadDeadline(time.Time{})
+		rc.SetWriteDeadline(time.Time{})
+
+		sub, replay, gap, err := events.subscribe(filter, lastEventID)
+		if err != nil {
+			apierrors.LoggingErrorEncoder(r.Context(), err, w)
+			return
+		}
+		defer events.unsubscribe(sub)
+		defer instrumenting.StreamOpened(componentName, eventsStreamName)()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if gap {
+			fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+		}
+		for _, e := range replay {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case e, ok := <-sub.events:
+				if !ok {
+					// evicted, the client reconnects with the ID of the last event it received
+					return
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+// decodeEventFilter reads the organisation_id and type query parameters, types can be repeated or comma separated
+func decodeEventFilter(r *http.Request) (EventFilter, error) {
+	filter := EventFilter{}
+	query := r.URL.Query()
+	if id := query.Get("organisation_id"); id != "" {
+		orgID, err := uuid.FromString(id)
+		if err != nil {
+			return filter, ErrInvalidEventFilter
+		}
+		filter.OrganisationID = orgID
+	}
+	for _, types := range query["type"] {
+		for _, t := range strings.Split(types, ",") {
+			if !eventTypes[EventType(t)] {
+				return filter, ErrInvalidEventFilter
+			}
+			if filter.Types == nil {
+				filter.Types = map[EventType]bool{}
+			}
+			filter.Types[EventType(t)] = true
+		}
+	}
+	return filter, nil
+}
+
+// scopeEventFilter restricts the events of a stream to the organisation of the caller, given by the X-Organisation-ID
+// header, unless the caller is granted by the admin check
+func scopeEventFilter(r *http.Request, filter EventFilter, admin PrivilegeCheck) (EventFilter, error) {
+	ctx := context.WithValue(r.Context(), contextKeyRoles, splitRoles(r.Header.Get(rolesHeader)))
+	if admin(ctx) {
+		return filter, nil
+	}
+	orgID, err := uuid.FromString(r.Header.Get(organisationHeader))
+	if err != nil || (filter.OrganisationID != uuid.Nil && filter.OrganisationID != orgID) {
+		return filter, ErrEventsForbidden
+	}
+	filter.OrganisationID = orgID
+	return filter, nil
+}
+
+func writeEvent(w io.Writer, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
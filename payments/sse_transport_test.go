@@ -0,0 +1,156 @@
+package payments
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent reads the next event of a stream, without its trailing blank line
+func readEvent(t *testing.T, r *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func Test_EventsHandler_Stream(t *testing.T) {
+	// Arrange
+	org := uuid.NewV4()
+	events := NewEventBroker(10, 10)
+	events.Publish(Event{Type: EventPaymentCreated, PaymentID: "1", OrganisationID: org})
+	events.Publish(Event{Type: EventPaymentCreated, PaymentID: "2", OrganisationID: uuid.NewV4()})
+	server := httptest.NewServer(MakeHTTPHandler(Endpoints{}, events, mux.NewRouter()))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/payments/events/?organisation_id="+org.String()+"&type=payment.created,payment.deleted", nil)
+	req.Header.Set(lastEventIDHeader, "0")
+	req.Header.Set(organisationHeader, org.String())
+
+	// Act
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	events.Publish(Event{Type: EventPaymentUpdated, PaymentID: "1", OrganisationID: org})
+	events.Publish(Event{Type: EventPaymentDeleted, PaymentID: "1", OrganisationID: org})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	lines := readEvent(t, bufio.NewReader(res.Body))
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id: 4", lines[0])
+	assert.Equal(t, "event: payment.deleted", lines[1])
+	assert.Contains(t, lines[2], `"payment_id":"1"`)
+}
+
+func Test_EventsHandler_Resume(t *testing.T) {
+	// Arrange
+	events := NewEventBroker(2, 10)
+	for i := 0; i < 3; i++ {
+		events.Publish(Event{Type: EventPaymentUpdated})
+	}
+	admin := WithAdminCheck(func(context.Context) bool { return true })
+	server := httptest.NewServer(MakeHTTPHandler(Endpoints{}, events, mux.NewRouter(), admin))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		expected    []string
+	}{
+		{"retained", "1", []string{"id: 2", "id: 3"}},
+		{"issued before a restart", "42", []string{"event: reset"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/payments/events/", nil)
+			req.Header.Set(lastEventIDHeader, tt.lastEventID)
+
+			// Act
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			// Assert
+			r := bufio.NewReader(res.Body)
+			for _, expected := range tt.expected {
+				assert.Equal(t, expected, readEvent(t, r)[0])
+			}
+		})
+	}
+}
+
+func Test_EventsHandler_InvalidRequests(t *testing.T) {
+	events := NewEventBroker(10, 10)
+	server := httptest.NewServer(MakeHTTPHandler(Endpoints{}, events, mux.NewRouter()))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		query       string
+		lastEventID string
+	}{
+		{"organisation", "?organisation_id=abc", ""},
+		{"type", "?type=payment.created,payment.unknown", ""},
+		{"last event id", "", "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/payments/events/"+tt.query, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set(lastEventIDHeader, tt.lastEventID)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+}
+
+func Test_EventsHandler_OtherOrganisation(t *testing.T) {
+	events := NewEventBroker(10, 10)
+	server := httptest.NewServer(MakeHTTPHandler(Endpoints{}, events, mux.NewRouter()))
+	defer server.Close()
+	org := uuid.NewV4()
+
+	tests := []struct {
+		name         string
+		query        string
+		organisation string
+	}{
+		{"no organisation", "", ""},
+		{"another organisation", "?organisation_id=" + uuid.NewV4().String(), org.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/payments/events/"+tt.query, nil)
+			if tt.organisation != "" {
+				req.Header.Set(organisationHeader, tt.organisation)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		})
+	}
+}
@@ -1,9 +1,24 @@
 package payments
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/satori/go.uuid"
 
 	valid "gopkg.in/go-playground/validator.v9"
+
+	"github.com/elkousy/payments-api/accounts"
+	"github.com/elkousy/payments-api/calendar"
+	"github.com/elkousy/payments-api/charges"
+	"github.com/elkousy/payments-api/currency"
+	"github.com/elkousy/payments-api/forex"
+	"github.com/elkousy/payments-api/ledger"
+	"github.com/elkousy/payments-api/schemes"
+	apierrors "github.com/elkousy/payments-api/utility/errors"
 )
 
 var tags = map[string]string{
@@ -11,6 +26,9 @@ var tags = map[string]string{
 	"exists":   "should_exist",
 }
 
+// maxIdempotencyKeyLength is the maximum length of the Idempotency-Key header
+const maxIdempotencyKeyLength = 255
+
 type validator struct {
 	next Service
 }
@@ -24,17 +42,28 @@ func (v validator) GetPayment(req GetPaymentRequest) (*GetPaymentResponse, error
 	if err := validatePaymentID(req.PaymentID); err != nil {
 		return nil, ErrInvalidPaymentID //.FromError(err)
 	}
+	if req.Version != nil && req.AsOf != nil {
+		return nil, ErrInvalidPaymentVersion
+	}
 	return v.next.GetPayment(req)
 }
 
 func (v validator) GetListOfPayments(req GetListOfPaymentsRequest) (*GetListOfPaymentsResponse, error) {
+	if req.Page < 0 || req.PageSize < 0 || req.PageSize > maxPageSize {
+		return nil, ErrInvalidPagination
+	}
 	return v.next.GetListOfPayments(req)
 }
 
 func (v validator) PostPayment(req CreatePaymentRequest) (*CreatePaymentResponse, error) {
-	err := validatePayload(req.Payment)
-	if err != nil {
-		return nil, ErrInvalidPaymentPayload //.FromError(err)
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	if errs := validatePayload(req.Payment); len(errs) > 0 {
+		return nil, ErrInvalidPaymentPayload.WithFieldErrors(errs...)
+	}
+	if errs := validateSchemeRules(req.Payment); len(errs) > 0 {
+		return nil, ErrSchemeRulesViolation.WithFieldErrors(errs...)
 	}
 	return v.next.PostPayment(req)
 }
@@ -43,9 +72,11 @@ func (v validator) UpdatePayment(req UpdatePaymentRequest) (*UpdatePaymentRespon
 	if err := validatePaymentID(req.PaymentID); err != nil {
 		return nil, ErrInvalidPaymentID//.FromError(err)
 	}
-	err := validatePayload(req.Payment)
-	if err != nil {
-		return nil, ErrInvalidPaymentPayload //.FromError(err)
+	if errs := validatePayload(req.Payment); len(errs) > 0 {
+		return nil, ErrInvalidPaymentPayload.WithFieldErrors(errs...)
+	}
+	if errs := validateSchemeRules(req.Payment); len(errs) > 0 {
+		return nil, ErrSchemeRulesViolation.WithFieldErrors(errs...)
 	}
 	return v.next.UpdatePayment(req)
 }
@@ -57,6 +88,178 @@ func (v validator) DeletePayment(req DeletePaymentRequest) (*DeletePaymentRespon
 	return v.next.DeletePayment(req)
 }
 
+func (v validator) RestorePayment(req RestorePaymentRequest) (*RestorePaymentResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	return v.next.RestorePayment(req)
+}
+
+func (v validator) ReviewPayment(req ReviewPaymentRequest) (*ReviewPaymentResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if req.Decision != ReviewRelease && req.Decision != ReviewReject {
+		return nil, ErrInvalidReviewDecision
+	}
+	return v.next.ReviewPayment(req)
+}
+
+func (v validator) ApprovePayment(req ApprovePaymentRequest) (*ApprovePaymentResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if req.Approver == "" {
+		return nil, ErrMissingUserID
+	}
+	if req.Decision != ApprovalApprove && req.Decision != ApprovalReject {
+		return nil, ErrInvalidApprovalDecision
+	}
+	return v.next.ApprovePayment(req)
+}
+
+func (v validator) GetPaymentApprovals(req GetPaymentApprovalsRequest) (*GetPaymentApprovalsResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	return v.next.GetPaymentApprovals(req)
+}
+
+func (v validator) GetPaymentAudit(req GetPaymentAuditRequest) (*GetPaymentAuditResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	return v.next.GetPaymentAudit(req)
+}
+
+func (v validator) CreateReturn(req CreateReturnRequest) (*CreateReturnResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if errs := validateReturn(req); len(errs) > 0 {
+		return nil, ErrInvalidReturn.WithFieldErrors(errs...)
+	}
+	return v.next.CreateReturn(req)
+}
+
+func (v validator) GetReturn(req GetReturnRequest) (*GetReturnResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if _, err := uuid.FromString(req.ReturnID); err != nil {
+		return nil, ErrInvalidReturnID
+	}
+	return v.next.GetReturn(req)
+}
+
+func (v validator) GetPaymentReturns(req GetPaymentReturnsRequest) (*GetPaymentReturnsResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	return v.next.GetPaymentReturns(req)
+}
+
+func (v validator) UpdateReturnStatus(req UpdateReturnStatusRequest) (*UpdateReturnStatusResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if _, err := uuid.FromString(req.ReturnID); err != nil {
+		return nil, ErrInvalidReturnID
+	}
+	if req.Status != ReturnStatusCompleted && req.Status != ReturnStatusFailed {
+		return nil, ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "status", Message: "is not completed or failed"})
+	}
+	return v.next.UpdateReturnStatus(req)
+}
+
+func (v validator) CreateRecall(req CreateRecallRequest) (*CreateRecallResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if _, ok := recallReasonCodes[req.ReasonCode]; !ok {
+		return nil, ErrInvalidRecall.WithFieldErrors(apierrors.FieldError{
+			Field:   "reason_code",
+			Message: "is not a recall reason code, expected one of " + strings.Join(recallCodes(), ", "),
+		})
+	}
+	return v.next.CreateRecall(req)
+}
+
+func (v validator) GetRecall(req GetRecallRequest) (*GetRecallResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if _, err := uuid.FromString(req.RecallID); err != nil {
+		return nil, ErrInvalidRecallID
+	}
+	return v.next.GetRecall(req)
+}
+
+func (v validator) GetPaymentRecalls(req GetPaymentRecallsRequest) (*GetPaymentRecallsResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	return v.next.GetPaymentRecalls(req)
+}
+
+func (v validator) DecideRecall(req DecideRecallRequest) (*DecideRecallResponse, error) {
+	if err := validatePaymentID(req.PaymentID); err != nil {
+		return nil, ErrInvalidPaymentID
+	}
+	if _, err := uuid.FromString(req.RecallID); err != nil {
+		return nil, ErrInvalidRecallID
+	}
+	if req.Decision != RecallAccept && req.Decision != RecallReject {
+		return nil, ErrInvalidRecall.WithFieldErrors(apierrors.FieldError{Field: "decision", Message: "is not accept or reject"})
+	}
+	return v.next.DecideRecall(req)
+}
+
+func (v validator) GetAccountBalance(req GetAccountBalanceRequest) (*GetAccountBalanceResponse, error) {
+	if !ledger.ValidAccount(req.Account) {
+		return nil, ErrInvalidAccount
+	}
+	return v.next.GetAccountBalance(req)
+}
+
+func (v validator) GetAccountEntries(req GetAccountEntriesRequest) (*GetAccountEntriesResponse, error) {
+	if !ledger.ValidAccount(req.Account) {
+		return nil, ErrInvalidAccount
+	}
+	if req.Page < 0 || req.PageSize < 0 || req.PageSize > maxPageSize {
+		return nil, ErrInvalidPagination
+	}
+	return v.next.GetAccountEntries(req)
+}
+
+func (v validator) CheckLedger(req CheckLedgerRequest) (*CheckLedgerResponse, error) {
+	return v.next.CheckLedger(req)
+}
+
+func (v validator) GetOrganisationLimits(req GetOrganisationLimitsRequest) (*GetOrganisationLimitsResponse, error) {
+	if _, err := uuid.FromString(req.OrganisationID); err != nil {
+		return nil, ErrInvalidOrganisationID
+	}
+	return v.next.GetOrganisationLimits(req)
+}
+
+func (v validator) UpdateOrganisationLimits(req UpdateOrganisationLimitsRequest) (*UpdateOrganisationLimitsResponse, error) {
+	if _, err := uuid.FromString(req.OrganisationID); err != nil {
+		return nil, ErrInvalidOrganisationID
+	}
+	if errs := validateLimits(req.Limits); len(errs) > 0 {
+		return nil, ErrInvalidLimits.WithFieldErrors(errs...)
+	}
+	return v.next.UpdateOrganisationLimits(req)
+}
+
+func (v validator) DeleteOrganisationLimits(req DeleteOrganisationLimitsRequest) (*DeleteOrganisationLimitsResponse, error) {
+	if _, err := uuid.FromString(req.OrganisationID); err != nil {
+		return nil, ErrInvalidOrganisationID
+	}
+	return v.next.DeleteOrganisationLimits(req)
+}
+
 func validatePaymentID(id string) error {
 	_, err := uuid.FromString(id)
 	if err != nil {
@@ -65,10 +268,237 @@ func validatePaymentID(id string) error {
 	return nil
 }
 
-func validatePayload(p Payment) error {
-	err := valid.New().Struct(p)
+// payloadValidator names the fields after their json name
+var payloadValidator = newPayloadValidator()
+
+func newPayloadValidator() *valid.Validate {
+	v := valid.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.Split(f.Tag.Get("json"), ",")[0]
+	})
+	return v
+}
+
+// validatePayload validates the payment fields, the account identifiers of its parties and its amounts.
+// It returns an error per invalid field, named after its json path, e.g. attributes.debtor_party.account_number
+func validatePayload(p Payment) []apierrors.FieldError {
+	var errs []apierrors.FieldError
+	invalid := map[string]bool{}
+	if err := payloadValidator.Struct(p); err != nil {
+		if fieldErrs, ok := err.(valid.ValidationErrors); ok {
+			for _, fe := range fieldErrs {
+				field := jsonPath(fe.Namespace())
+				invalid[field] = true
+				errs = append(errs, apierrors.FieldError{Field: field, Message: fieldErrorMessage(fe.Tag())})
+			}
+		}
+	}
+
+	for _, e := range append(validateAccounts(p), validateAmounts(p)...) {
+		// missing fields are already reported
+		if !invalid[e.Field] {
+			invalid[e.Field] = true
+			errs = append(errs, e)
+		}
+	}
+	if field := "attributes.charges_information.bearer_code"; !invalid[field] && !charges.ValidBearer(p.Attributes.ChargesInformation.BearerCode) {
+		errs = append(errs, apierrors.FieldError{Field: field, Message: "is not SHAR, OUR or BEN"})
+	}
+	if !invalid["attributes.processing_date"] {
+		if _, err := time.Parse(calendar.DateFormat, p.Attributes.ProcessingDate); err != nil {
+			errs = append(errs, apierrors.FieldError{Field: "attributes.processing_date", Message: "is not a date, expected YYYY-MM-DD"})
+		}
+	}
+	return append(errs, validateForex(p, invalid)...)
+}
+
+// validateAccounts validates the account identifiers of the parties
+func validateAccounts(p Payment) []apierrors.FieldError {
+	parties := []struct {
+		path string
+		id   accounts.Identifier
+	}{
+		{"attributes.beneficiary_party", identifierOf(p.Attributes.BeneficiaryParty.DebtorParty)},
+		{"attributes.debtor_party", identifierOf(p.Attributes.DebtorParty)},
+		{"attributes.sponsor_party", accounts.Identifier{
+			AccountNumber: p.Attributes.SponsorParty.AccountNumber,
+			BankID:        p.Attributes.SponsorParty.BankID,
+			BankIDCode:    p.Attributes.SponsorParty.BankIDCode,
+		}},
+	}
+	var errs []apierrors.FieldError
+	for _, party := range parties {
+		for _, e := range accounts.Validate(party.id) {
+			errs = append(errs, apierrors.FieldError{Field: party.path + "." + e.Field, Message: e.Message})
+		}
+	}
+	return errs
+}
+
+// validateAmounts validates the currencies are ISO 4217 codes and the amounts precision matches their minor units
+func validateAmounts(p Payment) []apierrors.FieldError {
+	type amount struct {
+		amountField, amount     string
+		currencyField, currency string
+	}
+	charges := p.Attributes.ChargesInformation
+	amounts := []amount{
+		{"attributes.amount", p.Attributes.Amount, "attributes.currency", p.Attributes.Currency},
+		{"attributes.charges_information.receiver_charges_amount", charges.ReceiverChargesAmount, "attributes.charges_information.receiver_charges_currency", charges.ReceiverChargesCurrency},
+		{"attributes.fx.original_amount", p.Attributes.Forex.OriginalAmount, "attributes.fx.original_currency", p.Attributes.Forex.OriginalCurrency},
+	}
+	for i, c := range charges.SenderCharges {
+		path := fmt.Sprintf("attributes.charges_information.sender_charges[%d]", i)
+		amounts = append(amounts, amount{path + ".amount", c.Amount, path + ".currency", c.Currency})
+	}
+
+	var errs []apierrors.FieldError
+	for _, a := range amounts {
+		c, ok := currency.Lookup(a.currency)
+		if !ok {
+			errs = append(errs, apierrors.FieldError{Field: a.currencyField, Message: "is not an ISO 4217 currency code"})
+			continue
+		}
+		if err := c.ValidateAmount(a.amount); err != nil {
+			errs = append(errs, apierrors.FieldError{Field: a.amountField, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// validateSchemeRules validates the payment against the rules of its payment scheme, e.g. the FPS amount limit
+func validateSchemeRules(p Payment) []apierrors.FieldError {
+	a := p.Attributes
+	in := schemes.Instruction{
+		Scheme:               a.PaymentScheme,
+		SchemePaymentType:    a.SchemePaymentType,
+		SchemePaymentSubType: a.SchemePaymentSubType,
+		Amount:               a.Amount,
+		Currency:             a.Currency,
+		Fields: map[string]string{
+			"reference":            a.Reference,
+			"end_to_end_reference": a.EndToEndReference,
+			"numeric_reference":    a.NumericReference,
+		},
+	}
+	var errs []apierrors.FieldError
+	for _, e := range schemes.Validate(in) {
+		errs = append(errs, apierrors.FieldError{Field: "attributes." + e.Field, Message: e.Message})
+	}
+	return errs
+}
+
+// validateForex validates the fx details are consistent with the amount: the original currency differs from the currency
+// and the original amount converted at the exchange rate matches the amount. It is skipped when the fields are invalid.
+func validateForex(p Payment, invalid map[string]bool) []apierrors.FieldError {
+	for _, field := range []string{"attributes.amount", "attributes.currency", "attributes.fx.exchange_rate", "attributes.fx.original_amount", "attributes.fx.original_currency"} {
+		if invalid[field] {
+			return nil
+		}
+	}
+	a := p.Attributes
+	if a.Forex.OriginalCurrency == a.Currency {
+		return []apierrors.FieldError{{Field: "attributes.fx.original_currency", Message: "should differ from the currency of the payment"}}
+	}
+	c, _ := currency.Lookup(a.Currency)
+	expected, ok, err := forex.Check(forex.Conversion{
+		Amount:         a.Amount,
+		MinorUnits:     c.MinorUnits,
+		OriginalAmount: a.Forex.OriginalAmount,
+		ExchangeRate:   a.Forex.ExchangeRate,
+	})
 	if err != nil {
-		return err
+		return []apierrors.FieldError{{Field: "attributes.fx.exchange_rate", Message: err.Error()}}
+	}
+	if !ok {
+		return []apierrors.FieldError{{Field: "attributes.amount", Message: fmt.Sprintf("does not match %s %s at the exchange rate %s, expected %s", a.Forex.OriginalAmount, a.Forex.OriginalCurrency, a.Forex.ExchangeRate, expected)}}
 	}
 	return nil
 }
+
+func identifierOf(party DebtorParty) accounts.Identifier {
+	return accounts.Identifier{
+		AccountNumber:     party.AccountNumber,
+		AccountNumberCode: party.AccountNumberCode,
+		BankID:            party.BankID,
+		BankIDCode:        party.BankIDCode,
+	}
+}
+
+// jsonPath turns the namespace of a validation error into the json path of the field:
+// the root struct and the embedded structs, named after their go type, are left out
+func jsonPath(namespace string) string {
+	var path []string
+	for _, name := range strings.Split(namespace, ".") {
+		if name != "" && !unicode.IsUpper(rune(name[0])) {
+			path = append(path, name)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+func fieldErrorMessage(tag string) string {
+	if msg, ok := tags[tag]; ok {
+		return msg
+	}
+	return "is_invalid"
+}
+
+// validateLimits validates the schemes are supported, the currencies are ISO 4217 codes limited once
+// and the amounts precision matches their minor units
+func validateLimits(l OrganisationLimits) []apierrors.FieldError {
+	var errs []apierrors.FieldError
+	for i, scheme := range l.AllowedSchemes {
+		if !schemes.Supports(scheme) {
+			errs = append(errs, apierrors.FieldError{Field: fmt.Sprintf("allowed_schemes[%d]", i), Message: "is not a supported payment scheme"})
+		}
+	}
+	limited := map[string]bool{}
+	for i, c := range l.Currencies {
+		path := fmt.Sprintf("currencies[%d]", i)
+		cur, ok := currency.Lookup(c.Currency)
+		if !ok {
+			errs = append(errs, apierrors.FieldError{Field: path + ".currency", Message: "is not an ISO 4217 currency code"})
+			continue
+		}
+		if limited[c.Currency] {
+			errs = append(errs, apierrors.FieldError{Field: path + ".currency", Message: "is already limited"})
+		}
+		limited[c.Currency] = true
+		amounts := []struct{ field, amount string }{
+			{"max_amount", c.MaxAmount},
+			{"daily_total", c.DailyTotal},
+			{"monthly_total", c.MonthlyTotal},
+			{"approval_threshold", c.ApprovalThreshold},
+		}
+		for _, a := range amounts {
+			if a.amount == "" {
+				continue
+			}
+			if err := cur.ValidateAmount(a.amount); err != nil {
+				errs = append(errs, apierrors.FieldError{Field: path + "." + a.field, Message: err.Error()})
+			}
+		}
+	}
+	return errs
+}
+
+// validateReturn validates the amount, when given, is a positive decimal and the reason code is a code of the type
+// of the return
+func validateReturn(req CreateReturnRequest) []apierrors.FieldError {
+	var errs []apierrors.FieldError
+	if req.Amount != "" {
+		if _, err := currency.Scale(req.Amount); err != nil {
+			errs = append(errs, apierrors.FieldError{Field: "amount", Message: err.Error()})
+		} else if parseAmount(req.Amount).Sign() <= 0 {
+			errs = append(errs, apierrors.FieldError{Field: "amount", Message: "must be greater than 0"})
+		}
+	}
+	if _, ok := returnReasonCodes[req.Type][req.ReasonCode]; !ok {
+		errs = append(errs, apierrors.FieldError{
+			Field:   "reason_code",
+			Message: fmt.Sprintf("is not a %s reason code, expected one of %s", req.Type, strings.Join(reasonCodes(req.Type), ", ")),
+		})
+	}
+	return errs
+}
@@ -3,10 +3,23 @@ package payments
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	apierrors "github.com/elkousy/payments-api/utility/errors"
 )
 
+// withoutFieldErrors strips the invalid fields details of an APIError, to compare it with the declared errors
+func withoutFieldErrors(err error) error {
+	if apiErr, ok := err.(apierrors.APIError); ok {
+		apiErr.Errors = nil
+		return apiErr
+	}
+	return err
+}
+
 func Test_validatorService_GetPayment(t *testing.T) {
 	type args struct {
 		req GetPaymentRequest
@@ -100,6 +113,21 @@ func Test_validatorService_UpdatePayment(t *testing.T) {
 			},
 			wantErr: ErrInvalidPaymentPayload,
 		},
+		{
+			name: "Should return a scheme rules violation when the payment breaks the rules of its scheme",
+			args: args{
+				req: UpdatePaymentRequest{
+					PaymentID: "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3",
+					Payment: func() Payment {
+						p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+						p.Attributes.Amount = "1000000.01"
+						p.Attributes.Forex.OriginalAmount = "2000000.02"
+						return p
+					}(),
+				},
+			},
+			wantErr: ErrSchemeRulesViolation,
+		},
 		{
 			name: "Should return a successful update response",
 			args: args{
@@ -125,7 +153,7 @@ func Test_validatorService_UpdatePayment(t *testing.T) {
 			s, _ := newValidator(mockService)
 			// Act & Assert
 			got, err := s.UpdatePayment(tt.args.req)
-			if err != tt.wantErr {
+			if withoutFieldErrors(err) != tt.wantErr {
 				t.Errorf("validatorService.UpdatePayment() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -195,6 +223,144 @@ func Test_validatorService_DeletePayment(t *testing.T) {
 	}
 }
 
+func Test_validatorService_ReviewPayment(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		req     ReviewPaymentRequest
+		want    *ReviewPaymentResponse
+		wantErr error
+	}{
+		{name: "Should return error invalid payment id when it is not a valid uuid", req: ReviewPaymentRequest{PaymentID: "1", Decision: ReviewRelease}, wantErr: ErrInvalidPaymentID},
+		{name: "Should return error invalid review decision", req: ReviewPaymentRequest{PaymentID: id, Decision: "approve"}, wantErr: ErrInvalidReviewDecision},
+		{name: "Should return review payment response", req: ReviewPaymentRequest{PaymentID: id, Decision: ReviewReject}, want: &ReviewPaymentResponse{PaymentID: id, Status: StatusRejected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			if tt.want != nil {
+				mockService.On("ReviewPayment", tt.req).Return(tt.want, nil)
+			}
+			s, _ := newValidator(mockService)
+			// Act
+			got, err := s.ReviewPayment(tt.req)
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validatorService_ApprovePayment(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		req     ApprovePaymentRequest
+		want    *ApprovePaymentResponse
+		wantErr error
+	}{
+		{name: "Should return error invalid payment id when it is not a valid uuid", req: ApprovePaymentRequest{PaymentID: "1", Approver: "bob", Decision: ApprovalApprove}, wantErr: ErrInvalidPaymentID},
+		{name: "Should return error missing user id", req: ApprovePaymentRequest{PaymentID: id, Decision: ApprovalApprove}, wantErr: ErrMissingUserID},
+		{name: "Should return error invalid approval decision", req: ApprovePaymentRequest{PaymentID: id, Approver: "bob", Decision: "release"}, wantErr: ErrInvalidApprovalDecision},
+		{name: "Should return approve payment response", req: ApprovePaymentRequest{PaymentID: id, Approver: "bob", Decision: ApprovalReject}, want: &ApprovePaymentResponse{Status: StatusRejected}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			if tt.want != nil {
+				mockService.On("ApprovePayment", tt.req).Return(tt.want, nil)
+			}
+			s, _ := newValidator(mockService)
+			// Act
+			got, err := s.ApprovePayment(tt.req)
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validatorService_CreateReturn(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	tests := []struct {
+		name    string
+		req     CreateReturnRequest
+		want    *CreateReturnResponse
+		wantErr error
+	}{
+		{name: "Should return error invalid payment id when it is not a valid uuid", req: CreateReturnRequest{PaymentID: "1", Type: ReturnTypeReturn, ReasonCode: "AC04"}, wantErr: ErrInvalidPaymentID},
+		{
+			name:    "Should return error invalid amount",
+			req:     CreateReturnRequest{PaymentID: id, Type: ReturnTypeReturn, Amount: "-1", ReasonCode: "AC04"},
+			wantErr: ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "is not a decimal amount"}),
+		},
+		{
+			name:    "Should return error zero amount",
+			req:     CreateReturnRequest{PaymentID: id, Type: ReturnTypeReturn, Amount: "0.00", ReasonCode: "AC04"},
+			wantErr: ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "amount", Message: "must be greater than 0"}),
+		},
+		{
+			name: "Should return error reason code of another type",
+			req:  CreateReturnRequest{PaymentID: id, Type: ReturnTypeReversal, ReasonCode: "AC04"},
+			wantErr: ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{
+				Field: "reason_code", Message: "is not a reversal reason code, expected one of AM05, FRAD, MS02, MS03, TECH",
+			}),
+		},
+		{name: "Should return create return response", req: CreateReturnRequest{PaymentID: id, Type: ReturnTypeReversal, ReasonCode: "AM05"}, want: &CreateReturnResponse{Return: Return{Amount: "100.21"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			if tt.want != nil {
+				mockService.On("CreateReturn", tt.req).Return(tt.want, nil)
+			}
+			s, _ := newValidator(mockService)
+			// Act
+			got, err := s.CreateReturn(tt.req)
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validatorService_UpdateReturnStatus(t *testing.T) {
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	returnID := "5e0b4c1e-8d2a-4f7e-9c3b-2a1d0e9f8c7b"
+	tests := []struct {
+		name    string
+		req     UpdateReturnStatusRequest
+		want    *UpdateReturnStatusResponse
+		wantErr error
+	}{
+		{name: "Should return error invalid return id", req: UpdateReturnStatusRequest{PaymentID: id, ReturnID: "1", Status: ReturnStatusFailed}, wantErr: ErrInvalidReturnID},
+		{
+			name:    "Should return error invalid status",
+			req:     UpdateReturnStatusRequest{PaymentID: id, ReturnID: returnID, Status: ReturnStatusPending},
+			wantErr: ErrInvalidReturn.WithFieldErrors(apierrors.FieldError{Field: "status", Message: "is not completed or failed"}),
+		},
+		{name: "Should return update return status response", req: UpdateReturnStatusRequest{PaymentID: id, ReturnID: returnID, Status: ReturnStatusFailed}, want: &UpdateReturnStatusResponse{Return: Return{Status: ReturnStatusFailed}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			if tt.want != nil {
+				mockService.On("UpdateReturnStatus", tt.req).Return(tt.want, nil)
+			}
+			s, _ := newValidator(mockService)
+			// Act
+			got, err := s.UpdateReturnStatus(tt.req)
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_validatePaymentID_OK(t *testing.T) {
 	//Arrange
 	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
@@ -211,3 +377,341 @@ func Test_validatePaymentID_Fail(t *testing.T) {
 	//Assert
 	require.Error(t, err)
 }
+
+func Test_validatorService_GetListOfPayments(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     GetListOfPaymentsRequest
+		wantErr error
+	}{
+		{
+			name:    "Should return error invalid pagination when page is negative",
+			req:     GetListOfPaymentsRequest{Page: -1},
+			wantErr: ErrInvalidPagination,
+		},
+		{
+			name:    "Should return error invalid pagination when page size is too large",
+			req:     GetListOfPaymentsRequest{PageSize: maxPageSize + 1},
+			wantErr: ErrInvalidPagination,
+		},
+		{
+			name: "Should return a successful list response",
+			req:  GetListOfPaymentsRequest{Page: 1, PageSize: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			mockService.On("GetListOfPayments", tt.req).Return(&GetListOfPaymentsResponse{}, nil)
+			s, _ := newValidator(mockService)
+			// Act
+			_, err := s.GetListOfPayments(tt.req)
+			// Assert
+			require.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_validatePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(p *Payment)
+		expected []apierrors.FieldError
+	}{
+		{
+			name:   "Should accept a valid payment",
+			update: func(p *Payment) {},
+		},
+		{
+			name:     "Should name the missing fields after their json path",
+			update:   func(p *Payment) { p.Attributes.DebtorParty.AccountName = "" },
+			expected: []apierrors.FieldError{{Field: "attributes.debtor_party.account_name", Message: "is_required"}},
+		},
+		{
+			name:     "Should check the IBANs",
+			update:   func(p *Payment) { p.Attributes.DebtorParty.AccountNumber = "GB29XABC10161234567801" },
+			expected: []apierrors.FieldError{{Field: "attributes.debtor_party.account_number", Message: "has invalid IBAN check digits"}},
+		},
+		{
+			name: "Should check the sort codes and the UK account numbers",
+			update: func(p *Payment) {
+				p.Attributes.BeneficiaryParty.AccountNumberCode = "BBAN"
+				p.Attributes.BeneficiaryParty.AccountNumber = "66374959"
+				p.Attributes.BeneficiaryParty.BankID = "089999"
+				p.Attributes.BeneficiaryParty.BankIDCode = "GBDSC"
+			},
+			expected: []apierrors.FieldError{{Field: "attributes.beneficiary_party.account_number", Message: "does not pass the modulus check of sort code 089999"}},
+		},
+		{
+			name: "Should check the BICs",
+			update: func(p *Payment) {
+				p.Attributes.SponsorParty.BankID = "NWBK"
+				p.Attributes.SponsorParty.BankIDCode = "SWBIC"
+			},
+			expected: []apierrors.FieldError{{Field: "attributes.sponsor_party.bank_id", Message: "is not a BIC"}},
+		},
+		{
+			name:     "Should check the processing date format",
+			update:   func(p *Payment) { p.Attributes.ProcessingDate = "18/01/2019" },
+			expected: []apierrors.FieldError{{Field: "attributes.processing_date", Message: "is not a date, expected YYYY-MM-DD"}},
+		},
+		{
+			name:     "Should check the currency codes",
+			update:   func(p *Payment) { p.Attributes.ChargesInformation.SenderCharges[1].Currency = "XYZ" },
+			expected: []apierrors.FieldError{{Field: "attributes.charges_information.sender_charges[1].currency", Message: "is not an ISO 4217 currency code"}},
+		},
+		{
+			name: "Should check the amounts precision against the currency minor units",
+			update: func(p *Payment) {
+				p.Attributes.Currency = "JPY"
+				p.Attributes.Forex.OriginalAmount = "200.421"
+			},
+			expected: []apierrors.FieldError{
+				{Field: "attributes.amount", Message: "has more than 0 decimals for JPY"},
+				{Field: "attributes.fx.original_amount", Message: "has more than 2 decimals for USD"},
+			},
+		},
+		{
+			name:     "Should check the amounts format",
+			update:   func(p *Payment) { p.Attributes.ChargesInformation.ReceiverChargesAmount = "1,00" },
+			expected: []apierrors.FieldError{{Field: "attributes.charges_information.receiver_charges_amount", Message: "is not a decimal amount"}},
+		},
+		{
+			name:     "Should check the bearer code",
+			update:   func(p *Payment) { p.Attributes.ChargesInformation.BearerCode = "CRED" },
+			expected: []apierrors.FieldError{{Field: "attributes.charges_information.bearer_code", Message: "is not SHAR, OUR or BEN"}},
+		},
+		{
+			name:   "Should accept the omitted sender charges",
+			update: func(p *Payment) { p.Attributes.ChargesInformation.SenderCharges = nil },
+		},
+		{
+			name:     "Should check the amount matches the fx original amount at the exchange rate",
+			update:   func(p *Payment) { p.Attributes.Forex.ExchangeRate = "2.0000" },
+			expected: []apierrors.FieldError{{Field: "attributes.amount", Message: "does not match 200.42 USD at the exchange rate 2.0000, expected 400.84"}},
+		},
+		{
+			name:   "Should accept the amounts within the fx tolerance",
+			update: func(p *Payment) { p.Attributes.Amount = "100.22" },
+		},
+		{
+			name:     "Should check the fx original currency differs from the currency",
+			update:   func(p *Payment) { p.Attributes.Forex.OriginalCurrency = "GBP" },
+			expected: []apierrors.FieldError{{Field: "attributes.fx.original_currency", Message: "should differ from the currency of the payment"}},
+		},
+		{
+			name:     "Should check the exchange rate",
+			update:   func(p *Payment) { p.Attributes.Forex.ExchangeRate = "0" },
+			expected: []apierrors.FieldError{{Field: "attributes.fx.exchange_rate", Message: "is not a positive decimal rate"}},
+		},
+		{
+			name:     "Should not report a missing account number twice",
+			update:   func(p *Payment) { p.Attributes.DebtorParty.AccountNumber = "" },
+			expected: []apierrors.FieldError{{Field: "attributes.debtor_party.account_number", Message: "is_required"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+			tt.update(&p)
+			// Act
+			errs := validatePayload(p)
+			// Assert
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
+
+func Test_validateSchemeRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(p *Payment)
+		expected []apierrors.FieldError
+	}{
+		{
+			name:   "Should accept a payment complying with the rules of its scheme",
+			update: func(p *Payment) {},
+		},
+		{
+			name:     "Should reject the unknown payment schemes",
+			update:   func(p *Payment) { p.Attributes.PaymentScheme = "Swift" },
+			expected: []apierrors.FieldError{{Field: "attributes.payment_scheme", Message: "is not a supported payment scheme"}},
+		},
+		{
+			name: "Should name the fields breaking the rules after their json path",
+			update: func(p *Payment) {
+				p.Attributes.Reference = "Payment for Em's piano lessons"
+				p.Attributes.SchemePaymentSubType = "Fax"
+			},
+			expected: []apierrors.FieldError{
+				{Field: "attributes.scheme_payment_sub_type", Message: "should be one of TelephoneBanking, InternetBanking, BranchInstruction, Letter, Email, MobilePaymentsService for ImmediatePayment"},
+				{Field: "attributes.reference", Message: "should have at most 18 characters for FPS"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+			tt.update(&p)
+
+			//Act
+			errs := validateSchemeRules(p)
+
+			//Assert
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
+
+func Test_validateLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   OrganisationLimits
+		expected []apierrors.FieldError
+	}{
+		{
+			name: "Should accept supported schemes and amounts in their currency",
+			limits: OrganisationLimits{
+				AllowedSchemes: []string{"FPS", "Bacs"},
+				Currencies:     []CurrencyLimit{{Currency: "GBP", MaxAmount: "250000.00", DailyTotal: "1000000"}, {Currency: "JPY", MonthlyTotal: "5000000"}},
+			},
+		},
+		{
+			name: "Should name the invalid limits after their json path",
+			limits: OrganisationLimits{
+				AllowedSchemes: []string{"FPS", "Swift"},
+				Currencies: []CurrencyLimit{
+					{Currency: "GBP", MaxAmount: "100.001", DailyTotal: "-1", ApprovalThreshold: "1O0"},
+					{Currency: "GBP"},
+					{Currency: "XYZ"},
+				},
+			},
+			expected: []apierrors.FieldError{
+				{Field: "allowed_schemes[1]", Message: "is not a supported payment scheme"},
+				{Field: "currencies[0].max_amount", Message: "has more than 2 decimals for GBP"},
+				{Field: "currencies[0].daily_total", Message: "is not a decimal amount"},
+				{Field: "currencies[0].approval_threshold", Message: "is not a decimal amount"},
+				{Field: "currencies[1].currency", Message: "is already limited"},
+				{Field: "currencies[2].currency", Message: "is not an ISO 4217 currency code"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			errs := validateLimits(tt.limits)
+
+			//Assert
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
+
+func Test_validatorService_OrganisationLimits(t *testing.T) {
+	// Arrange
+	s, _ := newValidator(&MockService{})
+
+	// Act
+	_, getErr := s.GetOrganisationLimits(GetOrganisationLimitsRequest{OrganisationID: "1"})
+	_, updateErr := s.UpdateOrganisationLimits(UpdateOrganisationLimitsRequest{OrganisationID: "1"})
+	_, deleteErr := s.DeleteOrganisationLimits(DeleteOrganisationLimitsRequest{OrganisationID: "1"})
+
+	// Assert
+	assert.Equal(t, ErrInvalidOrganisationID, getErr)
+	assert.Equal(t, ErrInvalidOrganisationID, updateErr)
+	assert.Equal(t, ErrInvalidOrganisationID, deleteErr)
+}
+
+func Test_validatorService_Recalls(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	recallID := "3f6d2a9b-1c4e-4b8a-9d7f-6e5c4b3a2d1e"
+	create := CreateRecallRequest{PaymentID: id, ReasonCode: "DUPL"}
+	decide := DecideRecallRequest{PaymentID: id, RecallID: recallID, Decision: RecallReject}
+	mockService := &MockService{}
+	mockService.On("CreateRecall", create).Return(&CreateRecallResponse{}, nil)
+	mockService.On("DecideRecall", decide).Return(&DecideRecallResponse{}, nil)
+	s, _ := newValidator(mockService)
+
+	//Act
+	_, invalidIDErr := s.CreateRecall(CreateRecallRequest{PaymentID: "1", ReasonCode: "DUPL"})
+	_, invalidCodeErr := s.CreateRecall(CreateRecallRequest{PaymentID: id, ReasonCode: "AC04"})
+	_, createErr := s.CreateRecall(create)
+	_, invalidRecallIDErr := s.GetRecall(GetRecallRequest{PaymentID: id, RecallID: "1"})
+	_, invalidDecisionErr := s.DecideRecall(DecideRecallRequest{PaymentID: id, RecallID: recallID, Decision: "cancel"})
+	_, decideErr := s.DecideRecall(decide)
+
+	//Assert
+	assert.Equal(t, ErrInvalidPaymentID, invalidIDErr)
+	assert.Equal(t, ErrInvalidRecall.WithFieldErrors(apierrors.FieldError{
+		Field: "reason_code", Message: "is not a recall reason code, expected one of AC03, AM09, CUST, DUPL, FRAD, TECH",
+	}), invalidCodeErr)
+	assert.NoError(t, createErr)
+	assert.Equal(t, ErrInvalidRecallID, invalidRecallIDErr)
+	assert.Equal(t, ErrInvalidRecall.WithFieldErrors(apierrors.FieldError{Field: "decision", Message: "is not accept or reject"}), invalidDecisionErr)
+	assert.NoError(t, decideErr)
+	mockService.AssertExpectations(t)
+}
+
+func Test_validatorService_GetPayment_VersionAndAsOf(t *testing.T) {
+	// Arrange
+	version, at := uint(1), time.Now()
+	s, _ := newValidator(&MockService{})
+
+	//Act
+	_, err := s.GetPayment(GetPaymentRequest{PaymentID: "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3", Version: &version, AsOf: &at})
+
+	//Assert
+	assert.Equal(t, ErrInvalidPaymentVersion, err)
+}
+
+func Test_validatorService_GetPaymentAudit(t *testing.T) {
+	// Arrange
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	mockService := &MockService{}
+	mockService.On("GetPaymentAudit", GetPaymentAuditRequest{PaymentID: id}).Return(&GetPaymentAuditResponse{}, nil)
+	s, _ := newValidator(mockService)
+
+	//Act
+	_, invalidIDErr := s.GetPaymentAudit(GetPaymentAuditRequest{PaymentID: "1"})
+	_, err := s.GetPaymentAudit(GetPaymentAuditRequest{PaymentID: id})
+
+	//Assert
+	assert.Equal(t, ErrInvalidPaymentID, invalidIDErr)
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func Test_validatorService_Ledger(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     GetAccountEntriesRequest
+		wantErr error
+	}{
+		{name: "Should return error invalid account", req: GetAccountEntriesRequest{Account: "134667"}, wantErr: ErrInvalidAccount},
+		{name: "Should return error invalid page size", req: GetAccountEntriesRequest{Account: "charges:134667", PageSize: maxPageSize + 1}, wantErr: ErrInvalidPagination},
+		{name: "Should return the entries of a party account", req: GetAccountEntriesRequest{Account: "party:134667:GB29NWBK60161331926819"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockService{}
+			mockService.On("GetAccountEntries", tt.req).Return(&GetAccountEntriesResponse{}, nil)
+			mockService.On("GetAccountBalance", GetAccountBalanceRequest{Account: tt.req.Account}).Return(&GetAccountBalanceResponse{}, nil)
+			s, _ := newValidator(mockService)
+			// Act
+			_, err := s.GetAccountEntries(tt.req)
+			_, balanceErr := s.GetAccountBalance(GetAccountBalanceRequest{Account: tt.req.Account})
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == ErrInvalidAccount {
+				assert.Equal(t, ErrInvalidAccount, balanceErr)
+			} else {
+				assert.NoError(t, balanceErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// PaymentVersion is a version of a payment, kept when it is updated or changes status. The attributes of a version
+// and their parties, charges and forex rows are never updated: an update of a payment inserts new ones.
+type PaymentVersion struct {
+	PaymentID        uuid.UUID `gorm:"type:uuid;primary_key;auto_increment:false"`
+	Version          uint      `gorm:"primary_key;auto_increment:false"`
+	Type             string
+	OrganisationID   uuid.UUID `gorm:"type:uuid"`
+	AttributesID     uint
+	Status           PaymentStatus
+	StatusReason     string
+	CreatedBy        string
+	ApprovalRequired bool
+	// CreatedAt is when the version replaced the previous one
+	CreatedAt time.Time `sql:"index"`
+}
+
+// recordVersion inserts the current version of a payment in the database transaction changing it
+func recordVersion(tx *gorm.DB, p Payment) error {
+	return tx.Create(&PaymentVersion{
+		PaymentID:        p.ID,
+		Version:          p.Version,
+		Type:             p.Type,
+		OrganisationID:   p.OrganisationID,
+		AttributesID:     p.AttributesID,
+		Status:           p.Status,
+		StatusReason:     p.StatusReason,
+		CreatedBy:        p.CreatedBy,
+		ApprovalRequired: p.ApprovalRequired,
+	}).Error
+}
+
+// apply sets the fields of a payment as they were in the version
+func (v PaymentVersion) apply(p *Payment, a Attributes) {
+	p.Version, p.Type, p.OrganisationID = v.Version, v.Type, v.OrganisationID
+	p.Attributes, p.AttributesID = a, v.AttributesID
+	p.Status, p.StatusReason, p.CreatedBy, p.ApprovalRequired = v.Status, v.StatusReason, v.CreatedBy, v.ApprovalRequired
+}
+
+// detachAttributes clears the keys of the attributes of a payment and of their nested rows, so saving the payment
+// inserts new rows instead of updating those of the previous version
+func detachAttributes(p *Payment) {
+	a := &p.Attributes
+	p.AttributesID, a.ID = 0, 0
+	a.BeneficiaryPartyID, a.BeneficiaryParty.ID = 0, 0
+	a.DebtorPartyID, a.DebtorParty.ID = 0, 0
+	a.SponsorPartyID, a.SponsorParty.ID = 0, 0
+	a.ForexID, a.Forex.ID = 0, 0
+	a.ChargesInformationID, a.ChargesInformation.ID = 0, 0
+	for i := range a.ChargesInformation.SenderCharges {
+		a.ChargesInformation.SenderCharges[i].ID, a.ChargesInformation.SenderCharges[i].ChargesInformationID = 0, 0
+	}
+}
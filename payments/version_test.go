@@ -0,0 +1,109 @@
+package payments
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detachAttributes(t *testing.T) {
+	//Arrange
+	p := mockNewPayment("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	p.AttributesID, p.Attributes.ID = 7, 7
+	p.Attributes.DebtorPartyID, p.Attributes.DebtorParty.ID = 8, 8
+	p.Attributes.BeneficiaryParty.ID = 9
+	p.Attributes.ChargesInformation.SenderCharges[0].ID = 10
+	p.Attributes.ChargesInformation.SenderCharges[0].ChargesInformationID = 11
+
+	//Act
+	detachAttributes(&p)
+
+	//Assert
+	assert.Zero(t, p.AttributesID)
+	assert.Zero(t, p.Attributes.ID)
+	assert.Zero(t, p.Attributes.DebtorPartyID)
+	assert.Zero(t, p.Attributes.DebtorParty.ID)
+	assert.Zero(t, p.Attributes.BeneficiaryParty.ID)
+	assert.Zero(t, p.Attributes.ChargesInformation.SenderCharges[0].ID)
+	assert.Zero(t, p.Attributes.ChargesInformation.SenderCharges[0].ChargesInformationID)
+	assert.Equal(t, "134667", p.Attributes.DebtorParty.BankID, "only the keys are cleared")
+}
+
+func Test_UpdatePayment_SavesNewVersion(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	var version []driver.NamedValue
+	attributesUpdated := false
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "version": 2, "attributes_id": 7, "status": StatusSubmitted}},
+		},
+		{
+			Pattern:      "UPDATE \"payments\"",
+			RowsAffected: 1,
+		},
+		{
+			Pattern:  "INSERT INTO \"payment_versions\"",
+			Callback: func(_ string, args []driver.NamedValue) { version = args },
+		},
+		{
+			Pattern:  "UPDATE \"attributes\"",
+			Callback: func(_ string, _ []driver.NamedValue) { attributesUpdated = true },
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	err := r.UpdatePayment(id, mockNewPayment(id), Actor{UserID: "bob"})
+
+	//Assert
+	require.NoError(t, err)
+	require.NotEmpty(t, version)
+	var values []interface{}
+	for _, arg := range version {
+		values = append(values, arg.Value)
+	}
+	assert.Contains(t, values, int64(3))
+	assert.Contains(t, values, string(StatusSubmitted))
+	assert.False(t, attributesUpdated, "the attributes of the previous version are kept")
+}
+
+func Test_GetPaymentVersion(t *testing.T) {
+	//Arrange
+	db := SetupDBTests()
+	defer db.Close()
+	id := "7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3"
+	mocket.Catcher.Reset().Attach([]*mocket.FakeResponse{
+		{
+			Pattern:  "SELECT * FROM \"payments\"",
+			Response: []map[string]interface{}{{"id": id, "version": 2, "attributes_id": 7, "status": StatusRejected}},
+		},
+		{
+			Pattern:  "SELECT * FROM \"payment_versions\"",
+			Response: []map[string]interface{}{{"payment_id": id, "version": 1, "attributes_id": 5, "status": StatusSubmitted}},
+			Once:     true,
+		},
+		{
+			Pattern:  "SELECT * FROM \"attributes\"",
+			Response: []map[string]interface{}{{"id": 5, "amount": "50.00"}},
+		},
+	})
+	r := NewPaymentRepository(db)
+
+	//Act
+	p, err := r.GetPaymentVersion(id, 1)
+	_, notFoundErr := r.GetPaymentVersion(id, 4)
+
+	//Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), p.Version)
+	assert.Equal(t, StatusSubmitted, p.Status)
+	assert.Equal(t, "50.00", p.Attributes.Amount)
+	assert.Equal(t, ErrVersionNotFound, notFoundErr)
+}
@@ -0,0 +1,261 @@
+// Package schemes validates the payments against the rules of their payment scheme, e.g. FPS, Bacs or SEPA.
+// The rules are declared in JSON, see data/rules.json.
+package schemes
+
+import (
+	_ "embed" // embeds the bundled scheme rules
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Rules are the declarative rules of a payment scheme
+type Rules struct {
+	// Currencies accepted by the scheme
+	Currencies []string `json:"currencies"`
+	// MinAmount and MaxAmount are the inclusive limits of the amount, no limit when empty
+	MinAmount string `json:"min_amount"`
+	MaxAmount string `json:"max_amount"`
+	// SchemePaymentTypes lists the payment types of the scheme with their allowed sub types, any sub type is allowed when empty
+	SchemePaymentTypes map[string][]string `json:"scheme_payment_types"`
+	// Fields constrain the text fields of the payment attributes, by json name
+	Fields map[string]FieldRule `json:"fields"`
+
+	minAmount, maxAmount *big.Rat
+	patterns             map[string]*regexp.Regexp
+}
+
+// FieldRule constrains a text field
+type FieldRule struct {
+	// MaxLength is the maximum number of characters, no limit when 0
+	MaxLength int `json:"max_length"`
+	// Pattern is a regular expression the field must match, if set
+	Pattern string `json:"pattern"`
+}
+
+// Instruction holds the attributes of a payment checked by the scheme rules
+type Instruction struct {
+	Scheme               string
+	SchemePaymentType    string
+	SchemePaymentSubType string
+	Amount               string
+	Currency             string
+	// Fields are the text fields constrained by the field rules, by json name
+	Fields map[string]string
+}
+
+// Fields of an Instruction reported by the validation errors, named after the payment attributes
+const (
+	FieldScheme               = "payment_scheme"
+	FieldSchemePaymentType    = "scheme_payment_type"
+	FieldSchemePaymentSubType = "scheme_payment_sub_type"
+	FieldAmount               = "amount"
+	FieldCurrency             = "currency"
+)
+
+// Error describes which rule of the scheme a field of the instruction breaks
+type Error struct {
+	Field   string
+	Message string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// compile parses the amounts and the patterns of the rules
+func (r *Rules) compile() error {
+	var ok bool
+	if r.MinAmount != "" {
+		if r.minAmount, ok = new(big.Rat).SetString(r.MinAmount); !ok {
+			return fmt.Errorf("invalid min_amount %s", r.MinAmount)
+		}
+	}
+	if r.MaxAmount != "" {
+		if r.maxAmount, ok = new(big.Rat).SetString(r.MaxAmount); !ok {
+			return fmt.Errorf("invalid max_amount %s", r.MaxAmount)
+		}
+	}
+	r.patterns = map[string]*regexp.Regexp{}
+	for field, rule := range r.Fields {
+		if rule.Pattern == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("field %s: %v", field, err)
+		}
+		r.patterns[field] = pattern
+	}
+	return nil
+}
+
+// Validate checks the instruction against the rules, it returns an error per broken rule
+func (r *Rules) Validate(in Instruction) []Error {
+	var errs []Error
+
+	if !contains(r.Currencies, in.Currency) {
+		errs = append(errs, Error{Field: FieldCurrency, Message: fmt.Sprintf("should be one of %s for %s", strings.Join(r.Currencies, ", "), in.Scheme)})
+	}
+
+	if amount, ok := new(big.Rat).SetString(in.Amount); ok {
+		if r.minAmount != nil && amount.Cmp(r.minAmount) < 0 {
+			errs = append(errs, Error{Field: FieldAmount, Message: fmt.Sprintf("should be at least %s for %s", r.MinAmount, in.Scheme)})
+		}
+		if r.maxAmount != nil && amount.Cmp(r.maxAmount) > 0 {
+			errs = append(errs, Error{Field: FieldAmount, Message: fmt.Sprintf("should be at most %s for %s", r.MaxAmount, in.Scheme)})
+		}
+	}
+
+	if subTypes, ok := r.SchemePaymentTypes[in.SchemePaymentType]; !ok {
+		errs = append(errs, Error{Field: FieldSchemePaymentType, Message: fmt.Sprintf("should be one of %s for %s", strings.Join(keys(r.SchemePaymentTypes), ", "), in.Scheme)})
+	} else if len(subTypes) > 0 && !contains(subTypes, in.SchemePaymentSubType) {
+		errs = append(errs, Error{Field: FieldSchemePaymentSubType, Message: fmt.Sprintf("should be one of %s for %s", strings.Join(subTypes, ", "), in.SchemePaymentType)})
+	}
+
+	fields := make([]string, 0, len(r.Fields))
+	for field := range r.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value, rule := in.Fields[field], r.Fields[field]
+		if rule.MaxLength > 0 && utf8.RuneCountInString(value) > rule.MaxLength {
+			errs = append(errs, Error{Field: field, Message: fmt.Sprintf("should have at most %d characters for %s", rule.MaxLength, in.Scheme)})
+			continue
+		}
+		if pattern, ok := r.patterns[field]; ok && !pattern.MatchString(value) {
+			errs = append(errs, Error{Field: field, Message: fmt.Sprintf("does not match the %s format %s", in.Scheme, rule.Pattern)})
+		}
+	}
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func keys(m map[string][]string) []string {
+	k := make([]string, 0, len(m))
+	for key := range m {
+		k = append(k, key)
+	}
+	sort.Strings(k)
+	return k
+}
+
+// Registry holds the rules of the payment schemes
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]*Rules
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{rules: map[string]*Rules{}}
+}
+
+// Register sets the rules of a scheme
+func (r *Registry) Register(scheme string, rules Rules) error {
+	if err := rules.compile(); err != nil {
+		return fmt.Errorf("scheme %s: %v", scheme, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[scheme] = &rules
+	return nil
+}
+
+// Load registers the rules of the schemes declared in JSON, by scheme name
+func (r *Registry) Load(reader io.Reader) error {
+	declared := map[string]Rules{}
+	if err := json.NewDecoder(reader).Decode(&declared); err != nil {
+		return err
+	}
+	for scheme, rules := range declared {
+		if err := r.Register(scheme, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile registers the rules of the schemes declared in a JSON file
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.Load(f)
+}
+
+// Validate checks the instruction against the rules of its scheme
+func (r *Registry) Validate(in Instruction) []Error {
+	r.mu.RLock()
+	rules, ok := r.rules[in.Scheme]
+	r.mu.RUnlock()
+	if !ok {
+		return []Error{{Field: FieldScheme, Message: "is not a supported payment scheme"}}
+	}
+	return rules.Validate(in)
+}
+
+// Supports reports whether the registry has the rules of a scheme
+func (r *Registry) Supports(scheme string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.rules[scheme]
+	return ok
+}
+
+// Schemes returns the names of the schemes of the registry, sorted
+func (r *Registry) Schemes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.rules))
+	for scheme := range r.rules {
+		names = append(names, scheme)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//go:embed data/rules.json
+var bundledRules string
+
+// DefaultRegistry holds the bundled rules of the FPS, Bacs and SEPA schemes
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	if err := DefaultRegistry.Load(strings.NewReader(bundledRules)); err != nil {
+		panic(err)
+	}
+}
+
+// Validate checks the instruction against the rules of the default registry
+func Validate(in Instruction) []Error {
+	return DefaultRegistry.Validate(in)
+}
+
+// Supports reports whether the default registry has the rules of a scheme
+func Supports(scheme string) bool {
+	return DefaultRegistry.Supports(scheme)
+}
+
+// Schemes returns the names of the schemes of the default registry, sorted
+func Schemes() []string {
+	return DefaultRegistry.Schemes()
+}
@@ -0,0 +1,185 @@
+package schemes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fields(reference, endToEndReference, numericReference string) map[string]string {
+	return map[string]string{
+		"reference":            reference,
+		"end_to_end_reference": endToEndReference,
+		"numeric_reference":    numericReference,
+	}
+}
+
+func Test_Validate_FPS(t *testing.T) {
+	valid := Instruction{
+		Scheme:               "FPS",
+		SchemePaymentType:    "ImmediatePayment",
+		SchemePaymentSubType: "InternetBanking",
+		Amount:               "100.21",
+		Currency:             "GBP",
+		Fields:               fields("Em piano lessons", "Wil piano Jan", "1002001"),
+	}
+	tests := []struct {
+		name   string
+		modify func(in *Instruction)
+		want   []string
+	}{
+		{"valid", func(in *Instruction) {}, nil},
+		{"forward dated", func(in *Instruction) { in.SchemePaymentType = "ForwardDatedPayment" }, nil},
+		{"maximum amount", func(in *Instruction) { in.Amount = "1000000.00" }, nil},
+		{"currency", func(in *Instruction) { in.Currency = "EUR" }, []string{FieldCurrency}},
+		{"amount over the limit", func(in *Instruction) { in.Amount = "1000000.01" }, []string{FieldAmount}},
+		{"zero amount", func(in *Instruction) { in.Amount = "0" }, []string{FieldAmount}},
+		{"payment type", func(in *Instruction) { in.SchemePaymentType = "DirectCredit" }, []string{FieldSchemePaymentType}},
+		{"payment sub type", func(in *Instruction) { in.SchemePaymentSubType = "Fax" }, []string{FieldSchemePaymentSubType}},
+		{"reference too long", func(in *Instruction) { in.Fields["reference"] = "Payment for Em's piano lessons" }, []string{"reference"}},
+		{"reference charset", func(in *Instruction) { in.Fields["reference"] = "Em piano lessons!" }, []string{"reference"}},
+		{"end to end reference too long", func(in *Instruction) { in.Fields["end_to_end_reference"] = strings.Repeat("x", 36) }, []string{"end_to_end_reference"}},
+		{"numeric reference", func(in *Instruction) { in.Fields["numeric_reference"] = "10O2001" }, []string{"numeric_reference"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			in := valid
+			in.Fields = fields(valid.Fields["reference"], valid.Fields["end_to_end_reference"], valid.Fields["numeric_reference"])
+			tt.modify(&in)
+
+			//Act
+			errs := Validate(in)
+
+			//Assert
+			assert.Equal(t, tt.want, fieldsOf(errs))
+		})
+	}
+}
+
+func Test_Validate_Bacs(t *testing.T) {
+	valid := Instruction{
+		Scheme:            "Bacs",
+		SchemePaymentType: "DirectCredit",
+		Amount:            "15000000.00",
+		Currency:          "GBP",
+		Fields:            fields("INV 2019/0042", "SALARY JAN", "42"),
+	}
+	tests := []struct {
+		name   string
+		modify func(in *Instruction)
+		want   []string
+	}{
+		{"valid", func(in *Instruction) {}, nil},
+		{"any sub type", func(in *Instruction) { in.SchemePaymentType, in.SchemePaymentSubType = "DirectDebit", "Anything" }, nil},
+		{"amount over the limit", func(in *Instruction) { in.Amount = "20000000.01" }, []string{FieldAmount}},
+		{"currency", func(in *Instruction) { in.Currency = "USD" }, []string{FieldCurrency}},
+		{"payment type", func(in *Instruction) { in.SchemePaymentType = "ImmediatePayment" }, []string{FieldSchemePaymentType}},
+		{"lowercase reference", func(in *Instruction) { in.Fields["reference"] = "inv 2019/0042" }, []string{"reference"}},
+		{"end to end reference too long", func(in *Instruction) { in.Fields["end_to_end_reference"] = "SALARY JANUARY 2019" }, []string{"end_to_end_reference"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			in := valid
+			in.Fields = fields(valid.Fields["reference"], valid.Fields["end_to_end_reference"], valid.Fields["numeric_reference"])
+			tt.modify(&in)
+
+			//Act
+			errs := Validate(in)
+
+			//Assert
+			assert.Equal(t, tt.want, fieldsOf(errs))
+		})
+	}
+}
+
+func Test_Validate_SEPA(t *testing.T) {
+	valid := Instruction{
+		Scheme:            "SEPA",
+		SchemePaymentType: "SepaCreditTransfer",
+		Amount:            "2500.50",
+		Currency:          "EUR",
+		Fields:            fields("Rechnung 2019-0042 für Klavierunterricht", "E2E-2019-0042", "20190042"),
+	}
+	tests := []struct {
+		name   string
+		modify func(in *Instruction)
+		want   []string
+	}{
+		{"valid", func(in *Instruction) {}, nil},
+		{"instant", func(in *Instruction) { in.SchemePaymentType = "SepaInstantCreditTransfer" }, nil},
+		{"currency", func(in *Instruction) { in.Currency = "GBP" }, []string{FieldCurrency}},
+		{"amount over the limit", func(in *Instruction) { in.Amount = "1000000000.00" }, []string{FieldAmount}},
+		{"reference too long", func(in *Instruction) { in.Fields["reference"] = strings.Repeat("é", 141) }, []string{"reference"}},
+		{"end to end reference charset", func(in *Instruction) { in.Fields["end_to_end_reference"] = "E2E_2019_0042" }, []string{"end_to_end_reference"}},
+		{"several rules", func(in *Instruction) { in.Currency, in.SchemePaymentType = "USD", "Swift" }, []string{FieldCurrency, FieldSchemePaymentType}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Arrange
+			in := valid
+			in.Fields = fields(valid.Fields["reference"], valid.Fields["end_to_end_reference"], valid.Fields["numeric_reference"])
+			tt.modify(&in)
+
+			//Act
+			errs := Validate(in)
+
+			//Assert
+			assert.Equal(t, tt.want, fieldsOf(errs))
+		})
+	}
+}
+
+func Test_Validate_UnknownScheme(t *testing.T) {
+	errs := Validate(Instruction{Scheme: "Swift"})
+	assert.Equal(t, []Error{{Field: FieldScheme, Message: "is not a supported payment scheme"}}, errs)
+}
+
+func Test_Registry_Load(t *testing.T) {
+	//Arrange
+	registry := NewRegistry()
+	rules := `{"CHAPS": {"currencies": ["GBP"], "scheme_payment_types": {"Chaps": []}, "fields": {"reference": {"pattern": "^[A-Z]+$"}}}}`
+
+	//Act
+	err := registry.Load(strings.NewReader(rules))
+
+	//Assert
+	require.NoError(t, err)
+	assert.Empty(t, registry.Validate(Instruction{Scheme: "CHAPS", SchemePaymentType: "Chaps", Amount: "250000000", Currency: "GBP", Fields: map[string]string{"reference": "ABC"}}))
+	errs := registry.Validate(Instruction{Scheme: "CHAPS", SchemePaymentType: "Chaps", Amount: "1", Currency: "GBP", Fields: map[string]string{"reference": "abc"}})
+	assert.Equal(t, []Error{{Field: "reference", Message: "does not match the CHAPS format ^[A-Z]+$"}}, errs)
+	assert.Equal(t, []string{FieldScheme}, fieldsOf(registry.Validate(Instruction{Scheme: "FPS"})))
+	assert.True(t, registry.Supports("CHAPS"))
+	assert.False(t, registry.Supports("FPS"))
+	assert.True(t, Supports("FPS"), "the default registry supports the bundled schemes")
+	assert.Equal(t, []string{"CHAPS"}, registry.Schemes())
+	assert.Contains(t, Schemes(), "SEPA")
+}
+
+func Test_Registry_Load_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"json", `{"FPS": [}`},
+		{"min amount", `{"FPS": {"min_amount": "one"}}`},
+		{"max amount", `{"FPS": {"max_amount": "1,000"}}`},
+		{"pattern", `{"FPS": {"fields": {"reference": {"pattern": "[A-Z"}}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, NewRegistry().Load(strings.NewReader(tt.rules)))
+		})
+	}
+}
+
+func fieldsOf(errs []Error) []string {
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
@@ -0,0 +1,160 @@
+// Package screening screens the names and addresses of the payment parties against sanctions lists, e.g. the OFAC SDN list.
+package screening
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultThreshold is the minimum similarity of a match, between 0 and 1
+const DefaultThreshold = 0.9
+
+// Fields of a party compared with the entries
+const (
+	FieldName    = "name"
+	FieldAddress = "address"
+)
+
+// Match is an entry of the lists similar to a party
+type Match struct {
+	// Field of the party matching the entry, name or address
+	Field string
+	// EntryUID and EntryName identify the entry
+	EntryUID  string
+	EntryName string
+	// Matched is the name, alias or address of the entry similar to the field
+	Matched string
+	// Score is the similarity between the field and the matched value, between 0 and 1
+	Score float64
+}
+
+// Screener fuzzy-matches the parties with the entries of the lists
+type Screener struct {
+	entries   []Entry
+	threshold float64
+}
+
+// NewScreener returns a screener of the entries, matches have a similarity of at least the threshold
+func NewScreener(entries []Entry, threshold float64) *Screener {
+	return &Screener{entries: entries, threshold: threshold}
+}
+
+// Screen returns the entries whose name, aliases or addresses are similar to the name or the address of a party,
+// sorted by decreasing score
+func (s *Screener) Screen(name, address string) []Match {
+	if s == nil {
+		return nil
+	}
+	nameTokens, addressTokens := tokens(name), tokens(address)
+	var matches []Match
+	for _, e := range s.entries {
+		if m, ok := s.best(e, FieldName, nameTokens, append([]string{e.Name}, e.Aliases...)); ok {
+			matches = append(matches, m)
+		}
+		if m, ok := s.best(e, FieldAddress, addressTokens, e.Addresses); ok {
+			matches = append(matches, m)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// best returns the most similar candidate of the entry, if it reaches the threshold
+func (s *Screener) best(e Entry, field string, party []string, candidates []string) (Match, bool) {
+	if len(party) == 0 {
+		return Match{}, false
+	}
+	match := Match{}
+	for _, candidate := range candidates {
+		if score := similarity(party, tokens(candidate)); score >= s.threshold && score > match.Score {
+			match = Match{Field: field, EntryUID: e.UID, EntryName: e.Name, Matched: candidate, Score: score}
+		}
+	}
+	return match, match.Score > 0
+}
+
+// tokens returns the lower case words of a text, the punctuation is dropped
+func tokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// similarity compares the words of a party with the words of an entry, regardless of their order,
+// e.g. IVANOV, Vladimir and Vladimir Ivanov. It is the best of:
+//   - the similarity of the sorted words;
+//   - the coverage of the entry: each word of the entry is compared with the most similar word of the party,
+//     weighted by its length, so extra words of the party are ignored, e.g. Vladimir Petrovich Ivanov.
+func similarity(party, entry []string) float64 {
+	if len(party) == 0 || len(entry) == 0 {
+		return 0
+	}
+	score := ratio(sortedJoin(party), sortedJoin(entry))
+
+	covered, length := 0.0, 0
+	for _, e := range entry {
+		best := 0.0
+		for _, p := range party {
+			if r := ratio(p, e); r > best {
+				best = r
+			}
+		}
+		n := len([]rune(e))
+		covered += best * float64(n)
+		length += n
+	}
+	if coverage := covered / float64(length); coverage > score {
+		score = coverage
+	}
+	return score
+}
+
+func sortedJoin(tokens []string) string {
+	sorted := append([]string(nil), tokens...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
+
+// ratio is the Levenshtein similarity of two strings: 1 minus their edit distance over the longest length
+func ratio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minOf(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
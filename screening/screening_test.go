@@ -0,0 +1,95 @@
+package screening
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestEntries(t *testing.T) []Entry {
+	entries, err := LoadSDN("testdata/sdn.csv", "testdata/alt.csv", "testdata/add.csv")
+	require.NoError(t, err)
+	return entries
+}
+
+func Test_LoadSDN(t *testing.T) {
+	//Act
+	entries := loadTestEntries(t)
+
+	//Assert
+	require.Len(t, entries, 3)
+	assert.Equal(t, Entry{
+		UID:      "2674",
+		Name:     "DRAVEK, Anton Mirkovic",
+		Type:     "individual",
+		Programs: []string{"UKRAINE-EO13660", "RUSSIA-EO14024"},
+		Aliases:  []string{"DRAWEK, Antoni"},
+	}, entries[1])
+	assert.Equal(t, "", entries[0].Type, "null fields are empty")
+	assert.Empty(t, entries[0].Addresses, "addresses without street are ignored")
+	assert.Equal(t, []string{"12 Harbour Road, Port Louis, Mauritius"}, entries[2].Addresses)
+}
+
+func Test_LoadSDN_WithoutAliasesAndAddresses(t *testing.T) {
+	entries, err := LoadSDN("testdata/sdn.csv", "", "")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Empty(t, entries[1].Aliases)
+}
+
+func Test_ReadSDN_Invalid(t *testing.T) {
+	_, err := ReadSDN(strings.NewReader(`36,"BLUEHARBOR SHIPPING CO",-0- `), nil, nil)
+	assert.Error(t, err)
+	_, err = LoadSDN("testdata/missing.csv", "", "")
+	assert.Error(t, err)
+}
+
+func Test_Screener_Screen(t *testing.T) {
+	screener := NewScreener(loadTestEntries(t), DefaultThreshold)
+	tests := []struct {
+		name    string
+		party   string
+		address string
+		want    []string
+	}{
+		{name: "exact name", party: "NORTHWIND TRADING LLC", want: []string{"9647 name"}},
+		{name: "words in another order", party: "Anton Mirkovic Dravek", want: []string{"2674 name"}},
+		{name: "typo", party: "Anton Mirkovich Dravek", want: []string{"2674 name"}},
+		{name: "extra words", party: "Mr Anton Mirkovic Dravek Jr", want: []string{"2674 name"}},
+		{name: "alias", party: "Antoni Drawek", want: []string{"2674 name"}},
+		{name: "address", party: "Emelia Jane Brown", address: "12 Harbour Rd, Port Louis, Mauritius", want: []string{"9647 address"}},
+		{name: "same surname only", party: "Dravek"},
+		{name: "common words", party: "Northwind Bakery"},
+		{name: "city of an address without street", party: "Wilfred Owens", address: "1 Malecon, Havana, Cuba"},
+		{name: "unrelated", party: "Wilfred Jeremiah Owens", address: "1 The Beneficiary Localtown SE2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//Act
+			matches := screener.Screen(tt.party, tt.address)
+
+			//Assert
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.EntryUID+" "+m.Field)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Screener_Threshold(t *testing.T) {
+	entries := loadTestEntries(t)
+
+	assert.Empty(t, NewScreener(entries, 1).Screen("Anton Mirkovich Dravek", ""))
+	assert.NotEmpty(t, NewScreener(entries, 0.5).Screen("Anton Dravek", ""))
+	assert.Empty(t, (*Screener)(nil).Screen("Anton Mirkovic Dravek", ""), "a nil screener screens nothing")
+}
+
+func Test_similarity(t *testing.T) {
+	assert.Equal(t, 1.0, similarity(tokens("Anton Mirkovic Dravek"), tokens("DRAVEK, Anton Mirkovic")))
+	assert.Equal(t, 0.0, similarity(nil, tokens("DRAVEK, Anton Mirkovic")))
+	assert.InDelta(t, 0.9, similarity(tokens("Antonn Mirkovic Dravek"), tokens("DRAVEK, Anton Mirkovic")), 0.1)
+}
@@ -0,0 +1,144 @@
+package screening
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Entry is a sanctioned party of a watch list
+type Entry struct {
+	// UID identifies the entry in its list, e.g. the OFAC ent_num
+	UID       string
+	Name      string
+	Type      string
+	Programs  []string
+	Aliases   []string
+	Addresses []string
+}
+
+// sdnNull is the OFAC marker of the empty fields
+const sdnNull = "-0-"
+
+// columns of the OFAC SDN.CSV, ALT.CSV and ADD.CSV files
+const (
+	sdnColumns = 12
+	altColumns = 5
+	addColumns = 6
+)
+
+// LoadSDN reads the entries of the OFAC SDN list from its SDN.CSV file, with the aliases of ALT.CSV
+// and the addresses of ADD.CSV when their path is not empty
+func LoadSDN(sdnPath, altPath, addPath string) ([]Entry, error) {
+	readers := make([]io.Reader, 3)
+	for i, path := range []string{sdnPath, altPath, addPath} {
+		if path == "" {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		readers[i] = f
+	}
+	return ReadSDN(readers[0], readers[1], readers[2])
+}
+
+// ReadSDN reads the entries of the OFAC SDN list in the CSV format of SDN.CSV, ALT.CSV and ADD.CSV.
+// The aliases and addresses readers are optional, only the addresses with a street are kept.
+func ReadSDN(sdn, alt, add io.Reader) ([]Entry, error) {
+	var entries []Entry
+	index := map[string]int{}
+	err := readRecords(sdn, sdnColumns, func(r []string) error {
+		index[r[0]] = len(entries)
+		entries = append(entries, Entry{
+			UID:      r[0],
+			Name:     r[1],
+			Type:     r[2],
+			Programs: splitPrograms(r[3]),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sdn: %v", err)
+	}
+
+	if alt != nil {
+		err = readRecords(alt, altColumns, func(r []string) error {
+			if i, ok := index[r[0]]; ok && r[3] != "" {
+				entries[i].Aliases = append(entries[i].Aliases, r[3])
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sdn aliases: %v", err)
+		}
+	}
+
+	if add != nil {
+		err = readRecords(add, addColumns, func(r []string) error {
+			// addresses without street, e.g. a city, would match every party of the city
+			if i, ok := index[r[0]]; ok && r[2] != "" {
+				entries[i].Addresses = append(entries[i].Addresses, joinNonEmpty(r[2], r[3], r[4]))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sdn addresses: %v", err)
+		}
+	}
+	return entries, nil
+}
+
+// readRecords reads the records of an OFAC CSV file, the null markers are turned into empty fields.
+// The files end with a SUB character, records shorter than expected are rejected.
+func readRecords(r io.Reader, columns int, fn func([]string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) == 1 && strings.Trim(record[0], "\x1a \t") == "" {
+			continue
+		}
+		if len(record) < columns {
+			return fmt.Errorf("line %d: expected %d fields, got %d", line, columns, len(record))
+		}
+		for i := range record {
+			if record[i] = strings.TrimSpace(record[i]); record[i] == sdnNull {
+				record[i] = ""
+			}
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// splitPrograms splits the sanctions programs, e.g. [SDGT] [IRGC]
+func splitPrograms(s string) []string {
+	var programs []string
+	for _, p := range strings.FieldsFunc(s, func(r rune) bool { return r == '[' || r == ']' || r == ' ' }) {
+		programs = append(programs, p)
+	}
+	return programs
+}
+
+func joinNonEmpty(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	logger "github.com/elkousy/payments-api/utility/logger"
 	"github.com/spf13/viper"
@@ -15,12 +16,95 @@ var (
 	AppPort    int
 	OpsPort    int
 	DebugPort  int
+	GRPCPort   int
 	DBHost     string
 	DBPort     int
 	DBName     string
 	DBUser     string
 	DBPassword string
 	DBTimeout  int
+
+	// PublicBaseURL is the externally visible base URL of the API (e.g. https://api.example.com)
+	// used to build HATEOAS links. When empty, links are derived from the incoming request.
+	PublicBaseURL string
+
+	// EventsRetention is the number of payment events retained for resuming the events streams
+	EventsRetention int
+	// EventsBufferSize is the number of events a stream subscriber can lag behind before being disconnected
+	EventsBufferSize int
+	// EventsPollInterval is how often the payment events are read from the database when no notification is received
+	EventsPollInterval time.Duration
+
+	// ModulusRulesFile is the path of the VocaLink modulus weight table used to check the UK account numbers.
+	// When empty, the rules bundled with the service are used.
+	ModulusRulesFile string
+	// SchemeRulesFile is the path of a JSON file declaring the payment scheme rules, see schemes/data/rules.json.
+	// Its schemes replace the bundled ones, the other bundled schemes are kept.
+	SchemeRulesFile string
+	// ChargesFile is the path of a JSON file declaring the fees of the payment schemes, see charges/data/fees.json.
+	// Its schemes replace the bundled ones, the other bundled schemes are kept.
+	ChargesFile string
+	// CalendarFiles are the comma separated paths of JSON files declaring business-day calendars, see calendar/data/uk.json.
+	// Each replaces the bundled calendar of the same name.
+	CalendarFiles []string
+
+	// FXRateDirection tells how the exchange rates of the payments are quoted: original_to_amount when the amount is
+	// the original amount multiplied by the rate, amount_to_original when it is the original amount divided by the rate
+	FXRateDirection string
+	// FXTolerance is the relative difference accepted between the amount and the converted original amount
+	FXTolerance string
+
+	// DuplicateWindow is how far back the payments looking like a new payment are looked up
+	DuplicateWindow time.Duration
+	// DuplicatePolicy tells what happens to the payments looking like a recent payment: off, warn or reject
+	DuplicatePolicy string
+	// DuplicatePolicyOrganisations overrides the duplicate policy of some organisations, e.g. <organisation_id>=reject,<organisation_id>=off
+	DuplicatePolicyOrganisations string
+
+	// ScreeningSDNFile is the path of the OFAC SDN.CSV file the payment parties are screened against, no screening when empty.
+	// ScreeningAltFile and ScreeningAddFile are the optional paths of its ALT.CSV aliases and ADD.CSV addresses.
+	ScreeningSDNFile string
+	ScreeningAltFile string
+	ScreeningAddFile string
+	// ScreeningThreshold is the minimum similarity, between 0 and 1, of a party holding its payment for review
+	ScreeningThreshold float64
+
+	// SchedulerInterval is how often the scheduled payments are checked for their processing date, 0 disables the scheduler
+	SchedulerInterval time.Duration
+
+	// PaymentRetention is how long the deleted payments can be restored before they are purged, 0 keeps them forever
+	PaymentRetention time.Duration
+	// PurgeInterval is how often the payments deleted longer ago than the retention period are purged
+	PurgeInterval time.Duration
+
+	// ArchiveTarget is the directory, or the http or https URL of the object store, of the archive files of the payments,
+	// no archive when empty
+	ArchiveTarget string
+	// ArchiveAfter is how long after their creation the payments are moved to the archive by the archive command
+	ArchiveAfter time.Duration
+	// ArchiveBatchSize is the number of payments of an archive file
+	ArchiveBatchSize int
+
+	// EncryptionKeysFile is the JSON file of the keys encrypting the parties of the payments, no encryption when empty
+	EncryptionKeysFile string
+	// KeyRotationInterval is how often the parties encrypted with a former key are encrypted with the current one
+	KeyRotationInterval time.Duration
+
+	// LogRedactFields are the keys of the values masked in the logs
+	LogRedactFields []string
+	// SQLLog logs the SQL statements, with the values of their string parameters masked
+	SQLLog bool
+	// PrivilegedRole is the role of the users seeing the account numbers of the parties in full, they are masked for the
+	// others
+	PrivilegedRole string
+	// AdminRole is the role of the users changing and removing the limits of the organisations
+	AdminRole string
+	// TrustedProxies are the comma separated IP addresses and CIDR ranges of the proxies whose X-Forwarded-For entries
+	// give the source IP of the requests, the remote address is the source IP when empty
+	TrustedProxies string
+
+	// RecallWindow is the number of business days after the processing date of a payment in which it can be recalled
+	RecallWindow int
 )
 
 func init() {
@@ -33,6 +117,26 @@ func InitConfig() {
 	viper.SetDefault("APP_PORT", 8080)
 	viper.SetDefault("OPS_PORT", 8081)
 	viper.SetDefault("DEBUG_PORT", 8082)
+	viper.SetDefault("GRPC_PORT", 8083)
+	viper.SetDefault("EVENTS_RETENTION", 1000)
+	viper.SetDefault("EVENTS_BUFFER_SIZE", 64)
+	viper.SetDefault("EVENTS_POLL_INTERVAL", "5s")
+	viper.SetDefault("FX_RATE_DIRECTION", "original_to_amount")
+	viper.SetDefault("FX_TOLERANCE", "0.0001")
+	viper.SetDefault("DUPLICATE_WINDOW", "24h")
+	viper.SetDefault("DUPLICATE_POLICY", "warn")
+	viper.SetDefault("SCREENING_THRESHOLD", 0.9)
+	viper.SetDefault("SCHEDULER_INTERVAL", "1m")
+	viper.SetDefault("RECALL_WINDOW", 10)
+	viper.SetDefault("PAYMENT_RETENTION", "0")
+	viper.SetDefault("PURGE_INTERVAL", "1h")
+	viper.SetDefault("ARCHIVE_AFTER", "17520h")
+	viper.SetDefault("ARCHIVE_BATCH_SIZE", 500)
+	viper.SetDefault("KEY_ROTATION_INTERVAL", "10m")
+	viper.SetDefault("LOG_REDACT_FIELDS", "account_number,account_name,name,address")
+	viper.SetDefault("SQL_LOG", false)
+	viper.SetDefault("PRIVILEGED_ROLE", "payments:pii")
+	viper.SetDefault("ADMIN_ROLE", "payments:admin")
 
 	var isDev bool
 	switch strings.ToLower(os.Getenv("ENVIRONMENT")) {
@@ -57,6 +161,38 @@ func InitConfig() {
 	AppPort = viper.GetInt("APP_PORT")
 	OpsPort = viper.GetInt("OPS_PORT")
 	DebugPort = viper.GetInt("DEBUG_PORT")
+	GRPCPort = viper.GetInt("GRPC_PORT")
+	PublicBaseURL = viper.GetString("PUBLIC_BASE_URL")
+	EventsRetention = viper.GetInt("EVENTS_RETENTION")
+	EventsBufferSize = viper.GetInt("EVENTS_BUFFER_SIZE")
+	EventsPollInterval = viper.GetDuration("EVENTS_POLL_INTERVAL")
+	ModulusRulesFile = viper.GetString("MODULUS_RULES_FILE")
+	SchemeRulesFile = viper.GetString("SCHEME_RULES_FILE")
+	ChargesFile = viper.GetString("CHARGES_FILE")
+	CalendarFiles = splitList(viper.GetString("CALENDAR_FILES"))
+	FXRateDirection = viper.GetString("FX_RATE_DIRECTION")
+	FXTolerance = viper.GetString("FX_TOLERANCE")
+	DuplicateWindow = viper.GetDuration("DUPLICATE_WINDOW")
+	DuplicatePolicy = viper.GetString("DUPLICATE_POLICY")
+	DuplicatePolicyOrganisations = viper.GetString("DUPLICATE_POLICY_ORGANISATIONS")
+	ScreeningSDNFile = viper.GetString("SCREENING_SDN_FILE")
+	ScreeningAltFile = viper.GetString("SCREENING_ALT_FILE")
+	ScreeningAddFile = viper.GetString("SCREENING_ADD_FILE")
+	ScreeningThreshold = viper.GetFloat64("SCREENING_THRESHOLD")
+	SchedulerInterval = viper.GetDuration("SCHEDULER_INTERVAL")
+	PaymentRetention = viper.GetDuration("PAYMENT_RETENTION")
+	PurgeInterval = viper.GetDuration("PURGE_INTERVAL")
+	ArchiveTarget = viper.GetString("ARCHIVE_TARGET")
+	ArchiveAfter = viper.GetDuration("ARCHIVE_AFTER")
+	ArchiveBatchSize = viper.GetInt("ARCHIVE_BATCH_SIZE")
+	EncryptionKeysFile = viper.GetString("ENCRYPTION_KEYS_FILE")
+	KeyRotationInterval = viper.GetDuration("KEY_ROTATION_INTERVAL")
+	LogRedactFields = splitList(viper.GetString("LOG_REDACT_FIELDS"))
+	SQLLog = viper.GetBool("SQL_LOG")
+	PrivilegedRole = viper.GetString("PRIVILEGED_ROLE")
+	AdminRole = viper.GetString("ADMIN_ROLE")
+	TrustedProxies = viper.GetString("TRUSTED_PROXIES")
+	RecallWindow = viper.GetInt("RECALL_WINDOW")
 
 	// db configuration
 	DBHost = viper.GetString("DB_HOST")
@@ -66,3 +202,14 @@ func InitConfig() {
 	DBPassword = viper.GetString("DB_PASSWORD")
 	DBTimeout = viper.GetInt("DB_TIMEOUT")
 }
+
+// splitList returns the non empty trimmed values of a comma separated list
+func splitList(s string) []string {
+	values := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +18,19 @@ func Test_InitConfig_Toml(t *testing.T) {
 	assert.NotEmpty(t, AppPort, "AppPort")
 	assert.NotEmpty(t, OpsPort, "OpsPort")
 	assert.NotEmpty(t, DebugPort, "DebugPort")
+	assert.NotEmpty(t, GRPCPort, "GRPCPort")
+	assert.NotEmpty(t, EventsRetention, "EventsRetention")
+	assert.NotEmpty(t, EventsBufferSize, "EventsBufferSize")
+	assert.NotEmpty(t, EventsPollInterval, "EventsPollInterval")
+	assert.Equal(t, "original_to_amount", FXRateDirection, "FXRateDirection")
+	assert.Equal(t, "0.0001", FXTolerance, "FXTolerance")
+	assert.Equal(t, 24*time.Hour, DuplicateWindow, "DuplicateWindow")
+	assert.Equal(t, "warn", DuplicatePolicy, "DuplicatePolicy")
+	assert.Equal(t, 0.9, ScreeningThreshold, "ScreeningThreshold")
+	assert.Equal(t, []string{"account_number", "account_name", "name", "address"}, LogRedactFields, "LogRedactFields")
+	assert.False(t, SQLLog, "SQLLog")
+	assert.Equal(t, "payments:pii", PrivilegedRole, "PrivilegedRole")
+	assert.Equal(t, "payments:admin", AdminRole, "AdminRole")
 	assert.NotEmpty(t, DBHost, "DBHost")
 	assert.NotEmpty(t, DBPort, "DBPort")
 	assert.NotEmpty(t, DBName, "DBName")
@@ -36,6 +50,7 @@ func Test_InitConfig_EnvVar(t *testing.T) {
 	os.Setenv("DB_PORT", "5432")
 	os.Setenv("DB_NAME", "postgres")
 	os.Setenv("DB_TIMEOUT", "5")
+	os.Setenv("CALENDAR_FILES", "uk.json, target2.json,")
 	//Act
 	InitConfig()
 	//Assert
@@ -47,6 +62,7 @@ func Test_InitConfig_EnvVar(t *testing.T) {
 	assert.Equal(t, DBUser, "raouf")
 	assert.Equal(t, DBPassword, "raouf")
 	assert.Equal(t, DBTimeout, 5)
+	assert.Equal(t, []string{"uk.json", "target2.json"}, CalendarFiles)
 }
 
 // func TestNewConfig(t *testing.T) {
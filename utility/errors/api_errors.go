@@ -1,10 +1,23 @@
 package errors
 
+import "encoding/json"
+
 // APIError is the model representing custom error
 type APIError struct {
 	Message       string `json:"message"`
 	ResponseCode  int    `json:"-"`
 	OriginalError string `json:"-"`
+	// Errors is a pointer to keep APIError comparable, errors are compared with ==
+	Errors *FieldErrors `json:"errors,omitempty"`
+}
+
+// FieldErrors details the invalid fields of a request
+type FieldErrors []FieldError
+
+// FieldError describes why a field of the request is not valid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 //FromError will add the original error in the APIError
@@ -13,6 +26,13 @@ func (f APIError) FromError(err error) APIError {
 	return f
 }
 
+// WithFieldErrors returns a copy of the error detailing the invalid fields of the request
+func (f APIError) WithFieldErrors(errs ...FieldError) APIError {
+	fieldErrors := FieldErrors(errs)
+	f.Errors = &fieldErrors
+	return f
+}
+
 func (f APIError) Error() string {
 	return f.Message
 }
@@ -22,3 +42,10 @@ func (f APIError) Error() string {
 func (f APIError) StatusCode() int {
 	return f.ResponseCode
 }
+
+// MarshalJSON encodes the error as a json object
+// Imported from kithttp, errors are returned as json instead of plain text
+func (f APIError) MarshalJSON() ([]byte, error) {
+	type apiError APIError
+	return json.Marshal(apiError(f))
+}
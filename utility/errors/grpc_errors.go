@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"context"
+	"net/http"
+
+	logger "github.com/elkousy/payments-api/utility/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCStatus maps the APIError to a gRPC status, it is picked up by grpc-go when returned by a handler.
+// The invalid fields are detailed as BadRequest field violations.
+func (f APIError) GRPCStatus() *status.Status {
+	st := status.New(GRPCCode(f.ResponseCode), f.Message)
+	if f.Errors == nil {
+		return st
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(*f.Errors))
+	for _, e := range *f.Errors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: e.Field, Description: e.Message})
+	}
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// GRPCCode maps an http response code to the closest gRPC status code
+func GRPCCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return codes.OK
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusInternalServerError:
+		return codes.Internal
+	}
+	if httpCode >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// LoggingServerInterceptor is the gRPC counterpart of LoggingErrorEncoder: it logs into stderr
+// the errors returned by the handlers and maps them to gRPC status errors.
+// Errors which are neither an APIError nor a gRPC status are reported as internal errors, as the http transport does.
+func LoggingServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	logger.LogStdErr.Error("err", zap.Error(err),
+		zap.String("grpc.method", info.FullMethod),
+	)
+
+	switch e := err.(type) {
+	case APIError:
+		return nil, e.GRPCStatus().Err()
+	case interface{ GRPCStatus() *status.Status }:
+		return nil, err
+	}
+	return nil, status.Error(codes.Internal, err.Error())
+}
@@ -9,10 +9,12 @@ import (
 	"go.uber.org/zap"
 )
 
-// LoggingErrorEncoder wraps GoKit's DefaultErrorEncoder to provide, on top of it, logging into stderr
+// LoggingErrorEncoder wraps GoKit's DefaultErrorEncoder to provide, on top of it, logging into stderr.
+// The sensitive query parameters of the URL are masked.
 func LoggingErrorEncoder(ctx context.Context, err error, w http.ResponseWriter) {
+	uri, _ := ctx.Value(kithttp.ContextKeyRequestURI).(string)
 	logger.LogStdErr.Error("err", zap.Error(err),
-		zap.Any("http.url", ctx.Value(kithttp.ContextKeyRequestURI)),
+		zap.String("http.url", logger.RedactURL(uri)),
 		zap.Any("http.path", ctx.Value(kithttp.ContextKeyRequestPath)),
 		zap.Any("http.method", ctx.Value(kithttp.ContextKeyRequestMethod)),
 		zap.Any("http.user_agent", ctx.Value(kithttp.ContextKeyRequestUserAgent)),
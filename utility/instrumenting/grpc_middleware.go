@@ -0,0 +1,49 @@
+package instrumenting
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// GRPCRequestsTotalCounter represents a prometheus counter for counting gRPC calls
+	GRPCRequestsTotalCounter *kitprometheus.Counter
+
+	// GRPCRequestDurationHistogram represents a promtheus histogram for measuring gRPC calls durations
+	GRPCRequestDurationHistogram *kitprometheus.Histogram
+)
+
+func init() {
+	GRPCRequestsTotalCounter = kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+		Name: "grpc_requests_total",
+		Help: "Number of gRPC requests received.",
+	}, []string{"component", "handler", "code", "success"})
+
+	GRPCRequestDurationHistogram = kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+		Name: "grpc_request_duration_seconds",
+		Help: "gRPC request duration in seconds",
+	}, []string{"component", "handler", "success"})
+}
+
+// UnaryServerInterceptor counts gRPC calls and measures their latency, the handler label is the gRPC method name
+func UnaryServerInterceptor(componentName string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func(begin time.Time) {
+			code := status.Code(err)
+			success := code == codes.OK
+			handlerName := path.Base(info.FullMethod)
+			GRPCRequestsTotalCounter.With("component", componentName, "handler", handlerName, "code", code.String(), "success", strconv.FormatBool(success)).Add(1)
+			GRPCRequestDurationHistogram.With("component", componentName, "handler", handlerName, "success", strconv.FormatBool(success)).Observe(time.Since(begin).Seconds())
+		}(time.Now())
+
+		return handler(ctx, req)
+	}
+}
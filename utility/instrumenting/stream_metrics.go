@@ -0,0 +1,36 @@
+package instrumenting
+
+import (
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// StreamConnectionsGauge represents a prometheus gauge for the number of open streaming connections
+	StreamConnectionsGauge *kitprometheus.Gauge
+
+	// StreamEvictionsCounter represents a prometheus counter for the streaming connections closed
+	// because the client could not keep up with the stream
+	StreamEvictionsCounter *kitprometheus.Counter
+)
+
+func init() {
+	StreamConnectionsGauge = kitprometheus.NewGaugeFrom(prometheus.GaugeOpts{
+		Name: "stream_connections",
+		Help: "Number of open streaming connections.",
+	}, []string{"component", "stream"})
+
+	StreamEvictionsCounter = kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+		Name: "stream_evictions_total",
+		Help: "Number of streaming connections closed because the client was too slow.",
+	}, []string{"component", "stream"})
+}
+
+// StreamOpened counts an open streaming connection, the returned function must be called when it is closed
+func StreamOpened(componentName string, streamName string) func() {
+	gauge := StreamConnectionsGauge.With("component", componentName, "stream", streamName)
+	gauge.Add(1)
+	return func() {
+		gauge.Add(-1)
+	}
+}
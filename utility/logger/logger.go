@@ -3,6 +3,8 @@ package logger
 import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/elkousy/payments-api/utility/redact"
 )
 
 var (
@@ -10,13 +12,26 @@ var (
 	LogStdErr *zap.SugaredLogger
 )
 
+// redactedFields are the keys of the values masked in the logs
+var redactedFields = redact.NewFields(DefaultRedactedFields...)
+
 func init() {
+	zap.RegisterEncoder("redacted-json", func(conf zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return newRedactingEncoder(zapcore.NewJSONEncoder(conf), redactedFields), nil
+	})
+	newLogger()
+}
+
+// Redact rebuilds the loggers to mask the values of the given keys, e.g. account_number
+func Redact(keys ...string) {
+	redactedFields = redact.NewFields(keys...)
 	newLogger()
 }
 
 //new : initialize a new logger
 func newLogger() {
 	conf := zap.NewProductionConfig()
+	conf.Encoding = "redacted-json"
 	conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	conf.EncoderConfig.MessageKey = "message"
 	conf.EncoderConfig.TimeKey = "timestamp"
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/elkousy/payments-api/utility/redact"
+)
+
+// DefaultRedactedFields are the keys of the values masked in the logs until Redact is called
+var DefaultRedactedFields = []string{"account_number", "account_name", "name", "address"}
+
+// redactingEncoder masks the values of the sensitive fields of the log entries: the fields named after a sensitive key,
+// and the sensitive keys of the JSON documents of the structs and maps logged
+type redactingEncoder struct {
+	zapcore.Encoder
+	fields redact.Fields
+}
+
+// newRedactingEncoder returns an encoder masking the fields before encoding the entries with enc
+func newRedactingEncoder(enc zapcore.Encoder, fields redact.Fields) zapcore.Encoder {
+	return redactingEncoder{Encoder: enc, fields: fields}
+}
+
+// Clone implements zapcore.Encoder
+func (e redactingEncoder) Clone() zapcore.Encoder {
+	return redactingEncoder{Encoder: e.Encoder.Clone(), fields: e.fields}
+}
+
+// AddString implements zapcore.ObjectEncoder, for the fields of the loggers built with With
+func (e redactingEncoder) AddString(key, value string) {
+	if e.fields.Has(key) {
+		value = redact.Mask(value)
+	}
+	e.Encoder.AddString(key, value)
+}
+
+// AddReflected implements zapcore.ObjectEncoder, for the fields of the loggers built with With
+func (e redactingEncoder) AddReflected(key string, value interface{}) error {
+	f := e.redact(zap.Reflect(key, value))
+	if f.Type == zapcore.StringType {
+		e.Encoder.AddString(key, f.String)
+		return nil
+	}
+	return e.Encoder.AddReflected(key, f.Interface)
+}
+
+// EncodeEntry implements zapcore.Encoder
+func (e redactingEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = e.redact(f)
+	}
+	return e.Encoder.EncodeEntry(entry, redacted)
+}
+
+// redact masks a sensitive field, or the sensitive keys of a struct or a map
+func (e redactingEncoder) redact(f zapcore.Field) zapcore.Field {
+	if e.fields.Has(f.Key) {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		return zap.String(f.Key, redact.Mask(fmt.Sprint(enc.Fields[f.Key])))
+	}
+	if f.Type != zapcore.ReflectType || f.Interface == nil {
+		return f
+	}
+	b, err := json.Marshal(f.Interface)
+	if err != nil {
+		return f
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return f
+	}
+	if redacted := e.fields.Value(doc); !reflect.DeepEqual(doc, redacted) {
+		return zap.Reflect(f.Key, redacted)
+	}
+	return f
+}
+
+// RedactURL masks the values of the sensitive query parameters of a URL, e.g. ?account_number=
+func RedactURL(rawURL string) string {
+	return redactedFields.URL(rawURL)
+}
+
+// SQLLogger logs the SQL statements of gorm with the values of their string parameters masked, as the columns they are
+// bound to are unknown
+type SQLLogger struct{}
+
+// Print implements the logger of gorm, which logs "sql" with the source, the duration, the statement, its parameters
+// and the number of rows affected, or "log" and "error" with the source and a message
+func (SQLLogger) Print(values ...interface{}) {
+	if len(values) < 2 {
+		return
+	}
+	if values[0] != "sql" || len(values) < 6 {
+		LogStdOut.Infow("gorm", "level", values[0], "source", values[1], "message", fmt.Sprint(values[2:]...))
+		return
+	}
+	vars, _ := values[4].([]interface{})
+	parameters := make([]interface{}, 0, len(vars))
+	for _, v := range vars {
+		parameters = append(parameters, redactParameter(v))
+	}
+	LogStdOut.Infow("sql", "source", values[1], "duration", values[2], "statement", values[3], "parameters", parameters, "rows", values[5])
+}
+
+// redactParameter masks the strings bound to a statement, the other values are logged as is
+func redactParameter(v interface{}) interface{} {
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return "?"
+		}
+		v = value
+	}
+	switch v := v.(type) {
+	case string:
+		return redact.Mask(v)
+	case []byte:
+		return redact.Mask(string(v))
+	}
+	return v
+}
@@ -0,0 +1,57 @@
+//go:build !integration
+// +build !integration
+
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/elkousy/payments-api/utility/redact"
+)
+
+func Test_redactingEncoder(t *testing.T) {
+	// Arrange
+	enc := newRedactingEncoder(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), redact.NewFields("account_number", "name"))
+	enc.AddString("name", "Wilfred Jeremiah Owens")
+	party := struct {
+		AccountNumber string `json:"account_number"`
+		BankID        string `json:"bank_id"`
+	}{AccountNumber: "GB29XABC10161234567801", BankID: "203301"}
+
+	//Act
+	buf, err := enc.EncodeEntry(zapcore.Entry{Message: "payment"}, []zapcore.Field{
+		zap.String("account_number", "12345678"),
+		zap.Int("count", 2),
+		zap.Any("party", party),
+	})
+
+	//Assert
+	require.NoError(t, err)
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "******************wens", logged["name"])
+	assert.Equal(t, "****5678", logged["account_number"])
+	assert.Equal(t, float64(2), logged["count"])
+	assert.Equal(t, map[string]interface{}{"account_number": "******************7801", "bank_id": "203301"}, logged["party"])
+}
+
+func Test_redactParameter(t *testing.T) {
+	// Arrange
+	id := uuid.FromStringOrNil("7c95bd23-b67f-4cc9-bfb2-9e4e31f093e3")
+	at := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	//Act & Assert
+	assert.Equal(t, "******************7801", redactParameter("GB29XABC10161234567801"))
+	assert.Equal(t, redact.Mask(id.String()), redactParameter(id), "the uuid is a driver.Valuer of its string")
+	assert.Equal(t, 42, redactParameter(42))
+	assert.Equal(t, at, redactParameter(at))
+	assert.Nil(t, redactParameter(nil))
+}
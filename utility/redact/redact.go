@@ -0,0 +1,94 @@
+// Package redact masks the sensitive values, e.g. the account numbers, names and addresses of the parties, written to
+// the logs and to the responses of the callers not allowed to see them.
+package redact
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// visible is the number of trailing characters left visible by Mask
+const visible = 4
+
+// Mask masks all but the last 4 characters of a value, all of them when it is shorter than 8 characters
+func Mask(s string) string {
+	n := utf8.RuneCountInString(s)
+	if n < 2*visible {
+		return strings.Repeat("*", n)
+	}
+	runes := []rune(s)
+	return strings.Repeat("*", n-visible) + string(runes[n-visible:])
+}
+
+// Fields are the keys of the sensitive values, matched regardless of case
+type Fields map[string]bool
+
+// NewFields returns the fields of the keys
+func NewFields(keys ...string) Fields {
+	f := Fields{}
+	for _, key := range keys {
+		f[strings.ToLower(key)] = true
+	}
+	return f
+}
+
+// Has reports whether the values of a key are sensitive
+func (f Fields) Has(key string) bool {
+	return f[strings.ToLower(key)]
+}
+
+// Value returns a copy of a decoded JSON document with the sensitive values masked, at any depth. The masked values
+// are turned into strings.
+func (f Fields) Value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if f.Has(key) && value != nil {
+				masked[key] = Mask(toString(value))
+				continue
+			}
+			masked[key] = f.Value(value)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, value := range v {
+			masked[i] = f.Value(value)
+		}
+		return masked
+	}
+	return v
+}
+
+// URL masks the values of the sensitive query parameters of a URL, it is returned as is when it cannot be parsed
+func (f Fields) URL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+	query := u.Query()
+	changed := false
+	for key, values := range query {
+		if f.Has(key) {
+			for i := range values {
+				values[i] = Mask(values[i])
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,53 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mask(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "GB29XABC10161234567801", want: "******************7801"},
+		{value: "12345678", want: "****5678"},
+		{value: "1234567", want: "*******"},
+		{value: "Émilie Brown", want: "********rown"},
+		{value: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, Mask(tt.value))
+		})
+	}
+}
+
+func Test_Fields_Value(t *testing.T) {
+	// Arrange
+	fields := NewFields("account_number", "Name")
+	doc := map[string]interface{}{
+		"id":           "7c95bd23",
+		"debtor_party": map[string]interface{}{"account_number": "GB29XABC10161234567801", "name": "Wilfred Jeremiah Owens", "bank_id": "203301"},
+		"parties":      []interface{}{map[string]interface{}{"ACCOUNT_NUMBER": 12345678}},
+	}
+
+	//Act
+	masked := fields.Value(doc).(map[string]interface{})
+
+	//Assert
+	debtor := masked["debtor_party"].(map[string]interface{})
+	assert.Equal(t, "******************7801", debtor["account_number"])
+	assert.Equal(t, "******************wens", debtor["name"])
+	assert.Equal(t, "203301", debtor["bank_id"])
+	assert.Equal(t, "****5678", masked["parties"].([]interface{})[0].(map[string]interface{})["ACCOUNT_NUMBER"])
+	assert.Equal(t, "GB29XABC10161234567801", doc["debtor_party"].(map[string]interface{})["account_number"], "the document is copied")
+}
+
+func Test_Fields_URL(t *testing.T) {
+	fields := NewFields("account_number")
+	assert.Equal(t, "/v1/payments/?account_number=%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A%2A7801&page=2",
+		fields.URL("/v1/payments/?page=2&account_number=GB29XABC10161234567801"))
+	assert.Equal(t, "/v1/payments/?page=2", fields.URL("/v1/payments/?page=2"))
+}